@@ -35,6 +35,7 @@ import (
 	"github.com/kaiachain/kaia/blockchain/vm"
 	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/consensus/misc"
 	"github.com/kaiachain/kaia/crypto"
 	"github.com/kaiachain/kaia/networks/rpc"
 	"github.com/kaiachain/kaia/node/cn/filters"
@@ -721,26 +722,28 @@ func (api *EthereumAPI) GetBlockTransactionCountByHash(ctx context.Context, bloc
 // RPCTransaction in go-ethereum has been renamed to EthRPCTransaction.
 // RPCTransaction is defined in go-ethereum's internal package, so RPCTransaction is redefined here as EthRPCTransaction.
 type EthRPCTransaction struct {
-	BlockHash         *common.Hash                 `json:"blockHash"`
-	BlockNumber       *hexutil.Big                 `json:"blockNumber"`
-	From              common.Address               `json:"from"`
-	Gas               hexutil.Uint64               `json:"gas"`
-	GasPrice          *hexutil.Big                 `json:"gasPrice"`
-	GasFeeCap         *hexutil.Big                 `json:"maxFeePerGas,omitempty"`
-	GasTipCap         *hexutil.Big                 `json:"maxPriorityFeePerGas,omitempty"`
-	Hash              common.Hash                  `json:"hash"`
-	Input             hexutil.Bytes                `json:"input"`
-	Nonce             hexutil.Uint64               `json:"nonce"`
-	To                *common.Address              `json:"to"`
-	TransactionIndex  *hexutil.Uint64              `json:"transactionIndex"`
-	Value             *hexutil.Big                 `json:"value"`
-	Type              hexutil.Uint64               `json:"type"`
-	Accesses          *types.AccessList            `json:"accessList,omitempty"`
-	ChainID           *hexutil.Big                 `json:"chainId,omitempty"`
-	AuthorizationList []types.SetCodeAuthorization `json:"authorizationList,omitempty"`
-	V                 *hexutil.Big                 `json:"v"`
-	R                 *hexutil.Big                 `json:"r"`
-	S                 *hexutil.Big                 `json:"s"`
+	BlockHash           *common.Hash                 `json:"blockHash"`
+	BlockNumber         *hexutil.Big                 `json:"blockNumber"`
+	From                common.Address               `json:"from"`
+	Gas                 hexutil.Uint64               `json:"gas"`
+	GasPrice            *hexutil.Big                 `json:"gasPrice"`
+	GasFeeCap           *hexutil.Big                 `json:"maxFeePerGas,omitempty"`
+	GasTipCap           *hexutil.Big                 `json:"maxPriorityFeePerGas,omitempty"`
+	Hash                common.Hash                  `json:"hash"`
+	Input               hexutil.Bytes                `json:"input"`
+	Nonce               hexutil.Uint64               `json:"nonce"`
+	To                  *common.Address              `json:"to"`
+	TransactionIndex    *hexutil.Uint64              `json:"transactionIndex"`
+	Value               *hexutil.Big                 `json:"value"`
+	Type                hexutil.Uint64               `json:"type"`
+	Accesses            *types.AccessList            `json:"accessList,omitempty"`
+	ChainID             *hexutil.Big                 `json:"chainId,omitempty"`
+	AuthorizationList   []types.SetCodeAuthorization `json:"authorizationList,omitempty"`
+	MaxFeePerBlobGas    *hexutil.Big                 `json:"maxFeePerBlobGas,omitempty"`
+	BlobVersionedHashes []common.Hash                `json:"blobVersionedHashes,omitempty"`
+	V                   *hexutil.Big                 `json:"v"`
+	R                   *hexutil.Big                 `json:"r"`
+	S                   *hexutil.Big                 `json:"s"`
 }
 
 // ethTxJSON is the JSON representation of Ethereum transaction.
@@ -773,6 +776,10 @@ type ethTxJSON struct {
 	// Set code transaction fields:
 	AuthorizationList []types.SetCodeAuthorization `json:"authorizationList,omitempty"`
 
+	// Blob transaction fields:
+	MaxFeePerBlobGas    *hexutil.Big  `json:"maxFeePerBlobGas,omitempty"`
+	BlobVersionedHashes []common.Hash `json:"blobVersionedHashes,omitempty"`
+
 	// Only used for encoding:
 	Hash common.Hash `json:"hash"`
 }
@@ -879,6 +886,20 @@ func newEthRPCTransaction(block *types.Block, tx *types.Transaction, blockHash c
 			result.GasPrice = (*hexutil.Big)(tx.EffectiveGasPrice(nil, nil))
 		}
 		result.AuthorizationList = tx.AuthList()
+	case types.TxTypeEthereumBlob:
+		al := tx.AccessList()
+		result.Accesses = &al
+		result.ChainID = (*hexutil.Big)(tx.ChainId())
+		result.GasFeeCap = (*hexutil.Big)(tx.GasFeeCap())
+		result.GasTipCap = (*hexutil.Big)(tx.GasTipCap())
+		if block != nil {
+			result.GasPrice = (*hexutil.Big)(tx.EffectiveGasPrice(block.Header(), config))
+		} else {
+			// transaction is not processed yet
+			result.GasPrice = (*hexutil.Big)(tx.EffectiveGasPrice(nil, nil))
+		}
+		result.MaxFeePerBlobGas = (*hexutil.Big)(tx.BlobGasFeeCap())
+		result.BlobVersionedHashes = tx.BlobHashes()
 	}
 	return result
 }
@@ -941,6 +962,14 @@ func formatTxToEthTxJSON(tx *types.Transaction) *ethTxJSON {
 		enc.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
 		enc.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
 		enc.AuthorizationList = tx.AuthList()
+	case types.TxTypeEthereumBlob:
+		al := tx.AccessList()
+		enc.AccessList = &al
+		enc.ChainID = (*hexutil.Big)(tx.ChainId())
+		enc.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		enc.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+		enc.MaxFeePerBlobGas = (*hexutil.Big)(tx.BlobGasFeeCap())
+		enc.BlobVersionedHashes = tx.BlobHashes()
 	default:
 		enc.GasPrice = (*hexutil.Big)(tx.GasPrice())
 	}
@@ -1089,6 +1118,15 @@ func (api *EthereumAPI) GetBlockReceipts(ctx context.Context, blockNrOrHash rpc.
 	return outputList, nil
 }
 
+// GetBlobSidecars returns the blob sidecars of the blob transactions in the block
+// identified by number or hash. The sidecars are available only within the
+// retention window. They are taken from the txpool when the block is imported,
+// and the ones the txpool never saw are fetched from the kaia/66 peers, so they
+// may be missing for a short while after the block is imported.
+func (api *EthereumAPI) GetBlobSidecars(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*RPCBlobSidecar, error) {
+	return api.publicBlockChainAPI.GetBlobSidecars(ctx, blockNrOrHash)
+}
+
 // newEthTransactionReceipt creates a transaction receipt in Ethereum format.
 func newEthTransactionReceipt(header *types.Header, tx *types.Transaction, b Backend, blockHash common.Hash, blockNumber, index, cumulativeGasUsed uint64, receipt *types.Receipt) (map[string]interface{}, error) {
 	// When an unknown transaction receipt is requested through rpc call,
@@ -1124,6 +1162,14 @@ func newEthTransactionReceipt(header *types.Header, tx *types.Transaction, b Bac
 	// Before EthTxType hard fork : return gas price of tx. (typed ethereum txs are not available.)
 	fields["effectiveGasPrice"] = hexutil.Uint64(tx.EffectiveGasPrice(header, b.ChainConfig()).Uint64())
 
+	// The blob fee is charged with the blob base fee of the block.
+	if blobGas := tx.BlobGas(); blobGas > 0 {
+		fields["blobGasUsed"] = hexutil.Uint64(blobGas)
+		if blobBaseFee := misc.BlobBaseFee(header); blobBaseFee != nil {
+			fields["blobGasPrice"] = (*hexutil.Big)(blobBaseFee)
+		}
+	}
+
 	// Always use the "status" field and Ignore the "root" field.
 	if receipt.Status != types.ReceiptStatusSuccessful {
 		// In Ethereum, status field can have 0(=Failure) or 1(=Success) only.
@@ -1346,6 +1392,12 @@ func (api *EthereumAPI) rpcMarshalHeader(head *types.Header, inclMiner bool) (ma
 		result["randomReveal"] = hexutil.Bytes(head.RandomReveal)
 		result["mixHash"] = hexutil.Bytes(head.MixHash)
 	}
+	if head.BlobGasUsed != nil {
+		result["blobGasUsed"] = hexutil.Uint64(*head.BlobGasUsed)
+	}
+	if head.ExcessBlobGas != nil {
+		result["excessBlobGas"] = hexutil.Uint64(*head.ExcessBlobGas)
+	}
 	return result, nil
 }
 
@@ -118,6 +118,59 @@ func (s *PublicBlockChainAPI) GetBlockReceipts(ctx context.Context, blockNrOrHas
 	return fieldsList, nil
 }
 
+// RPCBlobSidecar is the RPC representation of the blob sidecar of a transaction.
+type RPCBlobSidecar struct {
+	BlockHash           common.Hash     `json:"blockHash"`
+	BlockNumber         hexutil.Uint64  `json:"blockNumber"`
+	TxHash              common.Hash     `json:"transactionHash"`
+	TxIndex             hexutil.Uint64  `json:"transactionIndex"`
+	BlobVersionedHashes []common.Hash   `json:"blobVersionedHashes"`
+	Blobs               []hexutil.Bytes `json:"blobs"`
+	Commitments         []hexutil.Bytes `json:"commitments"`
+	Proofs              []hexutil.Bytes `json:"proofs"`
+}
+
+// GetBlobSidecars returns the blob sidecars of the blob transactions in the block
+// identified by number or hash. The sidecars are available only within the
+// retention window. They are taken from the txpool when the block is imported,
+// and the ones the txpool never saw are fetched from the kaia/66 peers, so they
+// may be missing for a short while after the block is imported.
+func (s *PublicBlockChainAPI) GetBlobSidecars(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*RPCBlobSidecar, error) {
+	block, err := s.b.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		blockHash = block.Hash()
+		sidecars  = s.b.GetBlobSidecars(ctx, blockHash, block.NumberU64())
+		indexes   = make(map[common.Hash]int, len(block.Transactions()))
+	)
+	for i, tx := range block.Transactions() {
+		indexes[tx.Hash()] = i
+	}
+	result := make([]*RPCBlobSidecar, 0, len(sidecars))
+	for _, sc := range sidecars {
+		index, ok := indexes[sc.TxHash]
+		if !ok || sc.Sidecar == nil {
+			continue
+		}
+		output := &RPCBlobSidecar{
+			BlockHash:           blockHash,
+			BlockNumber:         hexutil.Uint64(block.NumberU64()),
+			TxHash:              sc.TxHash,
+			TxIndex:             hexutil.Uint64(index),
+			BlobVersionedHashes: block.Transactions()[index].BlobHashes(),
+		}
+		for i := range sc.Sidecar.Blobs {
+			output.Blobs = append(output.Blobs, sc.Sidecar.Blobs[i][:])
+			output.Commitments = append(output.Commitments, sc.Sidecar.Commitments[i][:])
+			output.Proofs = append(output.Proofs, sc.Sidecar.Proofs[i][:])
+		}
+		result = append(result, output)
+	}
+	return result, nil
+}
+
 // GetBalance returns the amount of kei for the given address in the state of the
 // given block number or hash. The rpc.LatestBlockNumber and rpc.PendingBlockNumber meta
 // block numbers and hash are also allowed.
@@ -611,6 +664,14 @@ func RpcOutputBlock(b *types.Block, inclTx bool, fullTx bool, config *params.Cha
 		fields["randomReveal"] = hexutil.Bytes(head.RandomReveal)
 		fields["mixHash"] = hexutil.Bytes(head.MixHash)
 	}
+	if rules.IsBlobTx {
+		if head.BlobGasUsed != nil {
+			fields["blobGasUsed"] = hexutil.Uint64(*head.BlobGasUsed)
+		}
+		if head.ExcessBlobGas != nil {
+			fields["excessBlobGas"] = hexutil.Uint64(*head.ExcessBlobGas)
+		}
+	}
 
 	return fields, nil
 }
@@ -647,7 +708,7 @@ func newRPCTransaction(header *types.Header, tx *types.Transaction, blockHash co
 	output["from"] = getFrom(tx)
 	output["hash"] = tx.Hash()
 	output["transactionIndex"] = hexutil.Uint(index)
-	if tx.Type() == types.TxTypeEthereumDynamicFee || tx.Type() == types.TxTypeEthereumSetCode {
+	if tx.Type() == types.TxTypeEthereumDynamicFee || tx.Type() == types.TxTypeEthereumSetCode || tx.Type() == types.TxTypeEthereumBlob {
 		if header != nil {
 			output["gasPrice"] = (*hexutil.Big)(tx.EffectiveGasPrice(header, config))
 		} else {
@@ -30,6 +30,7 @@ import (
 	"math/big"
 
 	"github.com/kaiachain/kaia/accounts"
+	"github.com/kaiachain/kaia/blockchain"
 	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/common/hexutil"
@@ -386,6 +387,63 @@ func (s *PublicTransactionPoolAPI) SendRawTransaction(ctx context.Context, encod
 	return submitTransaction(ctx, s.b, tx)
 }
 
+const (
+	// defaultPrivateTxExpiry is the number of blocks after which a private
+	// transaction is dropped from the pool if no expiry is given.
+	defaultPrivateTxExpiry = 25
+	// maxPrivateTxExpiry is the maximum number of blocks a private transaction
+	// can stay in the pool.
+	maxPrivateTxExpiry = 1000
+)
+
+// SendPrivateRawTransaction will add the signed transaction to the transaction pool
+// as a private one. A private transaction is propagated only to consensus and proxy
+// nodes, never gossiped to other endpoint nodes, and is dropped from the pool if it
+// is not included within the given number of blocks.
+func (s *PublicTransactionPoolAPI) SendPrivateRawTransaction(ctx context.Context, encodedTx hexutil.Bytes, expiry *hexutil.Uint64) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(encodedTx, tx); err != nil {
+		return common.Hash{}, err
+	}
+	expiryBlocks := uint64(defaultPrivateTxExpiry)
+	if expiry != nil {
+		expiryBlocks = uint64(*expiry)
+	}
+	if expiryBlocks == 0 || expiryBlocks > maxPrivateTxExpiry {
+		return common.Hash{}, fmt.Errorf("expiry must be between 1 and %d blocks", maxPrivateTxExpiry)
+	}
+	if err := s.b.SendPrivateTx(ctx, tx, expiryBlocks); err != nil {
+		return common.Hash{}, err
+	}
+	return tx.Hash(), nil
+}
+
+// SendBundleArgs represents the arguments to submit a transaction bundle.
+type SendBundleArgs struct {
+	Txs         []hexutil.Bytes `json:"txs"`
+	BlockNumber hexutil.Uint64  `json:"blockNumber"` // The only block the bundle can be included in
+}
+
+// SendBundle will add the signed transactions as a bundle to the transaction pool.
+// The transactions of the bundle are included in a block contiguously in the given
+// order, or none of them is included if any of them fails. It returns the bundle hash.
+// Bundles are not propagated to the peers, so only a consensus node accepts them.
+func (s *PublicTransactionPoolAPI) SendBundle(ctx context.Context, args SendBundleArgs) (common.Hash, error) {
+	txs := make(types.Transactions, len(args.Txs))
+	for i, encodedTx := range args.Txs {
+		tx := new(types.Transaction)
+		if err := rlp.DecodeBytes(encodedTx, tx); err != nil {
+			return common.Hash{}, fmt.Errorf("invalid tx %d: %w", i, err)
+		}
+		txs[i] = tx
+	}
+	bundle := types.NewBundle(txs, uint64(args.BlockNumber))
+	if err := s.b.SendBundle(ctx, bundle); err != nil {
+		return common.Hash{}, err
+	}
+	return bundle.Hash(), nil
+}
+
 // Sign calculates an ECDSA signature for:
 // keccack256("\x19Ethereum Signed Message:\n" + len(message) + message).
 //
@@ -560,6 +618,123 @@ func resend(s *PublicTransactionPoolAPI, ctx context.Context, sendArgs NewTxArgs
 	return common.Hash{}, fmt.Errorf("Transaction %#x not found", matchTx.Hash())
 }
 
+// poolTransactionSender returns the transaction of the given hash in the txpool
+// and its sender.
+func (s *PublicTransactionPoolAPI) poolTransactionSender(hash common.Hash) (*types.Transaction, common.Address, error) {
+	tx := s.b.GetPoolTransaction(hash)
+	if tx == nil {
+		return nil, common.Address{}, fmt.Errorf("transaction %#x not found in the txpool", hash)
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(s.b.ChainConfig().ChainID), tx)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+	return tx, from, nil
+}
+
+// CancelTransaction replaces the transaction of the given hash in the txpool with
+// a TxTypeCancel transaction of the same nonce signed by the sender. A cancel
+// transaction replaces any transaction regardless of its gas price, so that the
+// sender can cancel a fee-delegated transaction without the fee payer.
+func (s *PublicTransactionPoolAPI) CancelTransaction(ctx context.Context, hash common.Hash) (common.Hash, error) {
+	tx, from, err := s.poolTransactionSender(hash)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	price, err := s.b.SuggestPrice(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	cancel, err := types.NewTransactionWithMap(types.TxTypeCancel, map[types.TxValueKeyType]interface{}{
+		types.TxValueKeyNonce:    tx.Nonce(),
+		types.TxValueKeyFrom:     from,
+		types.TxValueKeyGasLimit: params.TxGasCancel,
+		types.TxValueKeyGasPrice: price,
+	})
+	if err != nil {
+		return common.Hash{}, err
+	}
+	signedTx, err := s.sign(from, cancel)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return submitTransaction(ctx, s.b, signedTx)
+}
+
+// ReplaceTransaction replaces the transaction of the given hash in the txpool with
+// the same transaction of a bumped gas price. If gasPrice is not given, the gas
+// price is bumped by the default price bump of the txpool, or raised to the
+// suggested gas price if it is higher. For the transactions using dynamic fee,
+// gasPrice is used as maxFeePerGas and maxPriorityFeePerGas is bumped as well.
+// The replacement of a fee-delegated transaction must be signed by the fee payer
+// too, thus the fee payer account should be available in this node.
+func (s *PublicTransactionPoolAPI) ReplaceTransaction(ctx context.Context, hash common.Hash, gasPrice *hexutil.Big) (common.Hash, error) {
+	tx, from, err := s.poolTransactionSender(hash)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if !s.b.ChainConfig().IsMagmaForkEnabled(new(big.Int).Add(s.b.CurrentBlock().Number(), common.Big1)) {
+		// The gas price is fixed to the unit price before the Magma hardfork.
+		return common.Hash{}, blockchain.ErrAlreadyNonceExistInPool
+	}
+	if tx.Type() == types.TxTypeEthereumBlob {
+		return common.Hash{}, fmt.Errorf("%s cannot be replaced since the sidecar is not kept in the transaction", tx.Type())
+	}
+	args, err := newSendTxArgsFromTransaction(tx, from)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	suggested, err := s.b.SuggestPrice(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	priceBump := s.b.TxPoolPriceBump()
+	bump := func(old *big.Int) *big.Int {
+		bumped := new(big.Int).Div(new(big.Int).Mul(old, big.NewInt(100+int64(priceBump))), big.NewInt(100))
+		if bumped.Cmp(old) <= 0 {
+			bumped.Add(old, common.Big1)
+		}
+		return bumped
+	}
+	feeCap := bump(tx.GasFeeCap())
+	if feeCap.Cmp(suggested) < 0 {
+		feeCap = suggested
+	}
+	if gasPrice != nil {
+		feeCap = gasPrice.ToInt()
+	}
+	if tx.Type() == types.TxTypeEthereumDynamicFee || tx.Type() == types.TxTypeEthereumSetCode {
+		tipCap := bump(tx.GasTipCap())
+		if tipCap.Cmp(feeCap) > 0 {
+			tipCap = feeCap
+		}
+		args.MaxFeePerGas = (*hexutil.Big)(feeCap)
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tipCap)
+	} else {
+		args.Price = (*hexutil.Big)(feeCap)
+	}
+
+	replacement, err := args.toTransaction()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	signedTx, err := s.sign(from, replacement)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if signedTx.IsFeeDelegatedTransaction() {
+		feePayer, err := signedTx.FeePayer()
+		if err != nil {
+			return common.Hash{}, err
+		}
+		if signedTx, err = s.signAsFeePayer(feePayer, signedTx); err != nil {
+			return common.Hash{}, fmt.Errorf("the fee payer %s should sign the replacement: %w", feePayer.Hex(), err)
+		}
+	}
+	return submitTransaction(ctx, s.b, signedTx)
+}
+
 // RecoverFromTransaction recovers the sender address from a signed raw transaction.
 // The signature is validated against the sender account's key configuration at the given block number.
 func (s *PublicTransactionPoolAPI) RecoverFromTransaction(ctx context.Context, encodedTx hexutil.Bytes, blockNumber rpc.BlockNumber) (common.Address, error) {
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"math/big"
 	"os"
 	"reflect"
@@ -118,40 +119,7 @@ func TestTxTypeSupport(t *testing.T) {
 
 	// test for all possible tx types
 	for txType, internalData := range internalDataTypes {
-		// args contains values of tx fields
-		args := SendTxArgs{
-			TypeInt: &txType,
-			From:    testFrom,
-		}
-
-		// set required fields of each typed tx
-		internalType := reflect.TypeOf(internalData)
-		for i := 0; i < internalType.NumField(); i++ {
-			switch internalType.Field(i).Name {
-			case "AccountNonce":
-				args.AccountNonce = &testNonce
-			case "Amount":
-				args.Amount = testValue
-			case "Recipient":
-				args.Recipient = &testTo
-			case "FeePayer":
-				args.FeePayer = &testFeePayer
-			case "FeeRatio":
-				args.FeeRatio = &testFeeRatio
-			case "GasLimit":
-				args.GasLimit = &testGas
-			case "Price":
-				args.Price = testGasPrice
-			case "Payload":
-				args.Payload = &testData
-			case "CodeFormat":
-				args.CodeFormat = &testCodeFormat
-			case "HumanReadable":
-				args.HumanReadable = &testHumanReadable
-			case "Key":
-				args.Key = &testAccountKey
-			}
-		}
+		args := newTestSendTxArgs(txType, internalData)
 		if txType.IsFeeDelegatedTransaction() {
 			args.TxSignatures = testSig
 		}
@@ -163,6 +131,45 @@ func TestTxTypeSupport(t *testing.T) {
 	}
 }
 
+// newTestSendTxArgs returns SendTxArgs which has the test values for all fields of the tx type.
+func newTestSendTxArgs(txType types.TxType, internalData interface{}) SendTxArgs {
+	// args contains values of tx fields
+	args := SendTxArgs{
+		TypeInt: &txType,
+		From:    testFrom,
+	}
+
+	// set required fields of each typed tx
+	internalType := reflect.TypeOf(internalData)
+	for i := 0; i < internalType.NumField(); i++ {
+		switch internalType.Field(i).Name {
+		case "AccountNonce":
+			args.AccountNonce = &testNonce
+		case "Amount":
+			args.Amount = testValue
+		case "Recipient":
+			args.Recipient = &testTo
+		case "FeePayer":
+			args.FeePayer = &testFeePayer
+		case "FeeRatio":
+			args.FeeRatio = &testFeeRatio
+		case "GasLimit":
+			args.GasLimit = &testGas
+		case "Price":
+			args.Price = testGasPrice
+		case "Payload":
+			args.Payload = &testData
+		case "CodeFormat":
+			args.CodeFormat = &testCodeFormat
+		case "HumanReadable":
+			args.HumanReadable = &testHumanReadable
+		case "Key":
+			args.Key = &testAccountKey
+		}
+	}
+	return args
+}
+
 // testTxTypeSupport_normalCase tests APIs with proper SendTxArgs values.
 func testTxTypeSupport_normalCase(t *testing.T, api PublicTransactionPoolAPI, ctx context.Context, args SendTxArgs) {
 	var err error
@@ -339,3 +346,115 @@ func testTxTypeSupport_unnecessaryFieldValues(t *testing.T, api PublicTransactio
 		assert.Equal(t, "json:\"feeRatio\" is not a field of "+(*args.TypeInt).String(), err.Error())
 	}
 }
+
+// TestNewSendTxArgsFromTransaction tests that the SendTxArgs built from a
+// transaction regenerates the same transaction.
+func TestNewSendTxArgsFromTransaction(t *testing.T) {
+	for txType, internalData := range internalDataTypes {
+		args := newTestSendTxArgs(txType, internalData)
+		if txType == types.TxTypeLegacyTransaction {
+			args.Recipient = &testTo
+		}
+		tx, err := args.toTransaction()
+		assert.NoError(t, err, txType.String())
+
+		rebuiltArgs, err := newSendTxArgsFromTransaction(tx, testFrom)
+		assert.NoError(t, err, txType.String())
+		rebuilt, err := rebuiltArgs.toTransaction()
+		assert.NoError(t, err, txType.String())
+
+		assert.Equal(t, tx.GetTxInternalData(), rebuilt.GetTxInternalData(), txType.String())
+	}
+}
+
+// TestReplaceTransaction tests that the replacement and the cancel transaction
+// of a fee-delegated transaction are built and signed properly.
+func TestReplaceTransaction(t *testing.T) {
+	ctx := context.Background()
+	chainConf := params.ChainConfig{ChainID: big.NewInt(1), MagmaCompatibleBlock: big.NewInt(0)}
+	signer := types.LatestSignerForChainID(chainConf.ChainID)
+
+	ks := keystore.NewKeyStore(t.TempDir(), 2, 1)
+	acc, err := ks.ImportECDSA(senderPrvKey, "")
+	assert.NoError(t, err)
+	assert.NoError(t, ks.Unlock(acc, ""))
+	accFeePayer, err := ks.ImportECDSA(feePayerPrvKey, "")
+	assert.NoError(t, err)
+	assert.NoError(t, ks.Unlock(accFeePayer, ""))
+
+	old, err := types.NewTransactionWithMap(types.TxTypeFeeDelegatedValueTransferWithRatio, map[types.TxValueKeyType]interface{}{
+		types.TxValueKeyNonce:              uint64(testNonce),
+		types.TxValueKeyFrom:               acc.Address,
+		types.TxValueKeyTo:                 testTo,
+		types.TxValueKeyAmount:             (*big.Int)(testValue),
+		types.TxValueKeyGasLimit:           uint64(testGas),
+		types.TxValueKeyGasPrice:           (*big.Int)(testGasPrice),
+		types.TxValueKeyFeePayer:           accFeePayer.Address,
+		types.TxValueKeyFeeRatioOfFeePayer: testFeeRatio,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, old.SignWithKeys(signer, []*ecdsa.PrivateKey{senderPrvKey}))
+	assert.NoError(t, old.SignFeePayerWithKeys(signer, []*ecdsa.PrivateKey{feePayerPrvKey}))
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockBackend := mock_api.NewMockBackend(mockCtrl)
+	mockAccountManager := mock_accounts.NewMockAccountManager(mockCtrl)
+
+	var sent *types.Transaction
+	mockBackend.EXPECT().AccountManager().Return(mockAccountManager).AnyTimes()
+	mockBackend.EXPECT().CurrentBlock().Return(
+		types.NewBlockWithHeader(&types.Header{Number: new(big.Int).SetUint64(0)}),
+	).AnyTimes()
+	mockBackend.EXPECT().SuggestPrice(ctx).Return((*big.Int)(testGasPrice), nil).AnyTimes()
+	mockBackend.EXPECT().TxPoolPriceBump().Return(uint64(20)).AnyTimes()
+	mockBackend.EXPECT().ChainConfig().Return(&chainConf).AnyTimes()
+	mockBackend.EXPECT().GetPoolTransaction(old.Hash()).Return(old).AnyTimes()
+	mockBackend.EXPECT().GetPoolTransaction(gomock.Any()).Return(nil).AnyTimes()
+	mockBackend.EXPECT().SendTx(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, tx *types.Transaction) error {
+		sent = tx
+		return nil
+	}).AnyTimes()
+	mockAccountManager.EXPECT().Find(accounts.Account{Address: acc.Address}).Return(ks.Wallets()[0], nil).AnyTimes()
+	mockAccountManager.EXPECT().Find(accounts.Account{Address: accFeePayer.Address}).Return(ks.Wallets()[1], nil).AnyTimes()
+
+	api := PublicTransactionPoolAPI{
+		b:         mockBackend,
+		nonceLock: new(AddrLocker),
+	}
+
+	// The unknown transaction cannot be replaced.
+	_, err = api.ReplaceTransaction(ctx, common.Hash{0x1}, nil)
+	assert.Error(t, err)
+
+	// The replacement is the same transaction with the price bumped by the
+	// price bump of the running pool, signed by both.
+	hash, err := api.ReplaceTransaction(ctx, old.Hash(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, sent.Hash(), hash)
+	assert.Equal(t, old.Type(), sent.Type())
+	assert.Equal(t, old.Nonce(), sent.Nonce())
+	assert.Equal(t, old.Value(), sent.Value())
+	assert.Equal(t, big.NewInt(testGasPrice.ToInt().Int64()*120/100), sent.GasPrice())
+
+	from, err := types.Sender(signer, sent)
+	assert.NoError(t, err)
+	assert.Equal(t, acc.Address, from)
+	feePayer, err := types.SenderFeePayer(signer, sent)
+	assert.NoError(t, err)
+	assert.Equal(t, accFeePayer.Address, feePayer)
+
+	// The given gas price is used as it is.
+	_, err = api.ReplaceTransaction(ctx, old.Hash(), (*hexutil.Big)(big.NewInt(50*params.Gkei)))
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(50*params.Gkei), sent.GasPrice())
+
+	// The cancel transaction is signed only by the sender.
+	_, err = api.CancelTransaction(ctx, old.Hash())
+	assert.NoError(t, err)
+	assert.Equal(t, types.TxTypeCancel, sent.Type())
+	assert.Equal(t, old.Nonce(), sent.Nonce())
+	from, err = types.Sender(signer, sent)
+	assert.NoError(t, err)
+	assert.Equal(t, acc.Address, from)
+}
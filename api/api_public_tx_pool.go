@@ -23,11 +23,15 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
+	"github.com/kaiachain/kaia/blockchain"
 	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/networks/rpc"
 )
 
 // PublicTxPoolAPI offers and API for the transaction pool. It only operates on data that is non confidential.
@@ -67,6 +71,29 @@ func (s *PublicTxPoolAPI) Content() map[string]map[string]map[string]map[string]
 	return content
 }
 
+// ContentFrom returns the transactions contained within the transaction pool
+// sent from the given address.
+func (s *PublicTxPoolAPI) ContentFrom(addr common.Address) map[string]map[string]map[string]interface{} {
+	content := make(map[string]map[string]map[string]interface{}, 2)
+	pending, queue := s.b.TxPoolContentFrom(addr)
+
+	// Build the pending transactions
+	dump := make(map[string]map[string]interface{}, len(pending))
+	for _, tx := range pending {
+		dump[strconv.FormatUint(tx.Nonce(), 10)] = newRPCPendingTransaction(tx, s.b.ChainConfig())
+	}
+	content["pending"] = dump
+
+	// Build the queued transactions
+	dump = make(map[string]map[string]interface{}, len(queue))
+	for _, tx := range queue {
+		dump[strconv.FormatUint(tx.Nonce(), 10)] = newRPCPendingTransaction(tx, s.b.ChainConfig())
+	}
+	content["queued"] = dump
+
+	return content
+}
+
 // Status returns the number of pending and queued transaction in the pool.
 func (s *PublicTxPoolAPI) Status() map[string]hexutil.Uint {
 	pending, queue := s.b.Stats()
@@ -110,3 +137,77 @@ func (s *PublicTxPoolAPI) Inspect() map[string]map[string]map[string]string {
 	}
 	return content
 }
+
+// RPCTxStatus is the status of a transaction returned by txpool_txStatus.
+type RPCTxStatus struct {
+	Status      string          `json:"status"` // One of pending, queued, dropped, included and unknown
+	Reason      string          `json:"reason,omitempty"`
+	ReplacedBy  *common.Hash    `json:"replacedBy,omitempty"`
+	BlockHash   *common.Hash    `json:"blockHash,omitempty"`
+	BlockNumber *hexutil.Uint64 `json:"blockNumber,omitempty"`
+}
+
+// TxStatus returns the status of the transaction of the given hash. A dropped
+// transaction is reported with the reason only while the pool remembers it.
+func (s *PublicTxPoolAPI) TxStatus(hash common.Hash) *RPCTxStatus {
+	switch s.b.TxPoolStatus(hash) {
+	case blockchain.TxStatusPending:
+		return &RPCTxStatus{Status: "pending"}
+	case blockchain.TxStatusQueued:
+		return &RPCTxStatus{Status: "queued"}
+	}
+	if tx, blockHash, blockNumber, _ := s.b.GetTxAndLookupInfo(hash); tx != nil {
+		number := hexutil.Uint64(blockNumber)
+		return &RPCTxStatus{Status: "included", BlockHash: &blockHash, BlockNumber: &number}
+	}
+	if dropped := s.b.TxPoolDroppedTx(hash); dropped != nil {
+		return newRPCTxStatusDropped(dropped)
+	}
+	return &RPCTxStatus{Status: "unknown"}
+}
+
+func newRPCTxStatusDropped(dropped *blockchain.DroppedTx) *RPCTxStatus {
+	status := &RPCTxStatus{Status: "dropped", Reason: string(dropped.Reason)}
+	if dropped.Reason == blockchain.TxDropReasonReplaced {
+		replacement := dropped.Replacement
+		status.ReplacedBy = &replacement
+	}
+	return status
+}
+
+// RPCDroppedTx is the notification of txpool_subscribe("droppedTransactions").
+type RPCDroppedTx struct {
+	Hash common.Hash `json:"hash"`
+	*RPCTxStatus
+}
+
+// DroppedTransactions creates a subscription that is triggered each time a transaction
+// is dropped or replaced in the transaction pool.
+func (s *PublicTxPoolAPI) DroppedTransactions(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+	go func() {
+		droppedCh := make(chan blockchain.DroppedTxsEvent, 128)
+		droppedSub := s.b.SubscribeDroppedTxsEvent(droppedCh)
+		defer droppedSub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-droppedCh:
+				for _, dropped := range ev.Txs {
+					notifier.Notify(rpcSub.ID, &RPCDroppedTx{Hash: dropped.Tx.Hash(), RPCTxStatus: newRPCTxStatusDropped(dropped)})
+				}
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_api "github.com/kaiachain/kaia/api/mocks"
+	"github.com/kaiachain/kaia/blockchain"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicTxPoolAPI_TxStatus(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockBackend := mock_api.NewMockBackend(mockCtrl)
+	api := NewPublicTxPoolAPI(mockBackend)
+
+	var (
+		tx          = types.NewTransaction(0, common.Address{}, big.NewInt(0), 21000, big.NewInt(1), nil)
+		hash        = tx.Hash()
+		blockHash   = common.HexToHash("0x1234")
+		replacement = common.HexToHash("0x5678")
+		blockNumber = hexutil.Uint64(10)
+	)
+
+	mockBackend.EXPECT().TxPoolStatus(hash).Return(blockchain.TxStatusPending)
+	assert.Equal(t, &RPCTxStatus{Status: "pending"}, api.TxStatus(hash))
+
+	mockBackend.EXPECT().TxPoolStatus(hash).Return(blockchain.TxStatusQueued)
+	assert.Equal(t, &RPCTxStatus{Status: "queued"}, api.TxStatus(hash))
+
+	mockBackend.EXPECT().TxPoolStatus(hash).Return(blockchain.TxStatusUnknown)
+	mockBackend.EXPECT().GetTxAndLookupInfo(hash).Return(tx, blockHash, uint64(blockNumber), uint64(0))
+	assert.Equal(t, &RPCTxStatus{Status: "included", BlockHash: &blockHash, BlockNumber: &blockNumber}, api.TxStatus(hash))
+
+	mockBackend.EXPECT().TxPoolStatus(hash).Return(blockchain.TxStatusUnknown)
+	mockBackend.EXPECT().GetTxAndLookupInfo(hash).Return(nil, common.Hash{}, uint64(0), uint64(0))
+	mockBackend.EXPECT().TxPoolDroppedTx(hash).Return(&blockchain.DroppedTx{Tx: tx, Reason: blockchain.TxDropReasonReplaced, Replacement: replacement})
+	assert.Equal(t, &RPCTxStatus{Status: "dropped", Reason: "replaced", ReplacedBy: &replacement}, api.TxStatus(hash))
+
+	mockBackend.EXPECT().TxPoolStatus(hash).Return(blockchain.TxStatusUnknown)
+	mockBackend.EXPECT().GetTxAndLookupInfo(hash).Return(nil, common.Hash{}, uint64(0), uint64(0))
+	mockBackend.EXPECT().TxPoolDroppedTx(hash).Return(nil)
+	assert.Equal(t, &RPCTxStatus{Status: "unknown"}, api.TxStatus(hash))
+}
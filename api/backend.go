@@ -73,6 +73,7 @@ type Backend interface {
 	StateAndHeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*state.StateDB, *types.Header, error)
 	StateAndHeaderByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*state.StateDB, *types.Header, error)
 	GetBlockReceipts(ctx context.Context, blockHash common.Hash) types.Receipts
+	GetBlobSidecars(ctx context.Context, blockHash common.Hash, number uint64) []*types.BlobTxSidecarWithHash
 	GetTxLookupInfoAndReceipt(ctx context.Context, hash common.Hash) (*types.Transaction, common.Hash, uint64, uint64, *types.Receipt)
 	GetTxAndLookupInfo(hash common.Hash) (*types.Transaction, common.Hash, uint64, uint64)
 	GetEVM(ctx context.Context, msg blockchain.Message, state *state.StateDB, header *types.Header, vmCfg vm.Config) (*vm.EVM, func() error, error)
@@ -86,12 +87,19 @@ type Backend interface {
 
 	// TxPool API
 	SendTx(ctx context.Context, signedTx *types.Transaction) error
+	SendBundle(ctx context.Context, bundle *types.Bundle) error
+	SendPrivateTx(ctx context.Context, signedTx *types.Transaction, expiryBlocks uint64) error
 	GetPoolTransactions() (types.Transactions, error)
 	GetPoolTransaction(txHash common.Hash) *types.Transaction
 	GetPoolNonce(ctx context.Context, addr common.Address) uint64
 	Stats() (pending int, queued int)
 	TxPoolContent() (map[common.Address]types.Transactions, map[common.Address]types.Transactions)
+	TxPoolContentFrom(addr common.Address) (types.Transactions, types.Transactions)
+	TxPoolStatus(hash common.Hash) blockchain.TxStatus
+	TxPoolDroppedTx(hash common.Hash) *blockchain.DroppedTx
+	TxPoolPriceBump() uint64
 	SubscribeNewTxsEvent(chan<- blockchain.NewTxsEvent) event.Subscription
+	SubscribeDroppedTxsEvent(chan<- blockchain.DroppedTxsEvent) event.Subscription
 
 	ChainConfig() *params.ChainConfig
 	CurrentBlock() *types.Block
@@ -195,6 +195,20 @@ func (mr *MockBackendMockRecorder) FeeHistory(arg0, arg1, arg2, arg3 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FeeHistory", reflect.TypeOf((*MockBackend)(nil).FeeHistory), arg0, arg1, arg2, arg3)
 }
 
+// GetBlobSidecars mocks base method.
+func (m *MockBackend) GetBlobSidecars(arg0 context.Context, arg1 common.Hash, arg2 uint64) []*types.BlobTxSidecarWithHash {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlobSidecars", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*types.BlobTxSidecarWithHash)
+	return ret0
+}
+
+// GetBlobSidecars indicates an expected call of GetBlobSidecars.
+func (mr *MockBackendMockRecorder) GetBlobSidecars(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlobSidecars", reflect.TypeOf((*MockBackend)(nil).GetBlobSidecars), arg0, arg1, arg2)
+}
+
 // GetBlockReceipts mocks base method.
 func (m *MockBackend) GetBlockReceipts(arg0 context.Context, arg1 common.Hash) types.Receipts {
 	m.ctrl.T.Helper()
@@ -523,6 +537,34 @@ func (mr *MockBackendMockRecorder) RPCTxFeeCap() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RPCTxFeeCap", reflect.TypeOf((*MockBackend)(nil).RPCTxFeeCap))
 }
 
+// SendBundle mocks base method.
+func (m *MockBackend) SendBundle(arg0 context.Context, arg1 *types.Bundle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendBundle", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendBundle indicates an expected call of SendBundle.
+func (mr *MockBackendMockRecorder) SendBundle(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendBundle", reflect.TypeOf((*MockBackend)(nil).SendBundle), arg0, arg1)
+}
+
+// SendPrivateTx mocks base method.
+func (m *MockBackend) SendPrivateTx(arg0 context.Context, arg1 *types.Transaction, arg2 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPrivateTx", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendPrivateTx indicates an expected call of SendPrivateTx.
+func (mr *MockBackendMockRecorder) SendPrivateTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPrivateTx", reflect.TypeOf((*MockBackend)(nil).SendPrivateTx), arg0, arg1, arg2)
+}
+
 // SendTx mocks base method.
 func (m *MockBackend) SendTx(arg0 context.Context, arg1 *types.Transaction) error {
 	m.ctrl.T.Helper()
@@ -640,6 +682,20 @@ func (mr *MockBackendMockRecorder) SubscribeChainSideEvent(arg0 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeChainSideEvent", reflect.TypeOf((*MockBackend)(nil).SubscribeChainSideEvent), arg0)
 }
 
+// SubscribeDroppedTxsEvent mocks base method.
+func (m *MockBackend) SubscribeDroppedTxsEvent(arg0 chan<- blockchain.DroppedTxsEvent) event.Subscription {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeDroppedTxsEvent", arg0)
+	ret0, _ := ret[0].(event.Subscription)
+	return ret0
+}
+
+// SubscribeDroppedTxsEvent indicates an expected call of SubscribeDroppedTxsEvent.
+func (mr *MockBackendMockRecorder) SubscribeDroppedTxsEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeDroppedTxsEvent", reflect.TypeOf((*MockBackend)(nil).SubscribeDroppedTxsEvent), arg0)
+}
+
 // SubscribeNewTxsEvent mocks base method.
 func (m *MockBackend) SubscribeNewTxsEvent(arg0 chan<- blockchain.NewTxsEvent) event.Subscription {
 	m.ctrl.T.Helper()
@@ -699,6 +755,63 @@ func (mr *MockBackendMockRecorder) TxPoolContent() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TxPoolContent", reflect.TypeOf((*MockBackend)(nil).TxPoolContent))
 }
 
+// TxPoolContentFrom mocks base method.
+func (m *MockBackend) TxPoolContentFrom(arg0 common.Address) (types.Transactions, types.Transactions) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TxPoolContentFrom", arg0)
+	ret0, _ := ret[0].(types.Transactions)
+	ret1, _ := ret[1].(types.Transactions)
+	return ret0, ret1
+}
+
+// TxPoolContentFrom indicates an expected call of TxPoolContentFrom.
+func (mr *MockBackendMockRecorder) TxPoolContentFrom(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TxPoolContentFrom", reflect.TypeOf((*MockBackend)(nil).TxPoolContentFrom), arg0)
+}
+
+// TxPoolDroppedTx mocks base method.
+func (m *MockBackend) TxPoolDroppedTx(arg0 common.Hash) *blockchain.DroppedTx {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TxPoolDroppedTx", arg0)
+	ret0, _ := ret[0].(*blockchain.DroppedTx)
+	return ret0
+}
+
+// TxPoolDroppedTx indicates an expected call of TxPoolDroppedTx.
+func (mr *MockBackendMockRecorder) TxPoolDroppedTx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TxPoolDroppedTx", reflect.TypeOf((*MockBackend)(nil).TxPoolDroppedTx), arg0)
+}
+
+// TxPoolPriceBump mocks base method.
+func (m *MockBackend) TxPoolPriceBump() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TxPoolPriceBump")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// TxPoolPriceBump indicates an expected call of TxPoolPriceBump.
+func (mr *MockBackendMockRecorder) TxPoolPriceBump() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TxPoolPriceBump", reflect.TypeOf((*MockBackend)(nil).TxPoolPriceBump))
+}
+
+// TxPoolStatus mocks base method.
+func (m *MockBackend) TxPoolStatus(arg0 common.Hash) blockchain.TxStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TxPoolStatus", arg0)
+	ret0, _ := ret[0].(blockchain.TxStatus)
+	return ret0
+}
+
+// TxPoolStatus indicates an expected call of TxPoolStatus.
+func (mr *MockBackendMockRecorder) TxPoolStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TxPoolStatus", reflect.TypeOf((*MockBackend)(nil).TxPoolStatus), arg0)
+}
+
 // UpperBoundGasPrice mocks base method.
 func (m *MockBackend) UpperBoundGasPrice(arg0 context.Context) *big.Int {
 	m.ctrl.T.Helper()
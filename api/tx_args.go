@@ -399,6 +399,79 @@ func (args *SendTxArgs) setGasPrice(gasPrice *hexutil.Big) {
 	args.Price = gasPrice
 }
 
+// newSendTxArgsFromTransaction returns the SendTxArgs which builds a transaction
+// with the same fields as the given transaction except the signatures.
+func newSendTxArgsFromTransaction(tx *types.Transaction, from common.Address) (*SendTxArgs, error) {
+	var (
+		txType   = tx.Type()
+		nonce    = hexutil.Uint64(tx.Nonce())
+		gasLimit = hexutil.Uint64(tx.Gas())
+		output   = tx.MakeRPCOutput()
+	)
+	// hasField reports whether the field is used by the tx type. All fields are
+	// considered for the ethereum tx types since they are validated by types.
+	hasField := func(name string) bool {
+		return txType.IsEthereumTransaction() || isTxField[txType][name]
+	}
+	args := &SendTxArgs{
+		TypeInt:      &txType,
+		From:         from,
+		GasLimit:     &gasLimit,
+		AccountNonce: &nonce,
+	}
+	if hasField("Recipient") {
+		args.Recipient = tx.To()
+	}
+	if hasField("Amount") {
+		args.Amount = (*hexutil.Big)(tx.Value())
+	}
+	if hasField("Payload") {
+		payload := hexutil.Bytes(tx.Data())
+		args.Payload = &payload
+	}
+	if hasField("CodeFormat") {
+		if v, ok := output["codeFormat"].(hexutil.Uint); ok {
+			codeFormat := params.CodeFormat(v)
+			args.CodeFormat = &codeFormat
+		}
+	}
+	if hasField("HumanReadable") {
+		if v, ok := output["humanReadable"].(bool); ok {
+			args.HumanReadable = &v
+		}
+	}
+	if hasField("Key") {
+		if v, ok := output["key"].(hexutil.Bytes); ok {
+			args.Key = &v
+		}
+	}
+	if tx.IsFeeDelegatedTransaction() {
+		feePayer, err := tx.FeePayer()
+		if err != nil {
+			return nil, err
+		}
+		args.FeePayer = &feePayer
+	}
+	if feeRatio, ok := tx.FeeRatio(); ok {
+		args.FeeRatio = &feeRatio
+	}
+	if txType.IsEthTypedTransaction() {
+		accessList := tx.AccessList()
+		args.AccessList = &accessList
+		args.ChainID = (*hexutil.Big)(tx.ChainId())
+	}
+	if txType == types.TxTypeEthereumSetCode {
+		args.AuthorizationList = tx.AuthList()
+	}
+	if txType == types.TxTypeEthereumDynamicFee || txType == types.TxTypeEthereumSetCode {
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	} else {
+		args.Price = (*hexutil.Big)(tx.GasPrice())
+	}
+	return args, nil
+}
+
 type ValueTransferTxArgs struct {
 	From     common.Address  `json:"from"`
 	Gas      *hexutil.Uint64 `json:"gas"`
@@ -72,6 +72,20 @@ func (v *BlockValidator) ValidateBody(block *types.Block) error {
 	if hash := types.DeriveSha(block.Transactions(), block.Number()); hash != header.TxHash {
 		return fmt.Errorf("transaction root hash mismatch: have %x, want %x", hash, header.TxHash)
 	}
+	// The blob transactions in the block must not carry their sidecars, and the
+	// blob gas of them must add up to the one in the header.
+	if header.BlobGasUsed != nil {
+		var blobGasUsed uint64
+		for _, tx := range block.Transactions() {
+			if tx.BlobTxSidecar() != nil {
+				return fmt.Errorf("unexpected blob sidecar in block: txHash %x", tx.Hash())
+			}
+			blobGasUsed += tx.BlobGas()
+		}
+		if blobGasUsed != *header.BlobGasUsed {
+			return fmt.Errorf("invalid blob gas used (remote: %d local: %d)", *header.BlobGasUsed, blobGasUsed)
+		}
+	}
 	baseFee := block.Header().BaseFee
 	if baseFee != nil {
 		for _, tx := range block.Transactions() {
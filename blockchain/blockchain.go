@@ -111,7 +111,8 @@ const (
 const (
 	DefaultTriesInMemory        = 128
 	DefaultBlockInterval        = 128
-	DefaultLivePruningRetention = 172800 // 2*params.DefaultStakeUpdateInterval
+	DefaultLivePruningRetention = 172800  // 2*params.DefaultStakeUpdateInterval
+	DefaultBlobSidecarRetention = 1555200 // 18 days of 1-second blocks, the same period as in Ethereum
 	MaxPrefetchTxs              = 20000
 
 	// BlockChainVersion ensures that an incompatible database forces a resync from scratch.
@@ -132,6 +133,7 @@ type CacheConfig struct {
 	TriesInMemory        uint64                       // Maximum number of recent state tries according to its block number
 	LivePruningRetention uint64                       // Number of blocks before trie nodes in pruning marks to be deleted. If zero, obsolete nodes are not deleted.
 	SenderTxHashIndexing bool                         // Enables saving senderTxHash to txHash mapping information to database and cache
+	BlobSidecarRetention uint64                       // Number of blocks before blob sidecars to be deleted. If zero, blob sidecars are not deleted.
 	TrieNodeCacheConfig  *statedb.TrieNodeCacheConfig // Configures trie node cache
 	SnapshotCacheSize    int                          // Memory allowance (MB) to use for caching snapshot entries in memory
 	SnapshotAsyncGen     bool                         // Enables snapshot data generation asynchronously
@@ -240,6 +242,7 @@ func NewBlockChain(db database.DBManager, cacheConfig *CacheConfig, chainConfig
 			BlockInterval:        DefaultBlockInterval,
 			TriesInMemory:        DefaultTriesInMemory,
 			LivePruningRetention: DefaultLivePruningRetention,
+			BlobSidecarRetention: DefaultBlobSidecarRetention,
 			TrieNodeCacheConfig:  statedb.GetEmptyTrieNodeCacheConfig(),
 			SnapshotCacheSize:    512,
 			SnapshotAsyncGen:     true,
@@ -562,7 +565,7 @@ func (bc *BlockChain) setHeadBeyondRoot(head uint64, root common.Hash, repair bo
 					if root != (common.Hash{}) && !beyondRoot && newHeadBlock.Root() == root {
 						beyondRoot, rootNumber = true, newHeadBlock.NumberU64()
 					}
-					if _, err := state.New(newHeadBlock.Root(), bc.stateCache, bc.snaps, nil); err != nil {
+					if err := bc.recoverState(newHeadBlock); err != nil {
 						// Rewound state missing, rolled back to the parent block, reset to genesis
 						logger.Trace("Block state missing, rewinding further", "number", newHeadBlock.NumberU64(), "hash", newHeadBlock.Hash())
 						parent := bc.GetBlock(newHeadBlock.ParentHash(), newHeadBlock.NumberU64()-1)
@@ -923,6 +926,17 @@ func (bc *BlockChain) HasBlock(hash common.Hash, number uint64) bool {
 	return bc.db.HasBlock(hash, number)
 }
 
+// recoverState makes the state of the given block available. In the path-based scheme,
+// the newer diff layers are discarded and the disk layer is rewound if needed.
+func (bc *BlockChain) recoverState(block *types.Block) error {
+	root := block.Root()
+	if err := bc.stateCache.TrieDB().Recover(root, block.NumberU64()); err != nil {
+		return err
+	}
+	_, err := state.New(root, bc.stateCache, bc.snaps, nil)
+	return err
+}
+
 // HasState checks if state trie is fully present in the database or not.
 func (bc *BlockChain) HasState(hash common.Hash) bool {
 	_, err := bc.stateCache.OpenTrie(hash, nil)
@@ -1012,6 +1026,32 @@ func (bc *BlockChain) GetReceiptsByBlockHash(blockHash common.Hash) types.Receip
 	return bc.db.ReadReceiptsByBlockHash(blockHash)
 }
 
+// GetBlobSidecars retrieves the blob sidecars of the transactions in the block
+// of the given hash and number. It returns nil if the block is beyond the
+// retention window of the blob sidecars.
+func (bc *BlockChain) GetBlobSidecars(hash common.Hash, number uint64) []*types.BlobTxSidecarWithHash {
+	if retention := bc.cacheConfig.BlobSidecarRetention; retention != 0 && number+retention <= bc.CurrentBlock().NumberU64() {
+		return nil
+	}
+	return bc.db.ReadBlobSidecars(hash, number)
+}
+
+// WriteBlobSidecars stores the blob sidecars of the transactions in the block
+// of the given hash and number, and deletes the blob sidecars beyond the
+// retention window.
+func (bc *BlockChain) WriteBlobSidecars(hash common.Hash, number uint64, sidecars []*types.BlobTxSidecarWithHash) {
+	if len(sidecars) == 0 {
+		return
+	}
+	bc.db.WriteBlobSidecars(hash, number, sidecars)
+
+	if retention := bc.cacheConfig.BlobSidecarRetention; retention != 0 && number > retention {
+		if pruned := bc.db.PruneBlobSidecars(number - retention + 1); pruned > 0 {
+			logger.Debug("Pruned blob sidecars", "number", number, "retention", retention, "blocks", pruned)
+		}
+	}
+}
+
 // GetReceiptByTxHash retrieves a receipt for a given transaction hash.
 func (bc *BlockChain) GetReceiptByTxHash(txHash common.Hash) *types.Receipt {
 	receipt := bc.GetTxReceiptInCache(txHash)
@@ -1099,7 +1139,14 @@ func (bc *BlockChain) Stop() {
 		bc.snaps.Release()
 	}
 	triedb := bc.stateCache.TrieDB()
-	if !bc.isArchiveMode() {
+	if triedb.Scheme() == database.PathScheme {
+		// The diff layers live only in memory, so the state of the current block is written to disk.
+		// The layers of the side chains are discarded.
+		logger.Info("Writing diff layers to disk", "block", bc.CurrentBlock().Number(), "root", bc.CurrentBlock().Root())
+		if err := triedb.Flush(bc.CurrentBlock().Root()); err != nil {
+			logger.Error("Failed to flush diff layers", "err", err)
+		}
+	} else if !bc.isArchiveMode() {
 		number := bc.CurrentBlock().NumberU64()
 		recent := bc.GetBlockByNumber(number)
 		if recent == nil {
@@ -1374,9 +1421,14 @@ func (bc *BlockChain) writeStateTrie(block *types.Block, state *state.StateDB) e
 	trieDB := bc.stateCache.TrieDB()
 	trieDB.UpdateMetricNodes()
 
-	// If we're running an archive node, always flush
-	if bc.isArchiveMode() {
-		if err := trieDB.Commit(root, false, block.NumberU64()); err != nil {
+	// If we're running an archive node or using the path-based scheme, always flush.
+	// In the path-based scheme, the trie is moved into a diff layer on top of the parent state instead of disk.
+	if bc.isArchiveMode() || trieDB.Scheme() == database.PathScheme {
+		var parentRoot common.Hash
+		if parent := bc.GetHeader(block.ParentHash(), block.NumberU64()-1); parent != nil {
+			parentRoot = parent.Root
+		}
+		if err := trieDB.CommitState(root, parentRoot, false, block.NumberU64()); err != nil {
 			return err
 		}
 
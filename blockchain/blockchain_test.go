@@ -2492,3 +2492,44 @@ func applyTransaction(chain *BlockChain, state *state.StateDB, tx *types.Transac
 	_, _, err := chain.ApplyTransaction(chain.Config(), &author, state, header, tx, &usedGas, &vm.Config{})
 	return err
 }
+
+// TestPathSchemeRestart checks that the state of the current block is available after
+// restarting a chain of the path-based scheme, even if a side chain was committed last.
+func TestPathSchemeRestart(t *testing.T) {
+	var (
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		gspec  = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  GenesisAlloc{addr: {Balance: big.NewInt(10000000000000)}},
+		}
+		signer = types.LatestSignerForChainID(gspec.Config.ChainID)
+		gendb  = database.NewMemoryDBManager()
+	)
+	genesis := gspec.MustCommit(gendb)
+	blocks, _ := GenerateChain(gspec.Config, genesis, gxhash.NewFaker(), gendb, 10, nil)
+	sideBlocks, _ := GenerateChain(gspec.Config, genesis, gxhash.NewFaker(), gendb, 2, func(i int, gen *BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(addr), common.Address{0x01}, big.NewInt(1), params.TxGas, new(big.Int), nil), signer, key)
+		require.NoError(t, err)
+		gen.AddTx(tx)
+	})
+
+	db := database.NewMemoryDBManager()
+	db.WriteStateScheme(database.PathScheme)
+	gspec.MustCommit(db)
+	chain, err := NewBlockChain(db, nil, gspec.Config, gxhash.NewFaker(), vm.Config{})
+	require.NoError(t, err)
+	_, err = chain.InsertChain(blocks)
+	require.NoError(t, err)
+	_, err = chain.InsertChain(sideBlocks)
+	require.NoError(t, err)
+	require.Equal(t, blocks[9].Hash(), chain.CurrentBlock().Hash())
+	chain.Stop()
+
+	chain, err = NewBlockChain(db, nil, gspec.Config, gxhash.NewFaker(), vm.Config{})
+	require.NoError(t, err)
+	defer chain.Stop()
+	assert.Equal(t, blocks[9].Hash(), chain.CurrentBlock().Hash())
+	_, err = chain.State()
+	assert.NoError(t, err)
+}
@@ -100,6 +100,9 @@ func (b *BlockGen) AddTxWithChain(bc *BlockChain, tx *types.Transaction) {
 	}
 	b.txs = append(b.txs, tx)
 	b.receipts = append(b.receipts, receipt)
+	if b.header.BlobGasUsed != nil {
+		*b.header.BlobGasUsed += tx.BlobGas()
+	}
 }
 
 // AddTxWithChainEvenHasError is an AddTx that inherits the vmConfig of the received chain
@@ -267,6 +270,11 @@ func makeHeader(chain consensus.ChainReader, parent *types.Block, state *state.S
 	if chain.Config().IsMagmaForkEnabled(header.Number) {
 		header.BaseFee = misc.NextMagmaBlockBaseFee(parent.Header(), chain.Config().Governance.KIP71)
 	}
+	if chain.Config().IsBlobTxForkEnabled(header.Number) {
+		excessBlobGas := misc.CalcExcessBlobGas(chain.Config(), parent.Header())
+		header.ExcessBlobGas = &excessBlobGas
+		header.BlobGasUsed = new(uint64)
+	}
 	return header
 }
 
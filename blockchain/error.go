@@ -36,6 +36,10 @@ var (
 	// by a transaction is higher than what's left in the block.
 	ErrGasLimitReached = errors.New("gas limit reached")
 
+	// ErrBlobGasLimitReached is returned if the amount of blob gas required by a
+	// transaction is higher than what's left in the block.
+	ErrBlobGasLimitReached = errors.New("blob gas limit reached")
+
 	// ErrBlacklistedHash is returned if a block to import is on the blacklist.
 	ErrBlacklistedHash = errors.New("blacklisted hash")
 
@@ -172,3 +176,30 @@ var (
 	ErrAuthorizationNonceMismatch          = errors.New("EIP-7702 authorization nonce does not match current account nonce")
 	ErrAuthorizationNotAllowAccountKeyType = errors.New("EIP-7702 authorization don't allow AccountKeyType")
 )
+
+// Transaction bundle errors.
+var (
+	// ErrEmptyBundle is returned if a bundle has no transactions.
+	ErrEmptyBundle = errors.New("bundle has no transactions")
+
+	// ErrBundleTooLarge is returned if a bundle has more transactions than allowed.
+	ErrBundleTooLarge = errors.New("too many transactions in the bundle")
+
+	// ErrBundleNoTarget is returned if a bundle has no target block number.
+	ErrBundleNoTarget = errors.New("bundle has no target block number")
+
+	// ErrBundleExpired is returned if the target block of a bundle is already mined.
+	ErrBundleExpired = errors.New("target block of the bundle is already mined")
+
+	// ErrBundleTooFarFuture is returned if the target block of a bundle is too far from the current block.
+	ErrBundleTooFarFuture = errors.New("target block of the bundle is too far in the future")
+
+	// ErrKnownBundle is returned if a bundle is already in the tx pool.
+	ErrKnownBundle = errors.New("bundle already known")
+
+	// ErrBundlePoolFull is returned if the tx pool cannot accept more bundles.
+	ErrBundlePoolFull = errors.New("bundle pool is full")
+
+	// ErrBundleSenderLimit is returned if a sender of a bundle has too many bundles in the tx pool.
+	ErrBundleSenderLimit = errors.New("too many bundles from the sender")
+)
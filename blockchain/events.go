@@ -33,6 +33,21 @@ import (
 // NewTxsEvent is posted when a batch of transactions enter the transaction pool.
 type NewTxsEvent struct{ Txs []*types.Transaction }
 
+// NewPrivateTxsEvent is posted when a batch of private transactions enter the
+// transaction pool. They are never posted with NewTxsEvent.
+type NewPrivateTxsEvent struct{ Txs []*types.Transaction }
+
+// DroppedTxsEvent is posted when a batch of transactions are dropped or replaced
+// in the transaction pool.
+type DroppedTxsEvent struct{ Txs []*DroppedTx }
+
+// MissingBlobSidecarsEvent is posted when a block includes blob transactions
+// whose sidecars have never been received by the transaction pool.
+type MissingBlobSidecarsEvent struct {
+	Block    *types.Block
+	TxHashes []common.Hash
+}
+
 // PendingLogsEvent is posted pre mining and notifies of pending logs.
 type PendingLogsEvent struct {
 	Logs []*types.Log
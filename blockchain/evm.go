@@ -34,6 +34,7 @@ import (
 	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/common/hexutil"
 	"github.com/kaiachain/kaia/consensus"
+	"github.com/kaiachain/kaia/consensus/misc"
 	"github.com/kaiachain/kaia/params"
 )
 
@@ -54,6 +55,7 @@ func NewEVMBlockContext(header *types.Header, chain ChainContext, author *common
 		beneficiary common.Address
 		rewardBase  common.Address
 		baseFee     *big.Int
+		blobBaseFee *big.Int
 		random      common.Hash
 	)
 
@@ -71,6 +73,12 @@ func NewEVMBlockContext(header *types.Header, chain ChainContext, author *common
 		baseFee = new(big.Int).SetUint64(params.ZeroBaseFee)
 	}
 
+	if header.ExcessBlobGas != nil {
+		blobBaseFee = misc.CalcBlobFee(*header.ExcessBlobGas)
+	} else { // Before BlobTx hardfork, BLOBBASEFEE (0x4a) returns 0
+		blobBaseFee = new(big.Int).SetUint64(params.ZeroBaseFee)
+	}
+
 	if header.MixHash != nil {
 		random = common.BytesToHash(header.MixHash)
 	} else { // Before Randao hardfork, RANDOM (44) returns last block hash
@@ -87,6 +95,7 @@ func NewEVMBlockContext(header *types.Header, chain ChainContext, author *common
 		Time:        new(big.Int).Set(header.Time),
 		BlockScore:  new(big.Int).Set(header.BlockScore),
 		BaseFee:     baseFee,
+		BlobBaseFee: blobBaseFee,
 		Random:      random,
 	}
 }
@@ -94,8 +103,9 @@ func NewEVMBlockContext(header *types.Header, chain ChainContext, author *common
 // NewEVMTxContext creates a new transaction context for a single transaction.
 func NewEVMTxContext(msg Message, header *types.Header, config *params.ChainConfig) vm.TxContext {
 	return vm.TxContext{
-		Origin:   msg.ValidatedSender(),
-		GasPrice: new(big.Int).Set(msg.EffectiveGasPrice(header, config)),
+		Origin:     msg.ValidatedSender(),
+		GasPrice:   new(big.Int).Set(msg.EffectiveGasPrice(header, config)),
+		BlobHashes: msg.BlobHashes(),
 	}
 }
 
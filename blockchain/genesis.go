@@ -368,9 +368,16 @@ func (g *Genesis) ToBlock(baseStateRoot common.Hash, db database.DBManager) *typ
 		head.RandomReveal = params.ZeroRandomReveal
 		head.MixHash = params.ZeroMixHash
 	}
+	if g.Config != nil && g.Config.IsBlobTxForkEnabled(common.Big0) {
+		head.BlobGasUsed = new(uint64)
+		head.ExcessBlobGas = new(uint64)
+	}
 
 	stateDB.Commit(false)
 	stateDB.Database().TrieDB().Commit(root, true, g.Number)
+	if err := stateDB.Database().TrieDB().Flush(root); err != nil {
+		logger.Crit("Failed to flush genesis state", "err", err)
+	}
 
 	return types.NewBlock(head, nil, nil)
 }
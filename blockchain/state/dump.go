@@ -23,16 +23,40 @@
 package state
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
+	"time"
 
 	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/blockchain/types/account"
+	"github.com/kaiachain/kaia/blockchain/types/accountkey"
 	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
 	"github.com/kaiachain/kaia/rlp"
+	"github.com/kaiachain/kaia/snapshot"
 	"github.com/kaiachain/kaia/storage/statedb"
 )
 
+// DumpConfig is a set of options to control what portions of the state will be
+// iterated and collected.
+type DumpConfig struct {
+	SkipCode          bool
+	SkipStorage       bool
+	OnlyWithAddresses bool
+	Start             []byte // Hashed address to start the iteration from
+	Max               uint64 // Maximum number of accounts to collect. No limit if zero
+}
+
+// DumpCollector is the interface which the state calls during the iteration.
+type DumpCollector interface {
+	// OnRoot is called with the state root.
+	OnRoot(common.Hash)
+	// OnAccount is called once for each account in the state.
+	OnAccount(*common.Address, DumpAccount)
+}
+
 type DumpAccount struct {
 	Balance  string            `json:"balance"`
 	Nonce    uint64            `json:"nonce"`
@@ -40,6 +64,12 @@ type DumpAccount struct {
 	CodeHash string            `json:"codeHash"`
 	Code     string            `json:"code"`
 	Storage  map[string]string `json:"storage"`
+
+	// The fields below are filled only by DumpToCollector.
+	Address     *common.Address                  `json:"address,omitempty"` // Address only present in iterative (line-by-line) mode
+	SecureKey   hexutil.Bytes                    `json:"hashedAddress,omitempty"`
+	AccountType string                           `json:"accountType,omitempty"`
+	AccountKey  *accountkey.AccountKeySerializer `json:"accountKey,omitempty"`
 }
 
 type Dump struct {
@@ -47,6 +77,46 @@ type Dump struct {
 	Accounts map[string]DumpAccount `json:"accounts"`
 }
 
+// IteratorDump is an implementation of DumpCollector for paging requests.
+// Accounts without the preimage of the address are keyed by the hashed address.
+type IteratorDump struct {
+	Root     string                 `json:"root"`
+	Accounts map[string]DumpAccount `json:"accounts"`
+	Next     hexutil.Bytes          `json:"next,omitempty"` // nil if no more accounts
+}
+
+// OnRoot implements DumpCollector interface.
+func (d *IteratorDump) OnRoot(root common.Hash) {
+	d.Root = fmt.Sprintf("%x", root)
+}
+
+// OnAccount implements DumpCollector interface.
+func (d *IteratorDump) OnAccount(addr *common.Address, account DumpAccount) {
+	if addr == nil {
+		d.Accounts[fmt.Sprintf("pre(%x)", []byte(account.SecureKey))] = account
+		return
+	}
+	d.Accounts[common.Bytes2Hex(addr.Bytes())] = account
+}
+
+// iterativeDump is a DumpCollector which writes the root and the accounts as JSON lines.
+type iterativeDump struct {
+	*json.Encoder
+}
+
+// OnRoot implements DumpCollector interface.
+func (d iterativeDump) OnRoot(root common.Hash) {
+	d.Encode(struct {
+		Root common.Hash `json:"root"`
+	}{root})
+}
+
+// OnAccount implements DumpCollector interface.
+func (d iterativeDump) OnAccount(addr *common.Address, account DumpAccount) {
+	account.Address = addr
+	d.Encode(account)
+}
+
 func (self *StateDB) RawDump() Dump {
 	dump := Dump{
 		Root:     fmt.Sprintf("%x", self.trie.Hash()),
@@ -97,3 +167,209 @@ func (self *StateDB) Dump() []byte {
 
 	return json
 }
+
+// dumpIterator abstracts the iteration over the accounts or the storage slots
+// of the snapshot or the trie.
+type dumpIterator interface {
+	Next() bool
+	Hash() common.Hash
+	Value() []byte
+	Error() error
+	Release()
+}
+
+// trieDumpIterator iterates the leaves of a trie.
+type trieDumpIterator struct {
+	it *statedb.Iterator
+}
+
+func (t *trieDumpIterator) Next() bool        { return t.it.Next() }
+func (t *trieDumpIterator) Hash() common.Hash { return common.BytesToHash(t.it.Key) }
+func (t *trieDumpIterator) Value() []byte     { return t.it.Value }
+func (t *trieDumpIterator) Error() error      { return t.it.Err }
+func (t *trieDumpIterator) Release()          {}
+
+// snapAccountDumpIterator iterates the accounts of a snapshot.
+type snapAccountDumpIterator struct {
+	snapshot.AccountIterator
+}
+
+func (s snapAccountDumpIterator) Value() []byte { return s.Account() }
+
+// snapStorageDumpIterator iterates the storage slots of an account in a snapshot.
+type snapStorageDumpIterator struct {
+	snapshot.StorageIterator
+}
+
+func (s snapStorageDumpIterator) Value() []byte { return s.Slot() }
+
+// accountIterator returns an iterator over the accounts starting from the given hashed address.
+// The snapshot is used if it is available for the state.
+func (s *StateDB) accountIterator(start []byte) (dumpIterator, error) {
+	if s.snap != nil {
+		it, err := s.snaps.AccountIterator(s.snap.Root(), common.BytesToHash(start))
+		if err == nil {
+			return snapAccountDumpIterator{it}, nil
+		}
+		logger.Debug("Failed to iterate the snapshot, falling back to the trie", "root", s.snap.Root(), "err", err)
+	}
+	return &trieDumpIterator{statedb.NewIterator(s.trie.NodeIterator(start))}, nil
+}
+
+// storageIterator returns an iterator over the storage slots of the account.
+// The snapshot is used if it is available for the state.
+func (s *StateDB) storageIterator(addrHash common.Hash, root common.ExtHash) (dumpIterator, error) {
+	if s.snap != nil {
+		it, err := s.snaps.StorageIterator(s.snap.Root(), addrHash, common.Hash{})
+		if err == nil {
+			return snapStorageDumpIterator{it}, nil
+		}
+		logger.Debug("Failed to iterate the snapshot, falling back to the trie", "root", s.snap.Root(), "err", err)
+	}
+	tr, err := s.db.OpenStorageTrie(root, &statedb.TrieOpts{Owner: addrHash})
+	if err != nil {
+		return nil, err
+	}
+	return &trieDumpIterator{statedb.NewIterator(tr.NodeIterator(nil))}, nil
+}
+
+// DumpToCollector iterates the state according to the given options and inserts
+// the items into a collector for aggregation or serialization. The snapshot is
+// iterated instead of the trie if it is available for the state.
+// It returns the hashed address of the next account if the iteration stopped by conf.Max.
+func (s *StateDB) DumpToCollector(c DumpCollector, conf *DumpConfig) ([]byte, error) {
+	// Sanitize the input to allow nil configs
+	if conf == nil {
+		conf = new(DumpConfig)
+	}
+	var (
+		missingPreimages int
+		accounts         uint64
+		nextKey          []byte
+		start            = time.Now()
+		logged           = time.Now()
+	)
+	logger.Debug("Trie dumping started", "root", s.trie.Hash())
+	c.OnRoot(s.trie.Hash())
+
+	it, err := s.accountIterator(conf.Start)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Release()
+
+	for it.Next() {
+		addrHash := it.Hash()
+		var address *common.Address
+		if addrBytes := s.trie.GetKey(addrHash[:]); addrBytes != nil {
+			addr := common.BytesToAddress(addrBytes)
+			address = &addr
+		} else {
+			missingPreimages++
+			if conf.OnlyWithAddresses {
+				continue
+			}
+		}
+		acc, err := s.dumpAccount(addrHash, it.Value(), conf)
+		if err != nil {
+			return nil, err
+		}
+		c.OnAccount(address, acc)
+		accounts++
+		if time.Since(logged) > 8*time.Second {
+			logger.Debug("Trie dumping in progress", "at", addrHash, "accounts", accounts,
+				"elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+		if conf.Max > 0 && accounts >= conf.Max {
+			if it.Next() {
+				nextKey = it.Hash().Bytes()
+			}
+			break
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	if missingPreimages > 0 && conf.OnlyWithAddresses {
+		logger.Warn("Skipped accounts without preimages, use incompletes to include them", "skipped", missingPreimages)
+	} else if missingPreimages > 0 {
+		logger.Warn("Dump incomplete due to missing preimages", "missing", missingPreimages)
+	}
+	logger.Debug("Trie dumping complete", "accounts", accounts,
+		"elapsed", common.PrettyDuration(time.Since(start)))
+	return nextKey, nil
+}
+
+// dumpAccount decodes the account data and collects its code and storage according to the options.
+func (s *StateDB) dumpAccount(addrHash common.Hash, data []byte, conf *DumpConfig) (DumpAccount, error) {
+	serializer := account.NewAccountSerializer()
+	if err := rlp.DecodeBytes(data, serializer); err != nil {
+		return DumpAccount{}, fmt.Errorf("invalid account %x: %w", addrHash, err)
+	}
+	obj := serializer.GetAccount()
+	acc := DumpAccount{
+		Balance:     obj.GetBalance().String(),
+		Nonce:       obj.GetNonce(),
+		Root:        common.Bytes2Hex(types.EmptyRootHash.Bytes()),
+		CodeHash:    common.Bytes2Hex(emptyCodeHash),
+		SecureKey:   addrHash.Bytes(),
+		AccountType: obj.Type().String(),
+	}
+	if ak, ok := obj.(account.AccountWithKey); ok {
+		acc.AccountKey = accountkey.NewAccountKeySerializerWithAccountKey(ak.GetKey())
+	}
+	pa := account.GetProgramAccount(obj)
+	if pa == nil {
+		return acc, nil
+	}
+	acc.Root = common.Bytes2Hex(pa.GetStorageRoot().Unextend().Bytes())
+	acc.CodeHash = common.Bytes2Hex(pa.GetCodeHash())
+	if !conf.SkipCode && !bytes.Equal(pa.GetCodeHash(), emptyCodeHash) {
+		code, err := s.db.ContractCode(common.BytesToHash(pa.GetCodeHash()))
+		if err != nil {
+			return DumpAccount{}, fmt.Errorf("can't load code hash %x: %w", pa.GetCodeHash(), err)
+		}
+		acc.Code = common.Bytes2Hex(code)
+	}
+	if !conf.SkipStorage {
+		acc.Storage = make(map[string]string)
+		storageIt, err := s.storageIterator(addrHash, pa.GetStorageRoot())
+		if err != nil {
+			return DumpAccount{}, err
+		}
+		defer storageIt.Release()
+
+		for storageIt.Next() {
+			key := storageIt.Hash()
+			if preimage := s.trie.GetKey(key[:]); preimage != nil {
+				acc.Storage[common.Bytes2Hex(preimage)] = common.Bytes2Hex(storageIt.Value())
+			} else {
+				acc.Storage[fmt.Sprintf("pre(%x)", key)] = common.Bytes2Hex(storageIt.Value())
+			}
+		}
+		if err := storageIt.Error(); err != nil {
+			return DumpAccount{}, err
+		}
+	}
+	return acc, nil
+}
+
+// IteratorDump dumps the accounts of the state for a paging request.
+func (s *StateDB) IteratorDump(conf *DumpConfig) (IteratorDump, error) {
+	iterator := &IteratorDump{
+		Accounts: make(map[string]DumpAccount),
+	}
+	next, err := s.DumpToCollector(iterator, conf)
+	if err != nil {
+		return IteratorDump{}, err
+	}
+	iterator.Next = next
+	return *iterator, nil
+}
+
+// IterativeDump writes the root and the accounts of the state into the writer as JSON lines.
+func (s *StateDB) IterativeDump(conf *DumpConfig, output io.Writer) error {
+	_, err := s.DumpToCollector(iterativeDump{json.NewEncoder(output)}, conf)
+	return err
+}
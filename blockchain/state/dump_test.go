@@ -0,0 +1,137 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/params"
+	"github.com/kaiachain/kaia/snapshot"
+	"github.com/kaiachain/kaia/storage/database"
+	"github.com/kaiachain/kaia/storage/statedb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newDumpTestState commits a state with accounts of several types and returns
+// the state opened without and with the snapshot.
+func newDumpTestState(t *testing.T) (trieState, snapState *StateDB) {
+	db := database.NewMemoryDBManager()
+	sdb, err := New(types.EmptyRootHash, NewDatabase(db), nil, nil)
+	require.NoError(t, err)
+
+	for i := byte(1); i <= 10; i++ {
+		sdb.AddBalance(toAddr([]byte{i}), big.NewInt(int64(i)))
+	}
+	contract := toAddr([]byte{0xff})
+	sdb.CreateSmartContractAccount(contract, params.CodeFormatEVM, params.Rules{})
+	sdb.SetCode(contract, []byte{0x1, 0x2, 0x3})
+	sdb.SetState(contract, common.HexToHash("0x1"), common.HexToHash("0x2"))
+
+	root, err := sdb.Commit(false)
+	require.NoError(t, err)
+	require.NoError(t, sdb.Database().TrieDB().Commit(root, false, 0))
+
+	snaps, err := snapshot.New(db, statedb.NewDatabase(db), 256, root, false, true, false)
+	require.NoError(t, err)
+
+	trieState, err = New(root, NewDatabase(db), nil, nil)
+	require.NoError(t, err)
+	snapState, err = New(root, NewDatabase(db), snaps, nil)
+	require.NoError(t, err)
+	require.NotNil(t, snapState.snap)
+	return trieState, snapState
+}
+
+func TestIteratorDump(t *testing.T) {
+	trieState, snapState := newDumpTestState(t)
+
+	trieDump, err := trieState.IteratorDump(nil)
+	require.NoError(t, err)
+	snapDump, err := snapState.IteratorDump(nil)
+	require.NoError(t, err)
+	assert.Equal(t, trieDump, snapDump)
+	assert.Len(t, trieDump.Accounts, 11)
+	assert.Nil(t, trieDump.Next)
+
+	eoa := trieDump.Accounts[common.Bytes2Hex(toAddr([]byte{1}).Bytes())]
+	assert.Equal(t, "1", eoa.Balance)
+	assert.Equal(t, "ExternallyOwnedAccount", eoa.AccountType)
+	assert.NotNil(t, eoa.AccountKey)
+
+	contract := trieDump.Accounts[common.Bytes2Hex(toAddr([]byte{0xff}).Bytes())]
+	assert.Equal(t, "SmartContractAccount", contract.AccountType)
+	assert.Equal(t, "010203", contract.Code)
+	assert.Len(t, contract.Storage, 1)
+
+	// Page through the accounts.
+	for _, s := range []*StateDB{trieState, snapState} {
+		var (
+			conf     = &DumpConfig{Max: 4, SkipCode: true, SkipStorage: true}
+			accounts = make(map[string]DumpAccount)
+			pages    = 0
+		)
+		for {
+			page, err := s.IteratorDump(conf)
+			require.NoError(t, err)
+			assert.LessOrEqual(t, len(page.Accounts), 4)
+			for k, v := range page.Accounts {
+				accounts[k] = v
+			}
+			pages++
+			if page.Next == nil {
+				break
+			}
+			conf.Start = page.Next
+		}
+		assert.Equal(t, 3, pages)
+		assert.Len(t, accounts, 11)
+		assert.Empty(t, accounts[common.Bytes2Hex(toAddr([]byte{0xff}).Bytes())].Code)
+	}
+}
+
+func TestIterativeDump(t *testing.T) {
+	_, snapState := newDumpTestState(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, snapState.IterativeDump(&DumpConfig{SkipStorage: true}, &buf))
+
+	scanner := bufio.NewScanner(&buf)
+	require.True(t, scanner.Scan())
+	var root struct {
+		Root common.Hash `json:"root"`
+	}
+	require.NoError(t, json.Unmarshal(scanner.Bytes(), &root))
+	assert.Equal(t, snapState.trie.Hash(), root.Root)
+
+	lines := 0
+	for scanner.Scan() {
+		var acc DumpAccount
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &acc))
+		assert.NotNil(t, acc.Address)
+		assert.NotEmpty(t, acc.SecureKey)
+		assert.NotEmpty(t, acc.AccountType)
+		lines++
+	}
+	assert.Equal(t, 11, lines)
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/log"
 	"github.com/kaiachain/kaia/rlp"
+	"github.com/kaiachain/kaia/storage/database"
 	"github.com/kaiachain/kaia/storage/statedb"
 	"github.com/pkg/errors"
 )
@@ -126,7 +127,12 @@ func (it *NodeIterator) step() error {
 	obj := serializer.GetAccount()
 
 	if pa := account.GetProgramAccount(obj); pa != nil {
-		dataTrie, err := it.state.db.OpenStorageTrie(pa.GetStorageRoot(), nil)
+		// The owner is required only in the path-based scheme, where the iterator always starts from the state root.
+		opts := &statedb.TrieOpts{}
+		if it.state.db.TrieDB().Scheme() == database.PathScheme {
+			opts.Owner = common.BytesToHash(it.stateIt.LeafKey())
+		}
+		dataTrie, err := it.state.db.OpenStorageTrie(pa.GetStorageRoot(), opts)
 		if err != nil {
 			return err
 		}
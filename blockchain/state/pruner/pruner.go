@@ -0,0 +1,458 @@
+// Modifications Copyright 2025 The Kaia Authors
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+//
+// This file is derived from core/state/pruner/pruner.go (2021/10/21).
+// Modified and improved for the Kaia development.
+
+package pruner
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kaiachain/kaia/blockchain/state"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/log"
+	"github.com/kaiachain/kaia/snapshot"
+	"github.com/kaiachain/kaia/storage/database"
+	"github.com/kaiachain/kaia/storage/statedb"
+)
+
+const (
+	// stateBloomFilePrefix is the filename prefix of state bloom filter.
+	stateBloomFilePrefix = "statebloom"
+
+	// stateBloomFileSuffix is the filename suffix of state bloom filter.
+	stateBloomFileSuffix = "bf.gz"
+
+	// stateBloomFileTempSuffix is the filename suffix of state bloom filter
+	// while it is being written out to detect write aborts.
+	stateBloomFileTempSuffix = ".tmp"
+
+	// MinBloomSize is the minimal size of the state bloom filter in megabytes.
+	MinBloomSize = 256
+)
+
+var logger = log.NewModuleLogger(log.BlockchainStatePruner)
+
+// Pruner is an offline tool to prune the stale state with the help of the
+// snapshot. The workflow of pruner is very simple:
+//
+//   - iterate the snapshot, reconstruct the relevant state
+//   - iterate the state trie database, delete all other state entries which
+//     don't belong to the target state and the genesis state
+//
+// It can take several hours (around 2 hours for a state with hundreds of
+// millions of accounts) to finish the whole pruning work. It's recommended
+// to run this offline tool periodically in order to release the disk usage
+// and improve the disk read performance to some extent.
+type Pruner struct {
+	db         database.DBManager
+	stateBloom *stateBloom
+	datadir    string
+	headHeader *types.Header
+	snaptree   *snapshot.Tree
+}
+
+// NewPruner creates the pruner instance. The datadir is the directory to store
+// the state bloom filter, and bloomSize is the size of the filter in megabytes.
+func NewPruner(db database.DBManager, datadir string, bloomSize uint64) (*Pruner, error) {
+	if err := checkPrunable(db); err != nil {
+		return nil, err
+	}
+	headBlock := db.ReadBlockByHash(db.ReadHeadBlockHash())
+	if headBlock == nil {
+		return nil, errors.New("failed to load head block")
+	}
+	snaptree, err := snapshot.New(db, statedb.NewDatabase(db), 256, headBlock.Root(), false, false, false)
+	if err != nil {
+		return nil, err // The relevant snapshot(s) might not exist
+	}
+	// Sanitize the bloom filter size if it's too small.
+	if bloomSize < MinBloomSize {
+		logger.Warn("Sanitizing bloomfilter size", "provided(MB)", bloomSize, "updated(MB)", MinBloomSize)
+		bloomSize = MinBloomSize
+	}
+	stateBloom, err := newStateBloomWithSize(bloomSize)
+	if err != nil {
+		return nil, err
+	}
+	return &Pruner{
+		db:         db,
+		stateBloom: stateBloom,
+		datadir:    datadir,
+		headHeader: headBlock.Header(),
+		snaptree:   snaptree,
+	}, nil
+}
+
+// checkPrunable returns an error if the state trie database cannot be pruned by the pruner.
+func checkPrunable(db database.DBManager) error {
+	switch {
+	case db.ReadStateScheme() == database.PathScheme:
+		return errors.New("offline pruning is not needed in the path-based state scheme")
+	case db.ReadPruningEnabled():
+		return errors.New("offline pruning is not supported with live pruning")
+	case db.InMigration():
+		return errors.New("offline pruning is not supported during state migration")
+	}
+	return nil
+}
+
+func prune(snaptree *snapshot.Tree, root common.Hash, db database.DBManager, stateBloom *stateBloom, bloomPath string, middleStateRoots map[common.Hash]struct{}, start time.Time) error {
+	// Delete all stale trie nodes in the disk. With the help of state bloom
+	// the trie nodes (and codes) belonging to the active state will be filtered
+	// out. A very small part of stale tries will also be filtered because of
+	// the false-positive rate of bloom filter. But the assumption is held here
+	// that the false-positive is low enough (~0.05%). The probability of the
+	// dangling node is the state root is super low. So the dangling nodes in
+	// the disk is also super low.
+	var (
+		count  int
+		size   common.StorageSize
+		pstart = time.Now()
+		logged = time.Now()
+		trieDB = db.GetDatabase(database.StateTrieDB)
+		batch  = trieDB.NewBatch()
+		iter   = trieDB.NewIterator(nil, nil)
+	)
+	defer batch.Release()
+
+	for iter.Next() {
+		key := iter.Key()
+
+		// All state entries which don't belong to the specific state and genesis are deleted here
+		// - trie node
+		// - legacy contract code
+		// - new-scheme contract code
+		isCode, codeKey := database.IsCodeKey(key)
+		if len(key) == common.HashLength || isCode {
+			checkKey := key
+			if isCode {
+				checkKey = codeKey
+			}
+			if _, exist := middleStateRoots[common.BytesToHash(checkKey)]; exist {
+				logger.Debug("Forcibly delete the middle state roots", "hash", common.BytesToHash(checkKey))
+			} else if stateBloom.Contain(checkKey) {
+				continue
+			}
+			count += 1
+			size += common.StorageSize(len(key) + len(iter.Value()))
+			batch.Delete(key)
+
+			var eta time.Duration // Realistically will never remain uninited
+			if done := binary.BigEndian.Uint64(key[:8]); done > 0 {
+				var (
+					left  = math.MaxUint64 - binary.BigEndian.Uint64(key[:8])
+					speed = done/uint64(time.Since(pstart)/time.Millisecond+1) + 1 // +1s to avoid division by zero
+				)
+				eta = time.Duration(left/speed) * time.Millisecond
+			}
+			if time.Since(logged) > 8*time.Second {
+				logger.Info("Pruning state data", "nodes", count, "size", size,
+					"elapsed", common.PrettyDuration(time.Since(pstart)), "eta", common.PrettyDuration(eta))
+				logged = time.Now()
+			}
+			// Recreate the iterator after every batch commit in order
+			// to allow the underlying compactor to delete the entries.
+			if batch.ValueSize() >= database.IdealBatchSize {
+				if err := batch.Write(); err != nil {
+					iter.Release()
+					return err
+				}
+				batch.Reset()
+
+				iter.Release()
+				iter = trieDB.NewIterator(nil, key)
+			}
+		}
+	}
+	err := iter.Error()
+	iter.Release()
+	if err != nil {
+		return err
+	}
+	if batch.ValueSize() > 0 {
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		batch.Reset()
+	}
+	logger.Info("Pruned state data", "nodes", count, "size", size, "elapsed", common.PrettyDuration(time.Since(pstart)))
+
+	// Pruning is done, now drop the "useless" layers from the snapshot.
+	// Firstly, flushing the target layer into the disk. After that all
+	// diff layers below the target will all be merged into the disk.
+	if root != snaptree.DiskRoot() {
+		if err := snaptree.Cap(root, 0); err != nil {
+			return err
+		}
+	}
+	// Secondly, flushing the snapshot journal into the disk. All diff
+	// layers upon are dropped silently. Eventually the entire snapshot
+	// tree is converted into a single disk layer with the pruning target
+	// as the root.
+	if _, err := snaptree.Journal(root); err != nil {
+		return err
+	}
+	// Delete the state bloom, it marks the entire pruning procedure is
+	// finished. If any crashes or manual exit happens before this,
+	// `RecoverPruning` will pick it up in the next restarts to redo all
+	// the things.
+	os.RemoveAll(bloomPath)
+
+	// Start compactions, will remove the deleted data from the disk immediately.
+	cstart := time.Now()
+	logger.Info("Start compacting the state trie database")
+	if err := trieDB.Compact(nil, nil); err != nil {
+		logger.Error("Database compaction failed", "err", err)
+		return err
+	}
+	logger.Info("Database compaction finished", "elapsed", common.PrettyDuration(time.Since(cstart)))
+	logger.Info("State pruning successful", "pruned", size, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// Prune deletes all historical state nodes except the nodes belonging to the
+// specified state version. If user doesn't specify the state version, use
+// the bottom-most snapshot diff layer as the target.
+func (p *Pruner) Prune(root common.Hash) error {
+	// If the state bloom filter is already committed previously,
+	// reuse it for pruning instead of generating a new one. It's
+	// mandatory because a part of state may already be deleted,
+	// the recovery procedure is necessary.
+	_, stateBloomRoot, err := findBloomFilter(p.datadir)
+	if err != nil {
+		return err
+	}
+	if stateBloomRoot != (common.Hash{}) {
+		return RecoverPruning(p.datadir, p.db)
+	}
+	// If the target state root is not specified, use the HEAD-127 as the
+	// target. The reason for picking it is:
+	// - in most of the normal cases, the related state is available
+	// - the probability of this layer being reorg is very low
+	//
+	// Retrieve all snapshot layers from the current HEAD.
+	// In theory there are 128 difflayers + 1 disk layer present,
+	// so 128 diff layers are expected to be returned.
+	layers := p.snaptree.Snapshots(p.headHeader.Root, 128, true)
+	userSpecified := root != (common.Hash{})
+	if !userSpecified {
+		if len(layers) != 128 {
+			// Reject if the accumulated diff layers are less than 128. It
+			// means in most of normal cases, there is no associated state
+			// with bottom-most diff layer.
+			return fmt.Errorf("snapshot not old enough yet: need %d more blocks", 128-len(layers))
+		}
+		// Use the bottom-most diff layer as the target
+		root = layers[len(layers)-1].Root()
+	}
+	// Ensure the root is really present. The weak assumption
+	// is the presence of root can indicate the presence of the
+	// entire trie.
+	if ok, _ := p.db.HasTrieNode(root.ExtendZero()); !ok {
+		// Since the state trie is committed periodically, HEAD-127 may not
+		// be paired with a persisted state. Try to find the bottom-most
+		// snapshot layer with state available.
+		//
+		// Note HEAD and HEAD-1 is ignored. Usually there is the associated
+		// state available, but we don't want to use the topmost state
+		// as the pruning target.
+		var found bool
+		for i := len(layers) - 2; i >= 2; i-- {
+			if ok, _ := p.db.HasTrieNode(layers[i].Root().ExtendZero()); ok {
+				root = layers[i].Root()
+				found = true
+				logger.Info("Selecting middle-layer as the pruning target", "root", root, "depth", i)
+				break
+			}
+		}
+		if !found {
+			if len(layers) > 0 {
+				return errors.New("no snapshot paired state")
+			}
+			return fmt.Errorf("associated state[%x] is not present", root)
+		}
+	} else {
+		if userSpecified {
+			logger.Info("Selecting user-specified state as the pruning target", "root", root)
+		} else {
+			logger.Info("Selecting bottom-most difflayer as the pruning target", "root", root, "height", p.headHeader.Number.Uint64()-127)
+		}
+	}
+	// All the state roots of the middle layer should be forcibly pruned,
+	// otherwise the dangling state will be left.
+	middleRoots, err := findMiddleRoots(p.snaptree, p.headHeader.Root, root)
+	if err != nil {
+		return err
+	}
+	// Traverse the target state, re-construct the whole state trie and
+	// commit to the given bloom filter.
+	start := time.Now()
+	if err := snapshot.GenerateTrie(p.snaptree, root, p.db, p.stateBloom); err != nil {
+		return err
+	}
+	// Traverse the genesis, put all genesis state entries into the
+	// bloom filter too.
+	if err := extractGenesis(p.db, p.stateBloom); err != nil {
+		return err
+	}
+	filterName := bloomFilterName(p.datadir, root)
+
+	logger.Info("Writing state bloom to disk", "name", filterName)
+	if err := p.stateBloom.Commit(filterName, filterName+stateBloomFileTempSuffix); err != nil {
+		return err
+	}
+	logger.Info("State bloom filter committed", "name", filterName)
+	return prune(p.snaptree, root, p.db, p.stateBloom, filterName, middleRoots, start)
+}
+
+// RecoverPruning will resume the pruning procedure during the system restart.
+// This function is used in this case: user tries to prune state data, but the
+// system was interrupted midway because of crash or manual-kill. In this case
+// if the bloom filter for filtering active state is already constructed, the
+// pruning can be resumed. What's more if the bloom filter is constructed, the
+// pruning **has to be resumed**. Otherwise a lot of dangling nodes may be left
+// in the disk.
+func RecoverPruning(datadir string, db database.DBManager) error {
+	stateBloomPath, stateBloomRoot, err := findBloomFilter(datadir)
+	if err != nil {
+		return err
+	}
+	if stateBloomPath == "" {
+		return nil // nothing to recover
+	}
+	headBlock := db.ReadBlockByHash(db.ReadHeadBlockHash())
+	if headBlock == nil {
+		return errors.New("failed to load head block")
+	}
+	// Initialize the snapshot tree in recovery mode to handle this special case:
+	// - Users run the `prune-state` command multiple times
+	// - Neither these `prune-state` running is finished (e.g. interrupted manually)
+	// - The state bloom filter is already generated, a part of state is deleted,
+	//   so that resuming the pruning here is mandatory
+	// - The state HEAD is rewound already because of multiple incomplete `prune-state`
+	// In this case, even the state HEAD is not exactly matched with snapshot, it
+	// still feasible to recover the pruning correctly.
+	snaptree, err := snapshot.New(db, statedb.NewDatabase(db), 256, headBlock.Root(), false, false, true)
+	if err != nil {
+		return err // The relevant snapshot(s) might not exist
+	}
+	stateBloom, err := newStateBloomFromDisk(stateBloomPath)
+	if err != nil {
+		return err
+	}
+	logger.Info("Loaded state bloom filter", "path", stateBloomPath)
+
+	// All the state roots of the middle layers should be forcibly pruned,
+	// otherwise the dangling state will be left.
+	middleRoots, err := findMiddleRoots(snaptree, headBlock.Root(), stateBloomRoot)
+	if err != nil {
+		return err
+	}
+	return prune(snaptree, stateBloomRoot, db, stateBloom, stateBloomPath, middleRoots, time.Now())
+}
+
+// findMiddleRoots returns the state roots of the snapshot diff layers between the head and the target.
+// If the target is the disk layer of the snapshot, all the diff layers are in the middle.
+func findMiddleRoots(snaptree *snapshot.Tree, head, target common.Hash) (map[common.Hash]struct{}, error) {
+	var (
+		found       = target == snaptree.DiskRoot()
+		middleRoots = make(map[common.Hash]struct{})
+	)
+	for _, layer := range snaptree.Snapshots(head, 128, true) {
+		if layer.Root() == target {
+			found = true
+			break
+		}
+		middleRoots[layer.Root()] = struct{}{}
+	}
+	if !found {
+		return nil, fmt.Errorf("non-existent target state %x", target)
+	}
+	return middleRoots, nil
+}
+
+// extractGenesis loads the genesis state and commits all the state entries
+// into the given bloomfilter.
+func extractGenesis(db database.DBManager, stateBloom *stateBloom) error {
+	genesisHash := db.ReadCanonicalHash(0)
+	if genesisHash == (common.Hash{}) {
+		return errors.New("missing genesis hash")
+	}
+	genesis := db.ReadBlock(genesisHash, 0)
+	if genesis == nil {
+		return errors.New("missing genesis block")
+	}
+	sdb, err := state.New(genesis.Root(), state.NewDatabase(db), nil, nil)
+	if err != nil {
+		return err
+	}
+	it := state.NewNodeIterator(sdb)
+	for it.Next() {
+		if it.Hash == (common.Hash{}) {
+			continue // embedded nodes are not stored separately
+		}
+		if it.Type == "code" {
+			stateBloom.WriteCode(it.Hash, nil)
+		} else {
+			stateBloom.WriteTrieNode(it.Hash.ExtendZero(), nil)
+		}
+	}
+	return it.Error
+}
+
+func bloomFilterName(datadir string, hash common.Hash) string {
+	return filepath.Join(datadir, fmt.Sprintf("%s.%s.%s", stateBloomFilePrefix, hash.Hex(), stateBloomFileSuffix))
+}
+
+func isBloomFilter(filename string) (bool, common.Hash) {
+	filename = filepath.Base(filename)
+	if strings.HasPrefix(filename, stateBloomFilePrefix) && strings.HasSuffix(filename, stateBloomFileSuffix) {
+		return true, common.HexToHash(filename[len(stateBloomFilePrefix)+1 : len(filename)-len(stateBloomFileSuffix)-1])
+	}
+	return false, common.Hash{}
+}
+
+// findBloomFilter returns the path and the target state root of the state bloom filter in the datadir.
+// The bloom filter exists only if the previous pruning is not finished.
+func findBloomFilter(datadir string) (string, common.Hash, error) {
+	entries, err := os.ReadDir(datadir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", common.Hash{}, nil
+		}
+		return "", common.Hash{}, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if ok, root := isBloomFilter(entry.Name()); ok {
+			return filepath.Join(datadir, entry.Name()), root, nil
+		}
+	}
+	return "", common.Hash{}, nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package pruner
+
+import (
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/kaiachain/kaia/blockchain/state"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/params"
+	"github.com/kaiachain/kaia/snapshot"
+	"github.com/kaiachain/kaia/storage/database"
+	"github.com/kaiachain/kaia/storage/statedb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// commitBlock applies the modifier to the state of the parent root, commits the state into
+// the database and writes the block of the state as the head block.
+func commitBlock(t *testing.T, db database.DBManager, parent common.Hash, number uint64, modify func(*state.StateDB)) common.Hash {
+	sdb, err := state.New(parent, state.NewDatabase(db), nil, nil)
+	require.NoError(t, err)
+	modify(sdb)
+	root, err := sdb.Commit(true)
+	require.NoError(t, err)
+	require.NoError(t, sdb.Database().TrieDB().Commit(root, false, number))
+
+	block := types.NewBlockWithHeader(&types.Header{Number: new(big.Int).SetUint64(number), Root: root})
+	db.WriteBlock(block)
+	db.WriteCanonicalHash(block.Hash(), number)
+	db.WriteHeadBlockHash(block.Hash())
+	return root
+}
+
+func setupPrunableState(t *testing.T) (db database.DBManager, genesisRoot, staleRoot, headRoot common.Hash) {
+	db = database.NewMemoryDBManager()
+	var (
+		eoa      = common.HexToAddress("0x1000")
+		contract = common.HexToAddress("0x2000")
+	)
+	genesisRoot = commitBlock(t, db, types.EmptyRootHash, 0, func(sdb *state.StateDB) {
+		sdb.AddBalance(eoa, big.NewInt(1))
+	})
+	staleRoot = commitBlock(t, db, genesisRoot, 1, func(sdb *state.StateDB) {
+		sdb.AddBalance(eoa, big.NewInt(1))
+		sdb.CreateSmartContractAccount(contract, params.CodeFormatEVM, params.Rules{})
+		sdb.SetCode(contract, []byte{0x1, 0x2, 0x3})
+		for i := 0; i < 100; i++ {
+			sdb.SetState(contract, common.BigToHash(big.NewInt(int64(i))), common.BigToHash(big.NewInt(int64(i+1))))
+		}
+	})
+	headRoot = commitBlock(t, db, staleRoot, 2, func(sdb *state.StateDB) {
+		sdb.AddBalance(eoa, big.NewInt(1))
+		for i := 0; i < 50; i++ {
+			sdb.SetState(contract, common.BigToHash(big.NewInt(int64(i))), common.Hash{})
+		}
+	})
+	// Generate the snapshot of the head state.
+	_, err := snapshot.New(db, statedb.NewDatabase(db), 256, headRoot, false, true, false)
+	require.NoError(t, err)
+	return db, genesisRoot, staleRoot, headRoot
+}
+
+func checkStateIntact(t *testing.T, db database.DBManager, root common.Hash) {
+	sdb, err := state.New(root, state.NewDatabase(db), nil, nil)
+	require.NoError(t, err)
+	it := state.NewNodeIterator(sdb)
+	for it.Next() {
+	}
+	assert.NoError(t, it.Error, "root: %x", root)
+}
+
+func TestPruner_Prune(t *testing.T) {
+	db, genesisRoot, staleRoot, headRoot := setupPrunableState(t)
+	datadir := t.TempDir()
+
+	p, err := NewPruner(db, datadir, 0)
+	require.NoError(t, err)
+
+	// The default target is not available since the snapshot has no diff layers.
+	assert.Error(t, p.Prune(common.Hash{}))
+
+	require.NoError(t, p.Prune(headRoot))
+
+	// The target state and the genesis state are kept, but the stale state is deleted.
+	checkStateIntact(t, db, headRoot)
+	checkStateIntact(t, db, genesisRoot)
+	ok, _ := db.HasTrieNode(staleRoot.ExtendZero())
+	assert.False(t, ok)
+
+	// The bloom filter is removed after the pruning is finished.
+	path, _, err := findBloomFilter(datadir)
+	require.NoError(t, err)
+	assert.Empty(t, path)
+}
+
+func TestPruner_RecoverPruning(t *testing.T) {
+	db, genesisRoot, staleRoot, headRoot := setupPrunableState(t)
+	datadir := t.TempDir()
+
+	// Nothing to recover.
+	require.NoError(t, RecoverPruning(datadir, db))
+	ok, _ := db.HasTrieNode(staleRoot.ExtendZero())
+	assert.True(t, ok)
+
+	// Simulate the interruption right after the state bloom filter is committed.
+	p, err := NewPruner(db, datadir, 0)
+	require.NoError(t, err)
+	require.NoError(t, snapshot.GenerateTrie(p.snaptree, headRoot, db, p.stateBloom))
+	require.NoError(t, extractGenesis(db, p.stateBloom))
+	filterName := bloomFilterName(datadir, headRoot)
+	require.NoError(t, p.stateBloom.Commit(filterName, filterName+stateBloomFileTempSuffix))
+
+	path, root, err := findBloomFilter(datadir)
+	require.NoError(t, err)
+	assert.Equal(t, filterName, path)
+	assert.Equal(t, headRoot, root)
+
+	require.NoError(t, RecoverPruning(datadir, db))
+	checkStateIntact(t, db, headRoot)
+	checkStateIntact(t, db, genesisRoot)
+	ok, _ = db.HasTrieNode(staleRoot.ExtendZero())
+	assert.False(t, ok)
+
+	_, err = os.Stat(filterName)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestPruner_Unprunable(t *testing.T) {
+	db, _, _, _ := setupPrunableState(t)
+	db.WritePruningEnabled()
+	_, err := NewPruner(db, t.TempDir(), 0)
+	assert.Error(t, err)
+
+	db = database.NewMemoryDBManager()
+	db.WriteStateScheme(database.PathScheme)
+	_, err = NewPruner(db, t.TempDir(), 0)
+	assert.Error(t, err)
+}
@@ -904,6 +904,7 @@ func (s *StateDB) Copy() *StateDB {
 	state := &StateDB{
 		db:                       s.db,
 		trie:                     s.db.CopyTrie(s.trie),
+		trieOpts:                 s.trieOpts,
 		stateObjects:             make(map[common.Address]*stateObject, len(s.journal.dirties)),
 		stateObjectsDirty:        make(map[common.Address]struct{}, len(s.journal.dirties)),
 		stateObjectsDirtyStorage: make(map[common.Address]struct{}),
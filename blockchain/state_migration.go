@@ -320,6 +320,9 @@ func (bc *BlockChain) PrepareStateMigration() error {
 	if bc.db.ReadPruningEnabled() {
 		return errors.New("state migration not supported with live pruning enabled")
 	}
+	if bc.db.ReadStateScheme() == database.PathScheme {
+		return errors.New("state migration not supported with the path-based state scheme")
+	}
 
 	if bc.db.InMigration() || bc.prepareStateMigration {
 		return errors.New("migration already started")
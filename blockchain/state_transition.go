@@ -126,6 +126,11 @@ type Message interface {
 
 	AccessList() types.AccessList
 	AuthList() []types.SetCodeAuthorization
+
+	// For TxTypeEthereumBlob
+	BlobGas() uint64
+	BlobGasFeeCap() *big.Int
+	BlobHashes() []common.Hash
 }
 
 // ExecutionResult includes all output after executing given evm
@@ -256,6 +261,11 @@ func (st *StateTransition) buyGas() error {
 		st.state.SubBalance(validatedFeePayer, feePayerFee)
 		st.state.SubBalance(validatedSender, senderFee)
 	} else {
+		// The blob fee is burnt from the sender of a blob transaction along with the gas fee.
+		if blobGas := st.msg.BlobGas(); blobGas > 0 && st.evm.Context.BlobBaseFee != nil {
+			mgval.Add(mgval, new(big.Int).Mul(new(big.Int).SetUint64(blobGas), st.evm.Context.BlobBaseFee))
+		}
+
 		// to make a short circuit, process the special case feeRatio == MaxFeeRatio
 		if st.state.GetBalance(validatedFeePayer).Cmp(mgval) < 0 {
 			logger.Debug(errInsufficientBalanceForGasFeePayer.Error(), "feePayer", validatedFeePayer.String(),
@@ -304,6 +314,17 @@ func (st *StateTransition) preCheck() error {
 			return fmt.Errorf("%w (sender %v)", ErrEmptyAuthList, st.msg.ValidatedSender())
 		}
 	}
+
+	// Check that the blob transaction is allowed and pays enough for the blobs.
+	if st.msg.Type() == types.TxTypeEthereumBlob {
+		if !st.evm.ChainConfig().IsBlobTxForkEnabled(st.evm.Context.BlockNumber) {
+			return fmt.Errorf("%w (sender %v)", types.ErrTxTypeNotSupported, st.msg.ValidatedSender())
+		}
+		if blobBaseFee := st.evm.Context.BlobBaseFee; blobBaseFee != nil && st.msg.BlobGasFeeCap().Cmp(blobBaseFee) < 0 {
+			return fmt.Errorf("%w: address %v blobGasFeeCap: %v, blobBaseFee: %v", types.ErrBlobFeeCapTooLow,
+				st.msg.ValidatedSender().Hex(), st.msg.BlobGasFeeCap(), blobBaseFee)
+		}
+	}
 	return st.buyGas()
 }
 
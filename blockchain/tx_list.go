@@ -24,6 +24,7 @@ package blockchain
 
 import (
 	"container/heap"
+	"fmt"
 	"math"
 	"math/big"
 	"sort"
@@ -305,34 +306,71 @@ func (l *txList) Overlaps(tx *types.Transaction) bool {
 	return l.txs.Get(tx.Nonce()) != nil
 }
 
-// Add tries to insert a new transaction into the list, returning whether the
-// transaction was accepted, and if yes, any previous transaction it replaced.
+// Add tries to insert a new transaction into the list, returning any previous
+// transaction it replaced, or the reason why it cannot replace the transaction
+// of the same nonce.
 //
 // If the new transaction is accepted into the list, the lists' cost and gas
 // thresholds are also potentially updated.
-func (l *txList) Add(tx *types.Transaction, priceBump uint64, magmaHardforked bool) (bool, *types.Transaction) {
+func (l *txList) Add(tx *types.Transaction, priceBump uint64, magmaHardforked bool) (*types.Transaction, error) {
 	// If there's an older better transaction, abort
 	old := l.txs.Get(tx.Nonce())
 	if old != nil {
-		// If tx is CancelTransaction, replace it even thought tx has lower gasPrice than previous tx.
-		if tx.Type().IsCancelTransaction() {
-			logger.Trace("New tx is a cancel transaction. replace it!", "old", old.String(), "new", tx.String())
-		} else if magmaHardforked {
-			if old.GasPrice().Cmp(tx.GasPrice()) >= 0 {
-				// If gas price of older is bigger than newer, abort.
-				logger.Trace("already nonce exist and the gasprice is lower then older", "nonce", tx.Nonce(), "with gasprice", old.GasPrice(), "priceBump", priceBump, "new tx.gasprice", tx.GasPrice())
-				return false, nil
-			}
-			// Otherwise overwrite the old transaction with the current one.
-			logger.Trace("The transaction was substituted by competitive gas price", "old", old.String(), "new", tx.String())
-		} else {
-			logger.Trace("already nonce exist", "nonce", tx.Nonce(), "with gasprice", old.GasPrice(), "priceBump", priceBump, "new tx.gasprice", tx.GasPrice())
-			return false, nil
+		if err := checkReplacement(old, tx, priceBump, magmaHardforked); err != nil {
+			logger.Trace("Rejected replacement transaction", "nonce", tx.Nonce(), "old", old.Hash(), "new", tx.Hash(), "err", err)
+			return nil, err
 		}
+		logger.Trace("The transaction was substituted", "old", old.String(), "new", tx.String())
 	}
 
 	l.txs.Put(tx)
-	return true, old
+	return old, nil
+}
+
+// checkReplacement returns nil if tx can replace old which has the same nonce.
+// The replacement rules are as follows.
+//
+//   - A cancel transaction always replaces the old one regardless of its price,
+//     so that the sender can cancel a fee-delegated transaction without the
+//     consent of the fee payer.
+//   - Before the Magma hardfork, the gas price is fixed to the unit price, so no
+//     other transaction can replace the old one.
+//   - After the Magma hardfork, the gas price must be higher than the old one.
+//   - If either of the transactions is fee-delegated, including the ones with
+//     a fee ratio, the gas fee cap and the gas tip cap must be bumped by at least
+//     priceBump percent. Otherwise a sender could replace the transaction paid by
+//     a fee payer over and over with marginal price increases. The fee payer and
+//     the fee ratio may differ since the fee payer signs the replacement as well.
+func checkReplacement(old, tx *types.Transaction, priceBump uint64, magmaHardforked bool) error {
+	if tx.Type().IsCancelTransaction() {
+		return nil
+	}
+	if !magmaHardforked {
+		return ErrAlreadyNonceExistInPool
+	}
+
+	if old.IsFeeDelegatedTransaction() || tx.IsFeeDelegatedTransaction() {
+		oldFeeCap, oldTipCap := old.GasFeeCap(), old.GasTipCap()
+		// threshold = old * (100 + priceBump) / 100
+		a := big.NewInt(100 + int64(priceBump))
+		b := big.NewInt(100)
+		thresholdFeeCap := new(big.Int).Div(new(big.Int).Mul(a, oldFeeCap), b)
+		thresholdTipCap := new(big.Int).Div(new(big.Int).Mul(a, oldTipCap), b)
+
+		if tx.GasFeeCap().Cmp(thresholdFeeCap) < 0 {
+			return fmt.Errorf("%w: fee-delegated replacement requires gas fee cap of at least %v (%d%% bump), have %v",
+				ErrReplaceUnderpriced, thresholdFeeCap, priceBump, tx.GasFeeCap())
+		}
+		if tx.GasTipCap().Cmp(thresholdTipCap) < 0 {
+			return fmt.Errorf("%w: fee-delegated replacement requires gas tip cap of at least %v (%d%% bump), have %v",
+				ErrReplaceUnderpriced, thresholdTipCap, priceBump, tx.GasTipCap())
+		}
+		return nil
+	}
+	if tx.GasPrice().Cmp(old.GasPrice()) <= 0 {
+		return fmt.Errorf("%w: gas price %v must be higher than %v", ErrReplaceUnderpriced, tx.GasPrice(), old.GasPrice())
+	}
+	return nil
 }
 
 // Forward removes all transactions from the list with a nonce lower than the
@@ -173,12 +173,12 @@ func TestSubstituteTxByGasPrice(t *testing.T) {
 	oldTx := pricedTransaction(0, 21000, big.NewInt(50), key)
 	newTx := pricedTransaction(0, 21000, big.NewInt(60), key)
 
-	if result, _ := txList.Add(oldTx, DefaultTxPoolConfig.PriceBump, true); !result {
+	if _, err := txList.Add(oldTx, DefaultTxPoolConfig.PriceBump, true); err != nil {
 		t.Error("it cannot add tx in tx list.")
 	}
 
-	result, replaced := txList.Add(newTx, DefaultTxPoolConfig.PriceBump, true)
-	if !result {
+	replaced, err := txList.Add(newTx, DefaultTxPoolConfig.PriceBump, true)
+	if err != nil {
 		t.Error("it cannot replace tx in tx list.")
 	}
 
@@ -195,11 +195,69 @@ func TestSubstituteTransactionAbort(t *testing.T) {
 	oldTx := pricedTransaction(0, 21000, big.NewInt(50), key)
 	newTx := pricedTransaction(0, 21000, big.NewInt(40), key)
 
-	if result, _ := txList.Add(oldTx, DefaultTxPoolConfig.PriceBump, true); !result {
+	if _, err := txList.Add(oldTx, DefaultTxPoolConfig.PriceBump, true); err != nil {
 		t.Error("it cannot add tx in tx list.")
 	}
 
-	if result, replaced := txList.Add(newTx, DefaultTxPoolConfig.PriceBump, true); result || replaced != nil {
+	if replaced, err := txList.Add(newTx, DefaultTxPoolConfig.PriceBump, true); err == nil || replaced != nil {
 		t.Error("Expected to not substitute by a tx with lower gas price")
 	}
 }
+
+// TestReplacementRules checks the replacement rules for each kind of transaction.
+func TestReplacementRules(t *testing.T) {
+	sender, _ := crypto.GenerateKey()
+	feePayer, _ := crypto.GenerateKey()
+	from := crypto.PubkeyToAddress(sender.PublicKey)
+
+	var (
+		price  = big.NewInt(100)
+		higher = big.NewInt(101)
+		bumped = big.NewInt(100 + int64(DefaultTxPoolConfig.PriceBump))
+	)
+
+	testcases := []struct {
+		name    string
+		old     *types.Transaction
+		new     *types.Transaction
+		magma   bool
+		wantErr error
+	}{
+		{"before magma", pricedTransaction(0, 21000, price, sender), pricedTransaction(0, 21000, bumped, sender), false, ErrAlreadyNonceExistInPool},
+		{"cancel before magma", pricedTransaction(0, 21000, price, sender), cancelTx(0, 21000, price, from, sender), false, nil},
+		{"same price", pricedTransaction(0, 21000, price, sender), pricedTransaction(0, 21000, price, sender), true, ErrReplaceUnderpriced},
+		{"higher price", pricedTransaction(0, 21000, price, sender), pricedTransaction(0, 21000, higher, sender), true, nil},
+		// Only the gas price, which is the gas fee cap, is compared for the dynamic fee transactions.
+		{"dynamic fee lower tip", dynamicFeeTx(0, 21000, price, price, sender), dynamicFeeTx(0, 21000, higher, big.NewInt(1), sender), true, nil},
+		{"dynamic fee same price higher tip", dynamicFeeTx(0, 21000, price, big.NewInt(1), sender), dynamicFeeTx(0, 21000, price, price, sender), true, ErrReplaceUnderpriced},
+		{"dynamic fee higher price", dynamicFeeTx(0, 21000, price, price, sender), dynamicFeeTx(0, 21000, higher, price, sender), true, nil},
+
+		// The fee-delegated transactions require the price bump.
+		{"fee-delegated not bumped", feeDelegatedTx(0, 21000, price, big.NewInt(1), sender, feePayer), feeDelegatedTx(0, 21000, higher, big.NewInt(1), sender, feePayer), true, ErrReplaceUnderpriced},
+		{"fee-delegated bumped", feeDelegatedTx(0, 21000, price, big.NewInt(1), sender, feePayer), feeDelegatedTx(0, 21000, bumped, big.NewInt(1), sender, feePayer), true, nil},
+		{"fee-delegated by sender-paid not bumped", feeDelegatedTx(0, 21000, price, big.NewInt(1), sender, feePayer), pricedTransaction(0, 21000, higher, sender), true, ErrReplaceUnderpriced},
+		{"sender-paid by fee-delegated not bumped", pricedTransaction(0, 21000, price, sender), feeDelegatedTx(0, 21000, higher, big.NewInt(1), sender, feePayer), true, ErrReplaceUnderpriced},
+		{"ratio not bumped", feeDelegatedWithRatioTx(0, 21000, price, big.NewInt(1), sender, feePayer, 30), feeDelegatedWithRatioTx(0, 21000, higher, big.NewInt(1), sender, feePayer, 30), true, ErrReplaceUnderpriced},
+		{"ratio bumped with another ratio", feeDelegatedWithRatioTx(0, 21000, price, big.NewInt(1), sender, feePayer, 30), feeDelegatedWithRatioTx(0, 21000, bumped, big.NewInt(1), sender, feePayer, 70), true, nil},
+
+		// The sender can cancel a fee-delegated transaction at any price.
+		{"cancel fee-delegated", feeDelegatedTx(0, 21000, bumped, big.NewInt(1), sender, feePayer), cancelTx(0, 21000, price, from, sender), true, nil},
+		{"cancel ratio", feeDelegatedWithRatioTx(0, 21000, bumped, big.NewInt(1), sender, feePayer, 30), cancelTx(0, 21000, price, from, sender), true, nil},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			list := newTxList(false)
+			_, err := list.Add(tc.old, DefaultTxPoolConfig.PriceBump, tc.magma)
+			assert.NoError(t, err)
+
+			replaced, err := list.Add(tc.new, DefaultTxPoolConfig.PriceBump, tc.magma)
+			assert.ErrorIs(t, err, tc.wantErr)
+			if tc.wantErr != nil {
+				assert.Nil(t, replaced)
+			} else {
+				assert.Equal(t, tc.old, replaced)
+			}
+		})
+	}
+}
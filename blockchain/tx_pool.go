@@ -31,12 +31,14 @@ import (
 	"sync"
 	"time"
 
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/kaiachain/kaia/blockchain/state"
 	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/common/prque"
 	"github.com/kaiachain/kaia/consensus/misc"
 	"github.com/kaiachain/kaia/event"
+	"github.com/kaiachain/kaia/kaiax"
 	"github.com/kaiachain/kaia/kaiax/gov"
 	"github.com/kaiachain/kaia/kerrors"
 	"github.com/kaiachain/kaia/params"
@@ -66,6 +68,8 @@ const (
 	txMsgChSize = 100
 	// txFeedChSize is the number of transactions can be queued for event feed.
 	txFeedChSize = 100
+	// droppedTxsCacheSize is the number of recently dropped transactions kept for status lookups.
+	droppedTxsCacheSize = 4096
 )
 
 var (
@@ -75,6 +79,10 @@ var (
 	txPoolIsFullErr = fmt.Errorf("txpool is full")
 
 	errNotAllowedAnchoringTx = errors.New("locally anchoring chaindata tx is not allowed in this node")
+
+	errZeroPrivateTxExpiry    = errors.New("private transaction expiry must be positive")
+	errPrivateTxExpired       = errors.New("private transaction already expired")
+	errPrivateTxKnownAsPublic = errors.New("transaction already known as a public one")
 )
 
 var (
@@ -95,6 +103,8 @@ var (
 	underpricedTxCounter = metrics.NewRegisteredCounter("txpool/underpriced", nil)
 	refusedTxCounter     = metrics.NewRegisteredCounter("txpool/refuse", nil)
 	slotsGauge           = metrics.NewRegisteredGauge("txpool/slots", nil)
+
+	droppedEventDiscardCounter = metrics.NewRegisteredCounter("txpool/dropped/event/discard", nil) // Dropped tx events not delivered to a slow consumer
 )
 
 // TxStatus is the current status of a transaction as seen by the pool.
@@ -108,6 +118,28 @@ const (
 	TxStatusIncluded
 )
 
+// TxDropReason is the reason why a transaction is removed from the pool without being included.
+type TxDropReason string
+
+const (
+	TxDropReasonNone          TxDropReason = ""                 // Not dropped, e.g. included in a block
+	TxDropReasonUnderpriced   TxDropReason = "underpriced"      // A better priced transaction took the place
+	TxDropReasonNonceTooLow   TxDropReason = "nonce too low"    // The nonce has been used by another transaction
+	TxDropReasonExpired       TxDropReason = "lifetime expired" // Stayed in the queue longer than the lifetime
+	TxDropReasonReplaced      TxDropReason = "replaced"         // Replaced by another transaction of the same nonce
+	TxDropReasonUnexecutable  TxDropReason = "unexecutable"     // Insufficient funds or gas limit exceeded
+	TxDropReasonPoolOverflow  TxDropReason = "pool overflow"    // Evicted to keep the pool within its limits
+	TxDropReasonPrivateExpiry TxDropReason = "private expired"  // A private transaction not included before its expiry block
+)
+
+// DroppedTx is a transaction removed from the pool with the reason.
+type DroppedTx struct {
+	Tx          *types.Transaction
+	Reason      TxDropReason
+	Replacement common.Hash // The hash of the replacing transaction if replaced
+	Time        time.Time
+}
+
 // blockChain provides the state of blockchain and current gas limit to do
 // some pre checks in tx pool and event subscribers.
 type blockChain interface {
@@ -116,6 +148,8 @@ type blockChain interface {
 	StateAt(root common.Hash) (*state.StateDB, error)
 
 	SubscribeChainHeadEvent(ch chan<- ChainHeadEvent) event.Subscription
+
+	WriteBlobSidecars(hash common.Hash, number uint64, sidecars []*types.BlobTxSidecarWithHash)
 }
 
 // TxPoolConfig are the configuration parameters of the transaction pool.
@@ -125,6 +159,9 @@ type TxPoolConfig struct {
 	DenyRemoteTx       bool          // Denies remote transactions receiving from other peers
 	Journal            string        // Journal of local transactions to survive node restarts
 	JournalInterval    time.Duration // Time interval to regenerate the local transaction journal
+	Snapshot           string        // Snapshot of remote transactions to survive node restarts (disabled if empty)
+	SnapshotInterval   time.Duration // Time interval to regenerate the remote transaction snapshot
+	SnapshotMaxTxs     uint64        // Maximum number of transactions in the remote transaction snapshot
 
 	PriceLimit uint64 // Minimum gas price to enforce for acceptance into the pool
 	PriceBump  uint64 // Minimum price bump percentage to replace an already existing transaction (nonce)
@@ -144,8 +181,9 @@ type TxPoolConfig struct {
 // DefaultTxPoolConfig contains the default configurations for the transaction
 // pool.
 var DefaultTxPoolConfig = TxPoolConfig{
-	Journal:         "transactions.rlp",
-	JournalInterval: time.Hour,
+	Journal:          "transactions.rlp",
+	JournalInterval:  time.Hour,
+	SnapshotInterval: 10 * time.Minute,
 
 	PriceLimit: 1,
 	PriceBump:  10,
@@ -175,6 +213,13 @@ func (config *TxPoolConfig) sanitize() TxPoolConfig {
 		logger.Error("Sanitizing invalid txpool price bump", "provided", conf.PriceBump, "updated", DefaultTxPoolConfig.PriceBump)
 		conf.PriceBump = DefaultTxPoolConfig.PriceBump
 	}
+	if conf.SnapshotInterval < time.Second {
+		logger.Error("Sanitizing invalid txpool snapshot time", "provided", conf.SnapshotInterval, "updated", time.Second)
+		conf.SnapshotInterval = time.Second
+	}
+	if conf.SnapshotMaxTxs == 0 {
+		conf.SnapshotMaxTxs = conf.ExecSlotsAll + conf.NonExecSlotsAll
+	}
 	return conf
 }
 
@@ -190,16 +235,20 @@ type GovModule interface {
 // current state) and future transactions. Transactions move between those
 // two states over time as they are received and processed.
 type TxPool struct {
-	config       TxPoolConfig
-	chainconfig  *params.ChainConfig
-	chain        blockChain
-	gasPrice     *big.Int
-	txFeed       event.Feed
-	scope        event.SubscriptionScope
-	chainHeadCh  chan ChainHeadEvent
-	chainHeadSub event.Subscription
-	signer       types.Signer
-	mu           sync.RWMutex
+	config        TxPoolConfig
+	chainconfig   *params.ChainConfig
+	chain         blockChain
+	gasPrice      *big.Int
+	txFeed        event.Feed
+	privateTxFeed event.Feed
+	droppedFeed   event.Feed
+	blobFeed      event.Feed
+	scope         event.SubscriptionScope
+	droppedScope  event.SubscriptionScope // Subscriptions of droppedFeed, tracked apart to tell whether anyone is notified
+	chainHeadCh   chan ChainHeadEvent
+	chainHeadSub  event.Subscription
+	signer        types.Signer
+	mu            sync.RWMutex
 
 	currentBlockNumber uint64                    // Current block number
 	currentState       *state.StateDB            // Current state in the blockchain head
@@ -208,6 +257,8 @@ type TxPool struct {
 	locals  *accountSet // Set of local transaction to exempt from eviction rules
 	journal *txJournal  // Journal of local transaction to back up to disk
 
+	snapshot *txSnapshot // Snapshot of remote transactions to back up to disk
+
 	// TODO-Kaia
 	txMu sync.RWMutex
 
@@ -217,14 +268,27 @@ type TxPool struct {
 	all     *txLookup                    // All transactions to allow lookups
 	priced  *txPricedList                // All transactions sorted by price
 
+	bundles []*types.Bundle // Transaction bundles to be included atomically, in the order of arrival
+
+	droppedTxs *lru.Cache // Recently dropped transactions (hash -> *DroppedTx)
+
+	private map[common.Hash]uint64 // Private transactions not to be gossiped to ENs (hash -> expiry block number)
+
+	blobs      map[common.Hash]*types.BlobTxSidecar // Sidecars of the blob transactions, stored apart from the transactions
+	blobWrites []*blobSidecarsWrite                 // Sidecars of the included blob transactions, to be stored out of the lock
+
 	wg sync.WaitGroup // for shutdown sync
 
-	txMsgCh  chan types.Transactions // A buffer for async tx intake via AddRemotes
-	txFeedCh chan types.Transactions // A buffer for async tx event emission via txFeed
+	txMsgCh         chan types.Transactions // A buffer for async tx intake via AddRemotes
+	txFeedCh        chan types.Transactions // A buffer for async tx event emission via txFeed
+	privateTxFeedCh chan types.Transactions // A buffer for async private tx event emission via privateTxFeed
+	dropCh          chan []*DroppedTx       // A buffer for async dropped tx event emission via droppedFeed
 
 	rules params.Rules // Fork indicator
 
 	govModule GovModule
+
+	modules []kaiax.TxPoolModule
 }
 
 // NewTxPool creates a new transaction pool to gather, sort and filter inbound
@@ -237,25 +301,38 @@ func NewTxPool(config TxPoolConfig, chainconfig *params.ChainConfig, chain block
 
 	// Create the transaction pool with its initial settings
 	pool := &TxPool{
-		config:       config,
-		chainconfig:  chainconfig,
-		chain:        chain,
-		signer:       types.LatestSignerForChainID(chainconfig.ChainID),
-		pending:      make(map[common.Address]*txList),
-		queue:        make(map[common.Address]*txList),
-		beats:        make(map[common.Address]time.Time),
-		all:          newTxLookup(),
-		pendingNonce: make(map[common.Address]uint64),
-		chainHeadCh:  make(chan ChainHeadEvent, chainHeadChanSize),
-		gasPrice:     new(big.Int).SetUint64(pset.UnitPrice),
-		txMsgCh:      make(chan types.Transactions, txMsgChSize),
-		txFeedCh:     make(chan types.Transactions, txFeedChSize),
-		govModule:    govModule,
+		config:          config,
+		chainconfig:     chainconfig,
+		chain:           chain,
+		signer:          types.LatestSignerForChainID(chainconfig.ChainID),
+		pending:         make(map[common.Address]*txList),
+		queue:           make(map[common.Address]*txList),
+		beats:           make(map[common.Address]time.Time),
+		all:             newTxLookup(),
+		pendingNonce:    make(map[common.Address]uint64),
+		chainHeadCh:     make(chan ChainHeadEvent, chainHeadChanSize),
+		gasPrice:        new(big.Int).SetUint64(pset.UnitPrice),
+		txMsgCh:         make(chan types.Transactions, txMsgChSize),
+		txFeedCh:        make(chan types.Transactions, txFeedChSize),
+		privateTxFeedCh: make(chan types.Transactions, txFeedChSize),
+		dropCh:          make(chan []*DroppedTx, txFeedChSize),
+		private:         make(map[common.Hash]uint64),
+		blobs:           make(map[common.Hash]*types.BlobTxSidecar),
+		govModule:       govModule,
 	}
 	pool.locals = newAccountSet(pool.signer)
 	pool.priced = newTxPricedList(pool.all)
+	pool.droppedTxs, _ = lru.New(droppedTxsCacheSize)
 	pool.reset(nil, chain.CurrentBlock().Header())
 
+	// Subscribe events from blockchain
+	pool.chainHeadSub = pool.chain.SubscribeChainHeadEvent(pool.chainHeadCh)
+
+	// Start the tx feed handler before loading the transactions from disk,
+	// since the loaded transactions are fed through the bounded txFeedCh.
+	pool.wg.Add(1)
+	go pool.handleTxFeed()
+
 	// If local transactions and journaling is enabled, load from disk
 	if !config.NoLocals && config.Journal != "" {
 		pool.journal = newTxJournal(config.Journal)
@@ -267,14 +344,18 @@ func NewTxPool(config TxPoolConfig, chainconfig *params.ChainConfig, chain block
 			logger.Error("Failed to rotate transaction journal", "err", err)
 		}
 	}
-	// Subscribe events from blockchain
-	pool.chainHeadSub = pool.chain.SubscribeChainHeadEvent(pool.chainHeadCh)
+	// If the remote transaction snapshot is enabled, load from disk
+	if config.Snapshot != "" {
+		pool.snapshot = newTxSnapshot(config.Snapshot, config.SnapshotMaxTxs)
 
+		if err := pool.snapshot.load(pool.AddRemotes); err != nil {
+			logger.Error("Failed to load transaction snapshot", "err", err)
+		}
+	}
 	// Start the event loop and return
-	pool.wg.Add(3)
+	pool.wg.Add(2)
 	go pool.loop()
 	go pool.handleTxMsg()
-	go pool.handleTxFeed()
 
 	if config.EnableSpamThrottlerAtRuntime {
 		if err := pool.StartSpamThrottler(DefaultSpamThrottlerConfig); err != nil {
@@ -303,6 +384,13 @@ func (pool *TxPool) loop() {
 	journal := time.NewTicker(pool.config.JournalInterval)
 	defer journal.Stop()
 
+	var snapshot <-chan time.Time
+	if pool.snapshot != nil {
+		ticker := time.NewTicker(pool.config.SnapshotInterval)
+		defer ticker.Stop()
+		snapshot = ticker.C
+	}
+
 	// Track the previous head headers for transaction reorgs
 	head := pool.chain.CurrentBlock()
 
@@ -324,6 +412,8 @@ func (pool *TxPool) loop() {
 				pool.reset(head.Header(), ev.Block.Header())
 				head = ev.Block
 				pool.mu.Unlock()
+
+				pool.writeBlobSidecars()
 			}
 		// Be unsubscribed due to system stopped
 		case <-pool.chainHeadSub.Err():
@@ -357,7 +447,7 @@ func (pool *TxPool) loop() {
 				if time.Since(beat) > pool.config.Lifetime {
 					if pool.queue[addr] != nil {
 						for _, tx := range pool.queue[addr].Flatten() {
-							pool.removeTx(tx.Hash(), true)
+							pool.removeTx(tx.Hash(), true, TxDropReasonExpired)
 						}
 					}
 					delete(pool.beats, addr)
@@ -374,6 +464,10 @@ func (pool *TxPool) loop() {
 				}
 				pool.mu.Unlock()
 			}
+
+		// Handle remote transaction snapshot regeneration
+		case <-snapshot:
+			pool.writeSnapshot()
 		}
 	}
 }
@@ -382,9 +476,10 @@ func (pool *TxPool) loop() {
 // manner. This method is only ever used in the tester!
 func (pool *TxPool) lockedReset(oldHead, newHead *types.Header) {
 	pool.mu.Lock()
-	defer pool.mu.Unlock()
-
 	pool.reset(oldHead, newHead)
+	pool.mu.Unlock()
+
+	pool.writeBlobSidecars()
 }
 
 // reset retrieves the current state of the blockchain and ensures the content
@@ -477,11 +572,25 @@ func (pool *TxPool) reset(oldHead, newHead *types.Header) {
 
 	pool.addTxsLocked(reinject, false)
 
+	// Collect the transactions included in the new blocks to tell them from the dropped ones,
+	// only if anyone is notified of the dropped transactions.
+	var included map[common.Hash]struct{}
+	if pool.droppedScope.Count() > 0 {
+		included = pool.includedTxs(oldHead, newHead)
+	}
+
+	// Collect the sidecars of the blob transactions included in the new blocks
+	// before the transactions are removed from the pool.
+	if oldHead != nil && pool.chainconfig.IsBlobTxForkEnabled(newHead.Number) {
+		blocks, _ := pool.newBlocks(oldHead, newHead)
+		pool.collectBlobSidecars(blocks)
+	}
+
 	// validate the pool of pending transactions, this will remove
 	// any transactions that have been included in the block or
 	// have been invalidated because of another transaction (e.g.
 	// higher gas price)
-	pool.demoteUnexecutables()
+	pool.demoteUnexecutables(included)
 
 	pool.txMu.Lock()
 	// Update all accounts to the latest known pending nonce
@@ -495,7 +604,16 @@ func (pool *TxPool) reset(oldHead, newHead *types.Header) {
 	pool.txMu.Unlock()
 	// Check the queue and move transactions over to the pending if possible
 	// or remove those that have become invalid
-	pool.promoteExecutables(nil)
+	pool.promoteExecutables(nil, included)
+
+	// Remove the bundles which can no longer be included
+	pool.demoteBundles()
+
+	// Remove the private transactions which are expired or no longer in the pool
+	pool.demotePrivateTxs()
+
+	// Forget the blob sidecars of the transactions which are no longer in the pool
+	pool.demoteBlobSidecars()
 
 	// Update all fork indicator by next pending block number.
 	pool.rules = pool.chainconfig.Rules(new(big.Int).Add(newHead.Number, big.NewInt(1)))
@@ -511,6 +629,7 @@ func (pool *TxPool) reset(oldHead, newHead *types.Header) {
 func (pool *TxPool) Stop() {
 	// Unsubscribe all subscriptions registered from txpool
 	pool.scope.Close()
+	pool.droppedScope.Close()
 
 	// Unsubscribe subscriptions registered from blockchain
 	pool.chainHeadSub.Unsubscribe()
@@ -519,6 +638,9 @@ func (pool *TxPool) Stop() {
 	if pool.journal != nil {
 		pool.journal.close()
 	}
+	if pool.snapshot != nil {
+		pool.writeSnapshot()
+	}
 
 	pool.StopSpamThrottler()
 	logger.Info("Transaction pool stopped")
@@ -530,6 +652,12 @@ func (pool *TxPool) SubscribeNewTxsEvent(ch chan<- NewTxsEvent) event.Subscripti
 	return pool.scope.Track(pool.txFeed.Subscribe(ch))
 }
 
+// SubscribeNewPrivateTxsEvent registers a subscription of NewPrivateTxsEvent and
+// starts sending event to the given channel.
+func (pool *TxPool) SubscribeNewPrivateTxsEvent(ch chan<- NewPrivateTxsEvent) event.Subscription {
+	return pool.scope.Track(pool.privateTxFeed.Subscribe(ch))
+}
+
 // GasPrice returns the current gas price enforced by the transaction pool.
 func (pool *TxPool) GasPrice() *big.Int {
 	pool.mu.RLock()
@@ -538,6 +666,12 @@ func (pool *TxPool) GasPrice() *big.Int {
 	return new(big.Int).Set(pool.gasPrice)
 }
 
+// PriceBump returns the minimum price bump percentage to replace a transaction
+// in the transaction pool.
+func (pool *TxPool) PriceBump() uint64 {
+	return pool.config.PriceBump
+}
+
 // SetGasPrice updates the gas price of the transaction pool for new transactions, and drops all old transactions.
 func (pool *TxPool) SetGasPrice(price *big.Int) {
 	if pool.rules.IsMagma {
@@ -586,7 +720,8 @@ func (pool *TxPool) stats() (int, int) {
 }
 
 // Content retrieves the data content of the transaction pool, returning all the
-// pending as well as queued transactions, grouped by account and sorted by nonce.
+// pending as well as queued transactions except the private ones, grouped by
+// account and sorted by nonce.
 func (pool *TxPool) Content() (map[common.Address]types.Transactions, map[common.Address]types.Transactions) {
 	pool.mu.Lock()
 	defer pool.mu.Unlock()
@@ -595,11 +730,34 @@ func (pool *TxPool) Content() (map[common.Address]types.Transactions, map[common
 
 	pending := make(map[common.Address]types.Transactions)
 	for addr, list := range pool.pending {
-		pending[addr] = list.Flatten()
+		if txs := pool.publicTxs(list.Flatten()); len(txs) > 0 {
+			pending[addr] = txs
+		}
 	}
 	queued := make(map[common.Address]types.Transactions)
 	for addr, list := range pool.queue {
-		queued[addr] = list.Flatten()
+		if txs := pool.publicTxs(list.Flatten()); len(txs) > 0 {
+			queued[addr] = txs
+		}
+	}
+	return pending, queued
+}
+
+// ContentFrom retrieves the data content of the transaction pool, returning the
+// pending as well as queued transactions of the given address except the private
+// ones, sorted by nonce.
+func (pool *TxPool) ContentFrom(addr common.Address) (types.Transactions, types.Transactions) {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+	pool.txMu.RLock()
+	defer pool.txMu.RUnlock()
+
+	var pending, queued types.Transactions
+	if list, ok := pool.pending[addr]; ok {
+		pending = pool.publicTxs(list.Flatten())
+	}
+	if list, ok := pool.queue[addr]; ok {
+		queued = pool.publicTxs(list.Flatten())
 	}
 	return pending, queued
 }
@@ -620,6 +778,24 @@ func (pool *TxPool) Pending() (map[common.Address]types.Transactions, error) {
 	return pending, nil
 }
 
+// PublicPending retrieves all currently processable transactions except the
+// private ones, groupped by origin account and sorted by nonce. It is used for
+// the views of the pool, which must not expose the private transactions.
+func (pool *TxPool) PublicPending() (map[common.Address]types.Transactions, error) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	pool.txMu.Lock()
+	defer pool.txMu.Unlock()
+
+	pending := make(map[common.Address]types.Transactions)
+	for addr, list := range pool.pending {
+		if txs := pool.publicTxs(list.Flatten()); len(txs) > 0 {
+			pending[addr] = txs
+		}
+	}
+	return pending, nil
+}
+
 // CachedPendingTxsByCount retrieves about number of currently processable transactions
 // by requested count, grouped by origin account and sorted by nonce.
 func (pool *TxPool) CachedPendingTxsByCount(count int) types.Transactions {
@@ -662,22 +838,73 @@ func (pool *TxPool) CachedPendingTxsByCount(count int) types.Transactions {
 	return pending
 }
 
-// local retrieves all currently known local transactions, groupped by origin
-// account and sorted by nonce. The returned transaction set is a copy and can be
-// freely modified by calling code.
+// local retrieves all currently known local transactions except the private ones,
+// groupped by origin account and sorted by nonce. The blob transactions are in the
+// network form with their sidecars. The returned transaction set is a copy and can
+// be freely modified by calling code.
 func (pool *TxPool) local() map[common.Address]types.Transactions {
 	txs := make(map[common.Address]types.Transactions)
 	for addr := range pool.locals.accounts {
 		if pending := pool.pending[addr]; pending != nil {
-			txs[addr] = append(txs[addr], pending.Flatten()...)
+			txs[addr] = append(txs[addr], pool.backupTxs(pending.Flatten())...)
 		}
 		if queued := pool.queue[addr]; queued != nil {
-			txs[addr] = append(txs[addr], queued.Flatten()...)
+			txs[addr] = append(txs[addr], pool.backupTxs(queued.Flatten())...)
+		}
+	}
+	return txs
+}
+
+// remote retrieves all currently known remote transactions except the private ones,
+// groupped by origin account and sorted by nonce. The blob transactions are in the
+// network form with their sidecars. The returned transaction set is a copy and can
+// be freely modified by calling code.
+func (pool *TxPool) remote() map[common.Address]types.Transactions {
+	txs := make(map[common.Address]types.Transactions)
+	for addr, pending := range pool.pending {
+		if !pool.locals.contains(addr) {
+			txs[addr] = append(txs[addr], pool.backupTxs(pending.Flatten())...)
+		}
+	}
+	for addr, queued := range pool.queue {
+		if !pool.locals.contains(addr) {
+			txs[addr] = append(txs[addr], pool.backupTxs(queued.Flatten())...)
 		}
 	}
 	return txs
 }
 
+// backupTxs returns the given transactions to be backed up to disk, which are
+// the public ones in the network form, with the sidecars of the blob transactions.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *TxPool) backupTxs(txs types.Transactions) types.Transactions {
+	txs = pool.publicTxs(txs)
+	if len(pool.blobs) == 0 {
+		return txs
+	}
+	for i, tx := range txs {
+		if sidecar := pool.blobs[tx.Hash()]; sidecar != nil {
+			txs[i] = tx.WithBlobTxSidecar(sidecar)
+		}
+	}
+	return txs
+}
+
+// writeSnapshot regenerates the remote transaction snapshot. The disk write is
+// done outside the pool lock.
+func (pool *TxPool) writeSnapshot() {
+	pool.mu.RLock()
+	pool.txMu.RLock()
+	remotes := pool.remote()
+	pool.txMu.RUnlock()
+	pool.mu.RUnlock()
+
+	if err := pool.snapshot.write(remotes, pool.signer); err != nil {
+		logger.Error("Failed to write remote transaction snapshot", "err", err)
+	}
+}
+
 // validateTx checks whether a transaction is valid according to the consensus
 // rules and adheres to some heuristic limits of the local node (price and size).
 func (pool *TxPool) validateTx(tx *types.Transaction) error {
@@ -689,6 +916,10 @@ func (pool *TxPool) validateTx(tx *types.Transaction) error {
 	if !pool.rules.IsEthTxType && (tx.Type() == types.TxTypeEthereumDynamicFee || tx.Type() == types.TxTypeEthereumSetCode) {
 		return ErrTxTypeNotSupported
 	}
+	// Reject blob transactions until EIP-4844 activates.
+	if !pool.rules.IsBlobTx && tx.Type() == types.TxTypeEthereumBlob {
+		return ErrTxTypeNotSupported
+	}
 
 	// Check whether the init code size has been exceeded
 	if pool.rules.IsShanghai && tx.To() == nil && len(tx.Data()) > params.MaxInitCodeSize {
@@ -702,7 +933,7 @@ func (pool *TxPool) validateTx(tx *types.Transaction) error {
 
 	// NOTE-Kaia Drop transactions with unexpected gasPrice
 	// If the transaction type is DynamicFee tx, Compare transaction's GasFeeCap(MaxFeePerGas) and GasTipCap with tx pool's gasPrice to check to have same value.
-	if tx.Type() == types.TxTypeEthereumDynamicFee || tx.Type() == types.TxTypeEthereumSetCode {
+	if tx.Type() == types.TxTypeEthereumDynamicFee || tx.Type() == types.TxTypeEthereumSetCode || tx.Type() == types.TxTypeEthereumBlob {
 		// Sanity check for extremely large numbers
 		if tx.GasTipCap().BitLen() > 256 {
 			return ErrTipVeryHigh
@@ -717,6 +948,15 @@ func (pool *TxPool) validateTx(tx *types.Transaction) error {
 			return ErrTipAboveFeeCap
 		}
 
+		if tx.Type() == types.TxTypeEthereumBlob {
+			if tx.BlobGasFeeCap().BitLen() > 256 {
+				return types.ErrBlobFeeCapVeryHigh
+			}
+			if tx.BlobGasFeeCap().Cmp(big.NewInt(params.BlobTxMinBlobGasprice)) < 0 {
+				return types.ErrBlobFeeCapTooLow
+			}
+		}
+
 		if pool.rules.IsMagma {
 			// Ensure transaction's gasFeeCap is greater than or equal to transaction pool's gasPrice(baseFee).
 			if pool.gasPrice.Cmp(tx.GasFeeCap()) > 0 {
@@ -900,12 +1140,32 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (bool, error) {
 		logger.Trace("Discarding already known transaction", "hash", hash)
 		return false, fmt.Errorf("known transaction: %x", hash)
 	}
+	// The sidecar of a blob transaction is stored apart from the transaction,
+	// so that the pooled transactions are of the same form as in the blocks.
+	// The network form with the sidecar is kept to be journaled.
+	var sidecar *types.BlobTxSidecar
+	netTx := tx
+	if tx.Type() == types.TxTypeEthereumBlob {
+		sidecar, tx = tx.BlobTxSidecar(), tx.WithoutBlobTxSidecar()
+	}
 	// If the transaction fails basic validation, discard it
 	if err := pool.validateTx(tx); err != nil {
 		logger.Trace("Discarding invalid transaction", "hash", hash, "err", err)
 		invalidTxCounter.Inc(1)
 		return false, err
 	}
+	if tx.Type() == types.TxTypeEthereumBlob {
+		if err := validateBlobSidecar(tx, sidecar); err != nil {
+			logger.Trace("Discarding blob transaction with invalid sidecar", "hash", hash, "err", err)
+			invalidTxCounter.Inc(1)
+			return false, err
+		}
+	}
+	// If any of the modules rejects the transaction, discard it
+	if err := pool.preAddTx(tx, local); err != nil {
+		logger.Trace("Discarding transaction rejected by module", "hash", hash, "err", err)
+		return false, err
+	}
 
 	// If the transaction pool is full and new Tx is valid,
 	// (1) discard a new Tx if there is no room for the account of the Tx
@@ -924,7 +1184,7 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (bool, error) {
 		maxTx := pool.getMaxTxFromQueueWhenNonceIsMissing(tx, &from)
 		if maxTx != tx {
 			// (2) remove an old Tx with the largest nonce from queue to make a room for a new Tx with missing nonce
-			pool.removeTx(maxTx.Hash(), true)
+			pool.removeTx(maxTx.Hash(), true, TxDropReasonPoolOverflow)
 			logger.Trace("Removing an old Tx with the max nonce to insert a new Tx with missing nonce, because TxPool is full", "account", from, "new nonce(previously missing)", tx.Nonce(), "removed max nonce", maxTx.Nonce())
 		} else {
 			// (3) discard a new Tx if the new Tx does not have a missing nonce
@@ -945,32 +1205,36 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (bool, error) {
 		for _, tx := range drop {
 			logger.Trace("Discarding freshly underpriced transaction", "hash", tx.Hash(), "price", tx.GasPrice())
 			underpricedTxCounter.Inc(1)
-			pool.removeTx(tx.Hash(), false)
+			pool.removeTx(tx.Hash(), false, TxDropReasonUnderpriced)
 		}
 	}
 	// If the transaction is replacing an already pending one, do directly
 	from, _ := types.Sender(pool.signer, tx) // already validated
 	if list := pool.pending[from]; list != nil && list.Overlaps(tx) {
 		// Nonce already pending, check if required price bump is met
-		inserted, old := list.Add(tx, pool.config.PriceBump, pool.rules.IsMagma)
-		if !inserted {
+		old, err := list.Add(tx, pool.config.PriceBump, pool.rules.IsMagma)
+		if err != nil {
 			pendingDiscardCounter.Inc(1)
-			return false, ErrAlreadyNonceExistInPool
+			return false, err
 		}
 		// New transaction is better, replace old one
 		if old != nil {
 			pool.all.Remove(old.Hash())
 			pool.priced.Removed()
 			pendingReplaceCounter.Inc(1)
+			pool.postReplaceTx(old, tx)
 		}
 		pool.all.Add(tx)
 		pool.priced.Put(tx)
-		pool.journalTx(from, tx)
+		pool.journalTx(from, netTx)
+		if sidecar != nil {
+			pool.blobs[hash] = sidecar
+		}
 
 		logger.Trace("Pooled new executable transaction", "hash", hash, "from", from, "to", tx.To())
 
 		// We've directly injected a replacement transaction, notify subsystems
-		pool.txFeedCh <- types.Transactions{tx}
+		pool.feedTxs(types.Transactions{tx})
 
 		return old != nil, nil
 	}
@@ -983,7 +1247,10 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (bool, error) {
 	if local {
 		pool.locals.add(from)
 	}
-	pool.journalTx(from, tx)
+	pool.journalTx(from, netTx)
+	if sidecar != nil {
+		pool.blobs[hash] = sidecar
+	}
 
 	logger.Trace("Pooled new future transaction", "hash", hash, "from", from, "to", tx.To())
 	return replace, nil
@@ -998,17 +1265,18 @@ func (pool *TxPool) enqueueTx(hash common.Hash, tx *types.Transaction) (bool, er
 	if pool.queue[from] == nil {
 		pool.queue[from] = newTxList(false)
 	}
-	inserted, old := pool.queue[from].Add(tx, pool.config.PriceBump, pool.rules.IsMagma)
-	if !inserted {
+	old, err := pool.queue[from].Add(tx, pool.config.PriceBump, pool.rules.IsMagma)
+	if err != nil {
 		// An older transaction was better, discard this
 		queuedDiscardCounter.Inc(1)
-		return false, ErrAlreadyNonceExistInPool
+		return false, err
 	}
 	// Discard any previous transaction and mark this
 	if old != nil {
 		pool.all.Remove(old.Hash())
 		pool.priced.Removed()
 		queuedReplaceCounter.Inc(1)
+		pool.postReplaceTx(old, tx)
 	}
 	if pool.all.Get(hash) == nil {
 		pool.all.Add(tx)
@@ -1026,6 +1294,10 @@ func (pool *TxPool) journalTx(from common.Address, tx *types.Transaction) {
 	if pool.journal == nil || !pool.locals.contains(from) {
 		return
 	}
+	// Private transactions are not journaled not to be gossiped after restart
+	if _, ok := pool.private[tx.Hash()]; ok {
+		return
+	}
 	if err := pool.journal.insert(tx); err != nil {
 		logger.Error("Failed to journal local transaction", "err", err)
 	}
@@ -1042,13 +1314,14 @@ func (pool *TxPool) promoteTx(addr common.Address, hash common.Hash, tx *types.T
 	}
 	list := pool.pending[addr]
 
-	inserted, old := list.Add(tx, pool.config.PriceBump, pool.rules.IsMagma)
-	if !inserted {
+	old, err := list.Add(tx, pool.config.PriceBump, pool.rules.IsMagma)
+	if err != nil {
 		// An older transaction was better, discard this
 		pool.all.Remove(hash)
 		pool.priced.Removed()
 
 		pendingDiscardCounter.Inc(1)
+		pool.postRemoveTxs([]*DroppedTx{{Tx: tx, Reason: TxDropReasonUnderpriced}})
 		return false
 	}
 	// Otherwise discard any previous transaction and mark this
@@ -1057,6 +1330,7 @@ func (pool *TxPool) promoteTx(addr common.Address, hash common.Hash, tx *types.T
 		pool.priced.Removed()
 
 		pendingReplaceCounter.Inc(1)
+		pool.postReplaceTx(old, tx)
 	}
 	// Failsafe to work around direct pending inserts (tests)
 	if pool.all.Get(hash) == nil {
@@ -1207,6 +1481,10 @@ func (pool *TxPool) handleTxFeed() {
 		select {
 		case txs := <-pool.txFeedCh:
 			pool.txFeed.Send(NewTxsEvent{txs})
+		case txs := <-pool.privateTxFeedCh:
+			pool.privateTxFeed.Send(NewPrivateTxsEvent{txs})
+		case dropped := <-pool.dropCh:
+			pool.droppedFeed.Send(DroppedTxsEvent{dropped})
 		case <-pool.chainHeadSub.Err():
 			return
 		}
@@ -1230,6 +1508,272 @@ func (pool *TxPool) AddLocal(tx *types.Transaction) error {
 	return pool.addTx(tx, !pool.config.NoLocals)
 }
 
+// AddPrivate enqueues a single transaction into the pool as a local one, marking
+// it as private. A private transaction is only propagated to consensus and proxy
+// nodes, and is dropped if it is not included in the given number of blocks.
+func (pool *TxPool) AddPrivate(tx *types.Transaction, expiryBlocks uint64) error {
+	if expiryBlocks == 0 {
+		return errZeroPrivateTxExpiry
+	}
+	pool.mu.RLock()
+	expiry := pool.currentBlockNumber + expiryBlocks
+	pool.mu.RUnlock()
+
+	return pool.addPrivate(tx, !pool.config.NoLocals, expiry)
+}
+
+// AddPrivateRemote enqueues a single private transaction received from a peer,
+// keeping it private until the given expiry block number.
+func (pool *TxPool) AddPrivateRemote(tx *types.Transaction, expiry uint64) error {
+	return pool.addPrivate(tx, false, expiry)
+}
+
+// addPrivate enqueues a single transaction into the pool, marking it as private
+// until the given expiry block number.
+func (pool *TxPool) addPrivate(tx *types.Transaction, local bool, expiry uint64) error {
+	pool.mu.RLock()
+	poolSize := uint64(pool.all.Count())
+	pool.mu.RUnlock()
+	if poolSize >= pool.config.ExecSlotsAll+pool.config.NonExecSlotsAll {
+		return fmt.Errorf("txpool is full: %d", poolSize)
+	}
+	senderCacher.recover(pool.signer, []*types.Transaction{tx})
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if expiry <= pool.currentBlockNumber {
+		return errPrivateTxExpired
+	}
+	// A transaction which has already been gossiped can't be made private.
+	hash := tx.Hash()
+	_, known := pool.private[hash]
+	if !known && pool.all.Get(hash) != nil {
+		return errPrivateTxKnownAsPublic
+	}
+	// Mark the transaction before the insertion so that the new transaction
+	// event is never handled as a public one.
+	if !known {
+		pool.private[hash] = expiry
+	}
+	replace, err := pool.add(tx, local)
+	if err != nil {
+		if !known {
+			delete(pool.private, hash)
+		}
+		return err
+	}
+	if !replace {
+		from, _ := types.Sender(pool.signer, tx) // already validated
+		pool.promoteExecutables([]common.Address{from}, nil)
+	}
+	return nil
+}
+
+// IsPrivateTx returns whether the transaction of the given hash has been added
+// as a private one and is not expired yet.
+func (pool *TxPool) IsPrivateTx(hash common.Hash) bool {
+	_, ok := pool.PrivateTxExpiry(hash)
+	return ok
+}
+
+// PrivateTxExpiry returns the expiry block number of the private transaction
+// of the given hash, and false if the transaction is not a private one.
+func (pool *TxPool) PrivateTxExpiry(hash common.Hash) (uint64, bool) {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	expiry, ok := pool.private[hash]
+	return expiry, ok
+}
+
+// publicTxs filters out the private transactions from the given transactions.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *TxPool) publicTxs(txs types.Transactions) types.Transactions {
+	if len(pool.private) == 0 {
+		return txs
+	}
+	public := make(types.Transactions, 0, len(txs))
+	for _, tx := range txs {
+		if _, ok := pool.private[tx.Hash()]; !ok {
+			public = append(public, tx)
+		}
+	}
+	return public
+}
+
+// feedTxs emits the new transactions via txFeed, except the private ones which
+// are emitted via privateTxFeed, so that the subscribers of NewTxsEvent such as
+// newPendingTransactions never observe the private transactions.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *TxPool) feedTxs(txs types.Transactions) {
+	public := pool.publicTxs(txs)
+	if len(public) > 0 {
+		pool.txFeedCh <- public
+	}
+	if len(public) == len(txs) {
+		return
+	}
+	private := make(types.Transactions, 0, len(txs)-len(public))
+	for _, tx := range txs {
+		if _, ok := pool.private[tx.Hash()]; ok {
+			private = append(private, tx)
+		}
+	}
+	pool.privateTxFeedCh <- private
+}
+
+// demotePrivateTxs removes the private transactions which have reached their
+// expiry block, and forgets the ones which are no longer in the pool.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *TxPool) demotePrivateTxs() {
+	for hash, expiry := range pool.private {
+		if pool.all.Get(hash) == nil {
+			delete(pool.private, hash)
+			continue
+		}
+		if pool.currentBlockNumber >= expiry {
+			logger.Trace("Removed expired private transaction", "hash", hash, "expiry", expiry)
+			pool.removeTx(hash, true, TxDropReasonPrivateExpiry)
+			delete(pool.private, hash)
+		}
+	}
+}
+
+// validateBlobSidecar checks that the sidecar holds the blobs committed by the
+// blob transaction.
+func validateBlobSidecar(tx *types.Transaction, sidecar *types.BlobTxSidecar) error {
+	if sidecar == nil {
+		return types.ErrMissingBlobSidecar
+	}
+	return sidecar.Verify(tx.BlobHashes())
+}
+
+// GetBlobSidecar returns the sidecar of the pooled blob transaction of the
+// given hash, or nil if the pool doesn't have it.
+func (pool *TxPool) GetBlobSidecar(hash common.Hash) *types.BlobTxSidecar {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	return pool.blobs[hash]
+}
+
+// blobSidecarsWrite is the sidecars of the blob transactions included in a block,
+// collected under the pool lock to be stored out of it.
+type blobSidecarsWrite struct {
+	block    *types.Block
+	sidecars []*types.BlobTxSidecarWithHash
+	missing  []common.Hash // Blob transactions whose sidecars the pool never received
+}
+
+// collectBlobSidecars collects the sidecars of the pooled blob transactions which
+// have been included in the given blocks, to be stored by writeBlobSidecars. The
+// blob transactions the pool has never received are reported as missing ones, so
+// that their sidecars can be requested from the peers.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *TxPool) collectBlobSidecars(blocks []*types.Block) {
+	for _, block := range blocks {
+		var write *blobSidecarsWrite
+		for _, tx := range block.Transactions() {
+			if tx.Type() != types.TxTypeEthereumBlob {
+				continue
+			}
+			if write == nil {
+				write = &blobSidecarsWrite{block: block}
+			}
+			if sidecar := pool.blobs[tx.Hash()]; sidecar != nil {
+				write.sidecars = append(write.sidecars, &types.BlobTxSidecarWithHash{TxHash: tx.Hash(), Sidecar: sidecar})
+			} else {
+				write.missing = append(write.missing, tx.Hash())
+			}
+		}
+		if write != nil {
+			pool.blobWrites = append(pool.blobWrites, write)
+		}
+	}
+}
+
+// writeBlobSidecars stores the sidecars collected by collectBlobSidecars and
+// reports the missing ones. It must be called without the pool lock, since
+// storing the sidecars also prunes the ones beyond the retention window.
+func (pool *TxPool) writeBlobSidecars() {
+	pool.mu.Lock()
+	writes := pool.blobWrites
+	pool.blobWrites = nil
+	pool.mu.Unlock()
+
+	for _, write := range writes {
+		pool.chain.WriteBlobSidecars(write.block.Hash(), write.block.NumberU64(), write.sidecars)
+		if len(write.missing) > 0 {
+			logger.Debug("Missing blob sidecars of the included transactions", "number", write.block.NumberU64(), "hash", write.block.Hash(), "txs", len(write.missing))
+			pool.blobFeed.Send(MissingBlobSidecarsEvent{Block: write.block, TxHashes: write.missing})
+		}
+	}
+}
+
+// newBlocks returns the blocks from newHead back to, but not including, oldHead,
+// and whether they link newHead to oldHead. It looks up at most the same number of
+// blocks as a transaction reorg, and stops at the height of oldHead on a reorg.
+func (pool *TxPool) newBlocks(oldHead, newHead *types.Header) ([]*types.Block, bool) {
+	oldNum := uint64(0)
+	if oldHead != nil {
+		oldNum = oldHead.Number.Uint64()
+	}
+	var blocks []*types.Block
+	block := pool.chain.GetBlock(newHead.Hash(), newHead.Number.Uint64())
+	for depth := 0; block != nil && block.NumberU64() > oldNum && depth < 64; depth++ {
+		blocks = append(blocks, block)
+		block = pool.chain.GetBlock(block.ParentHash(), block.NumberU64()-1)
+	}
+	linked := oldHead != nil && block != nil && block.Hash() == oldHead.Hash()
+	return blocks, linked
+}
+
+// includedTxs returns the hashes of the transactions included in the new blocks from oldHead
+// to newHead. It returns nil if the new blocks cannot be all looked up, e.g. after a long sync
+// or on a reorg, in which case the inclusion of the processed transactions is unknown.
+func (pool *TxPool) includedTxs(oldHead, newHead *types.Header) map[common.Hash]struct{} {
+	blocks, linked := pool.newBlocks(oldHead, newHead)
+	if !linked {
+		return nil
+	}
+	included := make(map[common.Hash]struct{})
+	for _, block := range blocks {
+		for _, tx := range block.Transactions() {
+			included[tx.Hash()] = struct{}{}
+		}
+	}
+	return included
+}
+
+// oldNonceDropReason returns the reason for removing the transaction whose nonce has been
+// used. It is dropped only if it is known not to be included in the new blocks.
+func oldNonceDropReason(included map[common.Hash]struct{}, hash common.Hash) TxDropReason {
+	if included == nil {
+		return TxDropReasonNone
+	}
+	if _, ok := included[hash]; ok {
+		return TxDropReasonNone
+	}
+	return TxDropReasonNonceTooLow
+}
+
+// demoteBlobSidecars forgets the blob sidecars of the transactions which are no
+// longer in the pool.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *TxPool) demoteBlobSidecars() {
+	for hash := range pool.blobs {
+		if pool.all.Get(hash) == nil {
+			delete(pool.blobs, hash)
+		}
+	}
+}
+
 // AddRemote enqueues a single transaction into the pool if it is valid. If the
 // sender is not among the locally tracked ones, full pricing constraints will
 // apply.
@@ -1291,7 +1835,7 @@ func (pool *TxPool) addTx(tx *types.Transaction, local bool) error {
 	// If we added a new transaction, run promotion checks and return
 	if !replace {
 		from, _ := types.Sender(pool.signer, tx) // already validated
-		pool.promoteExecutables([]common.Address{from})
+		pool.promoteExecutables([]common.Address{from}, nil)
 	}
 	return nil
 }
@@ -1329,7 +1873,7 @@ func (pool *TxPool) addTxsLocked(txs []*types.Transaction, local bool) []error {
 		for addr := range dirty {
 			addrs = append(addrs, addr)
 		}
-		pool.promoteExecutables(addrs)
+		pool.promoteExecutables(addrs, nil)
 	}
 	return errs
 }
@@ -1371,7 +1915,7 @@ func (pool *TxPool) checkAndSetBeat(addr common.Address) {
 
 // removeTx removes a single transaction from the queue, moving all subsequent
 // transactions back to the future queue.
-func (pool *TxPool) removeTx(hash common.Hash, outofbound bool) {
+func (pool *TxPool) removeTx(hash common.Hash, outofbound bool, reason TxDropReason) {
 	// Fetch the transaction we wish to delete
 	tx := pool.all.Get(hash)
 	if tx == nil {
@@ -1384,6 +1928,8 @@ func (pool *TxPool) removeTx(hash common.Hash, outofbound bool) {
 	if outofbound {
 		pool.priced.Removed()
 	}
+	pool.postRemoveTxs([]*DroppedTx{{Tx: tx, Reason: reason}})
+
 	// Remove the transaction from the pending lists and reset the account nonce
 	if pending := pool.pending[addr]; pending != nil {
 		if removed, invalids := pending.Remove(tx); removed {
@@ -1395,6 +1941,7 @@ func (pool *TxPool) removeTx(hash common.Hash, outofbound bool) {
 			for _, tx := range invalids {
 				pool.enqueueTx(tx.Hash(), tx)
 			}
+			pool.postDemoteTxs(invalids)
 			pool.updatePendingNonce(addr, tx.Nonce())
 			return
 		}
@@ -1410,12 +1957,15 @@ func (pool *TxPool) removeTx(hash common.Hash, outofbound bool) {
 
 // promoteExecutables moves transactions that have become processable from the
 // future queue to the set of pending transactions. During this process, all
-// invalidated transactions (low nonce, low balance) are deleted.
-func (pool *TxPool) promoteExecutables(accounts []common.Address) {
+// invalidated transactions (low nonce, low balance) are deleted. The ones with a low
+// nonce are regarded as dropped as in demoteUnexecutables with the given included set.
+func (pool *TxPool) promoteExecutables(accounts []common.Address, included map[common.Hash]struct{}) {
 	pool.txMu.Lock()
 	defer pool.txMu.Unlock()
 	// Track the promoted transactions to broadcast them at once
 	var promoted []*types.Transaction
+	// Track the removed transactions to notify the modules at once
+	var removed []*DroppedTx
 
 	// Gather all the accounts potentially needing updates
 	if accounts == nil {
@@ -1437,6 +1987,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 			logger.Trace("Removed old queued transaction", "hash", hash)
 			pool.all.Remove(hash)
 			pool.priced.Removed()
+			removed = append(removed, &DroppedTx{Tx: tx, Reason: oldNonceDropReason(included, hash)})
 		}
 		// Drop all transactions that are too costly (low balance)
 		drops, _ := list.Filter(addr, pool)
@@ -1446,6 +1997,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 			pool.all.Remove(hash)
 			pool.priced.Removed()
 			queuedNofundsCounter.Inc(1)
+			removed = append(removed, &DroppedTx{Tx: tx, Reason: TxDropReasonUnexecutable})
 		}
 
 		// Gather all executable transactions and promote them
@@ -1471,6 +2023,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 				pool.priced.Removed()
 				queuedRateLimitCounter.Inc(1)
 				logger.Trace("Removed cap-exceeding queued transaction", "hash", hash)
+				removed = append(removed, &DroppedTx{Tx: tx, Reason: TxDropReasonPoolOverflow})
 			}
 		}
 		// Delete the entire queue entry if it became empty.
@@ -1480,7 +2033,8 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 	}
 	// Notify subsystem for new promoted transactions.
 	if len(promoted) > 0 {
-		pool.txFeedCh <- promoted
+		pool.feedTxs(promoted)
+		pool.postPromoteTxs(promoted)
 	}
 	// If the pending limit is overflown, start equalizing allowances
 	pending := uint64(0)
@@ -1523,6 +2077,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 							// Update the account nonce to the dropped transaction
 							pool.updatePendingNonce(offenders[i], tx.Nonce())
 							logger.Trace("Removed fairness-exceeding pending transaction", "hash", hash)
+							removed = append(removed, &DroppedTx{Tx: tx, Reason: TxDropReasonPoolOverflow})
 						}
 						pending--
 					}
@@ -1543,6 +2098,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 						// Update the account nonce to the dropped transaction
 						pool.updatePendingNonce(addr, tx.Nonce())
 						logger.Trace("Removed fairness-exceeding pending transaction", "hash", hash)
+						removed = append(removed, &DroppedTx{Tx: tx, Reason: TxDropReasonPoolOverflow})
 					}
 					pending--
 				}
@@ -1550,6 +2106,8 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 		}
 		pendingRateLimitCounter.Inc(int64(pendingBeforeCap - pending))
 	}
+	pool.postRemoveTxs(removed)
+
 	// If we've queued more transactions than the hard limit, drop oldest ones
 	queued := uint64(0)
 	for _, list := range pool.queue {
@@ -1576,7 +2134,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 			// Drop all transactions if they are less than the overflow
 			if size := uint64(list.Len()); size <= drop {
 				for _, tx := range list.Flatten() {
-					pool.removeTx(tx.Hash(), true)
+					pool.removeTx(tx.Hash(), true, TxDropReasonPoolOverflow)
 				}
 				drop -= size
 				queuedRateLimitCounter.Inc(int64(size))
@@ -1585,7 +2143,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 			// Otherwise drop only last few transactions
 			txs := list.Flatten()
 			for i := len(txs) - 1; i >= 0 && drop > 0; i-- {
-				pool.removeTx(txs[i].Hash(), true)
+				pool.removeTx(txs[i].Hash(), true, TxDropReasonPoolOverflow)
 				drop--
 				queuedRateLimitCounter.Inc(1)
 			}
@@ -1595,13 +2153,20 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 
 // demoteUnexecutables removes invalid and processed transactions from the pools
 // executable/pending queue and any subsequent transactions that become unexecutable
-// are moved back into the future queue.
-func (pool *TxPool) demoteUnexecutables() {
+// are moved back into the future queue. The processed transactions which are not in
+// the given included set are regarded as dropped, since their nonce was used by others.
+// If the included set is nil, the inclusion is unknown and none of them is regarded as dropped.
+func (pool *TxPool) demoteUnexecutables(included map[common.Hash]struct{}) {
 	pool.txMu.Lock()
 	defer pool.txMu.Unlock()
 
 	// full-validation count. demoteUnexecutables does full-validation for a limited number of txs.
 	cnt := 0
+	// Track the removed and demoted transactions to notify the modules at once
+	var (
+		removed []*DroppedTx
+		demoted []*types.Transaction
+	)
 	// Iterate over all accounts and demote any non-executable transactions
 	for addr, list := range pool.pending {
 		nonce := pool.getNonce(addr)
@@ -1613,6 +2178,8 @@ func (pool *TxPool) demoteUnexecutables() {
 			logger.Trace("Removed old pending transaction", "hash", hash)
 			pool.all.Remove(hash)
 			pool.priced.Removed()
+			// The ones included in the new blocks are not regarded as dropped
+			removed = append(removed, &DroppedTx{Tx: tx, Reason: oldNonceDropReason(included, hash)})
 		}
 
 		// demoteUnexecutables does full-validation for a limited number of txs. Otherwise, it only validate nonce.
@@ -1631,12 +2198,14 @@ func (pool *TxPool) demoteUnexecutables() {
 			pool.all.Remove(hash)
 			pool.priced.Removed()
 			pendingNofundsCounter.Inc(1)
+			removed = append(removed, &DroppedTx{Tx: tx, Reason: TxDropReasonUnexecutable})
 		}
 
 		for _, tx := range invalids {
 			hash := tx.Hash()
 			logger.Trace("Demoting pending transaction", "hash", hash)
 			pool.enqueueTx(hash, tx)
+			demoted = append(demoted, tx)
 		}
 		// If there's a gap in front, warn (should never happen) and postpone all transactions
 		if list.Len() > 0 && list.txs.Get(nonce) == nil {
@@ -1644,6 +2213,7 @@ func (pool *TxPool) demoteUnexecutables() {
 				hash := tx.Hash()
 				logger.Error("Demoting invalidated transaction", "hash", hash)
 				pool.enqueueTx(hash, tx)
+				demoted = append(demoted, tx)
 			}
 		}
 
@@ -1660,6 +2230,7 @@ func (pool *TxPool) demoteUnexecutables() {
 							pool.enqueueTx(invalidTx.Hash(), invalidTx)
 						}
 						pool.enqueueTx(hash, tx)
+						demoted = append(append(demoted, tx), invalids...)
 					}
 					break
 				}
@@ -1671,6 +2242,115 @@ func (pool *TxPool) demoteUnexecutables() {
 			delete(pool.pending, addr)
 		}
 	}
+	pool.postRemoveTxs(removed)
+	pool.postDemoteTxs(demoted)
+}
+
+// RegisterTxPoolModule registers kaiax.TxPoolModule to the txpool.
+func (pool *TxPool) RegisterTxPoolModule(modules ...kaiax.TxPoolModule) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	pool.modules = append(pool.modules, modules...)
+}
+
+// preAddTx lets the modules inspect a new transaction before it is pooled.
+func (pool *TxPool) preAddTx(tx *types.Transaction, local bool) error {
+	for _, module := range pool.modules {
+		var err error
+		if local {
+			err = module.PreAddLocal(tx)
+		} else {
+			err = module.PreAddRemote(tx)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (pool *TxPool) postPromoteTxs(txs []*types.Transaction) {
+	if len(txs) == 0 {
+		return
+	}
+	for _, module := range pool.modules {
+		module.PostPromoteTxs(txs)
+	}
+}
+
+func (pool *TxPool) postDemoteTxs(txs []*types.Transaction) {
+	if len(txs) == 0 {
+		return
+	}
+	for _, module := range pool.modules {
+		module.PostDemoteTxs(txs)
+	}
+}
+
+// postRemoveTxs notifies the modules of the removed transactions, and records
+// the ones removed with a drop reason as dropped.
+func (pool *TxPool) postRemoveTxs(removed []*DroppedTx) {
+	if len(removed) == 0 {
+		return
+	}
+	txs := make([]*types.Transaction, 0, len(removed))
+	dropped := make([]*DroppedTx, 0, len(removed))
+	for _, d := range removed {
+		txs = append(txs, d.Tx)
+		if d.Reason != TxDropReasonNone {
+			dropped = append(dropped, d)
+		}
+	}
+	for _, module := range pool.modules {
+		module.PostRemoveTxs(txs)
+	}
+	pool.recordDroppedTxs(dropped)
+}
+
+func (pool *TxPool) postReplaceTx(oldTx, newTx *types.Transaction) {
+	for _, module := range pool.modules {
+		module.PostReplaceTx(oldTx, newTx)
+	}
+	pool.recordDroppedTxs([]*DroppedTx{{Tx: oldTx, Reason: TxDropReasonReplaced, Replacement: newTx.Hash()}})
+}
+
+// recordDroppedTxs keeps the dropped transactions for status lookups and
+// notifies the subscribers of them.
+func (pool *TxPool) recordDroppedTxs(dropped []*DroppedTx) {
+	if len(dropped) == 0 {
+		return
+	}
+	now := time.Now()
+	for _, d := range dropped {
+		d.Time = now
+		pool.droppedTxs.Add(d.Tx.Hash(), d)
+	}
+	// Never block while holding the pool lock; the event is discarded if the consumer is slow.
+	select {
+	case pool.dropCh <- dropped:
+	default:
+		droppedEventDiscardCounter.Inc(int64(len(dropped)))
+	}
+}
+
+// DroppedTx returns the recently dropped transaction of the given hash, or nil if not found.
+func (pool *TxPool) DroppedTx(hash common.Hash) *DroppedTx {
+	if d, ok := pool.droppedTxs.Get(hash); ok {
+		return d.(*DroppedTx)
+	}
+	return nil
+}
+
+// SubscribeDroppedTxsEvent registers a subscription of DroppedTxsEvent and
+// starts sending event to the given channel.
+func (pool *TxPool) SubscribeDroppedTxsEvent(ch chan<- DroppedTxsEvent) event.Subscription {
+	return pool.droppedScope.Track(pool.droppedFeed.Subscribe(ch))
+}
+
+// SubscribeMissingBlobSidecarsEvent registers a subscription of MissingBlobSidecarsEvent
+// and starts sending event to the given channel.
+func (pool *TxPool) SubscribeMissingBlobSidecarsEvent(ch chan<- MissingBlobSidecarsEvent) event.Subscription {
+	return pool.scope.Track(pool.blobFeed.Subscribe(ch))
 }
 
 // getNonce returns the nonce of the account from the cache. If it is not in the cache, it gets the nonce from the stateDB.
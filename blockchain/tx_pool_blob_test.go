@@ -0,0 +1,177 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package blockchain
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/crypto/kzg4844"
+	"github.com/kaiachain/kaia/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func blobTx(nonce uint64, sidecar *types.BlobTxSidecar, blobHashes []common.Hash, key *ecdsa.PrivateKey) *types.Transaction {
+	d, err := types.NewTxInternalDataWithMap(types.TxTypeEthereumBlob, map[types.TxValueKeyType]interface{}{
+		types.TxValueKeyNonce:       nonce,
+		types.TxValueKeyTo:          common.HexToAddress("0xdead"),
+		types.TxValueKeyAmount:      big.NewInt(100),
+		types.TxValueKeyGasLimit:    uint64(21000),
+		types.TxValueKeyGasFeeCap:   big.NewInt(1),
+		types.TxValueKeyGasTipCap:   big.NewInt(1),
+		types.TxValueKeyData:        []byte{},
+		types.TxValueKeyAccessList:  types.AccessList{},
+		types.TxValueKeyBlobFeeCap:  big.NewInt(params.BlobTxMinBlobGasprice),
+		types.TxValueKeyBlobHashes:  blobHashes,
+		types.TxValueKeyBlobSidecar: sidecar,
+		types.TxValueKeyChainID:     params.TestChainConfig.ChainID,
+	})
+	if err != nil {
+		panic(err)
+	}
+	signedTx, err := types.SignTx(types.NewTx(d), types.LatestSignerForChainID(params.TestChainConfig.ChainID), key)
+	if err != nil {
+		panic(err)
+	}
+	return signedTx
+}
+
+func testBlobSidecar(t *testing.T) *types.BlobTxSidecar {
+	blob := kzg4844.Blob{}
+	commitment, err := kzg4844.BlobToCommitment(blob)
+	require.NoError(t, err)
+	proof, err := kzg4844.ComputeBlobProof(blob, commitment)
+	require.NoError(t, err)
+
+	return &types.BlobTxSidecar{
+		Blobs:       []kzg4844.Blob{blob},
+		Commitments: []kzg4844.Commitment{commitment},
+		Proofs:      []kzg4844.Proof{proof},
+	}
+}
+
+// Tests that the blob transactions are rejected before the blobTx hardfork.
+func TestBlobTransactionNotAcceptedNotEnableHardfork(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupTxPoolWithConfig(eip1559Config)
+	defer pool.Stop()
+
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000))
+
+	sidecar := testBlobSidecar(t)
+	tx := blobTx(0, sidecar, sidecar.BlobHashes(), key)
+	assert.Equal(t, ErrTxTypeNotSupported, pool.AddRemote(tx))
+}
+
+// Tests that the pool keeps the sidecars of the blob transactions aside and
+// rejects the blob transactions without a valid sidecar.
+func TestBlobTransactionSidecar(t *testing.T) {
+	t.Parallel()
+
+	blobConfig := eip1559Config.Copy()
+	blobConfig.BlobTxCompatibleBlock = common.Big0
+
+	pool, key := setupTxPoolWithConfig(blobConfig)
+	defer pool.Stop()
+
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000))
+
+	sidecar := testBlobSidecar(t)
+
+	// A blob transaction must carry its sidecar.
+	missing := blobTx(0, nil, sidecar.BlobHashes(), key)
+	assert.Equal(t, types.ErrMissingBlobSidecar, pool.AddRemote(missing))
+
+	// The sidecar must match the blob hashes of the transaction.
+	mismatched := blobTx(0, sidecar, []common.Hash{{0x01}}, key)
+	assert.Error(t, pool.AddRemote(mismatched))
+
+	tx := blobTx(0, sidecar, sidecar.BlobHashes(), key)
+	require.NoError(t, pool.AddRemote(tx))
+
+	// The pooled transaction is stripped, while the sidecar is kept by the pool.
+	assert.Nil(t, pool.Get(tx.Hash()).BlobTxSidecar())
+	assert.Equal(t, sidecar, pool.GetBlobSidecar(tx.Hash()))
+}
+
+// blobRecordingChain records the blob sidecars written by the pool.
+type blobRecordingChain struct {
+	*testBlockChain
+	written map[common.Hash][]*types.BlobTxSidecarWithHash
+}
+
+func (bc *blobRecordingChain) WriteBlobSidecars(hash common.Hash, number uint64, sidecars []*types.BlobTxSidecarWithHash) {
+	bc.written[hash] = sidecars
+}
+
+// Tests that the sidecars of the included blob transactions are stored out of
+// the pool lock, and the ones never received are reported as missing.
+func TestBlobTransactionSidecarPersistence(t *testing.T) {
+	t.Parallel()
+
+	blobConfig := eip1559Config.Copy()
+	blobConfig.BlobTxCompatibleBlock = common.Big0
+
+	pool, key := setupTxPoolWithConfig(blobConfig)
+	defer pool.Stop()
+
+	chain := &blobRecordingChain{pool.chain.(*testBlockChain), make(map[common.Hash][]*types.BlobTxSidecarWithHash)}
+	pool.mu.Lock()
+	pool.chain = chain
+	pool.mu.Unlock()
+
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000))
+
+	events := make(chan MissingBlobSidecarsEvent, 1)
+	sub := pool.SubscribeMissingBlobSidecarsEvent(events)
+	defer sub.Unsubscribe()
+
+	sidecar := testBlobSidecar(t)
+	pooled := blobTx(0, sidecar, sidecar.BlobHashes(), key)
+	require.NoError(t, pool.AddRemote(pooled))
+
+	// The pooled blob transaction is backed up in the network form.
+	pool.mu.RLock()
+	pool.txMu.RLock()
+	remotes := pool.remote()[crypto.PubkeyToAddress(key.PublicKey)]
+	pool.txMu.RUnlock()
+	pool.mu.RUnlock()
+	require.Len(t, remotes, 1)
+	assert.Equal(t, sidecar, remotes[0].BlobTxSidecar())
+
+	other, _ := crypto.GenerateKey()
+	unseen := blobTx(0, nil, sidecar.BlobHashes(), other)
+	block := types.NewBlock(&types.Header{Number: big.NewInt(1)}, []*types.Transaction{pooled.WithoutBlobTxSidecar(), unseen}, nil)
+
+	pool.mu.Lock()
+	pool.collectBlobSidecars([]*types.Block{block})
+	pool.mu.Unlock()
+	assert.Empty(t, chain.written)
+
+	pool.writeBlobSidecars()
+	assert.Equal(t, []*types.BlobTxSidecarWithHash{{TxHash: pooled.Hash(), Sidecar: sidecar}}, chain.written[block.Hash()])
+
+	ev := <-events
+	assert.Equal(t, block.Hash(), ev.Block.Hash())
+	assert.Equal(t, []common.Hash{unseen.Hash()}, ev.TxHashes)
+}
@@ -0,0 +1,204 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package blockchain
+
+import (
+	"fmt"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/rcrowley/go-metrics"
+)
+
+const (
+	// MaxBundleTxs is the maximum number of transactions in a bundle.
+	MaxBundleTxs = 16
+
+	// maxBundles is the maximum number of bundles kept in the tx pool.
+	maxBundles = 1024
+
+	// maxBundlesPerSender is the maximum number of bundles having a transaction of the same sender.
+	maxBundlesPerSender = 16
+
+	// maxBundleFutureBlocks is how far the target block of a bundle can be from the current block.
+	maxBundleFutureBlocks = 64
+)
+
+var (
+	bundleGauge          = metrics.NewRegisteredGauge("txpool/bundles", nil)
+	bundleDiscardCounter = metrics.NewRegisteredCounter("txpool/bundles/discard", nil)
+)
+
+// AddBundle validates the transactions of the bundle and adds the bundle into the pool.
+// The transactions of a bundle are kept apart from the pending and queued transactions;
+// they are neither broadcast to the peers nor included in a block individually.
+// A bundle must target a block, and it is discarded once the block is mined.
+func (pool *TxPool) AddBundle(bundle *types.Bundle) error {
+	if bundle.BlockNumber == 0 {
+		return ErrBundleNoTarget
+	}
+	if len(bundle.Txs) == 0 {
+		return ErrEmptyBundle
+	}
+	if len(bundle.Txs) > MaxBundleTxs {
+		return ErrBundleTooLarge
+	}
+	senderCacher.recover(pool.signer, bundle.Txs)
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if bundle.BlockNumber <= pool.currentBlockNumber {
+		return ErrBundleExpired
+	}
+	if bundle.BlockNumber > pool.currentBlockNumber+maxBundleFutureBlocks {
+		return ErrBundleTooFarFuture
+	}
+	hash := bundle.Hash()
+	for _, b := range pool.bundles {
+		if b.Hash() == hash {
+			return ErrKnownBundle
+		}
+	}
+	if len(pool.bundles) >= maxBundles {
+		return ErrBundlePoolFull
+	}
+	for i, tx := range bundle.Txs {
+		if err := pool.validateTx(tx); err != nil {
+			return fmt.Errorf("invalid bundle tx %d (%x): %w", i, tx.Hash(), err)
+		}
+	}
+	for _, from := range pool.bundleSenders(bundle) {
+		if pool.numBundlesOf(from) >= maxBundlesPerSender {
+			return fmt.Errorf("%w: %x", ErrBundleSenderLimit, from)
+		}
+	}
+	pool.bundles = append(pool.bundles, bundle)
+	bundleGauge.Update(int64(len(pool.bundles)))
+	logger.Debug("Added a bundle", "hash", hash, "txs", len(bundle.Txs), "target", bundle.BlockNumber)
+	return nil
+}
+
+// PendingBundles returns the bundles which can be included in the block of the given
+// number, in the order of arrival.
+func (pool *TxPool) PendingBundles(blockNumber uint64) []*types.Bundle {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	bundles := make([]*types.Bundle, 0, len(pool.bundles))
+	for _, b := range pool.bundles {
+		if b.IsTargeting(blockNumber) {
+			bundles = append(bundles, b)
+		}
+	}
+	return bundles
+}
+
+// RemoveBundles removes the bundles of the given hashes from the pool.
+// It is used to evict the bundles which failed or were reverted in the block building.
+func (pool *TxPool) RemoveBundles(hashes []common.Hash) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	pool.filterBundles(func(b *types.Bundle) bool {
+		for _, hash := range hashes {
+			if b.Hash() == hash {
+				logger.Trace("Removed a failed bundle", "hash", hash)
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// demoteBundles removes the bundles which can no longer be included in a block:
+// bundles whose target block is mined, and bundles that have a transaction with a
+// nonce lower than the current one, meaning that it is already included or replaced.
+// The caller must hold pool.mu.
+func (pool *TxPool) demoteBundles() {
+	pool.filterBundles(func(b *types.Bundle) bool {
+		if pool.isBundleStale(b) {
+			logger.Trace("Removed a stale bundle", "hash", b.Hash())
+			return true
+		}
+		return false
+	})
+}
+
+// filterBundles removes the bundles for which remove returns true.
+// The caller must hold pool.mu.
+func (pool *TxPool) filterBundles(remove func(b *types.Bundle) bool) {
+	bundles := pool.bundles[:0]
+	for _, b := range pool.bundles {
+		if remove(b) {
+			bundleDiscardCounter.Inc(1)
+			continue
+		}
+		bundles = append(bundles, b)
+	}
+	for i := len(bundles); i < len(pool.bundles); i++ {
+		pool.bundles[i] = nil
+	}
+	pool.bundles = bundles
+	bundleGauge.Update(int64(len(pool.bundles)))
+}
+
+func (pool *TxPool) isBundleStale(b *types.Bundle) bool {
+	if b.BlockNumber <= pool.currentBlockNumber {
+		return true
+	}
+	for _, tx := range b.Txs {
+		from, _ := types.Sender(pool.signer, tx) // already validated
+		if pool.getNonce(from) > tx.Nonce() {
+			return true
+		}
+	}
+	return false
+}
+
+// bundleSenders returns the distinct senders of the transactions of the bundle.
+func (pool *TxPool) bundleSenders(b *types.Bundle) []common.Address {
+	var senders []common.Address
+	for _, tx := range b.Txs {
+		from, _ := types.Sender(pool.signer, tx) // already validated
+		if !containsAddress(senders, from) {
+			senders = append(senders, from)
+		}
+	}
+	return senders
+}
+
+// numBundlesOf returns the number of bundles in the pool having a transaction of the sender.
+// The caller must hold pool.mu.
+func (pool *TxPool) numBundlesOf(from common.Address) int {
+	count := 0
+	for _, b := range pool.bundles {
+		if containsAddress(pool.bundleSenders(b), from) {
+			count++
+		}
+	}
+	return count
+}
+
+func containsAddress(addrs []common.Address, addr common.Address) bool {
+	for _, a := range addrs {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxPool_AddBundle(t *testing.T) {
+	pool, key := setupTxPool()
+	defer pool.Stop()
+
+	other, _ := crypto.GenerateKey()
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000))
+	testAddBalance(pool, crypto.PubkeyToAddress(other.PublicKey), big.NewInt(1000000))
+
+	assert.Equal(t, ErrBundleNoTarget, pool.AddBundle(types.NewBundle(types.Transactions{transaction(0, 100000, key)}, 0)))
+	assert.Equal(t, ErrEmptyBundle, pool.AddBundle(types.NewBundle(nil, 1)))
+
+	tooLarge := make(types.Transactions, MaxBundleTxs+1)
+	for i := range tooLarge {
+		tooLarge[i] = transaction(uint64(i), 100000, key)
+	}
+	assert.Equal(t, ErrBundleTooLarge, pool.AddBundle(types.NewBundle(tooLarge, 1)))
+
+	// A bundle of transactions from different senders.
+	bundle := types.NewBundle(types.Transactions{transaction(0, 100000, key), transaction(0, 100000, other)}, 1)
+	require.NoError(t, pool.AddBundle(bundle))
+	assert.Equal(t, ErrKnownBundle, pool.AddBundle(types.NewBundle(bundle.Txs, 1)))
+
+	// The same transactions can be resubmitted for the next block.
+	retry := types.NewBundle(bundle.Txs, 2)
+	assert.NotEqual(t, bundle.Hash(), retry.Hash())
+	require.NoError(t, pool.AddBundle(retry))
+	assert.Equal(t, []*types.Bundle{bundle}, pool.PendingBundles(1))
+	assert.Equal(t, []*types.Bundle{retry}, pool.PendingBundles(2))
+
+	// The transactions of the bundle are kept apart from the pending transactions.
+	pending, queued := pool.Stats()
+	assert.Equal(t, 0, pending+queued)
+
+	// A bundle is rejected if any transaction is invalid.
+	testSetNonce(pool, crypto.PubkeyToAddress(other.PublicKey), 1)
+	err := pool.AddBundle(types.NewBundle(types.Transactions{transaction(1, 100000, key), transaction(0, 100000, other)}, 1))
+	assert.ErrorIs(t, err, ErrNonceTooLow)
+
+	// A bundle targeting a mined block is rejected.
+	pool.mu.Lock()
+	pool.currentBlockNumber = 5
+	pool.mu.Unlock()
+	assert.Equal(t, ErrBundleExpired, pool.AddBundle(types.NewBundle(types.Transactions{transaction(1, 100000, key)}, 5)))
+	assert.NoError(t, pool.AddBundle(types.NewBundle(types.Transactions{transaction(1, 100000, key)}, 6)))
+	assert.Equal(t, ErrBundleTooFarFuture, pool.AddBundle(types.NewBundle(types.Transactions{transaction(1, 100000, key)}, 6+maxBundleFutureBlocks)))
+}
+
+func TestTxPool_BundleSenderLimit(t *testing.T) {
+	pool, key := setupTxPool()
+	defer pool.Stop()
+
+	other, _ := crypto.GenerateKey()
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000))
+	testAddBalance(pool, crypto.PubkeyToAddress(other.PublicKey), big.NewInt(1000000))
+
+	for i := 0; i < maxBundlesPerSender; i++ {
+		require.NoError(t, pool.AddBundle(types.NewBundle(types.Transactions{transaction(uint64(i), 100000, key)}, uint64(i+1))))
+	}
+	// A bundle having a transaction of the sender is rejected, while the other senders are not affected.
+	err := pool.AddBundle(types.NewBundle(types.Transactions{transaction(0, 100000, other), transaction(1, 100000, key)}, 1))
+	assert.ErrorIs(t, err, ErrBundleSenderLimit)
+	assert.NoError(t, pool.AddBundle(types.NewBundle(types.Transactions{transaction(0, 100000, other)}, 1)))
+
+	// The sender can add a bundle again once a bundle is evicted.
+	pool.RemoveBundles([]common.Hash{pool.PendingBundles(1)[0].Hash()})
+	assert.NoError(t, pool.AddBundle(types.NewBundle(types.Transactions{transaction(maxBundlesPerSender, 100000, key)}, 1)))
+}
+
+func TestTxPool_PendingBundles(t *testing.T) {
+	pool, key := setupTxPool()
+	defer pool.Stop()
+
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000))
+
+	var (
+		block1  = types.NewBundle(types.Transactions{transaction(0, 100000, key), transaction(1, 100000, key)}, 1)
+		block2  = types.NewBundle(types.Transactions{transaction(0, 100000, key)}, 2)
+		block2b = types.NewBundle(types.Transactions{transaction(1, 100000, key)}, 2)
+		block2c = types.NewBundle(types.Transactions{transaction(1, 100000, key), transaction(2, 100000, key)}, 2)
+	)
+	require.NoError(t, pool.AddBundle(block1))
+	require.NoError(t, pool.AddBundle(block2))
+	require.NoError(t, pool.AddBundle(block2b))
+	require.NoError(t, pool.AddBundle(block2c))
+
+	assert.Equal(t, []*types.Bundle{block1}, pool.PendingBundles(1))
+	assert.Equal(t, []*types.Bundle{block2, block2b, block2c}, pool.PendingBundles(2))
+
+	// The bundles targeting a mined block are removed.
+	pool.mu.Lock()
+	pool.currentBlockNumber = 1
+	pool.demoteBundles()
+	pool.mu.Unlock()
+	assert.Empty(t, pool.PendingBundles(1))
+	assert.Equal(t, []*types.Bundle{block2, block2b, block2c}, pool.PendingBundles(2))
+
+	// The bundles having an included transaction are removed.
+	pool.chain.(*testBlockChain).statedb.SetNonce(crypto.PubkeyToAddress(key.PublicKey), 1)
+	pool.lockedReset(nil, nil)
+	assert.Equal(t, []*types.Bundle{block2b, block2c}, pool.PendingBundles(2))
+
+	// The failed bundles are evicted.
+	pool.RemoveBundles([]common.Hash{block2b.Hash()})
+	assert.Equal(t, []*types.Bundle{block2c}, pool.PendingBundles(2))
+}
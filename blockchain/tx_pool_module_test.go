@@ -0,0 +1,123 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package blockchain
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errRejectedByModule = errors.New("rejected by module")
+
+// testTxPoolModule rejects the txs of the given gas limit, and records the lifecycle callbacks.
+type testTxPoolModule struct {
+	rejectGas uint64
+
+	promoted, demoted, removed []common.Hash
+	replaced                   [][2]common.Hash
+}
+
+func (m *testTxPoolModule) PreAddLocal(tx *types.Transaction) error { return m.PreAddRemote(tx) }
+
+func (m *testTxPoolModule) PreAddRemote(tx *types.Transaction) error {
+	if tx.Gas() == m.rejectGas {
+		return errRejectedByModule
+	}
+	return nil
+}
+
+func (m *testTxPoolModule) PostPromoteTxs(txs []*types.Transaction) {
+	m.promoted = append(m.promoted, hashes(txs)...)
+}
+
+func (m *testTxPoolModule) PostDemoteTxs(txs []*types.Transaction) {
+	m.demoted = append(m.demoted, hashes(txs)...)
+}
+
+func (m *testTxPoolModule) PostRemoveTxs(txs []*types.Transaction) {
+	m.removed = append(m.removed, hashes(txs)...)
+}
+
+func (m *testTxPoolModule) PostReplaceTx(oldTx, newTx *types.Transaction) {
+	m.replaced = append(m.replaced, [2]common.Hash{oldTx.Hash(), newTx.Hash()})
+}
+
+func (m *testTxPoolModule) PreCommitTxs(_ *types.Header, txs types.TransactionSet) types.TransactionSet {
+	return txs
+}
+
+func hashes(txs []*types.Transaction) []common.Hash {
+	ret := make([]common.Hash, len(txs))
+	for i, tx := range txs {
+		ret[i] = tx.Hash()
+	}
+	return ret
+}
+
+func TestTxPool_TxPoolModule(t *testing.T) {
+	pool, key := setupTxPool()
+	defer pool.Stop()
+
+	module := &testTxPoolModule{rejectGas: 54321}
+	pool.RegisterTxPoolModule(module)
+
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, addr, big.NewInt(100000000000000))
+
+	// The module rejects the tx before it enters the pool.
+	assert.Equal(t, errRejectedByModule, pool.AddRemote(transaction(0, 54321, key)))
+	assert.Equal(t, 0, pool.all.Count())
+
+	var (
+		tx0 = transaction(0, 100000, key)
+		tx1 = transaction(1, 100000, key)
+		tx2 = transaction(2, 100000, key)
+		tx3 = transaction(3, 100000, key)
+	)
+	require.NoError(t, pool.AddRemote(tx0))
+	require.NoError(t, pool.AddRemote(tx2))
+	assert.Equal(t, []common.Hash{tx0.Hash()}, module.promoted)
+
+	// Filling the nonce gap promotes the queued tx.
+	require.NoError(t, pool.AddRemote(tx1))
+	require.NoError(t, pool.AddRemote(tx3))
+	assert.Equal(t, []common.Hash{tx0.Hash(), tx1.Hash(), tx2.Hash(), tx3.Hash()}, module.promoted)
+
+	// Replacing a pending tx.
+	cancel := cancelTx(0, 100000, big.NewInt(1), addr, key)
+	require.NoError(t, pool.AddRemote(cancel))
+	assert.Equal(t, [][2]common.Hash{{tx0.Hash(), cancel.Hash()}}, module.replaced)
+
+	// Removing a pending tx demotes the subsequent txs.
+	pool.mu.Lock()
+	pool.removeTx(tx2.Hash(), true, TxDropReasonNone)
+	pool.mu.Unlock()
+	assert.Equal(t, []common.Hash{tx2.Hash()}, module.removed)
+	assert.Equal(t, []common.Hash{tx3.Hash()}, module.demoted)
+
+	// The txs are removed once their nonces are used.
+	testSetNonce(pool, addr, 2)
+	pool.lockedReset(nil, nil)
+	assert.Equal(t, []common.Hash{tx2.Hash(), cancel.Hash(), tx1.Hash()}, module.removed)
+}
@@ -0,0 +1,200 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package blockchain
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kaiachain/kaia/blockchain/state"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/event"
+	"github.com/kaiachain/kaia/params"
+	"github.com/kaiachain/kaia/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxPool_AddPrivate(t *testing.T) {
+	pool, key := setupTxPool()
+	defer pool.Stop()
+
+	account := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, account, big.NewInt(1000000000))
+
+	var (
+		public  = transaction(0, 100000, key)
+		private = transaction(1, 100000, key)
+	)
+	assert.Equal(t, errZeroPrivateTxExpiry, pool.AddPrivate(private, 0))
+	assert.False(t, pool.IsPrivateTx(private.Hash()))
+
+	require.NoError(t, pool.AddLocal(public))
+	require.NoError(t, pool.AddPrivate(private, 3))
+
+	// A transaction already known as a public one can't be made private.
+	assert.Equal(t, errPrivateTxKnownAsPublic, pool.AddPrivate(public, 3))
+	assert.False(t, pool.IsPrivateTx(public.Hash()))
+	assert.True(t, pool.IsPrivateTx(private.Hash()))
+
+	// The private transaction is processable as well as the public one.
+	pending, _ := pool.Pending()
+	assert.Equal(t, types.Transactions{public, private}, pending[account])
+
+	// The private transaction is not backed up to disk.
+	pool.mu.RLock()
+	pool.txMu.RLock()
+	assert.Equal(t, types.Transactions{public}, pool.local()[account])
+	pool.txMu.RUnlock()
+	pool.mu.RUnlock()
+
+	// A known transaction is rejected without losing its private mark.
+	assert.Error(t, pool.AddPrivate(private, 3))
+	assert.True(t, pool.IsPrivateTx(private.Hash()))
+
+	// The private transaction is kept until its expiry block.
+	pool.mu.Lock()
+	pool.currentBlockNumber = 2
+	pool.demotePrivateTxs()
+	pool.mu.Unlock()
+	assert.True(t, pool.IsPrivateTx(private.Hash()))
+
+	pool.mu.Lock()
+	pool.currentBlockNumber = 3
+	pool.demotePrivateTxs()
+	pool.mu.Unlock()
+	assert.False(t, pool.IsPrivateTx(private.Hash()))
+	assert.Nil(t, pool.Get(private.Hash()))
+	assert.Equal(t, TxDropReasonPrivateExpiry, pool.DroppedTx(private.Hash()).Reason)
+	assert.NotNil(t, pool.Get(public.Hash()))
+}
+
+// Tests that the private transactions are never observed via the new transaction
+// event nor the views of the pool, while they are still included by the miner.
+func TestTxPool_PrivateTxHidden(t *testing.T) {
+	pool, key := setupTxPool()
+	defer pool.Stop()
+
+	account := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, account, big.NewInt(1000000000))
+
+	var (
+		txsCh        = make(chan NewTxsEvent, 10)
+		privateTxsCh = make(chan NewPrivateTxsEvent, 10)
+		txsSub       = pool.SubscribeNewTxsEvent(txsCh)
+		privateSub   = pool.SubscribeNewPrivateTxsEvent(privateTxsCh)
+	)
+	defer txsSub.Unsubscribe()
+	defer privateSub.Unsubscribe()
+
+	var (
+		public  = transaction(0, 100000, key)
+		private = transaction(1, 100000, key)
+		queued  = transaction(3, 100000, key) // nonce gap
+	)
+	require.NoError(t, pool.AddLocal(public))
+	require.NoError(t, pool.AddPrivate(private, 3))
+	require.NoError(t, pool.AddPrivate(queued, 3))
+
+	// Only the public transaction is posted with NewTxsEvent.
+	assert.NoError(t, validateEvents(txsCh, 1))
+	select {
+	case ev := <-privateTxsCh:
+		assert.Equal(t, []*types.Transaction{private}, ev.Txs)
+	case <-time.After(time.Second):
+		t.Fatal("private transaction event not fired")
+	}
+
+	// The miner includes the private transaction.
+	pending, err := pool.Pending()
+	require.NoError(t, err)
+	assert.Equal(t, types.Transactions{public, private}, pending[account])
+
+	// The views of the pool don't expose the private transactions.
+	pending, err = pool.PublicPending()
+	require.NoError(t, err)
+	assert.Equal(t, map[common.Address]types.Transactions{account: {public}}, pending)
+
+	pending, queuedTxs := pool.Content()
+	assert.Equal(t, map[common.Address]types.Transactions{account: {public}}, pending)
+	assert.Empty(t, queuedTxs)
+
+	pendingFrom, queuedFrom := pool.ContentFrom(account)
+	assert.Equal(t, types.Transactions{public}, pendingFrom)
+	assert.Empty(t, queuedFrom)
+}
+
+// Tests that the private transactions received from peers are kept private
+// until the given expiry block number.
+func TestTxPool_AddPrivateRemote(t *testing.T) {
+	pool, key := setupTxPool()
+	defer pool.Stop()
+
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000))
+
+	pool.mu.Lock()
+	pool.currentBlockNumber = 5
+	pool.mu.Unlock()
+
+	tx := transaction(0, 100000, key)
+	assert.Equal(t, errPrivateTxExpired, pool.AddPrivateRemote(tx, 5))
+	assert.Nil(t, pool.Get(tx.Hash()))
+
+	require.NoError(t, pool.AddPrivateRemote(tx, 8))
+	expiry, ok := pool.PrivateTxExpiry(tx.Hash())
+	assert.True(t, ok)
+	assert.Equal(t, uint64(8), expiry)
+
+	// The remote private transaction is not backed up to disk.
+	pool.mu.RLock()
+	pool.txMu.RLock()
+	assert.Empty(t, pool.remote()[crypto.PubkeyToAddress(key.PublicKey)])
+	pool.txMu.RUnlock()
+	pool.mu.RUnlock()
+}
+
+// Tests that the private transactions are not journaled, so that they are
+// never reloaded as public ones after restart.
+func TestTxPool_PrivateTxJournaling(t *testing.T) {
+	journal := filepath.Join(t.TempDir(), "transactions.rlp")
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil, nil)
+	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
+
+	config := testTxPoolConfig
+	config.Journal = journal
+
+	pool := NewTxPool(config, params.TestChainConfig, blockchain, &dummyGovModule{chainConfig: params.TestChainConfig})
+
+	key, _ := crypto.GenerateKey()
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000))
+
+	require.NoError(t, pool.AddLocal(transaction(0, 100000, key)))
+	require.NoError(t, pool.AddPrivate(transaction(1, 100000, key), 10))
+	pool.Stop()
+
+	pool = NewTxPool(config, params.TestChainConfig, blockchain, &dummyGovModule{chainConfig: params.TestChainConfig})
+	defer pool.Stop()
+
+	pending, queued := pool.Stats()
+	assert.Equal(t, 1, pending)
+	assert.Equal(t, 0, queued)
+}
@@ -30,6 +30,7 @@ import (
 	"math/big"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
@@ -45,6 +46,7 @@ import (
 	"github.com/kaiachain/kaia/rlp"
 	"github.com/kaiachain/kaia/storage/database"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -113,6 +115,9 @@ func (bc *testBlockChain) SubscribeChainHeadEvent(ch chan<- ChainHeadEvent) even
 	return bc.chainHeadFeed.Subscribe(ch)
 }
 
+func (bc *testBlockChain) WriteBlobSidecars(hash common.Hash, number uint64, sidecars []*types.BlobTxSidecarWithHash) {
+}
+
 func transaction(nonce uint64, gaslimit uint64, key *ecdsa.PrivateKey) *types.Transaction {
 	return pricedTransaction(nonce, gaslimit, big.NewInt(1), key)
 }
@@ -536,7 +541,7 @@ func TestTransactionQueue(t *testing.T) {
 	pool.lockedReset(nil, nil)
 	pool.enqueueTx(tx.Hash(), tx)
 
-	pool.promoteExecutables([]common.Address{from})
+	pool.promoteExecutables([]common.Address{from}, nil)
 	if len(pool.pending) != 1 {
 		t.Error("expected valid txs to be 1 is", len(pool.pending))
 	}
@@ -545,7 +550,7 @@ func TestTransactionQueue(t *testing.T) {
 	from, _ = deriveSender(tx)
 	testSetNonce(pool, from, 2)
 	pool.enqueueTx(tx.Hash(), tx)
-	pool.promoteExecutables([]common.Address{from})
+	pool.promoteExecutables([]common.Address{from}, nil)
 	if _, ok := pool.pending[from].txs.items[tx.Nonce()]; ok {
 		t.Error("expected transaction to be in tx pool")
 	}
@@ -568,7 +573,7 @@ func TestTransactionQueue(t *testing.T) {
 	pool.enqueueTx(tx2.Hash(), tx2)
 	pool.enqueueTx(tx3.Hash(), tx3)
 
-	pool.promoteExecutables([]common.Address{from})
+	pool.promoteExecutables([]common.Address{from}, nil)
 
 	if len(pool.pending) != 1 {
 		t.Error("expected tx pool to be 1, got", len(pool.pending))
@@ -613,7 +618,7 @@ func TestTransactionChainFork(t *testing.T) {
 	if _, err := pool.add(tx, false); err != nil {
 		t.Error("didn't expect error", err)
 	}
-	pool.removeTx(tx.Hash(), true)
+	pool.removeTx(tx.Hash(), true, TxDropReasonNone)
 
 	// reset the pool's internal state
 	resetState()
@@ -650,7 +655,7 @@ func TestTransactionDoubleNonce(t *testing.T) {
 	if replace, err := pool.add(tx2, false); err == nil || replace {
 		t.Errorf("second transaction insert failed (%v) or not reported replacement (%v)", err, replace)
 	}
-	pool.promoteExecutables([]common.Address{addr})
+	pool.promoteExecutables([]common.Address{addr}, nil)
 	if pool.pending[addr].Len() != 1 {
 		t.Error("expected 1 pending transactions, got", pool.pending[addr].Len())
 	}
@@ -659,7 +664,7 @@ func TestTransactionDoubleNonce(t *testing.T) {
 	}
 	// NOTE-Kaia Add the third transaction and ensure it's not saved
 	pool.add(tx3, false)
-	pool.promoteExecutables([]common.Address{addr})
+	pool.promoteExecutables([]common.Address{addr}, nil)
 	if pool.pending[addr].Len() != 1 {
 		t.Error("expected 1 pending transactions, got", pool.pending[addr].Len())
 	}
@@ -2370,7 +2375,7 @@ func TestTransactionsPromoteFull(t *testing.T) {
 		pool.enqueueTx(tx.Hash(), tx)
 	}
 
-	pool.promoteExecutables(nil)
+	pool.promoteExecutables(nil, nil)
 
 	assert.Equal(t, pool.pending[from].Len(), 4)
 	for i, tx := range txs {
@@ -2409,7 +2414,7 @@ func TestTransactionsPromotePartial(t *testing.T) {
 	baseFee = big.NewInt(20)
 	pool.gasPrice = baseFee
 
-	pool.promoteExecutables(nil)
+	pool.promoteExecutables(nil, nil)
 
 	assert.Equal(t, pool.pending[from].Len(), 3)
 	assert.Equal(t, pool.queue[from].Len(), 1)
@@ -2461,7 +2466,7 @@ func TestTransactionsPromoteMultipleAccount(t *testing.T) {
 
 	pool.gasPrice = big.NewInt(20)
 
-	pool.promoteExecutables(nil)
+	pool.promoteExecutables(nil, nil)
 
 	assert.Equal(t, pool.pending[froms[0]].Len(), 4)
 
@@ -2517,7 +2522,7 @@ func TestTransactionsDemotionMultipleAccount(t *testing.T) {
 		pool.enqueueTx(txs[i].Hash(), txs[i])
 	}
 
-	pool.promoteExecutables(nil)
+	pool.promoteExecutables(nil, nil)
 	assert.Equal(t, 4, pool.pending[froms[0]].Len())
 	assert.Equal(t, 1, pool.pending[froms[1]].Len())
 	assert.Equal(t, 4, pool.pending[froms[2]].Len())
@@ -2534,7 +2539,7 @@ func TestTransactionsDemotionMultipleAccount(t *testing.T) {
 	// tx[7] : queue[from[2]]
 	// tx[7] : queue[from[2]]
 	pool.SetBaseFee(big.NewInt(35))
-	pool.demoteUnexecutables()
+	pool.demoteUnexecutables(nil)
 
 	assert.Equal(t, 2, pool.queue[froms[0]].Len())
 	assert.Equal(t, 2, pool.pending[froms[0]].Len())
@@ -2761,7 +2766,7 @@ func benchmarkPendingDemotion(b *testing.B, size int) {
 	// Benchmark the speed of pool validation
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		pool.demoteUnexecutables()
+		pool.demoteUnexecutables(nil)
 	}
 }
 
@@ -2786,7 +2791,7 @@ func benchmarkFuturePromotion(b *testing.B, size int) {
 	// Benchmark the speed of pool validation
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		pool.promoteExecutables(nil)
+		pool.promoteExecutables(nil, nil)
 	}
 }
 
@@ -2836,3 +2841,238 @@ func benchmarkPoolBatchInsert(b *testing.B, size int) {
 		pool.AddRemotes(batch)
 	}
 }
+
+// Tests that the dropped transactions are reported with the reasons, and
+// the transactions of an account can be retrieved.
+func TestTransactionDroppedReasons(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupTxPool()
+	defer pool.Stop()
+
+	account := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, account, big.NewInt(1000000000))
+
+	events := make(chan DroppedTxsEvent, 16)
+	sub := pool.SubscribeDroppedTxsEvent(events)
+	defer sub.Unsubscribe()
+
+	var (
+		tx0 = transaction(0, 100000, key)
+		tx1 = transaction(1, 100000, key)
+		tx5 = transaction(5, 100000, key)
+	)
+	if err := pool.AddRemotes([]*types.Transaction{tx0, tx1, tx5}); err[0] != nil || err[1] != nil || err[2] != nil {
+		t.Fatalf("failed to add transactions: %v", err)
+	}
+	pending, queued := pool.ContentFrom(account)
+	assert.Equal(t, types.Transactions{tx0, tx1}, pending)
+	assert.Equal(t, types.Transactions{tx5}, queued)
+
+	// Replace the pending transaction with a cancel transaction.
+	cancel := cancelTx(1, 100000, big.NewInt(1), account, key)
+	if err := pool.AddRemote(cancel); err != nil {
+		t.Fatalf("failed to add cancel transaction: %v", err)
+	}
+	ev := <-events
+	require.Len(t, ev.Txs, 1)
+	assert.Equal(t, tx1.Hash(), ev.Txs[0].Tx.Hash())
+	assert.Equal(t, TxDropReasonReplaced, ev.Txs[0].Reason)
+	assert.Equal(t, cancel.Hash(), ev.Txs[0].Replacement)
+
+	// The pending and queued transactions are dropped as their nonces are used
+	// without being included in the new blocks.
+	chain := newBlockLookupChain(pool)
+	genesis := chain.addBlock(nil)
+	testSetNonce(pool, account, 6)
+	pool.lockedReset(genesis.Header(), chain.addBlock(genesis).Header())
+	reasons := make(map[common.Hash]TxDropReason)
+	for len(reasons) < 3 {
+		ev = <-events
+		for _, d := range ev.Txs {
+			reasons[d.Tx.Hash()] = d.Reason
+		}
+	}
+	assert.Equal(t, map[common.Hash]TxDropReason{
+		tx0.Hash():    TxDropReasonNonceTooLow,
+		cancel.Hash(): TxDropReasonNonceTooLow,
+		tx5.Hash():    TxDropReasonNonceTooLow,
+	}, reasons)
+
+	assert.Equal(t, TxDropReasonNonceTooLow, pool.DroppedTx(tx0.Hash()).Reason)
+	assert.Equal(t, TxDropReasonReplaced, pool.DroppedTx(tx1.Hash()).Reason)
+	assert.Equal(t, TxDropReasonNonceTooLow, pool.DroppedTx(tx5.Hash()).Reason)
+
+	pending, queued = pool.ContentFrom(account)
+	assert.Empty(t, pending)
+	assert.Empty(t, queued)
+}
+
+// blockLookupChain is a testBlockChain which looks up the added blocks, and
+// counts the lookups.
+type blockLookupChain struct {
+	*testBlockChain
+	blocks  map[common.Hash]*types.Block
+	lookups int
+}
+
+// newBlockLookupChain replaces the chain of the pool with a blockLookupChain.
+func newBlockLookupChain(pool *TxPool) *blockLookupChain {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	chain := &blockLookupChain{pool.chain.(*testBlockChain), make(map[common.Hash]*types.Block), 0}
+	pool.chain = chain
+	return chain
+}
+
+// addBlock adds a child block of the parent with the transactions, or a genesis block if the parent is nil.
+func (bc *blockLookupChain) addBlock(parent *types.Block, txs ...*types.Transaction) *types.Block {
+	header := &types.Header{Number: big.NewInt(0)}
+	if parent != nil {
+		header = &types.Header{Number: new(big.Int).Add(parent.Number(), common.Big1), ParentHash: parent.Hash()}
+	}
+	block := types.NewBlock(header, txs, nil)
+	bc.blocks[block.Hash()] = block
+	return block
+}
+
+func (bc *blockLookupChain) GetBlock(hash common.Hash, number uint64) *types.Block {
+	bc.lookups++
+	return bc.blocks[hash]
+}
+
+// Tests that the processed transactions are not regarded as dropped unless they
+// are known not to be included in the new blocks, and the new blocks are not
+// looked up if no one is notified of the dropped transactions.
+func TestTransactionDroppedInclusionUnknown(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupTxPool()
+	defer pool.Stop()
+
+	account := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, account, big.NewInt(1000000000))
+
+	chain := newBlockLookupChain(pool)
+	genesis := chain.addBlock(nil)
+
+	// The new blocks are not looked up without a subscriber.
+	tx0 := transaction(0, 100000, key)
+	require.NoError(t, pool.AddRemote(tx0))
+	testSetNonce(pool, account, 1)
+	pool.lockedReset(genesis.Header(), chain.addBlock(genesis).Header())
+	assert.Zero(t, chain.lookups)
+	assert.Nil(t, pool.DroppedTx(tx0.Hash()))
+
+	events := make(chan DroppedTxsEvent, 16)
+	sub := pool.SubscribeDroppedTxsEvent(events)
+	defer sub.Unsubscribe()
+
+	// The inclusion is unknown if the new blocks are more than the ones looked up.
+	tx1 := transaction(1, 100000, key)
+	require.NoError(t, pool.AddRemote(tx1))
+	head := genesis
+	for i := 0; i < 65; i++ {
+		head = chain.addBlock(head)
+	}
+	testSetNonce(pool, account, 2)
+	pool.lockedReset(genesis.Header(), head.Header())
+	assert.NotZero(t, chain.lookups)
+	assert.Nil(t, pool.DroppedTx(tx1.Hash()))
+
+	// The next event is the one recorded after the reset.
+	marker := transaction(9, 100000, key)
+	pool.mu.Lock()
+	pool.recordDroppedTxs([]*DroppedTx{{Tx: marker, Reason: TxDropReasonExpired}})
+	pool.mu.Unlock()
+	ev := <-events
+	require.Len(t, ev.Txs, 1)
+	assert.Equal(t, marker.Hash(), ev.Txs[0].Tx.Hash())
+}
+
+// Tests that the pending transactions included in the new blocks are not
+// regarded as dropped, and a slow consumer of the dropped events doesn't
+// block the pool.
+func TestTransactionDroppedIncluded(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupTxPool()
+	defer pool.Stop()
+
+	account := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, account, big.NewInt(1000000000))
+
+	// Subscribe without receiving, so that the events are left undelivered.
+	events := make(chan DroppedTxsEvent)
+	sub := pool.SubscribeDroppedTxsEvent(events)
+	defer sub.Unsubscribe()
+
+	var (
+		tx0 = transaction(0, 100000, key)
+		tx1 = transaction(1, 100000, key)
+	)
+	if err := pool.AddRemotes([]*types.Transaction{tx0, tx1}); err[0] != nil || err[1] != nil {
+		t.Fatalf("failed to add transactions: %v", err)
+	}
+
+	testSetNonce(pool, account, 2)
+	pool.mu.Lock()
+	pool.demoteUnexecutables(map[common.Hash]struct{}{tx0.Hash(): {}})
+	for i := 0; i < 2*txFeedChSize; i++ {
+		pool.recordDroppedTxs([]*DroppedTx{{Tx: tx1, Reason: TxDropReasonNonceTooLow}})
+	}
+	pool.mu.Unlock()
+
+	assert.Nil(t, pool.DroppedTx(tx0.Hash()))
+	assert.Equal(t, TxDropReasonNonceTooLow, pool.DroppedTx(tx1.Hash()).Reason)
+}
+
+// Tests that the remote transactions are stored in the snapshot on shutdown,
+// and reloaded and revalidated on the next start.
+func TestTransactionSnapshot(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "remotes.rlp")
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil, nil)
+	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
+
+	config := testTxPoolConfig
+	config.Snapshot = path
+	config.SnapshotMaxTxs = 3
+
+	pool := NewTxPool(config, params.TestChainConfig, blockchain, &dummyGovModule{chainConfig: params.TestChainConfig})
+
+	local, _ := crypto.GenerateKey()
+	remote, _ := crypto.GenerateKey()
+	testAddBalance(pool, crypto.PubkeyToAddress(local.PublicKey), big.NewInt(1000000000))
+	testAddBalance(pool, crypto.PubkeyToAddress(remote.PublicKey), big.NewInt(1000000000))
+
+	require.NoError(t, pool.AddLocal(transaction(0, 100000, local)))
+	for nonce := uint64(0); nonce < 4; nonce++ {
+		require.NoError(t, pool.AddRemote(transaction(nonce, 100000, remote)))
+	}
+	require.NoError(t, pool.AddRemote(transaction(10, 100000, remote)))
+	pool.Stop()
+
+	// Only the remote transactions are stored within the limit.
+	statedb.SetNonce(crypto.PubkeyToAddress(remote.PublicKey), 1)
+	pool = NewTxPool(config, params.TestChainConfig, blockchain, &dummyGovModule{chainConfig: params.TestChainConfig})
+	pending, queued := pool.Stats()
+	assert.Equal(t, 2, pending) // nonce 0 is revalidated and dropped
+	assert.Equal(t, 0, queued)
+	pool.Stop()
+
+	// A snapshot of an unknown version is ignored.
+	out, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, rlp.Encode(out, &txSnapshotHeader{Version: txSnapshotVersion + 1, Count: 1}))
+	require.NoError(t, rlp.Encode(out, transaction(1, 100000, remote)))
+	out.Close()
+
+	pool = NewTxPool(config, params.TestChainConfig, blockchain, &dummyGovModule{chainConfig: params.TestChainConfig})
+	pending, queued = pool.Stats()
+	assert.Equal(t, 0, pending+queued)
+	pool.Stop()
+}
@@ -0,0 +1,157 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package blockchain
+
+import (
+	"bufio"
+	"io"
+	"os"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/rlp"
+)
+
+// txSnapshotVersion is the version of the snapshot encoding. A snapshot of
+// another version is ignored on load.
+const txSnapshotVersion = 1
+
+// txSnapshotHeader is written at the beginning of the snapshot, followed by
+// the RLP stream of the transactions in the same format as the txJournal.
+type txSnapshotHeader struct {
+	Version uint64
+	Count   uint64
+}
+
+// txSnapshot is a periodically regenerated dump of the remote transactions
+// to allow them to survive node restarts. Unlike the txJournal, the snapshot
+// is not appended on every insertion but written as a whole.
+type txSnapshot struct {
+	path   string // Filesystem path to store the transactions at
+	maxTxs uint64 // Maximum number of transactions to store
+}
+
+// newTxSnapshot creates a new transaction snapshot.
+func newTxSnapshot(path string, maxTxs uint64) *txSnapshot {
+	return &txSnapshot{
+		path:   path,
+		maxTxs: maxTxs,
+	}
+}
+
+// load parses a transaction snapshot from disk, loading its contents into
+// the specified pool. The transactions are revalidated by the pool.
+func (snapshot *txSnapshot) load(add func([]*types.Transaction) []error) error {
+	// Skip the parsing if the snapshot file doesn't exist at all
+	if _, err := os.Stat(snapshot.path); os.IsNotExist(err) {
+		return nil
+	}
+	input, err := os.Open(snapshot.path)
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	stream := rlp.NewStream(bufio.NewReader(input), 0)
+	var header txSnapshotHeader
+	if err := stream.Decode(&header); err != nil {
+		return err
+	}
+	if header.Version != txSnapshotVersion {
+		logger.Warn("Ignored transaction snapshot of unknown version", "version", header.Version, "expected", txSnapshotVersion)
+		return nil
+	}
+
+	total, dropped := 0, 0
+	loadBatch := func(txs types.Transactions) {
+		for _, err := range add(txs) {
+			if err != nil {
+				logger.Trace("Failed to add snapshot transaction", "err", err)
+				dropped++
+			}
+		}
+	}
+	var (
+		failure error
+		batch   types.Transactions
+	)
+	for uint64(total) < header.Count {
+		tx := new(types.Transaction)
+		if err = stream.Decode(tx); err != nil {
+			failure = err
+			break
+		}
+		total++
+
+		if batch = append(batch, tx); batch.Len() > 1024 {
+			loadBatch(batch)
+			batch = batch[:0]
+		}
+	}
+	if batch.Len() > 0 {
+		loadBatch(batch)
+	}
+	logger.Info("Loaded remote transaction snapshot", "transactions", total, "dropped", dropped)
+
+	return failure
+}
+
+// write regenerates the transaction snapshot based on the given transactions.
+// If there are more transactions than the limit, the ones with higher prices
+// are kept.
+func (snapshot *txSnapshot) write(all map[common.Address]types.Transactions, signer types.Signer) error {
+	txs := make(types.Transactions, 0)
+	txSet := types.NewTransactionsByPriceAndNonce(signer, all, nil)
+	for tx := txSet.Peek(); tx != nil && uint64(len(txs)) < snapshot.maxTxs; tx = txSet.Peek() {
+		txs = append(txs, tx)
+		txSet.Shift()
+	}
+
+	replacement, err := os.OpenFile(snapshot.path+".new", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if err := snapshot.encode(replacement, txs); err != nil {
+		replacement.Close()
+		return err
+	}
+	if err := replacement.Sync(); err != nil {
+		replacement.Close()
+		return err
+	}
+	replacement.Close()
+
+	// Replace the previous snapshot with the newly generated one
+	if err := os.Rename(snapshot.path+".new", snapshot.path); err != nil {
+		return err
+	}
+	logger.Info("Regenerated remote transaction snapshot", "transactions", len(txs), "accounts", len(all))
+	return nil
+}
+
+func (snapshot *txSnapshot) encode(w io.Writer, txs types.Transactions) error {
+	bw := bufio.NewWriter(w)
+	if err := rlp.Encode(bw, &txSnapshotHeader{Version: txSnapshotVersion, Count: uint64(len(txs))}); err != nil {
+		return err
+	}
+	for _, tx := range txs {
+		if err := rlp.Encode(bw, tx); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
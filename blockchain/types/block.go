@@ -72,6 +72,10 @@ type Header struct {
 	RandomReveal []byte `json:"randomReveal,omitempty" rlp:"optional"` // 96 byte BLS signature
 	MixHash      []byte `json:"mixHash,omitempty" rlp:"optional"`      // 32 byte RANDAO mix
 
+	// Added with BlobTx hardfork for EIP-4844 blob transactions.
+	BlobGasUsed   *uint64 `json:"blobGasUsed,omitempty" rlp:"optional"`
+	ExcessBlobGas *uint64 `json:"excessBlobGas,omitempty" rlp:"optional"`
+
 	// New header fields must be added at tail for backward compatibility.
 }
 
@@ -79,18 +83,20 @@ type Header struct {
 // gencodec will recognize headerMarshaling struct and use below types
 // instead of the default native types. e.g. []byte -> hexutil.Byte
 type headerMarshaling struct {
-	BlockScore   *hexutil.Big
-	Number       *hexutil.Big
-	GasUsed      hexutil.Uint64
-	Time         *hexutil.Big
-	TimeFoS      hexutil.Uint
-	Extra        hexutil.Bytes
-	BaseFee      *hexutil.Big
-	Hash         common.Hash `json:"hash"` // adds call to Hash() in MarshalJSON
-	Governance   hexutil.Bytes
-	Vote         hexutil.Bytes
-	RandomReveal hexutil.Bytes
-	MixHash      hexutil.Bytes
+	BlockScore    *hexutil.Big
+	Number        *hexutil.Big
+	GasUsed       hexutil.Uint64
+	Time          *hexutil.Big
+	TimeFoS       hexutil.Uint
+	Extra         hexutil.Bytes
+	BaseFee       *hexutil.Big
+	Hash          common.Hash `json:"hash"` // adds call to Hash() in MarshalJSON
+	Governance    hexutil.Bytes
+	Vote          hexutil.Bytes
+	RandomReveal  hexutil.Bytes
+	MixHash       hexutil.Bytes
+	BlobGasUsed   *hexutil.Uint64
+	ExcessBlobGas *hexutil.Uint64
 }
 
 // Hash returns the block hash of the header, which is simply the keccak256 hash of its
@@ -278,6 +284,15 @@ func CopyHeader(h *Header) *Header {
 		cpy.MixHash = make([]byte, len(h.MixHash))
 		copy(cpy.MixHash, h.MixHash)
 	}
+	if h.BlobGasUsed != nil {
+		// These fields exist after blobTx hardfork
+		blobGasUsed := *h.BlobGasUsed
+		cpy.BlobGasUsed = &blobGasUsed
+	}
+	if h.ExcessBlobGas != nil {
+		excessBlobGas := *h.ExcessBlobGas
+		cpy.ExcessBlobGas = &excessBlobGas
+	}
 	return &cpy
 }
 
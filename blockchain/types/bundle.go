@@ -0,0 +1,64 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"sync/atomic"
+
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/crypto"
+)
+
+// Bundle is a group of transactions which must be included in a block contiguously
+// and atomically. Either all transactions of the bundle are executed successfully
+// in the given order, or none of them is included in the block.
+type Bundle struct {
+	Txs Transactions
+
+	// BlockNumber is the number of the only block the bundle can be included in.
+	// The bundle is discarded once the block is mined.
+	BlockNumber uint64
+
+	hash atomic.Value
+}
+
+// NewBundle creates a bundle of the transactions for the given target block number.
+func NewBundle(txs Transactions, blockNumber uint64) *Bundle {
+	return &Bundle{Txs: txs, BlockNumber: blockNumber}
+}
+
+// Hash returns the hash of the bundle, which is the keccak256 hash of the
+// concatenated hashes of the transactions followed by the target block number.
+// The same transactions targeting different blocks are different bundles.
+func (b *Bundle) Hash() common.Hash {
+	if hash := b.hash.Load(); hash != nil {
+		return hash.(common.Hash)
+	}
+	data := make([][]byte, 0, len(b.Txs)+1)
+	for _, tx := range b.Txs {
+		data = append(data, tx.Hash().Bytes())
+	}
+	data = append(data, common.Int64ToByteBigEndian(b.BlockNumber))
+	v := crypto.Keccak256Hash(data...)
+	b.hash.Store(v)
+	return v
+}
+
+// IsTargeting returns true if the bundle can be included in the block of the given number.
+func (b *Bundle) IsTargeting(blockNumber uint64) bool {
+	return b.BlockNumber == blockNumber
+}
@@ -35,8 +35,9 @@ var (
 	// to identify whether the block is from Istanbul consensus engine
 	IstanbulDigest = common.HexToHash("0x63746963616c2062797a616e74696e65206661756c7420746f6c6572616e6365")
 
-	IstanbulExtraVanity = 32 // Fixed number of extra-data bytes reserved for validator vanity
-	IstanbulExtraSeal   = 65 // Fixed number of extra-data bytes reserved for validator seal
+	IstanbulExtraVanity  = 32 // Fixed number of extra-data bytes reserved for validator vanity
+	IstanbulExtraSeal    = 65 // Fixed number of extra-data bytes reserved for validator seal
+	IstanbulExtraBlsSeal = 96 // Fixed number of extra-data bytes reserved for BLS committed seal
 
 	// ErrInvalidIstanbulHeaderExtra is returned if the length of extra-data is less than 32 bytes
 	ErrInvalidIstanbulHeaderExtra = errors.New("invalid istanbul header extra-data")
@@ -46,28 +47,47 @@ type IstanbulExtra struct {
 	Validators    []common.Address
 	Seal          []byte
 	CommittedSeal [][]byte
+
+	// Since the BlsSeal hardfork, CommittedSeal is left empty and the committers are
+	// proven by the aggregate of their BLS committed seals. The i-th bit of SignerBitmap
+	// (LSB first) is set if the i-th member of the committee has signed.
+	AggregatedSeal []byte
+	SignerBitmap   []byte
+}
+
+// HasAggregatedSeal returns true if the committers are proven by an aggregated BLS seal.
+func (ist *IstanbulExtra) HasAggregatedSeal() bool {
+	return len(ist.AggregatedSeal) > 0 || len(ist.SignerBitmap) > 0
 }
 
 // EncodeRLP serializes the istanbul fields into the Kaia RLP format.
+// The aggregated seal fields are omitted if empty to keep the legacy format.
 func (ist *IstanbulExtra) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, []interface{}{
+	fields := []interface{}{
 		ist.Validators,
 		ist.Seal,
 		ist.CommittedSeal,
-	})
+	}
+	if ist.HasAggregatedSeal() {
+		fields = append(fields, ist.AggregatedSeal, ist.SignerBitmap)
+	}
+	return rlp.Encode(w, fields)
 }
 
 // DecodeRLP implements rlp.Decoder, and load the istanbul fields from a RLP stream.
 func (ist *IstanbulExtra) DecodeRLP(s *rlp.Stream) error {
 	var istanbulExtra struct {
-		Validators    []common.Address
-		Seal          []byte
-		CommittedSeal [][]byte
+		Validators     []common.Address
+		Seal           []byte
+		CommittedSeal  [][]byte
+		AggregatedSeal []byte `rlp:"optional"`
+		SignerBitmap   []byte `rlp:"optional"`
 	}
 	if err := s.Decode(&istanbulExtra); err != nil {
 		return err
 	}
 	ist.Validators, ist.Seal, ist.CommittedSeal = istanbulExtra.Validators, istanbulExtra.Seal, istanbulExtra.CommittedSeal
+	ist.AggregatedSeal, ist.SignerBitmap = istanbulExtra.AggregatedSeal, istanbulExtra.SignerBitmap
 	return nil
 }
 
@@ -101,6 +121,7 @@ func IstanbulFilteredHeader(h *Header, keepSeal bool) *Header {
 		istanbulExtra.Seal = []byte{}
 	}
 	istanbulExtra.CommittedSeal = [][]byte{}
+	istanbulExtra.AggregatedSeal, istanbulExtra.SignerBitmap = nil, nil
 
 	payload, err := rlp.EncodeToBytes(&istanbulExtra)
 	if err != nil {
@@ -112,6 +133,28 @@ func IstanbulFilteredHeader(h *Header, keepSeal bool) *Header {
 	return newHeader
 }
 
+// SignerBitmapIndices returns the committee indices whose bits are set in the signer bitmap.
+func SignerBitmapIndices(bitmap []byte) []int {
+	var indices []int
+	for i, b := range bitmap {
+		for j := 0; j < 8; j++ {
+			if b&(1<<uint(j)) != 0 {
+				indices = append(indices, i*8+j)
+			}
+		}
+	}
+	return indices
+}
+
+// NewSignerBitmap returns the signer bitmap of the given committee indices.
+func NewSignerBitmap(committeeSize int, indices []int) []byte {
+	bitmap := make([]byte, (committeeSize+7)/8)
+	for _, i := range indices {
+		bitmap[i/8] |= 1 << uint(i%8)
+	}
+	return bitmap
+}
+
 func SetRoundToHeader(h *Header, r int64) *Header {
 	h.Extra[IstanbulExtraVanity-1] = byte(r)
 	return h
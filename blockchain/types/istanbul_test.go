@@ -0,0 +1,86 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIstanbulExtraRLP(t *testing.T) {
+	legacy := &IstanbulExtra{
+		Validators:    []common.Address{common.HexToAddress("0x1"), common.HexToAddress("0x2")},
+		Seal:          bytes.Repeat([]byte{0x01}, IstanbulExtraSeal),
+		CommittedSeal: [][]byte{bytes.Repeat([]byte{0x02}, IstanbulExtraSeal)},
+	}
+	aggregated := &IstanbulExtra{
+		Validators:     legacy.Validators,
+		Seal:           legacy.Seal,
+		CommittedSeal:  [][]byte{},
+		AggregatedSeal: bytes.Repeat([]byte{0x03}, IstanbulExtraBlsSeal),
+		SignerBitmap:   NewSignerBitmap(2, []int{0, 1}),
+	}
+
+	// The legacy extra keeps the three-field encoding.
+	enc, err := rlp.EncodeToBytes(legacy)
+	require.NoError(t, err)
+	legacyEnc, err := rlp.EncodeToBytes([]interface{}{legacy.Validators, legacy.Seal, legacy.CommittedSeal})
+	require.NoError(t, err)
+	assert.Equal(t, legacyEnc, enc)
+
+	for _, extra := range []*IstanbulExtra{legacy, aggregated} {
+		enc, err := rlp.EncodeToBytes(extra)
+		require.NoError(t, err)
+
+		decoded := new(IstanbulExtra)
+		require.NoError(t, rlp.DecodeBytes(enc, decoded))
+		assert.Equal(t, extra.HasAggregatedSeal(), decoded.HasAggregatedSeal())
+		assert.Equal(t, extra.AggregatedSeal, decoded.AggregatedSeal)
+		assert.Equal(t, extra.SignerBitmap, decoded.SignerBitmap)
+		assert.Equal(t, extra.Validators, decoded.Validators)
+	}
+}
+
+func TestIstanbulFilteredHeaderAggregatedSeal(t *testing.T) {
+	extra := &IstanbulExtra{
+		Validators:     []common.Address{common.HexToAddress("0x1")},
+		Seal:           []byte{},
+		CommittedSeal:  [][]byte{},
+		AggregatedSeal: bytes.Repeat([]byte{0x03}, IstanbulExtraBlsSeal),
+		SignerBitmap:   NewSignerBitmap(1, []int{0}),
+	}
+	payload, err := rlp.EncodeToBytes(extra)
+	require.NoError(t, err)
+	header := &Header{Extra: append(make([]byte, IstanbulExtraVanity), payload...)}
+
+	// The aggregated seal is excluded from the hash signed by the committers.
+	filtered, err := ExtractIstanbulExtra(IstanbulFilteredHeader(header, true))
+	require.NoError(t, err)
+	assert.False(t, filtered.HasAggregatedSeal())
+}
+
+func TestSignerBitmap(t *testing.T) {
+	bitmap := NewSignerBitmap(10, []int{0, 3, 9})
+	assert.Equal(t, []byte{0x09, 0x02}, bitmap)
+	assert.Equal(t, []int{0, 3, 9}, SignerBitmapIndices(bitmap))
+	assert.Empty(t, SignerBitmapIndices(NewSignerBitmap(10, nil)))
+}
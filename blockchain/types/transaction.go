@@ -296,7 +296,7 @@ func (tx *Transaction) setDecoded(inner TxInternalData, size int) {
 func (tx *Transaction) Gas() uint64        { return tx.data.GetGasLimit() }
 func (tx *Transaction) GasPrice() *big.Int { return new(big.Int).Set(tx.data.GetPrice()) }
 func (tx *Transaction) GasTipCap() *big.Int {
-	if tx.Type() == TxTypeEthereumDynamicFee || tx.Type() == TxTypeEthereumSetCode {
+	if tx.Type() == TxTypeEthereumDynamicFee || tx.Type() == TxTypeEthereumBlob || tx.Type() == TxTypeEthereumSetCode {
 		te := tx.GetTxInternalData().(TxInternalDataBaseFee)
 		return te.GetGasTipCap()
 	}
@@ -305,7 +305,7 @@ func (tx *Transaction) GasTipCap() *big.Int {
 }
 
 func (tx *Transaction) GasFeeCap() *big.Int {
-	if tx.Type() == TxTypeEthereumDynamicFee || tx.Type() == TxTypeEthereumSetCode {
+	if tx.Type() == TxTypeEthereumDynamicFee || tx.Type() == TxTypeEthereumBlob || tx.Type() == TxTypeEthereumSetCode {
 		te := tx.GetTxInternalData().(TxInternalDataBaseFee)
 		return te.GetGasFeeCap()
 	}
@@ -352,6 +352,76 @@ func (tx *Transaction) AuthList() []SetCodeAuthorization {
 	return nil
 }
 
+// BlobGas returns the blob gas limit of the transaction for blob transactions, 0 otherwise.
+func (tx *Transaction) BlobGas() uint64 {
+	if te, ok := tx.data.(*TxInternalDataEthereumBlob); ok {
+		return te.BlobGas()
+	}
+	return 0
+}
+
+// BlobGasFeeCap returns the blob gas fee cap per blob gas of the transaction for blob transactions, nil otherwise.
+func (tx *Transaction) BlobGasFeeCap() *big.Int {
+	if te, ok := tx.data.(*TxInternalDataEthereumBlob); ok {
+		return new(big.Int).Set(te.GetBlobFeeCap())
+	}
+	return nil
+}
+
+// BlobHashes returns the hashes of the blob commitments for blob transactions, nil otherwise.
+func (tx *Transaction) BlobHashes() []common.Hash {
+	if te, ok := tx.data.(*TxInternalDataEthereumBlob); ok {
+		return te.GetBlobHashes()
+	}
+	return nil
+}
+
+// BlobTxSidecar returns the sidecar of a blob transaction, nil otherwise.
+func (tx *Transaction) BlobTxSidecar() *BlobTxSidecar {
+	if te, ok := tx.data.(*TxInternalDataEthereumBlob); ok {
+		return te.Sidecar
+	}
+	return nil
+}
+
+// WithoutBlobTxSidecar returns a copy of tx with the blob sidecar removed.
+// The transaction hash is not changed since the sidecar is not a part of it.
+func (tx *Transaction) WithoutBlobTxSidecar() *Transaction {
+	te, ok := tx.data.(*TxInternalDataEthereumBlob)
+	if !ok || te.Sidecar == nil {
+		return tx
+	}
+	return tx.copyWithData(te.withoutSidecar())
+}
+
+// WithBlobTxSidecar returns a copy of tx with the blob sidecar added.
+func (tx *Transaction) WithBlobTxSidecar(sidecar *BlobTxSidecar) *Transaction {
+	te, ok := tx.data.(*TxInternalDataEthereumBlob)
+	if !ok {
+		return tx
+	}
+	return tx.copyWithData(te.withSidecar(sidecar))
+}
+
+// copyWithData returns a copy of tx with the given internal data which has the
+// same hash as the original one, keeping the cached values except the size.
+func (tx *Transaction) copyWithData(data TxInternalData) *Transaction {
+	cpy := &Transaction{data: data, time: tx.time}
+	if hash := tx.hash.Load(); hash != nil {
+		cpy.hash.Store(hash)
+	}
+	if from := tx.from.Load(); from != nil {
+		cpy.from.Store(from)
+	}
+	tx.mu.RLock()
+	cpy.validatedSender = tx.validatedSender
+	cpy.validatedFeePayer = tx.validatedFeePayer
+	cpy.validatedGas = tx.validatedGas
+	cpy.checkNonce = tx.checkNonce
+	tx.mu.RUnlock()
+	return cpy
+}
+
 func (tx *Transaction) Value() *big.Int { return new(big.Int).Set(tx.data.GetAmount()) }
 func (tx *Transaction) Nonce() uint64   { return tx.data.GetAccountNonce() }
 func (tx *Transaction) CheckNonce() bool {
@@ -669,6 +739,9 @@ func (tx *Transaction) WithFeePayerSignature(signer Signer, sig []byte) (*Transa
 func (tx *Transaction) Cost() *big.Int {
 	total := tx.Fee()
 	total.Add(total, tx.data.GetAmount())
+	if tx.Type() == TxTypeEthereumBlob {
+		total.Add(total, new(big.Int).Mul(tx.BlobGasFeeCap(), new(big.Int).SetUint64(tx.BlobGas())))
+	}
 	return total
 }
 
@@ -1009,6 +1082,20 @@ func SortTxsByPriceAndTime(txs Transactions, baseFee *big.Int) Transactions {
 	return sortedTxs
 }
 
+// TransactionSet is a set of transactions that returns the transactions in the
+// order to be included in a block, while honouring the nonce order of each account.
+type TransactionSet interface {
+	// Peek returns the next transaction in the order.
+	Peek() *Transaction
+
+	// Shift replaces the next transaction with the next one from the same account.
+	Shift()
+
+	// Pop removes the next transaction, *not* replacing it with the next one from
+	// the same account.
+	Pop()
+}
+
 // TransactionsByPriceAndNonce represents a set of transactions that can return
 // transactions in a profit-maximizing sorted order, while supporting removing
 // entire batches of transactions for non-executable accounts.
@@ -1092,6 +1179,104 @@ func (t *TransactionsByPriceAndNonce) Clear() {
 	t.heads, t.txs = nil, nil
 }
 
+// txByTime implements the heap interface, sorting the transactions by the time
+// they were first seen.
+type txByTime []*txWithMinerFee
+
+func (s txByTime) Len() int { return len(s) }
+func (s txByTime) Less(i, j int) bool {
+	// If the times are equal, use the hash for deterministic sorting
+	if s[i].tx.Time().Equal(s[j].tx.Time()) {
+		return bytes.Compare(s[i].tx.Hash().Bytes(), s[j].tx.Hash().Bytes()) < 0
+	}
+	return s[i].tx.Time().Before(s[j].tx.Time())
+}
+func (s txByTime) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s *txByTime) Push(x interface{}) {
+	*s = append(*s, x.(*txWithMinerFee))
+}
+
+func (s *txByTime) Pop() interface{} {
+	old := *s
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	*s = old[0 : n-1]
+	return x
+}
+
+// TransactionsByTimeAndNonce represents a set of transactions that can return
+// transactions in the first-come-first-served order, while supporting removing
+// entire batches of transactions for non-executable accounts.
+type TransactionsByTimeAndNonce struct {
+	txs     map[common.Address]Transactions // Per account nonce-sorted list of transactions
+	heads   txByTime                        // Next transaction for each unique account (time heap)
+	baseFee *big.Int                        // Current base fee
+}
+
+// NewTransactionsByTimeAndNonce creates a transaction set that can retrieve
+// arrival time sorted transactions in a nonce-honouring way. The transactions
+// whose fee cap is lower than the base fee are skipped.
+//
+// Note, the input map is reowned so the caller should not interact any more with
+// if after providing it to the constructor.
+func NewTransactionsByTimeAndNonce(txs map[common.Address]Transactions, baseFee *big.Int) *TransactionsByTimeAndNonce {
+	// Initialize a received time based heap with the head transactions
+	heads := make(txByTime, 0, len(txs))
+	for from, accTxs := range txs {
+		wrapped, err := newTxWithMinerFee(accTxs[0], from, baseFee)
+		if err != nil {
+			delete(txs, from)
+			continue
+		}
+		heads = append(heads, wrapped)
+		txs[from] = accTxs[1:]
+	}
+	heap.Init(&heads)
+
+	// Assemble and return the transaction set
+	return &TransactionsByTimeAndNonce{
+		txs:     txs,
+		heads:   heads,
+		baseFee: baseFee,
+	}
+}
+
+// Peek returns the next transaction by arrival time and nonce.
+func (t *TransactionsByTimeAndNonce) Peek() *Transaction {
+	if len(t.heads) == 0 {
+		return nil
+	}
+	return t.heads[0].tx
+}
+
+// Shift replaces the current earliest head with the next one from the same account.
+func (t *TransactionsByTimeAndNonce) Shift() {
+	if len(t.heads) == 0 {
+		return
+	}
+	acc := t.heads[0].from
+	if txs, ok := t.txs[acc]; ok && len(txs) > 0 {
+		if wrapped, err := newTxWithMinerFee(txs[0], acc, t.baseFee); err == nil {
+			t.heads[0], t.txs[acc] = wrapped, txs[1:]
+			heap.Fix(&t.heads, 0)
+			return
+		}
+	}
+	heap.Pop(&t.heads)
+}
+
+// Pop removes the earliest transaction, *not* replacing it with the next one from
+// the same account. This should be used when a transaction cannot be executed
+// and hence all subsequent ones should be discarded from the same account.
+func (t *TransactionsByTimeAndNonce) Pop() {
+	if len(t.heads) == 0 {
+		return
+	}
+	heap.Pop(&t.heads)
+}
+
 // NewMessage returns a `*Transaction` object with the given arguments.
 func NewMessage(from common.Address, to *common.Address, nonce uint64, amount *big.Int, gasLimit uint64, gasPrice, gasFeeCap, gasTipCap *big.Int, data []byte, checkNonce bool, intrinsicGas uint64, list AccessList, chainId *big.Int, auth []SetCodeAuthorization) *Transaction {
 	transaction := &Transaction{
@@ -259,6 +259,7 @@ type pragueSigner struct{ londonSigner }
 
 // NewPragueSigner returns a signer that accepts
 // - EIP-7702 set code transactions,
+// - EIP-4844 blob transactions,
 // - EIP-1559 dynamic fee transactions,
 // - EIP-2930 access list transactions and
 // - EIP-155 replay protected transactions.
@@ -278,7 +279,7 @@ func (s pragueSigner) Equal(s2 Signer) bool {
 }
 
 func (s pragueSigner) Sender(tx *Transaction) (common.Address, error) {
-	if tx.Type() != TxTypeEthereumSetCode {
+	if tx.Type() != TxTypeEthereumSetCode && tx.Type() != TxTypeEthereumBlob {
 		return s.londonSigner.Sender(tx)
 	}
 
@@ -296,7 +297,7 @@ func (s pragueSigner) Sender(tx *Transaction) (common.Address, error) {
 
 // SenderPubkey returns the public key derived from tx signature and txhash.
 func (s pragueSigner) SenderPubkey(tx *Transaction) ([]*ecdsa.PublicKey, error) {
-	if tx.Type() != TxTypeEthereumSetCode {
+	if tx.Type() != TxTypeEthereumSetCode && tx.Type() != TxTypeEthereumBlob {
 		return s.londonSigner.SenderPubkey(tx)
 	}
 
@@ -314,14 +315,14 @@ func (s pragueSigner) SenderPubkey(tx *Transaction) ([]*ecdsa.PublicKey, error)
 
 // SenderFeePayer returns the public key derived from tx signature and txhash.
 func (s pragueSigner) SenderFeePayer(tx *Transaction) ([]*ecdsa.PublicKey, error) {
-	// EIP-7702(Set code transaction) tx don't supported fee-delegation.
+	// EIP-7702(Set code transaction) and EIP-4844(Blob transaction) tx don't supported fee-delegation.
 	return s.londonSigner.SenderFeePayer(tx)
 }
 
 // SignatureValues returns a new transaction with the given signature. This signature
 // needs to be in the [R || S || V] format where V is 0 or 1.
 func (s pragueSigner) SignatureValues(tx *Transaction, sig []byte) (R, S, V *big.Int, err error) {
-	if tx.Type() != TxTypeEthereumSetCode {
+	if tx.Type() != TxTypeEthereumSetCode && tx.Type() != TxTypeEthereumBlob {
 		return s.londonSigner.SignatureValues(tx, sig)
 	}
 
@@ -345,7 +346,7 @@ func (s pragueSigner) SignatureValues(tx *Transaction, sig []byte) (R, S, V *big
 // Hash returns the hash to be signed by the sender.
 // It does not uniquely identify the transaction.
 func (s pragueSigner) Hash(tx *Transaction) common.Hash {
-	if tx.Type() != TxTypeEthereumSetCode {
+	if tx.Type() != TxTypeEthereumSetCode && tx.Type() != TxTypeEthereumBlob {
 		return s.londonSigner.Hash(tx)
 	}
 
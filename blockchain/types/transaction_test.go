@@ -39,6 +39,7 @@ import (
 	"github.com/kaiachain/kaia/blockchain/types/accountkey"
 	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/crypto/kzg4844"
 	"github.com/kaiachain/kaia/params"
 	"github.com/kaiachain/kaia/rlp"
 	"github.com/stretchr/testify/assert"
@@ -751,6 +752,62 @@ func TestTransactionTimeSortDifferentGasPrice(t *testing.T) {
 	}
 }
 
+// TestTransactionsByTimeAndNonce tests that the transactions are returned in the order of
+// arrival regardless of the gas price, while honouring the nonce order of each account.
+func TestTransactionsByTimeAndNonce(t *testing.T) {
+	keys := make([]*ecdsa.PrivateKey, 3)
+	for i := 0; i < len(keys); i++ {
+		keys[i], _ = crypto.GenerateKey()
+	}
+	signer := LatestSignerForChainID(big.NewInt(1))
+
+	// Transactions of each account arrive in turn, and the later accounts pay more.
+	groups := map[common.Address]Transactions{}
+	expected := Transactions{}
+	for nonce := 0; nonce < 3; nonce++ {
+		for i, key := range keys {
+			addr := crypto.PubkeyToAddress(key.PublicKey)
+			tx, _ := SignTx(NewTransaction(uint64(nonce), common.Address{}, big.NewInt(100), 100, big.NewInt(int64(i+1)), nil), signer, key)
+			tx.time = time.Unix(0, int64(len(expected)))
+
+			groups[addr] = append(groups[addr], tx)
+			expected = append(expected, tx)
+		}
+	}
+	// The second transaction of the first account arrives the latest.
+	lastTime := time.Unix(0, int64(len(expected)))
+	groups[crypto.PubkeyToAddress(keys[0].PublicKey)][1].time = lastTime
+
+	var txset TransactionSet = NewTransactionsByTimeAndNonce(groups, nil)
+
+	txs := Transactions{}
+	for tx := txset.Peek(); tx != nil; tx = txset.Peek() {
+		txs = append(txs, tx)
+		txset.Shift()
+	}
+	// The transactions of the first account with higher nonces wait for the latest one.
+	assert.Equal(t, expected[0].Hash(), txs[0].Hash())
+	assert.Equal(t, expected[1].Hash(), txs[1].Hash())
+	assert.Equal(t, expected[2].Hash(), txs[2].Hash())
+	assert.Equal(t, expected[4].Hash(), txs[3].Hash())
+	assert.Equal(t, expected[5].Hash(), txs[4].Hash())
+	assert.Equal(t, expected[7].Hash(), txs[5].Hash())
+	assert.Equal(t, expected[8].Hash(), txs[6].Hash())
+	assert.Equal(t, expected[3].Hash(), txs[7].Hash())
+	assert.Equal(t, expected[6].Hash(), txs[8].Hash())
+
+	// Popping a transaction discards the following transactions of the account.
+	txset = NewTransactionsByTimeAndNonce(map[common.Address]Transactions{
+		crypto.PubkeyToAddress(keys[0].PublicKey): {expected[0], expected[3]},
+		crypto.PubkeyToAddress(keys[1].PublicKey): {expected[1]},
+	}, nil)
+	assert.Equal(t, expected[0].Hash(), txset.Peek().Hash())
+	txset.Pop()
+	assert.Equal(t, expected[1].Hash(), txset.Peek().Hash())
+	txset.Shift()
+	assert.Nil(t, txset.Peek())
+}
+
 // TestTransactionCoding tests serializing/de-serializing to/from rlp and JSON.
 func TestTransactionCoding(t *testing.T) {
 	key, err := crypto.GenerateKey()
@@ -1198,3 +1255,62 @@ func TestEmptyHeap(t *testing.T) {
 		heap.Clear()
 	})
 }
+
+func TestBlobTxSidecar(t *testing.T) {
+	var (
+		blob          = kzg4844.Blob{}
+		commitment, _ = kzg4844.BlobToCommitment(blob)
+		proof, _      = kzg4844.ComputeBlobProof(blob, commitment)
+		sidecar       = &BlobTxSidecar{
+			Blobs:       []kzg4844.Blob{blob},
+			Commitments: []kzg4844.Commitment{commitment},
+			Proofs:      []kzg4844.Proof{proof},
+		}
+		signer = LatestSignerForChainID(big.NewInt(1))
+		key, _ = crypto.HexToECDSA("45a915e4d060149eb4365960e6a7a45f334393093061116b197e3240065ff2d8")
+	)
+	data, err := NewTxInternalDataWithMap(TxTypeEthereumBlob, map[TxValueKeyType]interface{}{
+		TxValueKeyNonce:       uint64(0),
+		TxValueKeyTo:          testAddr,
+		TxValueKeyAmount:      big.NewInt(0),
+		TxValueKeyGasLimit:    uint64(100000),
+		TxValueKeyGasFeeCap:   big.NewInt(25),
+		TxValueKeyGasTipCap:   big.NewInt(25),
+		TxValueKeyData:        []byte{},
+		TxValueKeyAccessList:  AccessList{},
+		TxValueKeyBlobFeeCap:  big.NewInt(1),
+		TxValueKeyBlobHashes:  sidecar.BlobHashes(),
+		TxValueKeyBlobSidecar: sidecar,
+		TxValueKeyChainID:     big.NewInt(1),
+	})
+	assert.NoError(t, err)
+
+	tx, err := SignTx(NewTx(data), signer, key)
+	assert.NoError(t, err)
+	assert.NoError(t, tx.BlobTxSidecar().Verify(tx.BlobHashes()))
+	assert.Equal(t, uint64(params.BlobTxBlobGasPerBlob), tx.BlobGas())
+
+	// The sidecar is carried in the network encoding, but not in the hash.
+	stripped := tx.WithoutBlobTxSidecar()
+	assert.Nil(t, stripped.BlobTxSidecar())
+	assert.Equal(t, tx.Hash(), stripped.Hash())
+	assert.Less(t, int(stripped.Size()), int(tx.Size()))
+
+	for _, orig := range []*Transaction{tx, stripped} {
+		enc, err := rlp.EncodeToBytes(orig)
+		assert.NoError(t, err)
+
+		dec := new(Transaction)
+		assert.NoError(t, rlp.DecodeBytes(enc, dec))
+		assert.Equal(t, orig.Hash(), dec.Hash())
+		assert.Equal(t, orig.BlobTxSidecar(), dec.BlobTxSidecar())
+
+		from, err := Sender(signer, dec)
+		assert.NoError(t, err)
+		assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), from)
+	}
+
+	// A sidecar not matching the blob hashes is rejected.
+	assert.ErrorIs(t, sidecar.Verify([]common.Hash{{0x01}}), ErrInvalidBlobSidecar)
+	assert.ErrorIs(t, sidecar.Verify(nil), ErrInvalidBlobSidecar)
+}
@@ -64,10 +64,9 @@ const (
 	TxTypeKaiaLast, _, _
 	TxTypeEthereumAccessList = TxType(0x7801)
 	TxTypeEthereumDynamicFee = TxType(0x7802)
-	// EIP-4844 BLOB_TX_TYPE not supported in Kaia.
-	_                     = TxType(0x7803)
-	TxTypeEthereumSetCode = TxType(0x7804)
-	TxTypeEthereumLast    = TxType(0x7805)
+	TxTypeEthereumBlob       = TxType(0x7803)
+	TxTypeEthereumSetCode    = TxType(0x7804)
+	TxTypeEthereumLast       = TxType(0x7805)
 )
 
 type TxValueKeyType uint
@@ -93,6 +92,9 @@ const (
 	TxValueKeyGasTipCap
 	TxValueKeyGasFeeCap
 	TxValueKeyAuthorizationList
+	TxValueKeyBlobFeeCap
+	TxValueKeyBlobHashes
+	TxValueKeyBlobSidecar
 )
 
 type TxTypeMask uint8
@@ -129,6 +131,9 @@ var (
 	errValueKeyChainIDInvalid            = errors.New("ChainID must be a type of ChainID")
 	errValueKeyGasTipCapMustBigInt       = errors.New("GasTipCap must be a type of *big.Int")
 	errValueKeyGasFeeCapMustBigInt       = errors.New("GasFeeCap must be a type of *big.Int")
+	errValueKeyBlobFeeCapMustBigInt      = errors.New("BlobFeeCap must be a type of *big.Int")
+	errValueKeyBlobHashesInvalid         = errors.New("BlobHashes must be a slice of common.Hash")
+	errValueKeyBlobSidecarInvalid        = errors.New("BlobSidecar must be a type of *BlobTxSidecar")
 
 	ErrTxTypeNotSupported         = errors.New("transaction type not supported")
 	ErrSenderPubkeyNotSupported   = errors.New("SenderPubkey is not supported for this signer")
@@ -177,6 +182,12 @@ func (t TxValueKeyType) String() string {
 		return "TxValueKeyGasFeeCap"
 	case TxValueKeyAuthorizationList:
 		return "TxValueKeyAuthorizationList"
+	case TxValueKeyBlobFeeCap:
+		return "TxValueKeyBlobFeeCap"
+	case TxValueKeyBlobHashes:
+		return "TxValueKeyBlobHashes"
+	case TxValueKeyBlobSidecar:
+		return "TxValueKeyBlobSidecar"
 	}
 
 	return "UndefinedTxValueKeyType"
@@ -236,6 +247,8 @@ func (t TxType) String() string {
 		return "TxTypeEthereumAccessList"
 	case TxTypeEthereumDynamicFee:
 		return "TxTypeEthereumDynamicFee"
+	case TxTypeEthereumBlob:
+		return "TxTypeEthereumBlob"
 	case TxTypeEthereumSetCode:
 		return "TxTypeEthereumSetCode"
 	}
@@ -497,6 +510,8 @@ func NewTxInternalData(t TxType) (TxInternalData, error) {
 		return newTxInternalDataEthereumAccessList(), nil
 	case TxTypeEthereumDynamicFee:
 		return newTxInternalDataEthereumDynamicFee(), nil
+	case TxTypeEthereumBlob:
+		return newTxInternalDataEthereumBlob(), nil
 	case TxTypeEthereumSetCode:
 		return newTxInternalDataEthereumSetCode(), nil
 	}
@@ -556,6 +571,8 @@ func NewTxInternalDataWithMap(t TxType, values map[TxValueKeyType]interface{}) (
 		return newTxInternalDataEthereumAccessListWithMap(values)
 	case TxTypeEthereumDynamicFee:
 		return newTxInternalDataEthereumDynamicFeeWithMap(values)
+	case TxTypeEthereumBlob:
+		return newTxInternalDataEthereumBlobWithMap(values)
 	case TxTypeEthereumSetCode:
 		return newTxInternalDataEthereumSetCodeWithMap(values)
 	}
@@ -705,6 +722,7 @@ var txTypeToGasMap = map[TxType]uint64{
 	TxTypeFeeDelegatedChainDataAnchoringWithRatio: params.TxChainDataAnchoringGas + params.TxGasFeeDelegatedWithRatio,
 	TxTypeEthereumAccessList:                      params.TxGas,
 	TxTypeEthereumDynamicFee:                      params.TxGas,
+	TxTypeEthereumBlob:                            params.TxGas,
 	TxTypeEthereumSetCode:                         params.TxGas,
 }
 
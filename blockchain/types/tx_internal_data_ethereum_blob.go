@@ -0,0 +1,730 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+
+	"github.com/holiman/uint256"
+	"github.com/kaiachain/kaia/blockchain/types/accountkey"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/crypto/kzg4844"
+	"github.com/kaiachain/kaia/fork"
+	"github.com/kaiachain/kaia/kerrors"
+	"github.com/kaiachain/kaia/params"
+	"github.com/kaiachain/kaia/rlp"
+)
+
+var (
+	ErrMissingBlobHashes    = errors.New("blob transaction missing blob hashes")
+	ErrTooManyBlobs         = errors.New("blob transaction has too many blobs")
+	ErrInvalidBlobHash      = errors.New("blob transaction has an invalid blob hash version")
+	ErrMissingBlobSidecar   = errors.New("blob transaction missing sidecar")
+	ErrInvalidBlobSidecar   = errors.New("blob transaction has an invalid sidecar")
+	ErrBlobFeeCapTooLow     = errors.New("max fee per blob gas less than block blob gas fee")
+	ErrBlobFeeCapVeryHigh   = errors.New("max fee per blob gas higher than 2^256-1")
+	errBlobSidecarCountDiff = errors.New("the number of blobs, commitments and proofs must be the same")
+)
+
+// BlobTxSidecar contains the blobs of a blob transaction. It is not a part of
+// the transaction hash, thus it is carried only when the transaction is
+// propagated over the network.
+type BlobTxSidecar struct {
+	Blobs       []kzg4844.Blob       // Blobs needed by the blob pool
+	Commitments []kzg4844.Commitment // Commitments needed by the blob pool
+	Proofs      []kzg4844.Proof      // Proofs needed by the blob pool
+}
+
+// BlobHashes computes the blob hashes of the given blobs.
+func (sc *BlobTxSidecar) BlobHashes() []common.Hash {
+	hasher := sha256.New()
+	h := make([]common.Hash, len(sc.Commitments))
+	for i := range sc.Commitments {
+		h[i] = kzg4844.CalcBlobHashV1(hasher, &sc.Commitments[i])
+	}
+	return h
+}
+
+// Verify checks that the sidecar holds the blobs committed by the given hashes,
+// including the KZG proofs of the blobs.
+func (sc *BlobTxSidecar) Verify(hashes []common.Hash) error {
+	if len(sc.Blobs) != len(sc.Commitments) || len(sc.Blobs) != len(sc.Proofs) {
+		return fmt.Errorf("%w: %v", ErrInvalidBlobSidecar, errBlobSidecarCountDiff)
+	}
+	if len(sc.Blobs) != len(hashes) {
+		return fmt.Errorf("%w: %d blobs for %d blob hashes", ErrInvalidBlobSidecar, len(sc.Blobs), len(hashes))
+	}
+	hasher := sha256.New()
+	for i, commitment := range sc.Commitments {
+		if vhash := kzg4844.CalcBlobHashV1(hasher, &commitment); vhash != hashes[i] {
+			return fmt.Errorf("%w: blob %d: computed hash %#x mismatches transaction one %#x", ErrInvalidBlobSidecar, i, vhash, hashes[i])
+		}
+	}
+	for i := range sc.Blobs {
+		if err := kzg4844.VerifyBlobProof(sc.Blobs[i], sc.Commitments[i], sc.Proofs[i]); err != nil {
+			return fmt.Errorf("%w: blob %d: %v", ErrInvalidBlobSidecar, i, err)
+		}
+	}
+	return nil
+}
+
+// Copy returns a deep copy of the sidecar.
+func (sc *BlobTxSidecar) Copy() *BlobTxSidecar {
+	return &BlobTxSidecar{
+		Blobs:       append([]kzg4844.Blob(nil), sc.Blobs...),
+		Commitments: append([]kzg4844.Commitment(nil), sc.Commitments...),
+		Proofs:      append([]kzg4844.Proof(nil), sc.Proofs...),
+	}
+}
+
+// BlobTxSidecarWithHash couples a blob sidecar with the hash of the transaction
+// carrying it, so that the sidecars of a block can be stored together.
+type BlobTxSidecarWithHash struct {
+	TxHash  common.Hash
+	Sidecar *BlobTxSidecar
+}
+
+// TxInternalDataEthereumBlob represents an EIP-4844 blob transaction.
+type TxInternalDataEthereumBlob struct {
+	ChainID      *uint256.Int
+	AccountNonce uint64
+	GasTipCap    *big.Int // a.k.a. maxPriorityFeePerGas
+	GasFeeCap    *big.Int // a.k.a. maxFeePerGas
+	GasLimit     uint64
+	Recipient    common.Address
+	Amount       *big.Int
+	Payload      []byte
+	AccessList   AccessList
+	BlobFeeCap   *big.Int // a.k.a. maxFeePerBlobGas
+	BlobHashes   []common.Hash
+
+	// Signature values
+	V *big.Int
+	R *big.Int
+	S *big.Int
+
+	// Sidecar is only carried in the network representation of the transaction.
+	Sidecar *BlobTxSidecar `json:"-" rlp:"-"`
+
+	// This is only used when marshaling to JSON.
+	Hash *common.Hash `json:"hash" rlp:"-"`
+}
+
+// txInternalDataEthereumBlobRLP is the canonical RLP encoding of a blob transaction.
+type txInternalDataEthereumBlobRLP struct {
+	ChainID      *uint256.Int
+	AccountNonce uint64
+	GasTipCap    *big.Int
+	GasFeeCap    *big.Int
+	GasLimit     uint64
+	Recipient    common.Address
+	Amount       *big.Int
+	Payload      []byte
+	AccessList   AccessList
+	BlobFeeCap   *big.Int
+	BlobHashes   []common.Hash
+	V            *big.Int
+	R            *big.Int
+	S            *big.Int
+}
+
+// txInternalDataEthereumBlobWithSidecarRLP is the network RLP encoding of a blob
+// transaction, which carries the sidecar along with the transaction.
+type txInternalDataEthereumBlobWithSidecarRLP struct {
+	Tx          txInternalDataEthereumBlobRLP
+	Blobs       []kzg4844.Blob
+	Commitments []kzg4844.Commitment
+	Proofs      []kzg4844.Proof
+}
+
+type TxInternalDataEthereumBlobJSON struct {
+	Type                 TxType           `json:"typeInt"`
+	TypeStr              string           `json:"type"`
+	ChainID              *hexutil.U256    `json:"chainId"`
+	AccountNonce         hexutil.Uint64   `json:"nonce"`
+	MaxPriorityFeePerGas *hexutil.Big     `json:"maxPriorityFeePerGas"`
+	MaxFeePerGas         *hexutil.Big     `json:"maxFeePerGas"`
+	GasLimit             hexutil.Uint64   `json:"gas"`
+	Recipient            common.Address   `json:"to"`
+	Amount               *hexutil.Big     `json:"value"`
+	Payload              hexutil.Bytes    `json:"input"`
+	AccessList           AccessList       `json:"accessList"`
+	MaxFeePerBlobGas     *hexutil.Big     `json:"maxFeePerBlobGas"`
+	BlobVersionedHashes  []common.Hash    `json:"blobVersionedHashes"`
+	TxSignatures         TxSignaturesJSON `json:"signatures"`
+	Hash                 *common.Hash     `json:"hash"`
+}
+
+func newTxInternalDataEthereumBlob() *TxInternalDataEthereumBlob {
+	return &TxInternalDataEthereumBlob{
+		ChainID:      new(uint256.Int),
+		AccountNonce: 0,
+		GasTipCap:    new(big.Int),
+		GasFeeCap:    new(big.Int),
+		GasLimit:     0,
+		Recipient:    common.Address{},
+		Amount:       new(big.Int),
+		Payload:      []byte{},
+		AccessList:   AccessList{},
+		BlobFeeCap:   new(big.Int),
+		BlobHashes:   []common.Hash{},
+		V:            new(big.Int),
+		R:            new(big.Int),
+		S:            new(big.Int),
+	}
+}
+
+func newTxInternalDataEthereumBlobWithMap(values map[TxValueKeyType]interface{}) (*TxInternalDataEthereumBlob, error) {
+	d := newTxInternalDataEthereumBlob()
+
+	if v, ok := values[TxValueKeyChainID].(*big.Int); ok {
+		d.ChainID.Set(uint256.MustFromBig(v))
+		delete(values, TxValueKeyChainID)
+	} else {
+		return nil, errValueKeyChainIDInvalid
+	}
+
+	if v, ok := values[TxValueKeyNonce].(uint64); ok {
+		d.AccountNonce = v
+		delete(values, TxValueKeyNonce)
+	} else {
+		return nil, errValueKeyNonceMustUint64
+	}
+
+	if v, ok := values[TxValueKeyTo].(common.Address); ok {
+		d.Recipient = v
+		delete(values, TxValueKeyTo)
+	} else {
+		return nil, errValueKeyToMustAddress
+	}
+
+	if v, ok := values[TxValueKeyAmount].(*big.Int); ok {
+		d.Amount.Set(v)
+		delete(values, TxValueKeyAmount)
+	} else {
+		return nil, errValueKeyAmountMustBigInt
+	}
+
+	if v, ok := values[TxValueKeyData].([]byte); ok {
+		d.Payload = common.CopyBytes(v)
+		delete(values, TxValueKeyData)
+	} else {
+		return nil, errValueKeyDataMustByteSlice
+	}
+
+	if v, ok := values[TxValueKeyGasLimit].(uint64); ok {
+		d.GasLimit = v
+		delete(values, TxValueKeyGasLimit)
+	} else {
+		return nil, errValueKeyGasLimitMustUint64
+	}
+
+	if v, ok := values[TxValueKeyGasFeeCap].(*big.Int); ok {
+		d.GasFeeCap.Set(v)
+		delete(values, TxValueKeyGasFeeCap)
+	} else {
+		return nil, errValueKeyGasFeeCapMustBigInt
+	}
+	if v, ok := values[TxValueKeyGasTipCap].(*big.Int); ok {
+		d.GasTipCap.Set(v)
+		delete(values, TxValueKeyGasTipCap)
+	} else {
+		return nil, errValueKeyGasTipCapMustBigInt
+	}
+	if v, ok := values[TxValueKeyAccessList].(AccessList); ok {
+		d.AccessList = make(AccessList, len(v))
+		copy(d.AccessList, v)
+		delete(values, TxValueKeyAccessList)
+	} else {
+		return nil, errValueKeyAccessListInvalid
+	}
+	if v, ok := values[TxValueKeyBlobFeeCap].(*big.Int); ok {
+		d.BlobFeeCap.Set(v)
+		delete(values, TxValueKeyBlobFeeCap)
+	} else {
+		return nil, errValueKeyBlobFeeCapMustBigInt
+	}
+	if v, ok := values[TxValueKeyBlobHashes].([]common.Hash); ok {
+		d.BlobHashes = make([]common.Hash, len(v))
+		copy(d.BlobHashes, v)
+		delete(values, TxValueKeyBlobHashes)
+	} else {
+		return nil, errValueKeyBlobHashesInvalid
+	}
+	// The sidecar is optional since it is not a part of the transaction.
+	if v, exists := values[TxValueKeyBlobSidecar]; exists {
+		sidecar, ok := v.(*BlobTxSidecar)
+		if !ok {
+			return nil, errValueKeyBlobSidecarInvalid
+		}
+		d.Sidecar = sidecar
+		delete(values, TxValueKeyBlobSidecar)
+	}
+
+	if len(values) != 0 {
+		for k := range values {
+			logger.Warn("unnecessary key", k.String())
+		}
+		return nil, errUndefinedKeyRemains
+	}
+
+	return d, nil
+}
+
+func (t *TxInternalDataEthereumBlob) Type() TxType {
+	return TxTypeEthereumBlob
+}
+
+func (t *TxInternalDataEthereumBlob) GetAccountNonce() uint64 {
+	return t.AccountNonce
+}
+
+func (t *TxInternalDataEthereumBlob) GetPrice() *big.Int {
+	return t.GasFeeCap
+}
+
+func (t *TxInternalDataEthereumBlob) GetGasLimit() uint64 {
+	return t.GasLimit
+}
+
+func (t *TxInternalDataEthereumBlob) GetRecipient() *common.Address {
+	if t.Recipient == (common.Address{}) {
+		return nil
+	}
+
+	to := common.Address(t.Recipient)
+	return &to
+}
+
+func (t *TxInternalDataEthereumBlob) GetAmount() *big.Int {
+	return new(big.Int).Set(t.Amount)
+}
+
+func (t *TxInternalDataEthereumBlob) GetPayload() []byte {
+	return t.Payload
+}
+
+func (t *TxInternalDataEthereumBlob) GetAccessList() AccessList {
+	return t.AccessList
+}
+
+func (t *TxInternalDataEthereumBlob) GetGasTipCap() *big.Int {
+	return t.GasTipCap
+}
+
+func (t *TxInternalDataEthereumBlob) GetGasFeeCap() *big.Int {
+	return t.GasFeeCap
+}
+
+func (t *TxInternalDataEthereumBlob) GetBlobFeeCap() *big.Int {
+	return t.BlobFeeCap
+}
+
+func (t *TxInternalDataEthereumBlob) GetBlobHashes() []common.Hash {
+	return t.BlobHashes
+}
+
+// BlobGas returns the blob gas consumed by the blobs of the transaction.
+func (t *TxInternalDataEthereumBlob) BlobGas() uint64 {
+	return params.BlobTxBlobGasPerBlob * uint64(len(t.BlobHashes))
+}
+
+// withoutSidecar returns a copy of the transaction data without the sidecar.
+func (t *TxInternalDataEthereumBlob) withoutSidecar() *TxInternalDataEthereumBlob {
+	cpy := *t
+	cpy.Sidecar = nil
+	return &cpy
+}
+
+// withSidecar returns a copy of the transaction data with the given sidecar.
+func (t *TxInternalDataEthereumBlob) withSidecar(sidecar *BlobTxSidecar) *TxInternalDataEthereumBlob {
+	cpy := *t
+	cpy.Sidecar = sidecar
+	return &cpy
+}
+
+func (t *TxInternalDataEthereumBlob) GetHash() *common.Hash {
+	return t.Hash
+}
+
+func (t *TxInternalDataEthereumBlob) SetHash(h *common.Hash) {
+	t.Hash = h
+}
+
+func (t *TxInternalDataEthereumBlob) SetSignature(signatures TxSignatures) {
+	if len(signatures) != 1 {
+		logger.Crit("TxTypeEthereum can receive only single signature!")
+	}
+
+	t.V = signatures[0].V
+	t.R = signatures[0].R
+	t.S = signatures[0].S
+}
+
+func (t *TxInternalDataEthereumBlob) RawSignatureValues() TxSignatures {
+	return TxSignatures{&TxSignature{t.V, t.R, t.S}}
+}
+
+func (t *TxInternalDataEthereumBlob) ValidateSignature() bool {
+	v := byte(t.V.Uint64())
+	return crypto.ValidateSignatureValues(v, t.R, t.S, false)
+}
+
+func (t *TxInternalDataEthereumBlob) RecoverAddress(txhash common.Hash, homestead bool, vfunc func(*big.Int) *big.Int) (common.Address, error) {
+	V := vfunc(t.V)
+	return recoverPlain(txhash, t.R, t.S, V, homestead)
+}
+
+func (t *TxInternalDataEthereumBlob) RecoverPubkey(txhash common.Hash, homestead bool, vfunc func(*big.Int) *big.Int) ([]*ecdsa.PublicKey, error) {
+	V := vfunc(t.V)
+
+	pk, err := recoverPlainPubkey(txhash, t.R, t.S, V, homestead)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*ecdsa.PublicKey{pk}, nil
+}
+
+func (t *TxInternalDataEthereumBlob) ChainId() *big.Int {
+	return t.ChainID.ToBig()
+}
+
+func (t *TxInternalDataEthereumBlob) Equal(a TxInternalData) bool {
+	ta, ok := a.(*TxInternalDataEthereumBlob)
+	if !ok {
+		return false
+	}
+
+	return t.ChainID.Cmp(ta.ChainID) == 0 &&
+		t.AccountNonce == ta.AccountNonce &&
+		t.GasFeeCap.Cmp(ta.GasFeeCap) == 0 &&
+		t.GasTipCap.Cmp(ta.GasTipCap) == 0 &&
+		t.GasLimit == ta.GasLimit &&
+		t.Recipient == ta.Recipient &&
+		t.Amount.Cmp(ta.Amount) == 0 &&
+		reflect.DeepEqual(t.AccessList, ta.AccessList) &&
+		t.BlobFeeCap.Cmp(ta.BlobFeeCap) == 0 &&
+		reflect.DeepEqual(t.BlobHashes, ta.BlobHashes) &&
+		t.V.Cmp(ta.V) == 0 &&
+		t.R.Cmp(ta.R) == 0 &&
+		t.S.Cmp(ta.S) == 0
+}
+
+func (t *TxInternalDataEthereumBlob) IntrinsicGas(currentBlockNumber uint64) (uint64, error) {
+	return IntrinsicGas(t.Payload, t.AccessList, nil, false, *fork.Rules(big.NewInt(int64(currentBlockNumber))))
+}
+
+func (t *TxInternalDataEthereumBlob) SerializeForSign() []interface{} {
+	// If the chainId has nil or empty value, It will be set signer's chainId.
+	return []interface{}{
+		t.ChainID,
+		t.AccountNonce,
+		t.GasTipCap,
+		t.GasFeeCap,
+		t.GasLimit,
+		t.Recipient,
+		t.Amount,
+		t.Payload,
+		t.AccessList,
+		t.BlobFeeCap,
+		t.BlobHashes,
+	}
+}
+
+func (t *TxInternalDataEthereumBlob) TxHash() common.Hash {
+	return prefixedRlpHash(byte(t.Type()), t.toRLP())
+}
+
+func (t *TxInternalDataEthereumBlob) SenderTxHash() common.Hash {
+	return prefixedRlpHash(byte(t.Type()), t.toRLP())
+}
+
+func (t *TxInternalDataEthereumBlob) toRLP() *txInternalDataEthereumBlobRLP {
+	return &txInternalDataEthereumBlobRLP{
+		ChainID:      t.ChainID,
+		AccountNonce: t.AccountNonce,
+		GasTipCap:    t.GasTipCap,
+		GasFeeCap:    t.GasFeeCap,
+		GasLimit:     t.GasLimit,
+		Recipient:    t.Recipient,
+		Amount:       t.Amount,
+		Payload:      t.Payload,
+		AccessList:   t.AccessList,
+		BlobFeeCap:   t.BlobFeeCap,
+		BlobHashes:   t.BlobHashes,
+		V:            t.V,
+		R:            t.R,
+		S:            t.S,
+	}
+}
+
+func (t *TxInternalDataEthereumBlob) fromRLP(dec *txInternalDataEthereumBlobRLP) {
+	t.ChainID = dec.ChainID
+	t.AccountNonce = dec.AccountNonce
+	t.GasTipCap = dec.GasTipCap
+	t.GasFeeCap = dec.GasFeeCap
+	t.GasLimit = dec.GasLimit
+	t.Recipient = dec.Recipient
+	t.Amount = dec.Amount
+	t.Payload = dec.Payload
+	t.AccessList = dec.AccessList
+	t.BlobFeeCap = dec.BlobFeeCap
+	t.BlobHashes = dec.BlobHashes
+	t.V = dec.V
+	t.R = dec.R
+	t.S = dec.S
+}
+
+// EncodeRLP implements rlp.Encoder. The transaction is encoded in the network
+// representation if it carries the sidecar.
+func (t *TxInternalDataEthereumBlob) EncodeRLP(w io.Writer) error {
+	if t.Sidecar == nil {
+		return rlp.Encode(w, t.toRLP())
+	}
+	return rlp.Encode(w, &txInternalDataEthereumBlobWithSidecarRLP{
+		Tx:          *t.toRLP(),
+		Blobs:       t.Sidecar.Blobs,
+		Commitments: t.Sidecar.Commitments,
+		Proofs:      t.Sidecar.Proofs,
+	})
+}
+
+// DecodeRLP implements rlp.Decoder. It accepts both the canonical and the
+// network representation of the transaction.
+func (t *TxInternalDataEthereumBlob) DecodeRLP(s *rlp.Stream) error {
+	raw, err := s.Raw()
+	if err != nil {
+		return err
+	}
+	content, _, err := rlp.SplitList(raw)
+	if err != nil {
+		return err
+	}
+	kind, _, _, err := rlp.Split(content)
+	if err != nil {
+		return err
+	}
+	if kind != rlp.List {
+		dec := new(txInternalDataEthereumBlobRLP)
+		if err := rlp.DecodeBytes(raw, dec); err != nil {
+			return err
+		}
+		t.fromRLP(dec)
+		t.Sidecar = nil
+		return nil
+	}
+	dec := new(txInternalDataEthereumBlobWithSidecarRLP)
+	if err := rlp.DecodeBytes(raw, dec); err != nil {
+		return err
+	}
+	t.fromRLP(&dec.Tx)
+	t.Sidecar = &BlobTxSidecar{
+		Blobs:       dec.Blobs,
+		Commitments: dec.Commitments,
+		Proofs:      dec.Proofs,
+	}
+	return nil
+}
+
+func (t *TxInternalDataEthereumBlob) Validate(stateDB StateDB, currentBlockNumber uint64) error {
+	if t.Recipient == (common.Address{}) {
+		return kerrors.ErrEmptyRecipient
+	} else {
+		if common.IsPrecompiledContractAddress(t.Recipient, *fork.Rules(big.NewInt(int64(currentBlockNumber)))) {
+			return kerrors.ErrPrecompiledContractAddress
+		}
+	}
+	if len(t.BlobHashes) == 0 {
+		return ErrMissingBlobHashes
+	}
+	if t.BlobGas() > params.MaxBlobGasPerBlock {
+		return ErrTooManyBlobs
+	}
+	for _, hash := range t.BlobHashes {
+		if !kzg4844.IsValidVersionedHash(hash[:]) {
+			return ErrInvalidBlobHash
+		}
+	}
+	return t.ValidateMutableValue(stateDB, currentBlockNumber)
+}
+
+func (t *TxInternalDataEthereumBlob) ValidateMutableValue(stateDB StateDB, currentBlockNumber uint64) error {
+	return nil
+}
+
+func (t *TxInternalDataEthereumBlob) IsLegacyTransaction() bool {
+	return false
+}
+
+func (t *TxInternalDataEthereumBlob) GetRoleTypeForValidation() accountkey.RoleType {
+	return accountkey.RoleTransaction
+}
+
+func (t *TxInternalDataEthereumBlob) String() string {
+	var from, to string
+	tx := &Transaction{data: t}
+
+	v, r, s := t.V, t.R, t.S
+	if v != nil {
+		signer := LatestSignerForChainID(t.ChainId())
+		if f, err := Sender(signer, tx); err != nil { // derive but don't cache
+			from = "[invalid sender: invalid sig]"
+		} else {
+			from = hex.EncodeToString(f[:])
+		}
+	} else {
+		from = "[invalid sender: nil V field]"
+	}
+
+	if t.GetRecipient() == nil {
+		to = "[contract creation]"
+	} else {
+		to = hex.EncodeToString(t.GetRecipient().Bytes())
+	}
+	enc, _ := rlp.EncodeToBytes(tx.WithoutBlobTxSidecar())
+	return fmt.Sprintf(`
+		TX(%x)
+		Contract: %v
+		Chaind:   %#x
+		From:     %s
+		To:       %s
+		Nonce:    %v
+		GasTipCap: %#x
+		GasFeeCap: %#x
+		GasLimit  %#x
+		Value:    %#x
+		Data:     0x%x
+		AccessList: %x
+		BlobFeeCap: %#x
+		BlobHashes: %x
+		V:        %#x
+		R:        %#x
+		S:        %#x
+		Hex:      %x
+	`,
+		tx.Hash(),
+		t.GetRecipient() == nil,
+		t.ChainId(),
+		from,
+		to,
+		t.GetAccountNonce(),
+		t.GetGasTipCap(),
+		t.GetGasFeeCap(),
+		t.GetGasLimit(),
+		t.GetAmount(),
+		t.GetPayload(),
+		t.AccessList,
+		t.BlobFeeCap,
+		t.BlobHashes,
+		v,
+		r,
+		s,
+		enc,
+	)
+}
+
+func (t *TxInternalDataEthereumBlob) Execute(sender ContractRef, vm VM, stateDB StateDB, currentBlockNumber uint64, gas uint64, value *big.Int) (ret []byte, usedGas uint64, err error) {
+	///////////////////////////////////////////////////////
+	// OpcodeComputationCostLimit: The below code is commented and will be usd for debugging purposes.
+	//start := time.Now()
+	//defer func() {
+	//	elapsed := time.Since(start)
+	//	logger.Debug("[TxInternalDataLegacy] EVM execution done", "elapsed", elapsed)
+	//}()
+	///////////////////////////////////////////////////////
+	stateDB.IncNonce(sender.Address())
+	return vm.Call(sender, t.Recipient, t.Payload, gas, value)
+}
+
+func (t *TxInternalDataEthereumBlob) MakeRPCOutput() map[string]interface{} {
+	return map[string]interface{}{
+		"typeInt":              t.Type(),
+		"type":                 t.Type().String(),
+		"chainId":              (*hexutil.Big)(t.ChainId()),
+		"nonce":                hexutil.Uint64(t.AccountNonce),
+		"maxPriorityFeePerGas": (*hexutil.Big)(t.GasTipCap),
+		"maxFeePerGas":         (*hexutil.Big)(t.GasFeeCap),
+		"gas":                  hexutil.Uint64(t.GasLimit),
+		"to":                   t.Recipient,
+		"input":                hexutil.Bytes(t.Payload),
+		"value":                (*hexutil.Big)(t.Amount),
+		"accessList":           t.AccessList,
+		"maxFeePerBlobGas":     (*hexutil.Big)(t.BlobFeeCap),
+		"blobVersionedHashes":  t.BlobHashes,
+		"signatures":           TxSignaturesJSON{&TxSignatureJSON{(*hexutil.Big)(t.V), (*hexutil.Big)(t.R), (*hexutil.Big)(t.S)}},
+	}
+}
+
+func (t *TxInternalDataEthereumBlob) MarshalJSON() ([]byte, error) {
+	return json.Marshal(TxInternalDataEthereumBlobJSON{
+		t.Type(),
+		t.Type().String(),
+		(*hexutil.U256)(t.ChainID),
+		(hexutil.Uint64)(t.AccountNonce),
+		(*hexutil.Big)(t.GasTipCap),
+		(*hexutil.Big)(t.GasFeeCap),
+		(hexutil.Uint64)(t.GasLimit),
+		t.Recipient,
+		(*hexutil.Big)(t.Amount),
+		t.Payload,
+		t.AccessList,
+		(*hexutil.Big)(t.BlobFeeCap),
+		t.BlobHashes,
+		TxSignaturesJSON{&TxSignatureJSON{(*hexutil.Big)(t.V), (*hexutil.Big)(t.R), (*hexutil.Big)(t.S)}},
+		t.Hash,
+	})
+}
+
+func (t *TxInternalDataEthereumBlob) UnmarshalJSON(bytes []byte) error {
+	js := &TxInternalDataEthereumBlobJSON{}
+	if err := json.Unmarshal(bytes, js); err != nil {
+		return err
+	}
+
+	t.ChainID = (*uint256.Int)(js.ChainID)
+	t.AccountNonce = uint64(js.AccountNonce)
+	t.GasTipCap = (*big.Int)(js.MaxPriorityFeePerGas)
+	t.GasFeeCap = (*big.Int)(js.MaxFeePerGas)
+	t.GasLimit = uint64(js.GasLimit)
+	t.Recipient = js.Recipient
+	t.Amount = (*big.Int)(js.Amount)
+	t.Payload = js.Payload
+	t.AccessList = js.AccessList
+	t.BlobFeeCap = (*big.Int)(js.MaxFeePerBlobGas)
+	t.BlobHashes = js.BlobVersionedHashes
+	t.V = (*big.Int)(js.TxSignatures[0].V)
+	t.R = (*big.Int)(js.TxSignatures[0].R)
+	t.S = (*big.Int)(js.TxSignatures[0].S)
+	t.Hash = js.Hash
+
+	return nil
+}
+
+func (t *TxInternalDataEthereumBlob) setSignatureValues(chainID, v, r, s *big.Int) {
+	t.ChainID, t.V, t.R, t.S = uint256.MustFromBig(chainID), v, r, s
+}
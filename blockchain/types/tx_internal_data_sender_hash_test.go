@@ -61,6 +61,7 @@ func TestTransactionSenderTxHash(t *testing.T) {
 		{"AccessList", genAccessListTransaction()},
 		{"DynamicFee", genDynamicFeeTransaction()},
 		{"SetCode", genSetCodeTransaction()},
+		{"Blob", genBlobTransaction()},
 	}
 
 	testcases := []struct {
@@ -491,6 +492,31 @@ func testTransactionSenderTxHash(t *testing.T, tx TxInternalData) {
 
 		h := common.Hash{}
 
+		hw.Sum(h[:0])
+		senderTxHash := rawTx.GetTxInternalData().SenderTxHash()
+		assert.Equal(t, h, senderTxHash)
+	case *TxInternalDataEthereumBlob:
+		hw := sha3.NewKeccak256()
+		rlp.Encode(hw, byte(rawTx.Type()))
+		rlp.Encode(hw, []interface{}{
+			v.ChainID,
+			v.AccountNonce,
+			v.GasTipCap,
+			v.GasFeeCap,
+			v.GasLimit,
+			v.Recipient,
+			v.Amount,
+			v.Payload,
+			v.AccessList,
+			v.BlobFeeCap,
+			v.BlobHashes,
+			v.V,
+			v.R,
+			v.S,
+		})
+
+		h := common.Hash{}
+
 		hw.Sum(h[:0])
 		senderTxHash := rawTx.GetTxInternalData().SenderTxHash()
 		assert.Equal(t, h, senderTxHash)
@@ -43,6 +43,7 @@ var (
 	gasTipCap      = big.NewInt(25)
 	gasFeeCap      = big.NewInt(25)
 	accesses       = AccessList{{Address: common.HexToAddress("0x0000000000000000000000000000000000000001"), StorageKeys: []common.Hash{{0}}}}
+	blobHashes     = []common.Hash{common.HexToHash("0x0100000000000000000000000000000000000000000000000000000000000001")}
 	authorizations = []SetCodeAuthorization{{ChainID: *uint256.NewInt(2), Address: common.HexToAddress("0x0000000000000000000000000000000000000001"), Nonce: nonce, V: uint8(0), R: *uint256.NewInt(0), S: *uint256.NewInt(0)}}
 )
 
@@ -77,6 +78,7 @@ func TestTransactionSerialization(t *testing.T) {
 		{"AccessList", genAccessListTransaction()},
 		{"DynamicFee", genDynamicFeeTransaction()},
 		{"SetCode", genSetCodeTransaction()},
+		{"Blob", genBlobTransaction()},
 	}
 
 	testcases := []struct {
@@ -310,6 +312,27 @@ func genSetCodeTransaction() TxInternalData {
 	return tx
 }
 
+func genBlobTransaction() TxInternalData {
+	tx, err := NewTxInternalDataWithMap(TxTypeEthereumBlob, map[TxValueKeyType]interface{}{
+		TxValueKeyNonce:      nonce,
+		TxValueKeyTo:         to,
+		TxValueKeyAmount:     amount,
+		TxValueKeyGasLimit:   gasLimit,
+		TxValueKeyGasFeeCap:  gasFeeCap,
+		TxValueKeyGasTipCap:  gasTipCap,
+		TxValueKeyData:       []byte("1234"),
+		TxValueKeyAccessList: accesses,
+		TxValueKeyBlobFeeCap: gasFeeCap,
+		TxValueKeyBlobHashes: blobHashes,
+		TxValueKeyChainID:    big.NewInt(2),
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return tx
+}
+
 func genValueTransferTransaction() TxInternalData {
 	d, err := NewTxInternalDataWithMap(TxTypeValueTransfer, map[TxValueKeyType]interface{}{
 		TxValueKeyNonce:    nonce,
@@ -312,20 +312,24 @@ func enable6780(jt *JumpTable) {
 }
 
 // opBlobHash implements the BLOBHASH opcode
-// Since blob data is generated in dank sharding, opBlobHash will perform only the default action of setting the top of the stack as zero
-// as long as the blob txType is not fully supported in Kaia.
 func opBlobHash(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
 	index := scope.Stack.peek()
-	index.Clear()
-
+	if index.LtUint64(uint64(len(interpreter.evm.TxContext.BlobHashes))) {
+		blobHash := interpreter.evm.TxContext.BlobHashes[index.Uint64()]
+		index.SetBytes32(blobHash[:])
+	} else {
+		index.Clear()
+	}
 	return nil, nil
 }
 
 // opBlobBaseFee implements BLOBBASEFEE opcode
-// Since blob data is generated in dank sharding, opBlobBaseFee will use only the zeroBaseFee
-// as long as the blob txType is not fully supported in Kaia.
+// Before the blobTx hardfork, opBlobBaseFee uses the zeroBaseFee.
 func opBlobBaseFee(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
 	blobBaseFee := uint256.NewInt(params.ZeroBaseFee)
+	if interpreter.evm.Context.BlobBaseFee != nil {
+		blobBaseFee, _ = uint256.FromBig(interpreter.evm.Context.BlobBaseFee)
+	}
 	scope.Stack.push(blobBaseFee)
 	return nil, nil
 }
@@ -299,18 +299,23 @@ func TestBlobHash(t *testing.T) {
 		name   string
 		idx    uint64
 		expect common.Hash
-		// hashes []common.Hash // Kaia doesn't support blobHashes
+		hashes []common.Hash
 	}
-	zero := common.Hash{0}
+	var (
+		zero  = common.Hash{0}
+		one   = common.Hash{1}
+		two   = common.Hash{2}
+		three = common.Hash{3}
+	)
 	for _, tt := range []testcase{
-		{name: "[{1}]", idx: 0, expect: zero},
-		{name: "[1,{2},3]", idx: 2, expect: zero},
-		{name: "out-of-bounds (empty)", idx: 10, expect: zero},
-		{name: "out-of-bounds", idx: 25, expect: zero},
-		{name: "out-of-bounds (nil)", idx: 25, expect: zero},
+		{name: "[{1}]", idx: 0, expect: one, hashes: []common.Hash{one}},
+		{name: "[1,{2},3]", idx: 2, expect: three, hashes: []common.Hash{one, two, three}},
+		{name: "out-of-bounds (empty)", idx: 10, expect: zero, hashes: []common.Hash{}},
+		{name: "out-of-bounds", idx: 25, expect: zero, hashes: []common.Hash{one, two, three}},
+		{name: "out-of-bounds (nil)", idx: 25, expect: zero, hashes: nil},
 	} {
 		var (
-			env            = NewEVM(BlockContext{}, TxContext{}, nil, params.TestChainConfig, &Config{})
+			env            = NewEVM(BlockContext{}, TxContext{BlobHashes: tt.hashes}, nil, params.TestChainConfig, &Config{})
 			stack          = newstack()
 			pc             = uint64(0)
 			evmInterpreter = env.interpreter
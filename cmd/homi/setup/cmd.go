@@ -137,6 +137,8 @@ var HomiFlags = []cli.Flag{
 	altsrc.NewStringFlag(kip160ContractAddressFlag),
 	altsrc.NewInt64Flag(randaoCompatibleBlockNumberFlag),
 	altsrc.NewInt64Flag(pragueCompatibleBlockNumberFlag),
+	altsrc.NewInt64Flag(blobTxCompatibleBlockNumberFlag),
+	altsrc.NewInt64Flag(blsSealCompatibleBlockNumberFlag),
 	altsrc.NewStringFlag(kip113ProxyAddressFlag),
 	altsrc.NewStringFlag(kip113LogicAddressFlag),
 	altsrc.NewBoolFlag(kip113MockFlag),
@@ -790,6 +792,14 @@ func Gen(ctx *cli.Context) error {
 
 	genesisJson.Config.RandaoCompatibleBlock = big.NewInt(ctx.Int64(randaoCompatibleBlockNumberFlag.Name))
 	genesisJson.Config.PragueCompatibleBlock = big.NewInt(ctx.Int64(pragueCompatibleBlockNumberFlag.Name))
+	// BlobTx hardfork is optional
+	if ctx.IsSet(blobTxCompatibleBlockNumberFlag.Name) {
+		genesisJson.Config.BlobTxCompatibleBlock = big.NewInt(ctx.Int64(blobTxCompatibleBlockNumberFlag.Name))
+	}
+	// BlsSeal hardfork is optional
+	if ctx.IsSet(blsSealCompatibleBlockNumberFlag.Name) {
+		genesisJson.Config.BlsSealCompatibleBlock = big.NewInt(ctx.Int64(blsSealCompatibleBlockNumberFlag.Name))
+	}
 
 	genesisJsonBytes, _ = json.MarshalIndent(genesisJson, "", "    ")
 	genValidatorKeystore(privKeys)
@@ -561,6 +561,18 @@ var (
 		Aliases: []string{"genesis.hardfork.prague-compatible-blocknumber"},
 	}
 
+	blobTxCompatibleBlockNumberFlag = &cli.Int64Flag{
+		Name:    "blobtx-compatible-blocknumber",
+		Usage:   "blobTxCompatible blockNumber (the blob transaction hardfork is disabled if not set)",
+		Aliases: []string{"genesis.hardfork.blobtx-compatible-blocknumber"},
+	}
+
+	blsSealCompatibleBlockNumberFlag = &cli.Int64Flag{
+		Name:    "blsseal-compatible-blocknumber",
+		Usage:   "blsSealCompatible blockNumber (the BLS-aggregated committed seal hardfork is disabled if not set)",
+		Aliases: []string{"genesis.hardfork.blsseal-compatible-blocknumber"},
+	}
+
 	kip113ProxyAddressFlag = &cli.StringFlag{
 		Name:    "kip113-proxy-contract-address",
 		Usage:   "kip113 proxy contract address",
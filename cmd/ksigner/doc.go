@@ -0,0 +1,43 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+/*
+ksigner holds the consensus keys of a consensus node in a separate process, and signs the
+consensus data of the node: the block seals, the committed seals, the consensus messages and
+the Randao reveals.
+
+The consensus node connects to ksigner with the `--remote-signer` option, given the IPC path
+or the HTTP URL of ksigner. The consensus key of ksigner is the one of the validator, and the
+node key of the consensus node is only used for p2p: the consensus node proves to the other
+validators that its p2p node is bound to the validator with a signature of ksigner.
+The HTTP endpoint requires the token given by `--http.token`, which the consensus node
+presents with the `--remote-signer-token` option.
+ksigner refuses to sign conflicting data for the same view, and keeps the signed data in its
+slashing protection database under the data directory.
+
+# Options
+
+All available options are as follows.
+
+	--nodekey value      Consensus key file of the validator, which may differ from the p2p node key of the consensus node
+	--bls-nodekey value  BLS node key file of the consensus node. Required since the Randao fork
+	--datadir value      Data directory for the slashing protection database and the IPC endpoint (default: "ksigner")
+	--ipcpath value      Filename for the IPC endpoint within the data directory (explicit paths escape it) (default: "ksigner.ipc")
+	--http.addr value    Listening address of the HTTP endpoint, e.g. 127.0.0.1:8560. Disabled if empty
+	--http.token value   File containing the token the consensus node presents to the HTTP endpoint. Required with --http.addr
+	--help, -h           Show help
+*/
+package main
@@ -0,0 +1,175 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/kaiachain/kaia/cmd/utils/nodecmd"
+	istanbulSigner "github.com/kaiachain/kaia/consensus/istanbul/signer"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/crypto/bls"
+	"github.com/kaiachain/kaia/log"
+	"github.com/kaiachain/kaia/networks/rpc"
+	"github.com/kaiachain/kaia/storage/database"
+	"github.com/urfave/cli/v2"
+)
+
+var errNoToken = errors.New("the HTTP endpoint requires a token to authenticate the consensus node")
+
+var (
+	logger      = log.NewModuleLogger(log.CMDKSIGNER)
+	nodeKeyFlag = &cli.StringFlag{
+		Name:     "nodekey",
+		Usage:    "Consensus key file of the validator, which may differ from the p2p node key of the consensus node",
+		Required: true,
+	}
+	blsNodeKeyFlag = &cli.StringFlag{
+		Name:  "bls-nodekey",
+		Usage: "BLS node key file of the consensus node. Required since the Randao fork",
+	}
+	dataDirFlag = &cli.StringFlag{
+		Name:  "datadir",
+		Usage: "Data directory for the slashing protection database and the IPC endpoint",
+		Value: "ksigner",
+	}
+	ipcPathFlag = &cli.StringFlag{
+		Name:  "ipcpath",
+		Usage: "Filename for the IPC endpoint within the data directory (explicit paths escape it)",
+		Value: "ksigner.ipc",
+	}
+	httpAddrFlag = &cli.StringFlag{
+		Name:  "http.addr",
+		Usage: "Listening address of the HTTP endpoint, e.g. 127.0.0.1:8560. Disabled if empty",
+	}
+	httpTokenFlag = &cli.StringFlag{
+		Name:  "http.token",
+		Usage: "File containing the token the consensus node presents to the HTTP endpoint. Required with --http.addr",
+	}
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "ksigner"
+	app.Usage = "The remote signer of the consensus data for Kaia consensus nodes"
+	app.Copyright = "Copyright 2025 The Kaia Authors"
+	app.Action = runSigner
+	app.Flags = []cli.Flag{
+		nodeKeyFlag,
+		blsNodeKeyFlag,
+		dataDirFlag,
+		ipcPathFlag,
+		httpAddrFlag,
+		httpTokenFlag,
+	}
+	app.Commands = []*cli.Command{
+		nodecmd.VersionCommand,
+	}
+	app.HideVersion = true
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// runSigner serves the signer of the given keys until it is interrupted.
+func runSigner(ctx *cli.Context) error {
+	key, err := crypto.LoadECDSA(ctx.String(nodeKeyFlag.Name))
+	if err != nil {
+		return fmt.Errorf("option %q: %w", nodeKeyFlag.Name, err)
+	}
+	var blsKey bls.SecretKey
+	if file := ctx.String(blsNodeKeyFlag.Name); file != "" {
+		if blsKey, err = bls.LoadKey(file); err != nil {
+			return fmt.Errorf("option %q: %w", blsNodeKeyFlag.Name, err)
+		}
+	}
+
+	dataDir := ctx.String(dataDirFlag.Name)
+	if err := os.MkdirAll(dataDir, 0o700); err != nil {
+		return err
+	}
+	db, err := database.NewLevelDB(&database.DBConfig{Dir: filepath.Join(dataDir, "protection"), DBType: database.LevelDB}, database.MiscDB)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	signer := istanbulSigner.NewLocalSigner(key, blsKey, istanbulSigner.NewProtection(db))
+	apis := istanbulSigner.APIs(signer)
+
+	ipcPath := ctx.String(ipcPathFlag.Name)
+	if !filepath.IsAbs(ipcPath) {
+		ipcPath = filepath.Join(dataDir, ipcPath)
+	}
+	ipcListener, _, err := rpc.StartIPCEndpoint(ipcPath, apis)
+	if err != nil {
+		return err
+	}
+	defer ipcListener.Close()
+	logger.Info("IPC endpoint opened", "url", ipcPath)
+
+	if addr := ctx.String(httpAddrFlag.Name); addr != "" {
+		token, err := loadToken(ctx.String(httpTokenFlag.Name))
+		if err != nil {
+			return fmt.Errorf("option %q: %w", httpTokenFlag.Name, err)
+		}
+		handler := rpc.NewServer()
+		for _, api := range apis {
+			if err := handler.RegisterName(api.Namespace, api.Service); err != nil {
+				return err
+			}
+		}
+		httpListener, err := net.Listen("tcp", addr)
+		if err != nil {
+			return err
+		}
+		defer httpListener.Close()
+		go rpc.NewHTTPServer(nil, []string{"localhost"}, rpc.DefaultHTTPTimeouts, istanbulSigner.NewTokenHandler(token, handler)).Serve(httpListener)
+		logger.Info("HTTP endpoint opened", "url", "http://"+addr)
+	}
+	logger.Info("Serving the signer", "address", signer.Address(), "bls", blsKey != nil)
+
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
+	<-sigc
+	logger.Info("Shutting down the signer")
+	return nil
+}
+
+// loadToken reads the token of the HTTP endpoint from the given file.
+func loadToken(file string) (string, error) {
+	if file == "" {
+		return "", errNoToken
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", errNoToken
+	}
+	return token, nil
+}
@@ -517,6 +517,8 @@ func (kCfg *KaiaConfig) SetKaiaConfig(ctx *cli.Context, stack *node.Node) {
 	ks := stack.AccountManager().Backends(keystore.KeyStoreType)[0].(*keystore.KeyStore)
 	setServiceChainSigner(ctx, ks, cfg)
 	setRewardbase(ctx, ks, cfg)
+	cfg.RemoteSigner = ctx.String(RemoteSignerFlag.Name)
+	cfg.RemoteSignerTokenFile = ctx.String(RemoteSignerTokenFileFlag.Name)
 	setTxPool(ctx, &cfg.TxPool)
 
 	if ctx.IsSet(SyncModeFlag.Name) {
@@ -604,6 +606,12 @@ func (kCfg *KaiaConfig) SetKaiaConfig(ctx *cli.Context, stack *node.Node) {
 	cfg.TriesInMemory = ctx.Uint64(TriesInMemoryFlag.Name)
 	cfg.LivePruning = ctx.Bool(LivePruningFlag.Name)
 	cfg.LivePruningRetention = ctx.Uint64(LivePruningRetentionFlag.Name)
+	if ctx.IsSet(StateSchemeFlag.Name) {
+		cfg.StateScheme = ctx.String(StateSchemeFlag.Name)
+		if cfg.StateScheme != database.HashScheme && cfg.StateScheme != database.PathScheme {
+			log.Fatalf("Invalid --%s: %s", StateSchemeFlag.Name, cfg.StateScheme)
+		}
+	}
 
 	if ctx.IsSet(CacheScaleFlag.Name) {
 		common.CacheScale = ctx.Int(CacheScaleFlag.Name)
@@ -632,6 +640,23 @@ func (kCfg *KaiaConfig) SetKaiaConfig(ctx *cli.Context, stack *node.Node) {
 	}
 
 	cfg.SenderTxHashIndexing = ctx.Bool(SenderTxHashIndexingFlag.Name)
+	cfg.BlobSidecarRetention = ctx.Uint64(BlobSidecarRetentionFlag.Name)
+	cfg.DBCompaction = database.CompactionConfig{
+		Interval:    ctx.Duration(DBCompactionIntervalFlag.Name),
+		WindowStart: ctx.Int(DBCompactionWindowStartFlag.Name),
+		WindowEnd:   ctx.Int(DBCompactionWindowEndFlag.Name),
+		Throttle:    ctx.Duration(DBCompactionThrottleFlag.Name),
+	}
+	if cfg.DBCompaction.WindowStart < 0 || cfg.DBCompaction.WindowStart > 23 || cfg.DBCompaction.WindowEnd < 0 || cfg.DBCompaction.WindowEnd > 23 {
+		log.Fatalf("--%s and --%s must be in 0-23", DBCompactionWindowStartFlag.Name, DBCompactionWindowEndFlag.Name)
+	}
+	for _, name := range ctx.StringSlice(DBCompactionEntriesFlag.Name) {
+		et, err := database.ParseDBEntryType(name)
+		if err != nil {
+			log.Fatalf("Invalid --%s: %v", DBCompactionEntriesFlag.Name, err)
+		}
+		cfg.DBCompaction.Entries = append(cfg.DBCompaction.Entries, et)
+	}
 	cfg.ParallelDBWrite = !ctx.Bool(NoParallelDBWriteFlag.Name)
 	cfg.TrieNodeCacheConfig = statedb.TrieNodeCacheConfig{
 		CacheType: statedb.TrieNodeCacheType(ctx.String(TrieNodeCacheTypeFlag.
@@ -683,6 +708,7 @@ func (kCfg *KaiaConfig) SetKaiaConfig(ctx *cli.Context, stack *node.Node) {
 	if ctx.IsSet(BlockGenerationTimeLimitFlag.Name) {
 		params.BlockGenerationTimeLimit = ctx.Duration(BlockGenerationTimeLimitFlag.Name)
 	}
+	cfg.TxOrdering = ctx.String(BlockGenerationTxOrderingFlag.Name)
 	if ctx.IsSet(OpcodeComputationCostLimitFlag.Name) {
 		params.OpcodeComputationCostLimitOverride = ctx.Uint64(OpcodeComputationCostLimitFlag.Name)
 	}
@@ -802,6 +828,15 @@ func setTxPool(ctx *cli.Context, cfg *blockchain.TxPoolConfig) {
 	if ctx.IsSet(TxPoolJournalIntervalFlag.Name) {
 		cfg.JournalInterval = ctx.Duration(TxPoolJournalIntervalFlag.Name)
 	}
+	if ctx.IsSet(TxPoolSnapshotFlag.Name) {
+		cfg.Snapshot = ctx.String(TxPoolSnapshotFlag.Name)
+	}
+	if ctx.IsSet(TxPoolSnapshotIntervalFlag.Name) {
+		cfg.SnapshotInterval = ctx.Duration(TxPoolSnapshotIntervalFlag.Name)
+	}
+	if ctx.IsSet(TxPoolSnapshotMaxTxsFlag.Name) {
+		cfg.SnapshotMaxTxs = ctx.Uint64(TxPoolSnapshotMaxTxsFlag.Name)
+	}
 	if ctx.IsSet(TxPoolPriceLimitFlag.Name) {
 		cfg.PriceLimit = ctx.Uint64(TxPoolPriceLimitFlag.Name)
 	}
@@ -54,6 +54,7 @@ var FlagGroups = []FlagGroup{
 			StartBlockNumberFlag,
 			BlockGenerationIntervalFlag,
 			BlockGenerationTimeLimitFlag,
+			BlockGenerationTxOrderingFlag,
 			OpcodeComputationCostLimitFlag,
 			UseConsoleLogFlag,
 		},
@@ -75,6 +76,9 @@ var FlagGroups = []FlagGroup{
 			TxPoolDenyRemoteTxFlag,
 			TxPoolJournalFlag,
 			TxPoolJournalIntervalFlag,
+			TxPoolSnapshotFlag,
+			TxPoolSnapshotIntervalFlag,
+			TxPoolSnapshotMaxTxsFlag,
 			TxPoolPriceLimitFlag,
 			TxPoolPriceBumpFlag,
 			TxPoolExecSlotsAccountFlag,
@@ -114,7 +118,13 @@ var FlagGroups = []FlagGroup{
 			DynamoDBReadOnlyFlag,
 			NoParallelDBWriteFlag,
 			SenderTxHashIndexingFlag,
+			BlobSidecarRetentionFlag,
 			DBNoPerformanceMetricsFlag,
+			DBCompactionIntervalFlag,
+			DBCompactionWindowStartFlag,
+			DBCompactionWindowEndFlag,
+			DBCompactionThrottleFlag,
+			DBCompactionEntriesFlag,
 		},
 	},
 	{
@@ -203,6 +213,7 @@ var FlagGroups = []FlagGroup{
 			TriesInMemoryFlag,
 			LivePruningFlag,
 			LivePruningRetentionFlag,
+			StateSchemeFlag,
 		},
 	},
 	{
@@ -228,6 +239,8 @@ var FlagGroups = []FlagGroup{
 		Flags: []cli.Flag{
 			ServiceChainSignerFlag,
 			RewardbaseFlag,
+			RemoteSignerFlag,
+			RemoteSignerTokenFileFlag,
 		},
 	},
 	{
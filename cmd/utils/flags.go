@@ -44,6 +44,7 @@ import (
 	"github.com/kaiachain/kaia/params"
 	"github.com/kaiachain/kaia/storage/database"
 	"github.com/kaiachain/kaia/storage/statedb"
+	"github.com/kaiachain/kaia/work"
 	"github.com/urfave/cli/v2"
 )
 
@@ -234,6 +235,27 @@ var (
 		EnvVars:  []string{"KLAYTN_TXPOOL_JOURNAL_INTERVAL", "KAIA_TXPOOL_JOURNAL_INTERVAL"},
 		Category: "TXPOOL",
 	}
+	TxPoolSnapshotFlag = &cli.StringFlag{
+		Name:     "txpool.snapshot",
+		Usage:    "Disk snapshot of remote transactions to survive node restarts (disabled if empty)",
+		Value:    blockchain.DefaultTxPoolConfig.Snapshot,
+		EnvVars:  []string{"KLAYTN_TXPOOL_SNAPSHOT", "KAIA_TXPOOL_SNAPSHOT"},
+		Category: "TXPOOL",
+	}
+	TxPoolSnapshotIntervalFlag = &cli.DurationFlag{
+		Name:     "txpool.snapshot-interval",
+		Usage:    "Time interval to regenerate the remote transaction snapshot",
+		Value:    blockchain.DefaultTxPoolConfig.SnapshotInterval,
+		EnvVars:  []string{"KLAYTN_TXPOOL_SNAPSHOT_INTERVAL", "KAIA_TXPOOL_SNAPSHOT_INTERVAL"},
+		Category: "TXPOOL",
+	}
+	TxPoolSnapshotMaxTxsFlag = &cli.Uint64Flag{
+		Name:     "txpool.snapshot-max-txs",
+		Usage:    "Maximum number of transactions in the remote transaction snapshot (0 = txpool capacity)",
+		Value:    blockchain.DefaultTxPoolConfig.SnapshotMaxTxs,
+		EnvVars:  []string{"KLAYTN_TXPOOL_SNAPSHOT_MAX_TXS", "KAIA_TXPOOL_SNAPSHOT_MAX_TXS"},
+		Category: "TXPOOL",
+	}
 	TxPoolPriceLimitFlag = &cli.Uint64Flag{
 		Name:     "txpool.pricelimit",
 		Usage:    "Minimum gas price limit to enforce for acceptance into the pool",
@@ -488,6 +510,45 @@ var (
 		EnvVars:  []string{"KLAYTN_DB_NO_PERF_METRICS", "KAIA_DB_NO_PERF_METRICS"},
 		Category: "DATABASE",
 	}
+	DBCompactionIntervalFlag = &cli.DurationFlag{
+		Name:     "db.compaction.interval",
+		Usage:    "Minimum interval between periodic database compactions. Periodic compaction is disabled if zero",
+		Value:    0,
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_DB_COMPACTION_INTERVAL", "KAIA_DB_COMPACTION_INTERVAL"},
+		Category: "DATABASE",
+	}
+	DBCompactionWindowStartFlag = &cli.IntFlag{
+		Name:     "db.compaction.window-start",
+		Usage:    "Start hour (UTC, 0-23) of the low-load window in which periodic database compaction starts",
+		Value:    database.GetDefaultCompactionConfig().WindowStart,
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_DB_COMPACTION_WINDOW_START", "KAIA_DB_COMPACTION_WINDOW_START"},
+		Category: "DATABASE",
+	}
+	DBCompactionWindowEndFlag = &cli.IntFlag{
+		Name:     "db.compaction.window-end",
+		Usage:    "End hour (UTC, 0-23) of the low-load window in which periodic database compaction starts. The window is the whole day if it equals the start hour",
+		Value:    database.GetDefaultCompactionConfig().WindowEnd,
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_DB_COMPACTION_WINDOW_END", "KAIA_DB_COMPACTION_WINDOW_END"},
+		Category: "DATABASE",
+	}
+	DBCompactionThrottleFlag = &cli.DurationFlag{
+		Name:     "db.compaction.throttle",
+		Usage:    "Pause between the compactions of two consecutive key ranges",
+		Value:    database.GetDefaultCompactionConfig().Throttle,
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_DB_COMPACTION_THROTTLE", "KAIA_DB_COMPACTION_THROTTLE"},
+		Category: "DATABASE",
+	}
+	DBCompactionEntriesFlag = &cli.StringSliceFlag{
+		Name:     "db.compaction.entries",
+		Usage:    "Databases to compact periodically (e.g. statetrie,receipts). All databases if not set",
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_DB_COMPACTION_ENTRIES", "KAIA_DB_COMPACTION_ENTRIES"},
+		Category: "DATABASE",
+	}
 	SenderTxHashIndexingFlag = &cli.BoolFlag{
 		Name:     "sendertxhashindexing",
 		Usage:    "Enables storing mapping information of senderTxHash to txHash",
@@ -495,6 +556,14 @@ var (
 		EnvVars:  []string{"KLAYTN_SENDERTXHASHINDEXING", "KAIA_SENDERTXHASHINDEXING"},
 		Category: "DATABASE",
 	}
+	BlobSidecarRetentionFlag = &cli.Uint64Flag{
+		Name:     "db.blob-sidecar-retention",
+		Usage:    "Number of blocks from the latest block whose blob sidecars should be kept (0 = keep forever)",
+		Value:    blockchain.DefaultBlobSidecarRetention,
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_DB_BLOB_SIDECAR_RETENTION", "KAIA_DB_BLOB_SIDECAR_RETENTION"},
+		Category: "DATABASE",
+	}
 	SnapshotFlag = &cli.BoolFlag{
 		Name:     "snapshot",
 		Usage:    "Enables snapshot-database mode",
@@ -549,6 +618,14 @@ var (
 		EnvVars:  []string{"KLAYTN_STATE_LIVE_PRUNING", "KAIA_STATE_LIVE_PRUNING"},
 		Category: "STATE",
 	}
+	StateSchemeFlag = &cli.StringFlag{
+		Name:     "state.scheme",
+		Usage:    `Storage scheme of the state trie nodes ("hash", "path"). It can be selected only when the genesis state is written`,
+		Value:    database.HashScheme,
+		Aliases:  []string{},
+		EnvVars:  []string{"KLAYTN_STATE_SCHEME", "KAIA_STATE_SCHEME"},
+		Category: "STATE",
+	}
 	LivePruningRetentionFlag = &cli.Uint64Flag{
 		Name:     "state.live-pruning-retention",
 		Usage:    "Number of blocks from the latest block whose state data should not be pruned",
@@ -686,6 +763,20 @@ var (
 		EnvVars:  []string{"KLAYTN_REWARDBASE", "KAIA_REWARDBASE"},
 		Category: "CONSENSUS",
 	}
+	RemoteSignerFlag = &cli.StringFlag{
+		Name: "remote-signer",
+		Usage: "IPC path or HTTP URL of the remote signer (ksigner) holding the consensus key and the BLS node key. " +
+			"The consensus data are signed by the remote signer instead of the local keys, and the node key is only used for p2p. " +
+			"This flag is only applicable to CN",
+		EnvVars:  []string{"KLAYTN_REMOTE_SIGNER", "KAIA_REMOTE_SIGNER"},
+		Category: "CONSENSUS",
+	}
+	RemoteSignerTokenFileFlag = &cli.StringFlag{
+		Name:     "remote-signer-token",
+		Usage:    "File containing the token to authenticate to the HTTP endpoint of the remote signer",
+		EnvVars:  []string{"KLAYTN_REMOTE_SIGNER_TOKEN", "KAIA_REMOTE_SIGNER_TOKEN"},
+		Category: "CONSENSUS",
+	}
 	ExtraDataFlag = &cli.StringFlag{
 		Name:     "extradata",
 		Usage:    "Block extra data set by the work (default = client version)",
@@ -1980,6 +2071,14 @@ var (
 		EnvVars:  []string{"KLAYTN_BLOCK_GENERATION_TIME_LIMIT", "KAIA_BLOCK_GENERATION_TIME_LIMIT"},
 		Category: "KAIA",
 	}
+	BlockGenerationTxOrderingFlag = &cli.StringFlag{
+		Name: "block-generation-tx-ordering",
+		Usage: "Set the ordering policy of the transactions in a new block (price, fcfs). " +
+			"This flag is only applicable to CN",
+		Value:    work.DefaultTxOrdering,
+		EnvVars:  []string{"KLAYTN_BLOCK_GENERATION_TX_ORDERING", "KAIA_BLOCK_GENERATION_TX_ORDERING"},
+		Category: "KAIA",
+	}
 	OpcodeComputationCostLimitFlag = &cli.Uint64Flag{
 		Name: "opcode-computation-cost-limit",
 		Usage: "(experimental option) Set the computation cost limit for a tx. " +
@@ -2016,6 +2115,69 @@ var (
 		Category: "GAS PRICE ORACLE",
 	}
 
+	// db command settings
+	DBCmdEntryFlag = &cli.StringFlag{
+		Name:     "db.entry",
+		Usage:    "Name of the database entry to access (misc, header, body, receipts, statetrie, statetrie_migrated, txlookup, bridgeservice, snapshot)",
+		Value:    "misc",
+		Category: "DATABASE COMMAND",
+	}
+	DBCmdShardFlag = &cli.IntFlag{
+		Name:     "db.shard",
+		Usage:    "Shard index of a sharded database to iterate. All shards are iterated in key order if negative",
+		Value:    -1,
+		Category: "DATABASE COMMAND",
+	}
+	DBCmdWritableFlag = &cli.BoolFlag{
+		Name:     "db.writable",
+		Usage:    "Allows modification of the database (put, delete). The database is accessed read-only by default",
+		Category: "DATABASE COMMAND",
+	}
+	DBCmdDecodeRLPFlag = &cli.BoolFlag{
+		Name:     "db.rlp",
+		Usage:    "Prints values as decoded RLP lists instead of hex strings",
+		Category: "DATABASE COMMAND",
+	}
+	DBCmdLimitFlag = &cli.IntFlag{
+		Name:     "db.limit",
+		Usage:    "Maximum number of entries to print while iterating. No limit if zero",
+		Value:    100,
+		Category: "DATABASE COMMAND",
+	}
+
+	// snapshot command settings
+	PruneStateBloomSizeFlag = &cli.Uint64Flag{
+		Name:     "bloomfilter.size",
+		Usage:    "Megabytes of memory allocated to bloom-filter for pruning (minimum 256)",
+		Value:    2048,
+		Category: "SNAPSHOT COMMAND",
+	}
+	DumpNoCodeFlag = &cli.BoolFlag{
+		Name:     "nocode",
+		Usage:    "Exclude contract code from the dump",
+		Category: "SNAPSHOT COMMAND",
+	}
+	DumpNoStorageFlag = &cli.BoolFlag{
+		Name:     "nostorage",
+		Usage:    "Exclude storage entries from the dump",
+		Category: "SNAPSHOT COMMAND",
+	}
+	DumpIncompletesFlag = &cli.BoolFlag{
+		Name:     "incompletes",
+		Usage:    "Include accounts for which we don't have the address (missing preimage)",
+		Category: "SNAPSHOT COMMAND",
+	}
+	DumpStartFlag = &cli.StringFlag{
+		Name:     "start",
+		Usage:    "Start position of the dump. Either a hashed address or an address (hex)",
+		Category: "SNAPSHOT COMMAND",
+	}
+	DumpLimitFlag = &cli.Uint64Flag{
+		Name:     "limit",
+		Usage:    "Maximum number of accounts to dump. No limit if zero",
+		Category: "SNAPSHOT COMMAND",
+	}
+
 	// TODO-Kaia-Bootnode: Add bootnode's metric options
 	// TODO-Kaia-Bootnode: Implements bootnode's RPC
 )
@@ -31,6 +31,7 @@ import (
 	"github.com/kaiachain/kaia/blockchain"
 	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/cmd/utils"
+	"github.com/kaiachain/kaia/common"
 	headergov_impl "github.com/kaiachain/kaia/kaiax/gov/headergov/impl"
 	"github.com/kaiachain/kaia/log"
 	"github.com/kaiachain/kaia/params"
@@ -70,6 +71,7 @@ var (
 			utils.RocksDBCacheIndexAndFilterFlag,
 			utils.OverwriteGenesisFlag,
 			utils.LivePruningFlag,
+			utils.StateSchemeFlag,
 		},
 		Category: "BLOCKCHAIN COMMANDS",
 		Description: `
@@ -138,6 +140,13 @@ func initGenesis(ctx *cli.Context) error {
 	numStateTrieShards := ctx.Uint(utils.NumStateTrieShardsFlag.Name)
 	overwriteGenesis := ctx.Bool(utils.OverwriteGenesisFlag.Name)
 	livePruning := ctx.Bool(utils.LivePruningFlag.Name)
+	stateScheme := ctx.String(utils.StateSchemeFlag.Name)
+	if stateScheme != database.HashScheme && stateScheme != database.PathScheme {
+		logger.Crit("Invalid state scheme", "scheme", stateScheme)
+	}
+	if stateScheme == database.PathScheme && livePruning {
+		logger.Crit("Live pruning is not supported with the path-based state scheme")
+	}
 
 	dbtype := database.DBType(ctx.String(utils.DbTypeFlag.Name)).ToValid()
 	if len(dbtype) == 0 {
@@ -179,6 +188,14 @@ func initGenesis(ctx *cli.Context) error {
 		}
 		chainDB := stack.OpenDatabase(dbc)
 
+		// Write the state scheme to database before the genesis state is committed
+		if chainDB.ReadHeadBlockHash() == (common.Hash{}) {
+			chainDB.WriteStateScheme(stateScheme)
+		} else if scheme := chainDB.ReadStateScheme(); ctx.IsSet(utils.StateSchemeFlag.Name) && scheme != stateScheme {
+			logger.Crit("Cannot change the state scheme of an initialized database", "stored", scheme, "given", stateScheme)
+		}
+		logger.Info("Using the state scheme", "scheme", chainDB.ReadStateScheme())
+
 		// Initialize DeriveSha implementation
 		blockchain.InitDeriveSha(genesis.Config)
 
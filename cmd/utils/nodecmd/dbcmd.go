@@ -0,0 +1,363 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package nodecmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kaiachain/kaia/cmd/utils"
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/rlp"
+	"github.com/kaiachain/kaia/storage/database"
+	"github.com/urfave/cli/v2"
+)
+
+var errDBReadOnly = errors.New("database is opened as read-only. Use --db.writable to modify the database")
+
+var DBCommand = &cli.Command{
+	Name:     "db",
+	Usage:    "Low level database operations",
+	Category: "DATABASE COMMANDS",
+	Description: `
+A set of developer commands to read and modify raw entries of the chain database.
+The target database is selected by --db.entry (e.g. misc, header, statetrie).
+Keys and values are given in hex with 0x prefix. Keys without 0x prefix are used as is
+(e.g. LastBlock). The database is read-only unless --db.writable is given.
+Note: Do not run these commands while a node is using the database.
+`,
+	Subcommands: []*cli.Command{
+		{
+			Name:      "get",
+			Usage:     "Print the value of a key",
+			ArgsUsage: "<key>",
+			Action:    utils.MigrateFlags(dbGet),
+			Flags:     utils.DBCmdFlags,
+		},
+		{
+			Name:      "put",
+			Usage:     "Write a key-value pair (requires --db.writable)",
+			ArgsUsage: "<key> <hex value>",
+			Action:    utils.MigrateFlags(dbPut),
+			Flags:     utils.DBCmdFlags,
+		},
+		{
+			Name:      "delete",
+			Usage:     "Delete a key (requires --db.writable)",
+			ArgsUsage: "<key>",
+			Action:    utils.MigrateFlags(dbDelete),
+			Flags:     utils.DBCmdFlags,
+		},
+		{
+			Name:      "iterate",
+			Usage:     "Print the entries with the given prefix, starting from the given key",
+			ArgsUsage: "[prefix] [start]",
+			Action:    utils.MigrateFlags(dbIterate),
+			Flags:     utils.DBCmdFlags,
+			Description: `
+Kaia db iterate [prefix] [start]
+prints at most --db.limit entries in key order. Well-known keys are decoded
+based on the database schema. If --db.shard is given, only the shard is iterated.
+`,
+		},
+		{
+			Name:      "dump-range",
+			Usage:     "Dump the entries in [start, end) as JSON lines",
+			ArgsUsage: "<start> <end>",
+			Action:    utils.MigrateFlags(dbDumpRange),
+			Flags:     utils.DBCmdFlags,
+			Description: `
+Kaia db dump-range <start> <end>
+dumps every entry whose key is in [start, end) as a JSON object per line.
+An empty end (0x) means the end of the database. --db.limit is applied.
+`,
+		},
+	},
+}
+
+// openDBEntry opens the database manager and returns the database of the entry type given by --db.entry.
+// The database is opened read-only unless writable is set, which is only allowed with --db.writable.
+// The returned database manager should be closed by the caller.
+func openDBEntry(ctx *cli.Context, writable bool) (database.DBManager, database.Database, error) {
+	if writable && !ctx.Bool(utils.DBCmdWritableFlag.Name) {
+		return nil, nil, errDBReadOnly
+	}
+	et, err := database.ParseDBEntryType(ctx.String(utils.DBCmdEntryFlag.Name))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dbc := getConfig(ctx)
+	if !writable {
+		dbc.ReadOnly = true
+		dbc.RocksDBConfig.Secondary = true
+		dbc.DynamoDBConfig.ReadOnly = true
+	}
+	stack, _ := utils.MakeConfigNode(ctx)
+	dbm := stack.OpenDatabase(dbc)
+	db := dbm.GetDatabase(et)
+	if db == nil {
+		dbm.Close()
+		return nil, nil, fmt.Errorf("database %s is not available", et)
+	}
+	return dbm, db, nil
+}
+
+// parseDBKey parses a 0x-prefixed hex string into bytes. Other strings are used as raw bytes.
+func parseDBKey(input string) ([]byte, error) {
+	if strings.HasPrefix(input, "0x") || strings.HasPrefix(input, "0X") {
+		return hexutil.Decode(input)
+	}
+	return []byte(input), nil
+}
+
+// formatDBValue returns the hex string of the value, or the decoded RLP structure if decodeRLP is set.
+func formatDBValue(value []byte, decodeRLP bool) string {
+	if !decodeRLP {
+		return hexutil.Encode(value)
+	}
+	decoded, err := decodeRLPValue(value)
+	if err != nil {
+		return fmt.Sprintf("%s (not rlp: %v)", hexutil.Encode(value), err)
+	}
+	b, _ := json.Marshal(decoded)
+	return string(b)
+}
+
+// decodeRLPValue decodes a single RLP item into nested lists of hex strings.
+func decodeRLPValue(b []byte) (interface{}, error) {
+	kind, content, rest, err := rlp.Split(b)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("%d trailing bytes", len(rest))
+	}
+	if kind != rlp.List {
+		return hexutil.Encode(content), nil
+	}
+
+	items := []interface{}{}
+	for len(content) > 0 {
+		_, _, next, err := rlp.Split(content)
+		if err != nil {
+			return nil, err
+		}
+		item, err := decodeRLPValue(content[:len(content)-len(next)])
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		content = next
+	}
+	return items, nil
+}
+
+func printDBEntry(key, value []byte, decodeRLP bool) {
+	if desc := database.DescribeKey(key); desc != "" {
+		fmt.Printf("%s [%s]: %s\n", hexutil.Encode(key), desc, formatDBValue(value, decodeRLP))
+	} else {
+		fmt.Printf("%s: %s\n", hexutil.Encode(key), formatDBValue(value, decodeRLP))
+	}
+}
+
+func dbGet(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return ErrInvalidCmd
+	}
+	key, err := parseDBKey(ctx.Args().Get(0))
+	if err != nil {
+		return err
+	}
+
+	dbm, db, err := openDBEntry(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer dbm.Close()
+
+	value, err := db.Get(key)
+	if err != nil {
+		return fmt.Errorf("failed to get %s: %v", hexutil.Encode(key), err)
+	}
+	printDBEntry(key, value, ctx.Bool(utils.DBCmdDecodeRLPFlag.Name))
+	return nil
+}
+
+func dbPut(ctx *cli.Context) error {
+	if ctx.NArg() != 2 {
+		return ErrInvalidCmd
+	}
+	key, err := parseDBKey(ctx.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	value, err := hexutil.Decode(ctx.Args().Get(1))
+	if err != nil {
+		return err
+	}
+
+	dbm, db, err := openDBEntry(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer dbm.Close()
+
+	if prev, err := db.Get(key); err == nil {
+		logger.Info("Overwriting an existing value", "key", hexutil.Encode(key), "prev", hexutil.Encode(prev))
+	}
+	if err := db.Put(key, value); err != nil {
+		return err
+	}
+	logger.Info("Put a value", "key", hexutil.Encode(key), "value", hexutil.Encode(value))
+	return nil
+}
+
+func dbDelete(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return ErrInvalidCmd
+	}
+	key, err := parseDBKey(ctx.Args().Get(0))
+	if err != nil {
+		return err
+	}
+
+	dbm, db, err := openDBEntry(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer dbm.Close()
+
+	if prev, err := db.Get(key); err == nil {
+		logger.Info("Deleting a value", "key", hexutil.Encode(key), "prev", hexutil.Encode(prev))
+	}
+	return db.Delete(key)
+}
+
+// newDBCmdIterator creates an iterator of the database, or the shard given by --db.shard.
+func newDBCmdIterator(ctx *cli.Context, db database.Database, prefix, start []byte) (database.Iterator, error) {
+	if shard := ctx.Int(utils.DBCmdShardFlag.Name); shard >= 0 {
+		sdb, err := database.GetShard(db, uint(shard))
+		if err != nil {
+			return nil, err
+		}
+		return sdb.NewIterator(prefix, start), nil
+	}
+	return db.NewIterator(prefix, start), nil
+}
+
+func dbIterate(ctx *cli.Context) error {
+	if ctx.NArg() > 2 {
+		return ErrInvalidCmd
+	}
+	var prefix, start []byte
+	var err error
+	if ctx.NArg() > 0 {
+		if prefix, err = parseDBKey(ctx.Args().Get(0)); err != nil {
+			return err
+		}
+	}
+	if ctx.NArg() > 1 {
+		if start, err = parseDBKey(ctx.Args().Get(1)); err != nil {
+			return err
+		}
+	}
+
+	dbm, db, err := openDBEntry(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer dbm.Close()
+
+	it, err := newDBCmdIterator(ctx, db, prefix, start)
+	if err != nil {
+		return err
+	}
+	defer it.Release()
+
+	var (
+		limit     = ctx.Int(utils.DBCmdLimitFlag.Name)
+		decodeRLP = ctx.Bool(utils.DBCmdDecodeRLPFlag.Name)
+		count     = 0
+	)
+	for it.Next() {
+		if limit > 0 && count >= limit {
+			break
+		}
+		printDBEntry(it.Key(), it.Value(), decodeRLP)
+		count++
+	}
+	logger.Info("Iterated database", "entry", ctx.String(utils.DBCmdEntryFlag.Name), "count", count)
+	return it.Error()
+}
+
+func dbDumpRange(ctx *cli.Context) error {
+	if ctx.NArg() != 2 {
+		return ErrInvalidCmd
+	}
+	start, err := parseDBKey(ctx.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	end, err := parseDBKey(ctx.Args().Get(1))
+	if err != nil {
+		return err
+	}
+
+	dbm, db, err := openDBEntry(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer dbm.Close()
+
+	it, err := newDBCmdIterator(ctx, db, nil, start)
+	if err != nil {
+		return err
+	}
+	defer it.Release()
+
+	var (
+		limit     = ctx.Int(utils.DBCmdLimitFlag.Name)
+		decodeRLP = ctx.Bool(utils.DBCmdDecodeRLPFlag.Name)
+		count     = 0
+	)
+	for it.Next() {
+		if len(end) > 0 && bytes.Compare(it.Key(), end) >= 0 {
+			break
+		}
+		if limit > 0 && count >= limit {
+			break
+		}
+		entry := map[string]string{
+			"key":   hexutil.Encode(it.Key()),
+			"value": formatDBValue(it.Value(), decodeRLP),
+		}
+		if desc := database.DescribeKey(it.Key()); desc != "" {
+			entry["desc"] = desc
+		}
+		b, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		count++
+	}
+	return it.Error()
+}
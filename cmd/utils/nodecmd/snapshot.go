@@ -26,12 +26,16 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"os"
 	"sync"
 	"time"
 
 	"github.com/kaiachain/kaia/blockchain/state"
+	"github.com/kaiachain/kaia/blockchain/state/pruner"
 	"github.com/kaiachain/kaia/cmd/utils"
 	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/crypto"
 	"github.com/kaiachain/kaia/snapshot"
 	"github.com/kaiachain/kaia/storage/database"
 	"github.com/kaiachain/kaia/storage/statedb"
@@ -54,6 +58,45 @@ Kaia snapshot verify-state <state-root>
 will traverse the whole accounts and storages set based on the specified
 snapshot and recalculate the root hash of state for verification.
 In other words, this command does the snapshot to trie conversion.
+`,
+		},
+		{
+			Name:      "prune-state",
+			Usage:     "Prune stale state data based on the snapshot",
+			ArgsUsage: "<root>",
+			Action:    utils.MigrateFlags(pruneState),
+			Flags:     utils.PruneStateFlags,
+			Description: `
+Kaia snapshot prune-state <state-root>
+will prune historical state data with the help of the state snapshot.
+All trie nodes and contract codes that do not belong to the specified
+version state will be deleted from the state trie database (all shards).
+After pruning, the node starts from the specified version state.
+If no root is given, the state of HEAD-127 is used as the target.
+
+The pruning can be interrupted safely after the state bloom filter is
+written into the data directory. In that case, the pruning is resumed
+by running the command again, or by starting the node.
+
+This command cannot be used with live pruning or the path-based state scheme.
+Note: Do not run this command while a node is using the database.
+`,
+		},
+		{
+			Name:      "dump",
+			Usage:     "Dump the accounts of a state as JSON lines, using the snapshot if available",
+			ArgsUsage: "[<root>]",
+			Action:    utils.MigrateFlags(dumpState),
+			Flags:     utils.SnapshotDumpFlags,
+			Description: `
+Kaia snapshot dump [<state-root>]
+will stream all accounts of the specified state into stdout as JSON lines.
+The first line contains the state root, and each following line contains
+an account with its balance, nonce, code hash, account type and account key.
+If no root is given, the state of the head block is dumped.
+Accounts whose address preimage is unknown are skipped unless --incompletes is given.
+The snapshot is used for the iteration if it is available for the root,
+otherwise the state trie is iterated.
 `,
 		},
 		{
@@ -182,6 +225,110 @@ func verifyState(ctx *cli.Context) error {
 	return nil
 }
 
+// pruneState deletes the state data which does not belong to the given state root.
+// if a root hash isn't given, the root hash of HEAD-127 block is used.
+func pruneState(ctx *cli.Context) error {
+	stack := MakeFullNode(ctx)
+	db := stack.OpenDatabase(getConfig(ctx))
+	defer db.Close()
+
+	if ctx.NArg() > 1 {
+		logger.Error("Too many arguments given")
+		return errors.New("too many arguments")
+	}
+	var (
+		root common.Hash
+		err  error
+	)
+	if ctx.NArg() == 1 {
+		root, err = parseRoot(ctx.Args().First())
+		if err != nil {
+			logger.Error("Failed to resolve state root", "err", err)
+			return err
+		}
+	}
+	p, err := pruner.NewPruner(db, stack.ResolvePath(""), ctx.Uint64(utils.PruneStateBloomSizeFlag.Name))
+	if err != nil {
+		logger.Error("Failed to create the state pruner", "err", err)
+		return err
+	}
+	if err := p.Prune(root); err != nil {
+		logger.Error("Failed to prune state", "err", err)
+		return err
+	}
+	return nil
+}
+
+// dumpState streams the accounts of the given state root into stdout as JSON lines.
+// if a root hash isn't given, the root hash of current block is used.
+func dumpState(ctx *cli.Context) error {
+	stack := MakeFullNode(ctx)
+	db := stack.OpenDatabase(getConfig(ctx))
+	defer db.Close()
+
+	head := db.ReadHeadBlockHash()
+	if head == (common.Hash{}) {
+		// Corrupt or empty database, init from scratch
+		return errors.New("empty database")
+	}
+	// Make sure the entire head block is available
+	headBlock := db.ReadBlockByHash(head)
+	if headBlock == nil {
+		return fmt.Errorf("head block missing: %v", head.String())
+	}
+	if ctx.NArg() > 1 {
+		logger.Error("Too many arguments given")
+		return errors.New("too many arguments")
+	}
+	root := headBlock.Root()
+	if ctx.NArg() == 1 {
+		var err error
+		root, err = parseRoot(ctx.Args().First())
+		if err != nil {
+			logger.Error("Failed to resolve state root", "err", err)
+			return err
+		}
+	}
+	conf := &state.DumpConfig{
+		SkipCode:          ctx.Bool(utils.DumpNoCodeFlag.Name),
+		SkipStorage:       ctx.Bool(utils.DumpNoStorageFlag.Name),
+		OnlyWithAddresses: !ctx.Bool(utils.DumpIncompletesFlag.Name),
+		Max:               ctx.Uint64(utils.DumpLimitFlag.Name),
+	}
+	if start := ctx.String(utils.DumpStartFlag.Name); start != "" {
+		b, err := hexutil.Decode(start)
+		if err != nil {
+			return fmt.Errorf("invalid start position: %v", err)
+		}
+		switch len(b) {
+		case common.AddressLength:
+			conf.Start = crypto.Keccak256(b)
+		case common.HashLength:
+			conf.Start = b
+		default:
+			return fmt.Errorf("invalid start position length: %d", len(b))
+		}
+	}
+
+	snaptree, err := snapshot.New(db, statedb.NewDatabase(db), 256, headBlock.Root(), false, false, false)
+	if err != nil {
+		logger.Warn("Failed to open snapshot tree, dumping from the state trie", "err", err)
+		snaptree = nil
+	}
+	sdb, err := state.New(root, state.NewDatabase(db), snaptree, nil)
+	if err != nil {
+		logger.Error("Failed to open the state", "root", root, "err", err)
+		return err
+	}
+	start := time.Now()
+	logger.Info("Dumping the state", "root", root)
+	if err := sdb.IterativeDump(conf, os.Stdout); err != nil {
+		return err
+	}
+	logger.Info("Dumped the state", "root", root, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
 func traceTrie(ctx *cli.Context) error {
 	var childWait, logWait sync.WaitGroup
 
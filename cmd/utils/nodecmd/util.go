@@ -214,6 +214,10 @@ func decodeExtra(headerFile string) (map[string]interface{}, error) {
 	m["committers"] = committers
 	m["validatorSize"] = len(validators)
 	m["committedSealSize"] = len(cSeals)
+	if istanbulExtra.HasAggregatedSeal() {
+		m["aggregatedSeal"] = hexutil.Encode(istanbulExtra.AggregatedSeal)
+		m["signerBitmap"] = hexutil.Encode(istanbulExtra.SignerBitmap)
+	}
 	m["proposer"] = proposer.String()
 	m["round"] = header.Round()
 	return m, nil
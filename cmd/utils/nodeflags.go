@@ -182,6 +182,9 @@ var CommonNodeFlags = []cli.Flag{
 	altsrc.NewBoolFlag(TxPoolDenyRemoteTxFlag),
 	altsrc.NewStringFlag(TxPoolJournalFlag),
 	altsrc.NewDurationFlag(TxPoolJournalIntervalFlag),
+	altsrc.NewStringFlag(TxPoolSnapshotFlag),
+	altsrc.NewDurationFlag(TxPoolSnapshotIntervalFlag),
+	altsrc.NewUint64Flag(TxPoolSnapshotMaxTxsFlag),
 	altsrc.NewUint64Flag(TxPoolPriceLimitFlag),
 	altsrc.NewUint64Flag(TxPoolPriceBumpFlag),
 	altsrc.NewUint64Flag(TxPoolExecSlotsAccountFlag),
@@ -217,11 +220,18 @@ var CommonNodeFlags = []cli.Flag{
 	altsrc.NewIntFlag(PebbleDBCacheSizeFlag),
 	altsrc.NewBoolFlag(NoParallelDBWriteFlag),
 	altsrc.NewBoolFlag(SenderTxHashIndexingFlag),
+	altsrc.NewUint64Flag(BlobSidecarRetentionFlag),
+	altsrc.NewDurationFlag(DBCompactionIntervalFlag),
+	altsrc.NewIntFlag(DBCompactionWindowStartFlag),
+	altsrc.NewIntFlag(DBCompactionWindowEndFlag),
+	altsrc.NewDurationFlag(DBCompactionThrottleFlag),
+	altsrc.NewStringSliceFlag(DBCompactionEntriesFlag),
 	altsrc.NewIntFlag(TrieMemoryCacheSizeFlag),
 	altsrc.NewUintFlag(TrieBlockIntervalFlag),
 	altsrc.NewUint64Flag(TriesInMemoryFlag),
 	altsrc.NewBoolFlag(LivePruningFlag),
 	altsrc.NewUint64Flag(LivePruningRetentionFlag),
+	altsrc.NewStringFlag(StateSchemeFlag),
 	altsrc.NewIntFlag(CacheTypeFlag),
 	altsrc.NewIntFlag(CacheScaleFlag),
 	altsrc.NewStringFlag(CacheUsageLevelFlag),
@@ -333,10 +343,13 @@ var BNFlags = []cli.Flag{
 
 var KCNFlags = []cli.Flag{
 	altsrc.NewStringFlag(RewardbaseFlag),
+	altsrc.NewStringFlag(RemoteSignerFlag),
+	altsrc.NewStringFlag(RemoteSignerTokenFileFlag),
 	altsrc.NewBoolFlag(MainnetFlag),
 	altsrc.NewBoolFlag(KairosFlag),
 	altsrc.NewInt64Flag(BlockGenerationIntervalFlag),
 	altsrc.NewDurationFlag(BlockGenerationTimeLimitFlag),
+	altsrc.NewStringFlag(BlockGenerationTxOrderingFlag),
 }
 
 var KPNFlags = []cli.Flag{
@@ -383,6 +396,7 @@ var KSCNFlags = []cli.Flag{
 	altsrc.NewStringFlag(RewardbaseFlag),
 	altsrc.NewInt64Flag(BlockGenerationIntervalFlag),
 	altsrc.NewDurationFlag(BlockGenerationTimeLimitFlag),
+	altsrc.NewStringFlag(BlockGenerationTxOrderingFlag),
 	altsrc.NewStringFlag(ServiceChainSignerFlag),
 	altsrc.NewUint64Flag(AnchoringPeriodFlag),
 	altsrc.NewUint64Flag(SentChainTxsLimit),
@@ -511,6 +525,26 @@ var SnapshotFlags = []cli.Flag{
 	altsrc.NewBoolFlag(RocksDBCacheIndexAndFilterFlag),
 }
 
+var DBCmdFlags = append([]cli.Flag{
+	DBCmdEntryFlag,
+	DBCmdShardFlag,
+	DBCmdWritableFlag,
+	DBCmdDecodeRLPFlag,
+	DBCmdLimitFlag,
+}, SnapshotFlags...)
+
+var PruneStateFlags = append([]cli.Flag{
+	PruneStateBloomSizeFlag,
+}, SnapshotFlags...)
+
+var SnapshotDumpFlags = append([]cli.Flag{
+	DumpNoCodeFlag,
+	DumpNoStorageFlag,
+	DumpIncompletesFlag,
+	DumpStartFlag,
+	DumpLimitFlag,
+}, SnapshotFlags...)
+
 var DBMigrationSrcFlags = []cli.Flag{
 	altsrc.NewStringFlag(DbTypeFlag),
 	altsrc.NewPathFlag(DataDirFlag),
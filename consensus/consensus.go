@@ -158,6 +158,24 @@ type Handler interface {
 	RegisterConsensusMsgCode(Peer)
 }
 
+// NodeBinding proves that a p2p node is operated by the validator of the address, whose consensus
+// key differs from the p2p node key.
+type NodeBinding struct {
+	Address   common.Address // Consensus address of the validator
+	Signature []byte         // Signature of the p2p node address by the consensus key
+}
+
+// NodeBinder is implemented by the consensus engines identifying the validators by their consensus keys,
+// so that the validators can connect to each other with separate p2p node keys.
+type NodeBinder interface {
+	// NodeBinding returns the binding of the p2p node to the consensus address of the node,
+	// or nil if the node key is the consensus key.
+	NodeBinding() (*NodeBinding, error)
+
+	// VerifyNodeBinding returns the consensus address bound to the given p2p node address by the binding.
+	VerifyNodeBinding(node common.Address, binding *NodeBinding) (common.Address, error)
+}
+
 // Istanbul is a consensus engine to avoid byzantine failure
 type Istanbul interface {
 	Engine
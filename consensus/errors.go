@@ -37,4 +37,7 @@ var (
 
 	// ErrInvalidBaseFee is returned if a block before fork has a base fee field, not nil
 	ErrInvalidBaseFee = errors.New("invalid baseFee before fork")
+
+	// ErrInvalidBlobGas is returned if a block before fork has blob gas fields, not nil
+	ErrInvalidBlobGas = errors.New("invalid blob gas fields before fork")
 )
@@ -48,17 +48,21 @@ type Backend interface {
 
 	GossipSubPeer(prevHash common.Hash, payload []byte)
 
-	// Commit delivers an approved proposal to backend.
-	// The delivered proposal will be put into blockchain.
-	Commit(proposal Proposal, seals [][]byte) error
+	// Commit delivers an approved proposal to backend with the committed seals
+	// and their committers. The delivered proposal will be put into blockchain.
+	Commit(proposal Proposal, seals [][]byte, committers []common.Address) error
 
 	// Verify verifies the proposal. If a consensus.ErrFutureBlock error is returned,
 	// the time difference of the proposal and current time is also returned.
 	Verify(Proposal) (time.Duration, error)
 
-	// Sign signs input data with the backend's private key
+	// Sign signs the consensus message without the signature with the backend's signer
 	Sign([]byte) ([]byte, error)
 
+	// SignCommittedSeal signs the committed seal of the proposal, which is
+	// signed with the BLS secret key since the BlsSeal hardfork.
+	SignCommittedSeal(proposal Proposal) ([]byte, error)
+
 	// CheckSignature verifies the signature by checking if it's signed by
 	// the given validator
 	CheckSignature(data []byte, addr common.Address, sig []byte) error
@@ -86,4 +90,8 @@ type Backend interface {
 	GetCommitteeState(num uint64) (*RoundCommitteeState, error)
 
 	GetCommitteeStateByRound(num uint64, round uint64) (*RoundCommitteeState, error)
+
+	// GetRoundTimeouts returns the governed base round change timeout and the timeout backoff
+	// of the given block height. Each of them is zero if not governed.
+	GetRoundTimeouts(num uint64) (baseTimeout, timeoutBackoff time.Duration)
 }
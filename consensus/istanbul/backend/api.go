@@ -25,8 +25,10 @@ package backend
 import (
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"reflect"
+	"time"
 
 	"github.com/kaiachain/kaia/accounts/abi/bind/backends"
 	kaiaApi "github.com/kaiachain/kaia/api"
@@ -34,6 +36,7 @@ import (
 	"github.com/kaiachain/kaia/blockchain/system"
 	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
 	"github.com/kaiachain/kaia/consensus"
 	"github.com/kaiachain/kaia/consensus/istanbul"
 	istanbulCore "github.com/kaiachain/kaia/consensus/istanbul/core"
@@ -124,6 +127,158 @@ func (api *API) Discard(address common.Address) {
 	delete(api.istanbul.candidates, address)
 }
 
+// EvidenceResult is an equivocation evidence returned by istanbul_getEvidence.
+// The messages are the signed payloads as received, which can be verified by anyone.
+type EvidenceResult struct {
+	Validator     common.Address `json:"validator"`
+	Type          string         `json:"type"`
+	Sequence      uint64         `json:"sequence"`
+	Round         uint64         `json:"round"`
+	Time          uint64         `json:"time"`
+	FirstDigest   common.Hash    `json:"firstDigest"`
+	SecondDigest  common.Hash    `json:"secondDigest"`
+	FirstMessage  hexutil.Bytes  `json:"firstMessage"`
+	SecondMessage hexutil.Bytes  `json:"secondMessage"`
+}
+
+// GetEvidence returns the equivocations detected by the node between the given block numbers.
+// If from is nil, it starts from the genesis. If to is nil or latest, it includes the height in progress.
+func (api *API) GetEvidence(from *rpc.BlockNumber, to *rpc.BlockNumber) ([]*EvidenceResult, error) {
+	if api.istanbul.evidence == nil {
+		return nil, errNoEvidenceStore
+	}
+	start, end := uint64(0), uint64(math.MaxUint64)
+	if from != nil && *from >= 0 {
+		start = uint64(from.Int64())
+	}
+	if to != nil && *to >= 0 {
+		end = uint64(to.Int64())
+	}
+	if start > end {
+		return nil, errStartLargerThanEnd
+	}
+
+	evidences, err := api.istanbul.evidence.Evidences(start, end)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*EvidenceResult, 0, len(evidences))
+	for _, e := range evidences {
+		first, second, err := e.Subjects()
+		if err != nil {
+			logger.Warn("Skip an invalid equivocation evidence", "validator", e.Validator, "sequence", e.Sequence, "err", err)
+			continue
+		}
+		results = append(results, &EvidenceResult{
+			Validator:     e.Validator,
+			Type:          istanbulCore.MessageCodeName(e.Code),
+			Sequence:      e.Sequence,
+			Round:         e.Round,
+			Time:          e.Time,
+			FirstDigest:   first.Digest,
+			SecondDigest:  second.Digest,
+			FirstMessage:  e.First,
+			SecondMessage: e.Second,
+		})
+	}
+	return results, nil
+}
+
+// ValidatorTimelineResult is the arrival times of the messages of a committee member in milliseconds
+// since the preprepare. The time is null if the message did not arrive.
+type ValidatorTimelineResult struct {
+	Validator common.Address `json:"validator"`
+	Prepare   *float64       `json:"prepare"`
+	Commit    *float64       `json:"commit"`
+	Late      bool           `json:"late"`
+}
+
+// ConsensusTimelineResult is a consensus timeline returned by istanbul_getConsensusTimeline.
+type ConsensusTimelineResult struct {
+	Number      uint64                     `json:"number"`
+	Round       uint64                     `json:"round"`
+	Proposer    common.Address             `json:"proposer"`
+	Digest      common.Hash                `json:"digest"`
+	Preprepare  uint64                     `json:"preprepare"` // Unix time in milliseconds
+	Committed   *float64                   `json:"committed"`  // Milliseconds since the preprepare
+	Validators  []*ValidatorTimelineResult `json:"validators"`
+	LateCommits []common.Address           `json:"lateCommits"`
+	Missing     []common.Address           `json:"missing"`
+}
+
+func timelineMilliseconds(ns uint64) *float64 {
+	if ns == 0 {
+		return nil
+	}
+	ms := float64(ns) / float64(time.Millisecond)
+	return &ms
+}
+
+// GetConsensusTimeline returns the consensus timelines of the blocks in the given range, recorded by the node,
+// one for each round the node has seen in the order of the rounds. The blocks whose timelines are not
+// recorded or already evicted from the ring are left out.
+func (api *API) GetConsensusTimeline(start *rpc.BlockNumber, end *rpc.BlockNumber) ([]*ConsensusTimelineResult, error) {
+	if api.istanbul.timelines == nil {
+		return nil, errNoTimelineStore
+	}
+	if start == nil || end == nil {
+		return nil, errRangeNil
+	}
+	s, e := start.Int64(), end.Int64()
+	if *end == rpc.LatestBlockNumber {
+		e = api.chain.CurrentHeader().Number.Int64()
+	}
+	if s < 0 {
+		return nil, errStartNotPositive
+	}
+	if s > e {
+		return nil, errStartLargerThanEnd
+	}
+	if (e - s) > 50 {
+		return nil, errRequestedBlocksTooLarge
+	}
+
+	results := make([]*ConsensusTimelineResult, 0, e-s+1)
+	for i := s; i <= e; i++ {
+		timelines, err := api.istanbul.timelines.Read(uint64(i))
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range timelines {
+			validators := make([]*ValidatorTimelineResult, len(t.Validators))
+			for j, v := range t.Validators {
+				validators[j] = &ValidatorTimelineResult{
+					Validator: v.Validator,
+					Prepare:   timelineMilliseconds(v.Prepare),
+					Commit:    timelineMilliseconds(v.Commit),
+					Late:      v.Late,
+				}
+			}
+			results = append(results, &ConsensusTimelineResult{
+				Number:      t.Sequence,
+				Round:       t.Round,
+				Proposer:    t.Proposer,
+				Digest:      t.Digest,
+				Preprepare:  t.Preprepare / uint64(time.Millisecond),
+				Committed:   timelineMilliseconds(t.Committed),
+				Validators:  validators,
+				LateCommits: t.LateCommits(),
+				Missing:     t.Missing(),
+			})
+		}
+	}
+	return results, nil
+}
+
+// MyStatus returns whether the node is ready to validate the next block: its council, committee and
+// proposer membership, the registration of its BLS key and its staking amount against reward.minimumstake.
+func (api *API) MyStatus() (*ValidatorStatus, error) {
+	if api.istanbul.nodetype != common.CONSENSUSNODE {
+		return nil, errNotConsensusNode
+	}
+	return api.istanbul.validatorStatus()
+}
+
 // API extended by Kaia developers
 type APIExtension struct {
 	chain    consensus.ChainReader
@@ -139,6 +294,8 @@ var (
 	errRequestedBlocksTooLarge = errors.New("number of requested blocks should be smaller than 50")
 	errRangeNil                = errors.New("range values should not be nil")
 	errNoBlockNumber           = errors.New("block number is not assigned")
+	errNoEvidenceStore         = errors.New("equivocation evidence is only recorded by consensus nodes")
+	errNoTimelineStore         = errors.New("consensus timelines are only recorded by consensus nodes")
 )
 
 // GetCouncil retrieves the list of authorized validators at the specified block.
@@ -403,7 +560,12 @@ func (api *APIExtension) GetActiveAddressFromRegistry(name string, number rpc.Bl
 	}
 }
 
+// GetTimeout returns the round change timeout of the round 0 of the next block in milliseconds.
+// It is the governed base timeout if set, otherwise the node-local one.
 func (api *API) GetTimeout() uint64 {
+	if baseTimeout, _ := api.istanbul.GetRoundTimeouts(api.chain.CurrentHeader().Number.Uint64() + 1); baseTimeout > 0 {
+		return uint64(baseTimeout.Milliseconds())
+	}
 	return istanbul.DefaultConfig.Timeout
 }
 
@@ -36,6 +36,7 @@ import (
 	"github.com/kaiachain/kaia/consensus"
 	"github.com/kaiachain/kaia/consensus/istanbul"
 	istanbulCore "github.com/kaiachain/kaia/consensus/istanbul/core"
+	istanbulSigner "github.com/kaiachain/kaia/consensus/istanbul/signer"
 	"github.com/kaiachain/kaia/crypto"
 	"github.com/kaiachain/kaia/crypto/bls"
 	"github.com/kaiachain/kaia/event"
@@ -58,8 +59,9 @@ var logger = log.NewModuleLogger(log.ConsensusIstanbulBackend)
 type BackendOpts struct {
 	IstanbulConfig *istanbul.Config // Istanbul consensus core config
 	Rewardbase     common.Address
-	PrivateKey     *ecdsa.PrivateKey // Consensus message signing key
-	BlsSecretKey   bls.SecretKey     // Randao signing key. Required since Randao fork
+	PrivateKey     *ecdsa.PrivateKey     // P2P node key. Signs the consensus data if there is no signer
+	BlsSecretKey   bls.SecretKey         // Randao signing key. Required since Randao fork
+	Signer         istanbulSigner.Signer // Signer of the consensus data. If nil, the keys above sign locally
 	DB             database.DBManager
 	GovModule      gov.GovModule
 	NodeType       common.ConnType
@@ -68,12 +70,29 @@ type BackendOpts struct {
 func New(opts *BackendOpts) consensus.Istanbul {
 	recentMessages, _ := lru.NewARC(inmemoryPeers)
 	knownMessages, _ := lru.NewARC(inmemoryMessages)
+
+	// Only the consensus nodes persist the consensus state to vote consistently after a restart,
+	// record the equivocations and the timelines of the consensus messages they received,
+	// and protect their local keys from signing conflicting data
+	var db database.Database
+	if opts.DB != nil && opts.NodeType == common.CONSENSUSNODE {
+		db = opts.DB.GetMiscDB()
+	}
+	signer := opts.Signer
+	if signer == nil {
+		var protection *istanbulSigner.Protection
+		if db != nil {
+			protection = istanbulSigner.NewProtection(db)
+		}
+		signer = istanbulSigner.NewLocalSigner(opts.PrivateKey, opts.BlsSecretKey, protection)
+	}
+
 	backend := &backend{
 		config:           opts.IstanbulConfig,
 		istanbulEventMux: new(event.TypeMux),
-		privateKey:       opts.PrivateKey,
-		address:          crypto.PubkeyToAddress(opts.PrivateKey.PublicKey),
-		blsSecretKey:     opts.BlsSecretKey,
+		signer:           signer,
+		address:          signer.Address(),
+		p2pAddress:       signer.Address(),
 		logger:           logger.NewWith(),
 		db:               opts.DB,
 		commitCh:         make(chan *types.Result, 1),
@@ -86,8 +105,15 @@ func New(opts *BackendOpts) consensus.Istanbul {
 		nodetype:         opts.NodeType,
 	}
 
+	if opts.PrivateKey != nil {
+		backend.p2pAddress = crypto.PubkeyToAddress(opts.PrivateKey.PublicKey)
+	}
 	backend.currentView.Store(&istanbul.View{Sequence: big.NewInt(0), Round: big.NewInt(0)})
-	backend.core = istanbulCore.New(backend, backend.config)
+	if db != nil {
+		backend.evidence = istanbulCore.NewEvidenceStore(db)
+		backend.timelines = istanbulCore.NewTimelineStore(db)
+	}
+	backend.core = istanbulCore.New(backend, backend.config, db)
 	return backend
 }
 
@@ -96,10 +122,12 @@ func New(opts *BackendOpts) consensus.Istanbul {
 type backend struct {
 	config           *istanbul.Config
 	istanbulEventMux *event.TypeMux
-	privateKey       *ecdsa.PrivateKey
-	address          common.Address
-	blsSecretKey     bls.SecretKey
+	signer           istanbulSigner.Signer
+	address          common.Address // Consensus address signed by the signer
+	p2pAddress       common.Address // Address of the p2p node key, bound to the consensus address if they differ
 	core             istanbulCore.Engine
+	evidence         *istanbulCore.EvidenceStore // Equivocation evidence detected by the core. Nil if not a consensus node
+	timelines        *istanbulCore.TimelineStore // Consensus timelines recorded by the core. Nil if not a consensus node
 	logger           log.Logger
 	db               database.DBManager
 	chain            consensus.ChainReader
@@ -139,6 +167,15 @@ type backend struct {
 	nodetype common.ConnType
 
 	isRestoringSnapshots atomic.Bool
+
+	// The binding of the p2p node to the consensus address, signed once
+	bindingMu sync.Mutex
+	binding   *consensus.NodeBinding
+
+	// The result of the ready check, cached for the head block it was computed on
+	readyMu    sync.Mutex
+	readyBlock common.Hash
+	readyErr   error
 }
 
 func (sb *backend) NodeType() common.ConnType {
@@ -275,7 +312,7 @@ func (sb *backend) GossipSubPeer(prevHash common.Hash, payload []byte) {
 }
 
 // Commit implements istanbul.Backend.Commit
-func (sb *backend) Commit(proposal istanbul.Proposal, seals [][]byte) error {
+func (sb *backend) Commit(proposal istanbul.Proposal, seals [][]byte, committers []common.Address) error {
 	// Check if the proposal is a valid block
 	block, ok := proposal.(*types.Block)
 	if !ok {
@@ -286,7 +323,12 @@ func (sb *backend) Commit(proposal istanbul.Proposal, seals [][]byte) error {
 	round := sb.currentView.Load().(*istanbul.View).Round.Int64()
 	h = types.SetRoundToHeader(h, round)
 	// Append seals into extra-data
-	err := writeCommittedSeals(h, seals)
+	var err error
+	if sb.isBlsSealEnabled(h.Number) {
+		err = sb.aggregateCommittedSeals(h, seals, committers)
+	} else {
+		err = writeCommittedSeals(h, seals)
+	}
 	if err != nil {
 		return err
 	}
@@ -350,8 +392,7 @@ func (sb *backend) Verify(proposal istanbul.Proposal) (time.Duration, error) {
 
 // Sign implements istanbul.Backend.Sign
 func (sb *backend) Sign(data []byte) ([]byte, error) {
-	hashData := crypto.Keccak256([]byte(data))
-	return crypto.Sign(hashData, sb.privateKey)
+	return sb.signer.SignMessage(data)
 }
 
 // CheckSignature implements istanbul.Backend.CheckSignature
@@ -440,6 +481,12 @@ func (sb *backend) GetCommitteeStateByRound(num uint64, round uint64) (*istanbul
 	return istanbul.NewRoundCommitteeState(blockValSet, committeeSize, committee, proposer), nil
 }
 
+// GetRoundTimeouts implements istanbul.Backend.GetRoundTimeouts
+func (sb *backend) GetRoundTimeouts(num uint64) (time.Duration, time.Duration) {
+	pset := sb.govModule.GetParamSet(num)
+	return time.Duration(pset.BaseTimeout) * time.Millisecond, time.Duration(pset.TimeoutBackoff) * time.Millisecond
+}
+
 // GetProposer implements istanbul.Backend.GetProposer
 func (sb *backend) GetProposer(number uint64) common.Address {
 	if h := sb.chain.GetHeaderByNumber(number); h != nil {
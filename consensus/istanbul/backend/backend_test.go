@@ -32,9 +32,11 @@ import (
 	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/consensus/istanbul"
+	istanbulSigner "github.com/kaiachain/kaia/consensus/istanbul/signer"
 	"github.com/kaiachain/kaia/crypto"
 	"github.com/kaiachain/kaia/kaiax/valset"
 	"github.com/kaiachain/kaia/params"
+	"github.com/kaiachain/kaia/rlp"
 	"github.com/kaiachain/kaia/storage/database"
 	"github.com/stretchr/testify/assert"
 )
@@ -159,14 +161,65 @@ func TestSign(t *testing.T) {
 	b := newTestBackend()
 	defer b.Stop()
 
-	sig, err := b.Sign(testSigningData)
+	data := makeTestPrepare(t, b.address, common.HexToHash("0x1"))
+	sig, err := b.Sign(data)
 	assert.NoError(t, err)
 
 	// Check signature recover
-	hashData := crypto.Keccak256([]byte(testSigningData))
+	hashData := crypto.Keccak256(data)
 	pubkey, _ := crypto.Ecrecover(hashData, sig)
 	actualSigner := common.BytesToAddress(crypto.Keccak256(pubkey[1:])[12:])
 	assert.Equal(t, b.address, actualSigner)
+
+	// The same message can be signed again, but a conflicting one cannot
+	_, err = b.Sign(data)
+	assert.NoError(t, err)
+	_, err = b.Sign(makeTestPrepare(t, b.address, common.HexToHash("0x2")))
+	assert.ErrorIs(t, err, istanbulSigner.ErrConflict)
+
+	// The data other than the consensus messages are not signed
+	_, err = b.Sign(testSigningData)
+	assert.Error(t, err)
+}
+
+// makeTestPrepare returns a PREPARE message of the sequence 1 and the round 0 without the signature.
+func makeTestPrepare(t *testing.T, addr common.Address, digest common.Hash) []byte {
+	subject, err := rlp.EncodeToBytes(&istanbul.Subject{
+		View:     &istanbul.View{Sequence: big.NewInt(1), Round: big.NewInt(0)},
+		Digest:   digest,
+		PrevHash: common.Hash{},
+	})
+	assert.NoError(t, err)
+	msg, err := rlp.EncodeToBytes([]interface{}{common.Hash{}, uint64(1), subject, addr, []byte{}, []byte{}})
+	assert.NoError(t, err)
+	return msg
+}
+
+func TestNodeBinding(t *testing.T) {
+	// The node key is the consensus key
+	b := newTestBackend()
+	binding, err := b.NodeBinding()
+	assert.NoError(t, err)
+	assert.Nil(t, binding)
+
+	// The remote signer holds a consensus key other than the node key
+	nodeKey, _ := crypto.GenerateKey()
+	consensusKey, _ := crypto.GenerateKey()
+	b = New(&BackendOpts{
+		IstanbulConfig: istanbul.DefaultConfig,
+		PrivateKey:     nodeKey,
+		Signer:         istanbulSigner.NewLocalSigner(consensusKey, nil, nil),
+		NodeType:       common.CONSENSUSNODE,
+	}).(*backend)
+	binding, err = b.NodeBinding()
+	assert.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(consensusKey.PublicKey), binding.Address)
+
+	addr, err := b.VerifyNodeBinding(crypto.PubkeyToAddress(nodeKey.PublicKey), binding)
+	assert.NoError(t, err)
+	assert.Equal(t, binding.Address, addr)
+	_, err = b.VerifyNodeBinding(crypto.PubkeyToAddress(consensusKey.PublicKey), binding)
+	assert.ErrorIs(t, err, errInvalidNodeBinding)
 }
 
 func TestCheckSignature(t *testing.T) {
@@ -248,7 +301,7 @@ func TestCommit(t *testing.T) {
 		}()
 
 		engine.proposedBlockHash = expBlock.Hash()
-		assert.Equal(t, test.expectedErr, engine.Commit(expBlock, test.expectedSignature))
+		assert.Equal(t, test.expectedErr, engine.Commit(expBlock, test.expectedSignature, nil))
 
 		if test.expectedErr == nil {
 			// to avoid race condition is occurred by goroutine
@@ -0,0 +1,183 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+package backend
+
+import (
+	"math/big"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/consensus/istanbul"
+	istanbulSigner "github.com/kaiachain/kaia/consensus/istanbul/signer"
+	"github.com/kaiachain/kaia/crypto/bls"
+	"github.com/kaiachain/kaia/kaiax/valset"
+	"github.com/kaiachain/kaia/rlp"
+)
+
+// Since the BlsSeal hardfork, the committee members sign the COMMIT messages with
+// their KIP-113 BLS keys instead of the node keys. The committed seals are aggregated
+// into a single 96-byte signature and a signer bitmap over the sorted committee,
+// so that the header extra no longer grows by 65 bytes per committer and the
+// committers are verified with a single pairing check instead of N ecrecovers.
+// The KIP-113 registry verifies the proof-of-possession of every BLS public key,
+// which prevents rogue key attacks on the aggregated public key.
+
+func (sb *backend) isBlsSealEnabled(num *big.Int) bool {
+	return sb.chain != nil && sb.chain.Config().IsBlsSealForkEnabled(num)
+}
+
+// blsCommittedSealMsg returns the message signed by the BLS committed seal of the given proposal hash.
+func blsCommittedSealMsg(hash common.Hash) common.Hash {
+	return istanbulSigner.CommittedSealHash(hash)
+}
+
+// SignCommittedSeal implements istanbul.Backend.SignCommittedSeal
+func (sb *backend) SignCommittedSeal(proposal istanbul.Proposal) ([]byte, error) {
+	if !sb.isBlsSealEnabled(proposal.Number()) {
+		return sb.signer.SignCommittedSeal(proposal.Header())
+	}
+	return sb.signer.SignBlsCommittedSeal(proposal.Header())
+}
+
+// aggregateCommittedSeals writes the aggregate of the BLS committed seals into the extra-data
+// of the given header. The seals that are not signed by the committee are left out, and
+// errInvalidCommittedSeals is returned if the remaining seals do not reach the quorum.
+func (sb *backend) aggregateCommittedSeals(h *types.Header, seals [][]byte, committers []common.Address) error {
+	if len(seals) == 0 || len(seals) != len(committers) {
+		return errInvalidCommittedSeals
+	}
+
+	cState, err := sb.GetCommitteeStateByRound(h.Number.Uint64(), uint64(h.Round()))
+	if err != nil {
+		return err
+	}
+	committee := cState.Committee()
+
+	var (
+		msg     = blsCommittedSealMsg(h.Hash())
+		sigs    = make([][]byte, 0, len(seals))
+		indices = make([]int, 0, len(seals))
+		signed  = make(map[int]bool)
+	)
+	for i, seal := range seals {
+		idx := committee.IndexOf(committers[i])
+		if idx < 0 || signed[idx] {
+			continue
+		}
+		pub, err := sb.randaoModule.GetBlsPubkey(committers[i], h.Number)
+		if err != nil {
+			sb.logger.Warn("Failed to get the BLS public key of the committer", "committer", committers[i], "err", err)
+			continue
+		}
+		if ok, err := bls.VerifySignature(seal, msg, pub); err != nil || !ok {
+			sb.logger.Warn("Discard an invalid BLS committed seal", "committer", committers[i], "err", err)
+			continue
+		}
+		sigs = append(sigs, seal)
+		indices = append(indices, idx)
+		signed[idx] = true
+	}
+	if len(sigs) <= 2*cState.F() {
+		return errInvalidCommittedSeals
+	}
+
+	aggregated, err := bls.AggregateCompressedSignatures(sigs)
+	if err != nil {
+		return err
+	}
+	return writeAggregatedSeal(h, aggregated.Marshal(), types.NewSignerBitmap(committee.Len(), indices))
+}
+
+// verifyAggregatedSeal checks whether the aggregated seal is signed by more than 2F members of the committee.
+func (sb *backend) verifyAggregatedSeal(header *types.Header, extra *types.IstanbulExtra, cState *istanbul.RoundCommitteeState) error {
+	if len(extra.CommittedSeal) != 0 {
+		return errUnexpectedAggregatedSeal
+	}
+	if len(extra.AggregatedSeal) == 0 {
+		return errEmptyCommittedSeals
+	}
+	if len(extra.AggregatedSeal) != types.IstanbulExtraBlsSeal {
+		return errInvalidAggregatedSeal
+	}
+
+	committers, err := signerBitmapCommitters(cState.Committee(), extra.SignerBitmap)
+	if err != nil {
+		return err
+	}
+	// The number of committers should be larger than 2F
+	if len(committers) <= 2*cState.F() {
+		return errInvalidCommittedSeals
+	}
+
+	pubs := make([]bls.PublicKey, len(committers))
+	for i, committer := range committers {
+		if pubs[i], err = sb.randaoModule.GetBlsPubkey(committer, header.Number); err != nil {
+			return err
+		}
+	}
+	aggregatedPub, err := bls.AggregateMultiplePubkeys(pubs)
+	if err != nil {
+		return err
+	}
+
+	ok, err := bls.VerifySignature(extra.AggregatedSeal, blsCommittedSealMsg(header.Hash()), aggregatedPub)
+	if err != nil {
+		return err
+	} else if !ok {
+		return errInvalidAggregatedSeal
+	}
+	return nil
+}
+
+// signerBitmapCommitters returns the committee members marked in the signer bitmap.
+func signerBitmapCommitters(committee *valset.AddressSet, bitmap []byte) ([]common.Address, error) {
+	if len(bitmap) != (committee.Len()+7)/8 {
+		return nil, errInvalidAggregatedSeal
+	}
+	indices := types.SignerBitmapIndices(bitmap)
+	committers := make([]common.Address, len(indices))
+	for i, idx := range indices {
+		if idx >= committee.Len() {
+			return nil, errInvalidAggregatedSeal
+		}
+		committers[i] = committee.At(idx)
+	}
+	return committers, nil
+}
+
+// writeAggregatedSeal writes the extra-data field of a block header with the given aggregated seal and signer bitmap.
+func writeAggregatedSeal(h *types.Header, aggregatedSeal []byte, signerBitmap []byte) error {
+	if len(aggregatedSeal) != types.IstanbulExtraBlsSeal || len(signerBitmap) == 0 {
+		return errInvalidAggregatedSeal
+	}
+
+	istanbulExtra, err := types.ExtractIstanbulExtra(h)
+	if err != nil {
+		return err
+	}
+
+	istanbulExtra.CommittedSeal = [][]byte{}
+	istanbulExtra.AggregatedSeal = aggregatedSeal
+	istanbulExtra.SignerBitmap = signerBitmap
+
+	payload, err := rlp.EncodeToBytes(&istanbulExtra)
+	if err != nil {
+		return err
+	}
+
+	h.Extra = append(h.Extra[:types.IstanbulExtraVanity], payload...)
+	return nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+package backend
+
+import (
+	"testing"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/crypto/bls"
+	"github.com/kaiachain/kaia/kaiax/valset"
+	"github.com/kaiachain/kaia/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testBlsSealConfig() *params.ChainConfig {
+	config := testRandaoConfig.Copy()
+	config.KaiaCompatibleBlock = common.Big0
+	config.PragueCompatibleBlock = common.Big0
+	config.BlsSealCompatibleBlock = common.Big0
+	return config
+}
+
+// MakeBlsCommittedSeals returns the BLS committed seals of the given nodes.
+func (ctx *testContext) MakeBlsCommittedSeals(hash common.Hash, nodes []int) ([][]byte, []common.Address) {
+	msg := blsCommittedSealMsg(hash)
+	seals := make([][]byte, len(nodes))
+	committers := make([]common.Address, len(nodes))
+	for i, node := range nodes {
+		seals[i] = bls.Sign(ctx.nodeBlsKeys[node], msg[:]).Marshal()
+		committers[i] = ctx.nodeAddrs[node]
+	}
+	return seals, committers
+}
+
+func TestBlsSeal_SignCommittedSeal(t *testing.T) {
+	ctx := newTestContext(1, testBlsSealConfig(), nil)
+	chain, engine := ctx.chain, ctx.engine
+	defer ctx.Cleanup()
+
+	block := ctx.MakeBlock(chain.Genesis())
+	seal, err := engine.SignCommittedSeal(block)
+	require.NoError(t, err)
+	assert.Len(t, seal, types.IstanbulExtraBlsSeal)
+
+	ok, err := bls.VerifySignature(seal, blsCommittedSealMsg(block.Hash()), ctx.nodeBlsKeys[0].PublicKey())
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// Before the fork, the committed seal is signed with the node key.
+	legacyCtx := newTestContext(1, testRandaoConfig.Copy(), nil)
+	defer legacyCtx.Cleanup()
+
+	seal, err = legacyCtx.engine.SignCommittedSeal(legacyCtx.MakeBlock(legacyCtx.chain.Genesis()))
+	require.NoError(t, err)
+	assert.Len(t, seal, types.IstanbulExtraSeal)
+}
+
+func TestBlsSeal_Verify(t *testing.T) {
+	ctx := newTestContext(4, testBlsSealConfig(), nil)
+	chain, engine := ctx.chain, ctx.engine
+	defer ctx.Cleanup()
+
+	block, err := engine.updateBlock(ctx.MakeBlock(chain.Genesis()))
+	require.NoError(t, err)
+	cState, err := engine.GetCommitteeStateByRound(1, 0)
+	require.NoError(t, err)
+	require.Equal(t, 4, cState.Committee().Len())
+	quorum := 2*cState.F() + 1
+
+	all := []int{0, 1, 2, 3}
+	aggregated := func(nodes []int) *types.Header {
+		header := block.Header()
+		seals, committers := ctx.MakeBlsCommittedSeals(block.Hash(), nodes)
+		require.NoError(t, engine.aggregateCommittedSeals(header, seals, committers))
+		return header
+	}
+
+	// All committers
+	header := aggregated(all)
+	assert.NoError(t, engine.VerifyHeader(chain, header, false))
+
+	extra, err := types.ExtractIstanbulExtra(header)
+	require.NoError(t, err)
+	assert.Empty(t, extra.CommittedSeal)
+	assert.Len(t, extra.AggregatedSeal, types.IstanbulExtraBlsSeal)
+	assert.Equal(t, []int{0, 1, 2, 3}, types.SignerBitmapIndices(extra.SignerBitmap))
+
+	// The aggregated seal is smaller than the legacy committed seals.
+	legacy := block.Header()
+	require.NoError(t, writeCommittedSeals(legacy, ctx.MakeCommittedSeals(block.Hash())))
+	assert.Less(t, len(header.Extra), len(legacy.Extra))
+
+	// The legacy committed seals are not allowed after the fork.
+	assert.Equal(t, errUnexpectedAggregatedSeal, engine.VerifyHeader(chain, legacy, false))
+
+	// The committers are recovered from the signer bitmap.
+	_, err = chain.InsertChain(types.Blocks{block.WithSeal(aggregated(all[:quorum]))})
+	require.NoError(t, err)
+	info, err := engine.GetConsensusInfo(chain.GetBlockByNumber(1))
+	require.NoError(t, err)
+	assert.Equal(t, valset.NewAddressSet(ctx.nodeAddrs[:quorum]).List(), info.Committers)
+
+	// Quorum of committers
+	header = aggregated(all[:quorum])
+	assert.NoError(t, engine.VerifyHeader(chain, header, false))
+
+	// Not enough committers
+	header = block.Header()
+	seals, committers := ctx.MakeBlsCommittedSeals(block.Hash(), all[:quorum-1])
+	assert.Equal(t, errInvalidCommittedSeals, engine.aggregateCommittedSeals(header, seals, committers))
+
+	sig, err := bls.AggregateCompressedSignatures(seals)
+	require.NoError(t, err)
+	indices := make([]int, quorum-1)
+	for i := range indices {
+		indices[i] = cState.Committee().IndexOf(committers[i])
+	}
+	require.NoError(t, writeAggregatedSeal(header, sig.Marshal(), types.NewSignerBitmap(4, indices)))
+	assert.Equal(t, errInvalidCommittedSeals, engine.VerifyHeader(chain, header, false))
+
+	// The signer bitmap claims a committer who did not sign
+	header = aggregated(all[:quorum])
+	extra, err = types.ExtractIstanbulExtra(header)
+	require.NoError(t, err)
+	require.NoError(t, writeAggregatedSeal(header, extra.AggregatedSeal, types.NewSignerBitmap(4, all)))
+	assert.Equal(t, errInvalidAggregatedSeal, engine.VerifyHeader(chain, header, false))
+
+	// Invalid seals are left out of the aggregation
+	header = block.Header()
+	seals, committers = ctx.MakeBlsCommittedSeals(block.Hash(), all)
+	seals[0] = seals[1]
+	require.NoError(t, engine.aggregateCommittedSeals(header, seals, committers))
+	assert.NoError(t, engine.VerifyHeader(chain, header, false))
+	extra, err = types.ExtractIstanbulExtra(header)
+	require.NoError(t, err)
+	assert.Len(t, types.SignerBitmapIndices(extra.SignerBitmap), 3)
+}
+
+func TestBlsSeal_NotEnabled(t *testing.T) {
+	ctx := newTestContext(1, testRandaoConfig.Copy(), nil)
+	chain, engine := ctx.chain, ctx.engine
+	defer ctx.Cleanup()
+
+	block, err := engine.updateBlock(ctx.MakeBlock(chain.Genesis()))
+	require.NoError(t, err)
+
+	// The aggregated seal is not allowed before the fork.
+	header := block.Header()
+	seals, _ := ctx.MakeBlsCommittedSeals(block.Hash(), []int{0})
+	require.NoError(t, writeAggregatedSeal(header, seals[0], types.NewSignerBitmap(1, []int{0})))
+	assert.Equal(t, errUnexpectedAggregatedSeal, engine.VerifyHeader(chain, header, false))
+}
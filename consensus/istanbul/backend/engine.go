@@ -39,8 +39,8 @@ import (
 	"github.com/kaiachain/kaia/consensus"
 	"github.com/kaiachain/kaia/consensus/istanbul"
 	istanbulCore "github.com/kaiachain/kaia/consensus/istanbul/core"
+	istanbulSigner "github.com/kaiachain/kaia/consensus/istanbul/signer"
 	"github.com/kaiachain/kaia/consensus/misc"
-	"github.com/kaiachain/kaia/crypto/sha3"
 	"github.com/kaiachain/kaia/kaiax"
 	"github.com/kaiachain/kaia/kaiax/gov"
 	"github.com/kaiachain/kaia/kaiax/randao"
@@ -84,14 +84,16 @@ var (
 	errEmptyCommittedSeals = errors.New("zero committed seals")
 	// errMismatchTxhashes is returned if the TxHash in header is mismatch.
 	errMismatchTxhashes = errors.New("mismatch transactions hashes")
-	// errNoBlsKey is returned if the BLS secret key is not configured.
-	errNoBlsKey = errors.New("bls key not configured")
 	// errNoBlsPub is returned if the BLS public key is not found for the proposer.
 	errNoBlsPub = errors.New("bls pubkey not found for the proposer")
 	// errInvalidRandaoFields is returned if the Randao fields randomReveal or mixHash are invalid.
 	errInvalidRandaoFields = errors.New("invalid randao fields")
 	// errUnexpectedRandao is returned if the Randao fields randomReveal or mixHash are present when must not.
 	errUnexpectedRandao = errors.New("unexpected randao fields")
+	// errInvalidAggregatedSeal is returned if the aggregated BLS seal or the signer bitmap is invalid.
+	errInvalidAggregatedSeal = errors.New("invalid aggregated seal")
+	// errUnexpectedAggregatedSeal is returned if the committed seals are in the format of the other side of the BlsSeal fork.
+	errUnexpectedAggregatedSeal = errors.New("unexpected committed seal format")
 )
 
 var (
@@ -214,6 +216,15 @@ func (sb *backend) verifyHeader(chain consensus.ChainReader, header *types.Heade
 		return consensus.ErrInvalidBaseFee
 	}
 
+	// Header verify before/after blobTx fork
+	if chain.Config().IsBlobTxForkEnabled(header.Number) {
+		if err := misc.VerifyEIP4844Header(chain.Config(), parents[len(parents)-1], header); err != nil {
+			return err
+		}
+	} else if header.BlobGasUsed != nil || header.ExcessBlobGas != nil {
+		return consensus.ErrInvalidBlobGas
+	}
+
 	// Don't waste time checking blocks from the future
 	if header.Time.Cmp(big.NewInt(now().Add(allowedFutureBlockTime).Unix())) > 0 {
 		return consensus.ErrFutureBlock
@@ -358,6 +369,12 @@ func (sb *backend) verifyCommittedSeals(chain consensus.ChainReader, header *typ
 	if err != nil {
 		return err
 	}
+	// Since the BlsSeal hardfork, the committers are proven by the aggregated seal
+	if chain.Config().IsBlsSealForkEnabled(header.Number) {
+		return sb.verifyAggregatedSeal(header, extra, valSet)
+	} else if extra.HasAggregatedSeal() {
+		return errUnexpectedAggregatedSeal
+	}
 	// The length of Committed seals should be larger than 0
 	if len(extra.CommittedSeal) == 0 {
 		return errEmptyCommittedSeals
@@ -635,7 +652,7 @@ func (sb *backend) Seal(chain consensus.ChainReader, block *types.Block, stop <-
 func (sb *backend) updateBlock(block *types.Block) (*types.Block, error) {
 	header := block.Header()
 	// sign the hash
-	seal, err := sb.Sign(sigHash(header).Bytes())
+	seal, err := sb.signer.SignSeal(header)
 	if err != nil {
 		return nil, err
 	}
@@ -756,13 +773,22 @@ func (sb *backend) GetConsensusInfo(block *types.Block) (consensus.ConsensusInfo
 	if err != nil {
 		return consensus.ConsensusInfo{}, err
 	}
-	committers, err := RecoverCommittedSeals(extra, block.Hash())
+	round := block.Header().Round()
+
+	var committers []common.Address
+	if extra.HasAggregatedSeal() {
+		var cState *istanbul.RoundCommitteeState
+		if cState, err = sb.GetCommitteeStateByRound(blockNumber, uint64(round)); err != nil {
+			return consensus.ConsensusInfo{}, err
+		}
+		committers, err = signerBitmapCommitters(cState.Committee(), extra.SignerBitmap)
+	} else {
+		committers, err = RecoverCommittedSeals(extra, block.Hash())
+	}
 	if err != nil {
 		return consensus.ConsensusInfo{}, err
 	}
 
-	round := block.Header().Round()
-
 	// get the committee list of this block (blockNumber, round)
 	currentRoundCState, err := sb.GetCommitteeState(block.NumberU64())
 	if err != nil {
@@ -813,13 +839,8 @@ func (sb *backend) PurgeCache() {
 // Note, the method requires the extra data to be at least 65 bytes, otherwise it
 // panics. This is done to avoid accidentally using both forms (signature present
 // or not), which could be abused to produce different hashes for the same header.
-func sigHash(header *types.Header) (hash common.Hash) {
-	hasher := sha3.NewKeccak256()
-
-	// Clean seal is required for calculating proposer seal.
-	rlp.Encode(hasher, types.IstanbulFilteredHeader(header, false))
-	hasher.Sum(hash[:0])
-	return hash
+func sigHash(header *types.Header) common.Hash {
+	return istanbulSigner.SealHash(header)
 }
 
 // ecrecover extracts the Kaia account address from a signed header.
@@ -41,6 +41,7 @@ import (
 	"github.com/kaiachain/kaia/consensus"
 	"github.com/kaiachain/kaia/consensus/istanbul"
 	"github.com/kaiachain/kaia/consensus/istanbul/core"
+	istanbulSigner "github.com/kaiachain/kaia/consensus/istanbul/signer"
 	"github.com/kaiachain/kaia/crypto"
 	"github.com/kaiachain/kaia/datasync/downloader"
 	"github.com/kaiachain/kaia/kaiax/gov"
@@ -513,7 +514,8 @@ func TestVerifySeal(t *testing.T) {
 	signatureAddresses.Purge()
 
 	// unauthorized users but still can get correct signer address
-	engine.privateKey, _ = crypto.GenerateKey()
+	key, _ := crypto.GenerateKey()
+	engine.signer = istanbulSigner.NewLocalSigner(key, nil, nil)
 	err = engine.VerifySeal(chain, block.Header())
 	if err != nil {
 		t.Errorf("error mismatch: have %v, want nil", err)
@@ -29,6 +29,8 @@ import (
 	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/consensus"
 	"github.com/kaiachain/kaia/consensus/istanbul"
+	istanbulSigner "github.com/kaiachain/kaia/consensus/istanbul/signer"
+	"github.com/kaiachain/kaia/crypto"
 	"github.com/kaiachain/kaia/networks/p2p"
 )
 
@@ -41,12 +43,13 @@ var (
 	errDecodeFailed       = errors.New("fail to decode istanbul message")
 	errNoChainReader      = errors.New("sb.chain is nil! --mine option might be missing")
 	errInvalidPeerAddress = errors.New("invalid address")
+	errInvalidNodeBinding = errors.New("invalid node binding")
 
 	// TODO-Kaia-Istanbul: define Versions and Lengths with correct values.
 	IstanbulProtocol = consensus.Protocol{
 		Name:     "istanbul",
-		Versions: []uint{65, 64},
-		Lengths:  []uint64{23, 21},
+		Versions: []uint{66, 65, 64},
+		Lengths:  []uint64{26, 23, 21},
 	}
 )
 
@@ -116,6 +119,35 @@ func (sb *backend) ValidatePeerType(addr common.Address) error {
 	return errInvalidPeerAddress
 }
 
+// NodeBinding implements consensus.NodeBinder.NodeBinding
+func (sb *backend) NodeBinding() (*consensus.NodeBinding, error) {
+	if sb.p2pAddress == sb.address {
+		return nil, nil
+	}
+	sb.bindingMu.Lock()
+	defer sb.bindingMu.Unlock()
+	if sb.binding == nil {
+		sig, err := sb.signer.SignNode(sb.p2pAddress)
+		if err != nil {
+			return nil, err
+		}
+		sb.binding = &consensus.NodeBinding{Address: sb.address, Signature: sig}
+	}
+	return sb.binding, nil
+}
+
+// VerifyNodeBinding implements consensus.NodeBinder.VerifyNodeBinding
+func (sb *backend) VerifyNodeBinding(node common.Address, binding *consensus.NodeBinding) (common.Address, error) {
+	pubKey, err := crypto.SigToPub(istanbulSigner.NodeHash(node).Bytes(), binding.Signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if crypto.PubkeyToAddress(*pubKey) != binding.Address {
+		return common.Address{}, errInvalidNodeBinding
+	}
+	return binding.Address, nil
+}
+
 // SetBroadcaster implements consensus.Handler.SetBroadcaster
 func (sb *backend) SetBroadcaster(broadcaster consensus.Broadcaster, nodetype common.ConnType) {
 	sb.broadcaster = broadcaster
@@ -23,6 +23,7 @@ import (
 	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/common/hexutil"
 	"github.com/kaiachain/kaia/consensus"
+	istanbulSigner "github.com/kaiachain/kaia/consensus/istanbul/signer"
 	"github.com/kaiachain/kaia/crypto"
 	"github.com/kaiachain/kaia/crypto/bls"
 	"github.com/kaiachain/kaia/params"
@@ -31,19 +32,16 @@ import (
 // Calculate KIP-114 Randao header fields
 // https://github.com/klaytn/kips/blob/kip114/KIPs/kip-114.md
 func (sb *backend) CalcRandao(number *big.Int, prevMixHash []byte) ([]byte, []byte, error) {
-	if sb.blsSecretKey == nil {
-		return nil, nil, errNoBlsKey
-	}
 	if len(prevMixHash) != 32 {
 		logger.Error("invalid prevMixHash", "number", number.Uint64(), "prevMixHash", hexutil.Encode(prevMixHash))
 		return nil, nil, errInvalidRandaoFields
 	}
 
-	// block_num_to_bytes() = num.to_bytes(32, byteorder="big")
-	msg := calcRandaoMsg(number)
-
 	// calc_random_reveal() = sign(privateKey, headerNumber)
-	randomReveal := bls.Sign(sb.blsSecretKey, msg[:]).Marshal()
+	randomReveal, err := sb.signer.SignRandao(number)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	// calc_mix_hash() = xor(prevMixHash, keccak256(randomReveal))
 	mixHash := calcMixHash(randomReveal, prevMixHash)
@@ -89,7 +87,7 @@ func (sb *backend) VerifyRandao(chain consensus.ChainReader, header *types.Heade
 
 // block_num_to_bytes() = num.to_bytes(32, byteorder="big")
 func calcRandaoMsg(number *big.Int) common.Hash {
-	return common.BytesToHash(number.Bytes())
+	return istanbulSigner.RandaoMsg(number)
 }
 
 // calc_mix_hash() = xor(prevMixHash, keccak256(randomReveal))
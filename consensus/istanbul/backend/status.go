@@ -0,0 +1,182 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+package backend
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/kaiachain/kaia/common"
+	gov_impl "github.com/kaiachain/kaia/kaiax/gov/impl"
+	"github.com/kaiachain/kaia/kaiax/valset"
+)
+
+// ValidatorStatus is the readiness report of the node as a validator of the next block.
+type ValidatorStatus struct {
+	Address     common.Address `json:"address"`     // Address of the consensus signing key
+	NodeAddress common.Address `json:"nodeAddress"` // Address used by the header governance to cast votes
+	Number      uint64         `json:"number"`      // Number of the next block
+
+	InCouncil     bool    `json:"inCouncil"`
+	Demoted       bool    `json:"demoted"`
+	InCommittee   bool    `json:"inCommittee"`   // Whether the node is in the committee of the round 0
+	ProposerRound *uint64 `json:"proposerRound"` // The first round the node proposes. Null if it does not propose in the next rounds of a committee size
+
+	BlsKeyRegistered *bool `json:"blsKeyRegistered"` // Whether the KIP-113 registry has the BLS key of the node. Null before the Randao fork
+	BlsKeyMatched    *bool `json:"blsKeyMatched"`    // Whether the registered BLS key is the one the node signs with. Null if not registered
+
+	StakingAmount uint64 `json:"stakingAmount"` // Staking amount of the node in KAIA
+	MinimumStake  uint64 `json:"minimumStake"`  // reward.minimumstake in KAIA
+
+	Ready    bool     `json:"ready"`
+	Problems []string `json:"problems"`
+}
+
+var (
+	errNotReady         = errors.New("the node is not ready to validate")
+	errNoValsetModule   = errors.New("the validator set is not available")
+	errNoStakingModule  = errors.New("the staking information is not available")
+	errNotConsensusNode = errors.New("the node is not a consensus node")
+)
+
+// nodeAddress returns the address the header governance uses for the node.
+func (sb *backend) nodeAddress() common.Address {
+	if m, ok := sb.govModule.(*gov_impl.GovModule); ok && m.Hgm != nil {
+		return m.Hgm.NodeAddress()
+	}
+	return sb.address
+}
+
+// validatorStatus reports whether the node can take part in the consensus of the next block.
+func (sb *backend) validatorStatus() (*ValidatorStatus, error) {
+	if sb.chain == nil || sb.valsetModule == nil || sb.govModule == nil {
+		return nil, errNoValsetModule
+	}
+	var (
+		num    = sb.chain.CurrentHeader().Number.Uint64() + 1
+		bigNum = new(big.Int).SetUint64(num)
+		pset   = sb.govModule.GetParamSet(num)
+		status = &ValidatorStatus{
+			Address:      sb.address,
+			NodeAddress:  sb.nodeAddress(),
+			Number:       num,
+			MinimumStake: pset.MinimumStake.Uint64(),
+			Problems:     []string{},
+		}
+	)
+	if status.NodeAddress != sb.address {
+		status.Problems = append(status.Problems, "the governance node address differs from the consensus address")
+	}
+
+	council, err := sb.valsetModule.GetCouncil(num)
+	if err != nil {
+		return nil, err
+	}
+	demoted, err := sb.valsetModule.GetDemotedValidators(num)
+	if err != nil {
+		return nil, err
+	}
+	status.InCouncil = valset.NewAddressSet(council).Contains(sb.address)
+	status.Demoted = valset.NewAddressSet(demoted).Contains(sb.address)
+	if !status.InCouncil {
+		status.Problems = append(status.Problems, "not in the council")
+	}
+	if status.Demoted {
+		// The validators are only demoted for the staking amount below the minimum stake
+		status.Problems = append(status.Problems, "demoted: the staking amount is below the minimum stake")
+	}
+
+	if status.InCouncil && !status.Demoted {
+		committee, err := sb.valsetModule.GetCommittee(num, 0)
+		if err != nil {
+			return nil, err
+		}
+		status.InCommittee = valset.NewAddressSet(committee).Contains(sb.address)
+
+		for round := uint64(0); round < pset.CommitteeSize; round++ {
+			proposer, err := sb.valsetModule.GetProposer(num, round)
+			if err != nil {
+				return nil, err
+			}
+			if proposer == sb.address {
+				r := round
+				status.ProposerRound = &r
+				break
+			}
+		}
+	}
+
+	if sb.chain.Config().IsRandaoForkEnabled(bigNum) {
+		registered, matched := false, false
+		if pub, err := sb.randaoModule.GetBlsPubkey(sb.address, bigNum); err == nil {
+			registered = true
+			if myPub, err := sb.signer.BlsPublicKey(); err == nil {
+				matched = bytes.Equal(pub.Marshal(), myPub.Marshal())
+			}
+			status.BlsKeyMatched = &matched
+		}
+		status.BlsKeyRegistered = &registered
+		if !registered {
+			status.Problems = append(status.Problems, "the BLS public key is not registered")
+		} else if !matched {
+			status.Problems = append(status.Problems, "the registered BLS public key does not match the node's BLS key")
+		}
+	}
+
+	if sb.stakingModule == nil {
+		return nil, errNoStakingModule
+	}
+	sInfo, err := sb.stakingModule.GetStakingInfo(num)
+	if err != nil {
+		return nil, err
+	}
+	for _, cn := range sInfo.ConsolidatedNodes() {
+		for _, nodeId := range cn.NodeIds {
+			if nodeId == sb.address {
+				status.StakingAmount = cn.StakingAmount
+			}
+		}
+	}
+	status.Ready = len(status.Problems) == 0
+	return status, nil
+}
+
+// ReadyCheck returns an error if the consensus node is not ready to validate the next block.
+// The other types of nodes are always ready. The result is computed once per head block.
+func (sb *backend) ReadyCheck() error {
+	if sb.nodetype != common.CONSENSUSNODE {
+		return nil
+	}
+	if sb.chain == nil {
+		return errNoValsetModule
+	}
+	head := sb.chain.CurrentHeader().Hash()
+
+	sb.readyMu.Lock()
+	defer sb.readyMu.Unlock()
+	if sb.readyBlock == head {
+		return sb.readyErr
+	}
+	status, err := sb.validatorStatus()
+	if err == nil && !status.Ready {
+		err = fmt.Errorf("%w: %s", errNotReady, strings.Join(status.Problems, ", "))
+	}
+	sb.readyBlock, sb.readyErr = head, err
+	return err
+}
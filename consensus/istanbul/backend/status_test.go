@@ -0,0 +1,104 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+package backend
+
+import (
+	"testing"
+
+	"github.com/kaiachain/kaia/common"
+	istanbulSigner "github.com/kaiachain/kaia/consensus/istanbul/signer"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/crypto/bls"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatorStatus(t *testing.T) {
+	ctx := newTestContext(4, testRandaoConfig.Copy(), nil)
+	engine := ctx.engine
+	defer ctx.Cleanup()
+	api := &API{chain: ctx.chain, istanbul: engine}
+
+	status, err := api.MyStatus()
+	require.NoError(t, err)
+	assert.Equal(t, ctx.nodeAddrs[0], status.Address)
+	assert.Equal(t, ctx.nodeAddrs[0], status.NodeAddress)
+	assert.Equal(t, uint64(1), status.Number)
+	assert.True(t, status.InCouncil)
+	assert.False(t, status.Demoted)
+	assert.True(t, status.InCommittee)
+	require.NotNil(t, status.ProposerRound)
+	assert.Less(t, *status.ProposerRound, uint64(4))
+	proposer, err := engine.valsetModule.GetProposer(1, *status.ProposerRound)
+	require.NoError(t, err)
+	assert.Equal(t, ctx.nodeAddrs[0], proposer)
+	require.NotNil(t, status.BlsKeyRegistered)
+	assert.True(t, *status.BlsKeyRegistered)
+	require.NotNil(t, status.BlsKeyMatched)
+	assert.True(t, *status.BlsKeyMatched)
+	assert.Equal(t, uint64(2000000), status.MinimumStake)
+	assert.True(t, status.Ready)
+	assert.Empty(t, status.Problems)
+	assert.NoError(t, engine.ReadyCheck())
+
+	// The node signs with a BLS key different from the registered one
+	blsKey, _ := bls.RandKey()
+	engine.signer = istanbulSigner.NewLocalSigner(ctx.nodeKeys[0], blsKey, nil)
+	status, err = api.MyStatus()
+	require.NoError(t, err)
+	assert.True(t, *status.BlsKeyRegistered)
+	assert.False(t, *status.BlsKeyMatched)
+	assert.False(t, status.Ready)
+	// The ready check is cached for the head block
+	assert.NoError(t, engine.ReadyCheck())
+	engine.readyBlock = common.Hash{}
+	assert.ErrorIs(t, engine.ReadyCheck(), errNotReady)
+
+	// The node is not a validator
+	key, _ := crypto.GenerateKey()
+	engine.address = crypto.PubkeyToAddress(key.PublicKey)
+	status, err = api.MyStatus()
+	require.NoError(t, err)
+	assert.False(t, status.InCouncil)
+	assert.False(t, status.InCommittee)
+	assert.Nil(t, status.ProposerRound)
+	assert.False(t, *status.BlsKeyRegistered)
+	assert.Nil(t, status.BlsKeyMatched)
+	assert.Len(t, status.Problems, 3)
+	engine.readyBlock = common.Hash{}
+	assert.ErrorIs(t, engine.ReadyCheck(), errNotReady)
+
+	// The other types of nodes have no status, and are always ready
+	engine.nodetype = common.ENDPOINTNODE
+	_, err = api.MyStatus()
+	assert.Equal(t, errNotConsensusNode, err)
+	assert.NoError(t, engine.ReadyCheck())
+}
+
+func TestValidatorStatus_BeforeRandao(t *testing.T) {
+	ctx := newTestContext(1, testKoreConfig.Copy(), nil)
+	defer ctx.Cleanup()
+	api := &API{chain: ctx.chain, istanbul: ctx.engine}
+
+	status, err := api.MyStatus()
+	require.NoError(t, err)
+	assert.Nil(t, status.BlsKeyRegistered)
+	assert.Nil(t, status.BlsKeyMatched)
+	assert.True(t, status.InCommittee)
+	require.NotNil(t, status.ProposerRound)
+	assert.Equal(t, uint64(0), *status.ProposerRound)
+	assert.True(t, status.Ready)
+}
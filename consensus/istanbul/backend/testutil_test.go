@@ -236,9 +236,10 @@ func newTestContext(numNodes int, config *params.ChainConfig, overrides *testOve
 	}
 
 	return &testContext{
-		config:    config,
-		nodeKeys:  nodeKeys,
-		nodeAddrs: nodeAddrs,
+		config:      config,
+		nodeKeys:    nodeKeys,
+		nodeAddrs:   nodeAddrs,
+		nodeBlsKeys: nodeBlsKeys,
 
 		chain:  chain,
 		engine: engine,
@@ -78,8 +78,8 @@ func (c *core) handleCommit(msg *message, src common.Address) error {
 		return errInvalidMessage
 	}
 
-	if vrank != nil {
-		vrank.AddCommit(commit, src)
+	if c.vrank != nil {
+		c.vrank.AddCommit(commit, src)
 	}
 
 	// logger.Error("receive handle commit","num", commit.View.Sequence)
@@ -111,7 +111,9 @@ func (c *core) handleCommit(msg *message, src common.Address) error {
 			c.sendCommit()
 		} else if c.current.GetPrepareOrCommitSize() >= c.currentCommittee.RequiredMessageCount() {
 			logger.Info("received a quorum of the messages and change state to prepared", "msgType", msgCommit, "valSet", c.currentCommittee.Qualified().Len())
-			c.current.LockHash()
+			if err := c.lockHash(); err != nil {
+				return err
+			}
 			c.setState(StatePrepared)
 			c.sendCommit()
 		}
@@ -124,7 +126,8 @@ func (c *core) handleCommit(msg *message, src common.Address) error {
 	//logger.Error("### consensus check","len(commits)",c.current.Commits.Size(),"f(2/3)",2*c.valSet.F(),"state",c.state.Cmp(StateCommitted))
 	if c.state.Cmp(StateCommitted) < 0 && c.current.Commits.Size() >= c.currentCommittee.RequiredMessageCount() {
 		// Still need to call LockHash here since state can skip Prepared state and jump directly to the Committed state.
-		c.current.LockHash()
+		// The proposal is committed even if the lock fails to be written, since a quorum has already committed it.
+		c.lockHash()
 		c.commit()
 	}
 
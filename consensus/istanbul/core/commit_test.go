@@ -45,13 +45,14 @@ func TestCore_sendCommit(t *testing.T) {
 			mockBackend, mockCtrl := newMockBackend(t, validatorAddrs)
 			if tc.valid {
 				mockBackend.EXPECT().Sign(gomock.Any()).Return(nil, nil).AnyTimes()
+				mockBackend.EXPECT().SignCommittedSeal(gomock.Any()).Return(nil, nil).AnyTimes()
 				mockBackend.EXPECT().Broadcast(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 			}
 
 			istConfig := istanbul.DefaultConfig.Copy()
 			istConfig.ProposerPolicy = istanbul.WeightedRandom
 
-			istCore := New(mockBackend, istConfig).(*core)
+			istCore := New(mockBackend, istConfig, nil).(*core)
 			assert.NoError(t, istCore.Start())
 
 			lastProposal, _ := mockBackend.LastProposal()
@@ -30,21 +30,29 @@ import (
 	"sync/atomic"
 	"time"
 
-	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/common/prque"
 	"github.com/kaiachain/kaia/consensus/istanbul"
 	"github.com/kaiachain/kaia/event"
 	"github.com/kaiachain/kaia/log"
+	"github.com/kaiachain/kaia/storage/database"
 	"github.com/rcrowley/go-metrics"
 )
 
 var logger = log.NewModuleLogger(log.ConsensusIstanbulCore)
 
-// New creates an Istanbul consensus core
-func New(backend istanbul.Backend, config *istanbul.Config) Engine {
+// defaultTimeoutBackoff is the unit of the extra round change timeout of the round r > 0,
+// which is defaultTimeoutBackoff * 2^r, if the timeout backoff is not governed.
+const defaultTimeoutBackoff = time.Second
+
+// New creates an Istanbul consensus core. The consensus WAL, the equivocation evidence and the
+// consensus timelines are stored in db. If db is nil, they are not persisted.
+func New(backend istanbul.Backend, config *istanbul.Config, db database.Database) Engine {
 	c := &core{
 		config:             config,
+		clock:              systemClock{},
+		votes:              make(map[voteKey]*vote),
+		reports:            make(map[reportKey]int),
 		address:            backend.Address(),
 		state:              StateAcceptRequest,
 		handlerWg:          new(sync.WaitGroup),
@@ -63,8 +71,14 @@ func New(backend istanbul.Backend, config *istanbul.Config) Engine {
 		councilSizeGauge:   metrics.NewRegisteredGauge("consensus/istanbul/core/councilSize", nil),
 		committeeSizeGauge: metrics.NewRegisteredGauge("consensus/istanbul/core/committeeSize", nil),
 		hashLockGauge:      metrics.NewRegisteredGauge("consensus/istanbul/core/hashLock", nil),
+		equivocationMeter:  metrics.NewRegisteredMeter("consensus/istanbul/core/equivocation", nil),
 	}
 	c.validateFn = c.checkValidatorSignature
+	if db != nil {
+		c.wal = NewWAL(db)
+		c.evidence = NewEvidenceStore(db)
+		c.timelines = NewTimelineStore(db)
+	}
 	return c
 }
 
@@ -80,11 +94,23 @@ type core struct {
 	events                *event.TypeMuxSubscription
 	finalCommittedSub     *event.TypeMuxSubscription
 	timeoutSub            *event.TypeMuxSubscription
-	futurePreprepareTimer *time.Timer
+	futurePreprepareTimer Timer
+	clock                 Clock
+	dispatch              func(interface{}) // If set, the internal events are dispatched by the caller instead of the event mux
 
 	waitingForRoundChange bool
 	validateFn            func([]byte, []byte) (common.Address, error)
 
+	wal       *WAL
+	replaying bool // whether the received messages are being replayed from the WAL
+
+	evidence *EvidenceStore
+	votes    map[voteKey]*vote // PREPARE and COMMIT messages seen to detect equivocations
+	reports  map[reportKey]int // Number of evidence stored for a validator in a sequence
+
+	vrank     *Vrank // Measures the arrival times of the messages of the current view
+	timelines *TimelineStore
+
 	backlogs   map[common.Address]*prque.Prque
 	backlogsMu *sync.Mutex
 
@@ -93,7 +119,7 @@ type core struct {
 	handlerWg        *sync.WaitGroup
 
 	roundChangeSet    *roundChangeSet
-	roundChangeTimer  atomic.Value //*time.Timer
+	roundChangeTimer  atomic.Value // Timer
 	pendingRequests   *prque.Prque
 	pendingRequestsMu *sync.Mutex
 
@@ -111,6 +137,8 @@ type core struct {
 
 	councilSizeGauge   metrics.Gauge
 	committeeSizeGauge metrics.Gauge
+	// the meter to record the detected equivocations
+	equivocationMeter metrics.Meter
 }
 
 func (c *core) finalizeMessage(msg *message) ([]byte, error) {
@@ -122,8 +150,7 @@ func (c *core) finalizeMessage(msg *message) ([]byte, error) {
 	msg.CommittedSeal = []byte{}
 	// Assign the CommittedSeal if it's a COMMIT message and proposal is not nil
 	if msg.Code == msgCommit && c.current.Proposal() != nil {
-		seal := PrepareCommittedSeal(c.current.Proposal().Hash())
-		msg.CommittedSeal, err = c.backend.Sign(seal)
+		msg.CommittedSeal, err = c.backend.SignCommittedSeal(c.current.Proposal())
 		if err != nil {
 			return nil, err
 		}
@@ -157,6 +184,12 @@ func (c *core) broadcast(msg *message) {
 		return
 	}
 
+	// Write the message ahead, not to send a conflicting one after a restart
+	if err = c.writeSentWAL(msg, payload); err != nil {
+		logger.Error("Failed to write message ahead", "msg", msg, "err", err)
+		return
+	}
+
 	// Broadcast payload
 	if err = c.backend.Broadcast(msg.Hash, payload); err != nil {
 		logger.Error("Failed to broadcast message", "msg", msg, "err", err)
@@ -185,24 +218,26 @@ func (c *core) commit() {
 	proposal := c.current.Proposal()
 	if proposal != nil {
 		committedSeals := make([][]byte, c.current.Commits.Size())
+		committers := make([]common.Address, c.current.Commits.Size())
 		for i, v := range c.current.Commits.Values() {
-			committedSeals[i] = make([]byte, types.IstanbulExtraSeal)
-			copy(committedSeals[i][:], v.CommittedSeal[:])
+			committedSeals[i] = common.CopyBytes(v.CommittedSeal)
+			committers[i] = v.Address
 		}
 
-		if err := c.backend.Commit(proposal, committedSeals); err != nil {
-			c.current.UnlockHash() // Unlock block when insertion fails
+		if err := c.backend.Commit(proposal, committedSeals, committers); err != nil {
+			c.unlockHash() // Unlock block when insertion fails
 			c.sendNextRoundChange("commit failure")
 			return
 		}
 
-		if vrank != nil {
-			vrank.HandleCommitted(proposal.Number())
+		if c.vrank != nil {
+			c.vrank.HandleCommitted(proposal.Number())
+			c.writeTimeline(c.vrank.Timeline())
 		}
 	} else {
 		// TODO-Kaia never happen, but if proposal is nil, mining is not working.
 		logger.Error("istanbul.core current.Proposal is NULL")
-		c.current.UnlockHash() // Unlock block when insertion fails
+		c.unlockHash() // Unlock block when insertion fails
 		c.sendNextRoundChange("commit failure. proposal is nil")
 		return
 	}
@@ -292,6 +327,11 @@ func (c *core) startNewRound(round *big.Int) {
 	c.roundChangeSet = newRoundChangeSet(c.currentCommittee.ValSet())
 	// New snapshot for new round
 	c.updateRoundState(newView, c.currentCommittee, roundChange)
+	if !roundChange {
+		c.pruneWAL(newView.Sequence.Uint64())
+		c.pruneVotes(newView.Sequence.Uint64())
+		c.pruneEvidence(newView.Sequence.Uint64())
+	}
 	// Calculate new proposer
 	c.waitingForRoundChange = false
 	c.setState(StateAcceptRequest)
@@ -373,25 +413,38 @@ func (c *core) stopTimer() {
 	c.stopFuturePreprepareTimer()
 
 	if c.roundChangeTimer.Load() != nil {
-		c.roundChangeTimer.Load().(*time.Timer).Stop()
+		c.roundChangeTimer.Load().(Timer).Stop()
+	}
+}
+
+// roundChangeTimeout returns the round change timeout of the given round of the given block height.
+// The governed base timeout and backoff take precedence over the node-local ones.
+func (c *core) roundChangeTimeout(num, round uint64) time.Duration {
+	baseTimeout, timeoutBackoff := c.backend.GetRoundTimeouts(num)
+	if baseTimeout == 0 {
+		// TODO-Kaia-Istanbul: Replace &istanbul.DefaultConfig.Timeout to c.config.Timeout
+		baseTimeout = time.Duration(atomic.LoadUint64(&istanbul.DefaultConfig.Timeout)) * time.Millisecond
+	}
+	if timeoutBackoff == 0 {
+		timeoutBackoff = defaultTimeoutBackoff
 	}
+	if round == 0 {
+		return baseTimeout
+	}
+	return baseTimeout + time.Duration(math.Pow(2, float64(round)))*timeoutBackoff
 }
 
 func (c *core) newRoundChangeTimer() {
 	c.stopTimer()
 
-	// TODO-Kaia-Istanbul: Replace &istanbul.DefaultConfig.Timeout to c.config.Timeout
 	// set timeout based on the round number
-	timeout := time.Duration(atomic.LoadUint64(&istanbul.DefaultConfig.Timeout)) * time.Millisecond
 	round := c.current.Round().Uint64()
-	if round > 0 {
-		timeout += time.Duration(math.Pow(2, float64(round))) * time.Second
-	}
+	timeout := c.roundChangeTimeout(c.current.Sequence().Uint64(), round)
 
 	current := c.current
 	proposer := c.currentCommittee.Proposer()
 
-	c.roundChangeTimer.Store(time.AfterFunc(timeout, func() {
+	c.roundChangeTimer.Store(c.clock.AfterFunc(timeout, func() {
 		var loc, proposerStr string
 
 		if round == 0 {
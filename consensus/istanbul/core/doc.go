@@ -32,6 +32,7 @@ Core package is composed of following files
   - `core.go`: Defines core struct and its methods related to timer setup, start new round and round state update
   - `errors.go`: Defines consensus message related errors
   - `events.go`: Defines backlog event and timeout event
+  - `evidence.go`: Detects the equivocations of the validators and stores their evidence
   - `final_committed.go`: Start a new round when a final committed proposal is stored
   - `handler.go`: Implements core.Engine.Start and Stop. Provides event and message hendlers
   - `message_set.go`: Defines messageSet struct which has a validator set and messages from other nodes
@@ -40,6 +41,10 @@ Core package is composed of following files
   - `request.go`: Implements core methods which handle, check, store and process preprepare messages
   - `roundchange.go`: Implement core methods receiving and handling roundchange messages
   - `roundstate.go`: Defines roundState struct which has messages of each phase for a round
+  - `sync_engine.go`: Defines SyncEngine, the core driven by the caller with a replaceable clock, which is used by the simulations
+  - `timeline.go`: Records the consensus timelines measured by Vrank in a bounded ring
   - `types.go`: Defines Engine interface and message, State type
+  - `vrank.go`: Measures the arrival times of the consensus messages of the committee
+  - `wal.go`: Implements the write-ahead log of the consensus messages and the lock state
 */
 package core
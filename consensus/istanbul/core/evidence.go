@@ -0,0 +1,265 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/consensus/istanbul"
+	"github.com/kaiachain/kaia/rlp"
+	"github.com/kaiachain/kaia/storage/database"
+)
+
+var evidencePrefix = []byte("istanbul-evidence-")
+
+const (
+	// evidenceRoundWindow is the number of rounds ahead of the current one whose
+	// messages are tracked. A validator cannot vote far ahead of the others.
+	evidenceRoundWindow = 10
+
+	// maxEvidencePerValidator is the maximum number of evidence stored for
+	// a validator in a sequence. One is enough to prove the misbehavior.
+	maxEvidencePerValidator = 4
+
+	// evidenceRetainedHeights is the number of heights whose evidence is kept.
+	evidenceRetainedHeights = 1209600 // about two weeks of one-second blocks
+
+	// evidencePruneInterval is the number of heights between two prunings of the evidence.
+	evidencePruneInterval = 1024
+)
+
+// Evidence is the proof that a validator signed two conflicting PREPARE or COMMIT
+// messages for the same view. Both signed messages are kept as received, so that
+// anyone can verify the evidence without trusting the reporting node.
+type Evidence struct {
+	Validator common.Address
+	Code      uint64
+	Sequence  uint64
+	Round     uint64
+	Time      uint64 // Unix time in nanoseconds when the equivocation was detected
+	First     []byte // Payload of the message received first
+	Second    []byte // Payload of the conflicting message
+}
+
+// Subjects verifies the signatures of both messages and returns their subjects.
+func (e *Evidence) Subjects() (*istanbul.Subject, *istanbul.Subject, error) {
+	first, err := e.subject(e.First)
+	if err != nil {
+		return nil, nil, err
+	}
+	second, err := e.subject(e.Second)
+	if err != nil {
+		return nil, nil, err
+	}
+	return first, second, nil
+}
+
+func (e *Evidence) subject(payload []byte) (*istanbul.Subject, error) {
+	msg := new(message)
+	if err := msg.FromPayload(payload, istanbul.GetSignatureAddress); err != nil {
+		return nil, err
+	}
+	if msg.Address != e.Validator || msg.Code != e.Code {
+		return nil, errInvalidSigner
+	}
+	var subject *istanbul.Subject
+	if err := msg.Decode(&subject); err != nil {
+		return nil, err
+	}
+	return subject, nil
+}
+
+// EvidenceStore keeps the equivocation evidence in a database. The evidence is
+// kept much longer than the WAL, for evidenceRetainedHeights.
+type EvidenceStore struct {
+	db database.Database
+}
+
+// NewEvidenceStore returns an evidence store in the given database.
+func NewEvidenceStore(db database.Database) *EvidenceStore {
+	return &EvidenceStore{db: db}
+}
+
+func evidenceKey(e *Evidence) []byte {
+	key := binary.BigEndian.AppendUint64(append([]byte{}, evidencePrefix...), e.Sequence)
+	key = binary.BigEndian.AppendUint64(key, e.Round)
+	key = binary.BigEndian.AppendUint64(key, e.Code)
+	return append(key, e.Validator.Bytes()...)
+}
+
+// Write stores the evidence. An evidence of the same validator and message in the same view is overwritten.
+func (s *EvidenceStore) Write(e *Evidence) error {
+	enc, err := rlp.EncodeToBytes(e)
+	if err != nil {
+		return err
+	}
+	return s.db.Put(evidenceKey(e), enc)
+}
+
+// Evidences returns the evidence of the sequences in [from, to], ordered by the view.
+func (s *EvidenceStore) Evidences(from, to uint64) ([]*Evidence, error) {
+	start := binary.BigEndian.AppendUint64(nil, from)
+	it := s.db.NewIterator(evidencePrefix, start)
+	defer it.Release()
+
+	var evidences []*Evidence
+	for it.Next() {
+		if binary.BigEndian.Uint64(it.Key()[len(evidencePrefix):]) > to {
+			break
+		}
+		e := new(Evidence)
+		if err := rlp.DecodeBytes(it.Value(), e); err != nil {
+			return nil, err
+		}
+		evidences = append(evidences, e)
+	}
+	return evidences, it.Error()
+}
+
+// Prune deletes the evidence of the sequences lower than the given one.
+func (s *EvidenceStore) Prune(sequence uint64) error {
+	it := s.db.NewIterator(evidencePrefix, nil)
+	defer it.Release()
+
+	batch := s.db.NewBatch()
+	defer batch.Release()
+	for it.Next() {
+		key := it.Key()
+		if binary.BigEndian.Uint64(key[len(evidencePrefix):]) >= sequence {
+			break
+		}
+		if err := batch.Delete(common.CopyBytes(key)); err != nil {
+			return err
+		}
+		if batch.ValueSize() > database.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// vote is a PREPARE or COMMIT message seen from a validator in a view.
+type vote struct {
+	digest   common.Hash
+	payload  []byte
+	reported bool
+}
+
+type voteKey struct {
+	code     uint64
+	sequence uint64
+	round    uint64
+	address  common.Address
+}
+
+// reportKey identifies the equivocations of a validator in a sequence.
+type reportKey struct {
+	sequence uint64
+	address  common.Address
+}
+
+// checkEquivocation records the authenticated PREPARE and COMMIT messages and reports
+// the validators who signed different digests for the same view. The messages of the
+// sequences or the rounds far from the current one are not tracked to bound the memory,
+// and at most maxEvidencePerValidator evidence is stored for a validator in a sequence.
+func (c *core) checkEquivocation(msg *message, payload []byte) {
+	if msg.Code != msgPrepare && msg.Code != msgCommit {
+		return
+	}
+	var subject *istanbul.Subject
+	if err := msg.Decode(&subject); err != nil || subject.View == nil {
+		return
+	}
+	sequence, current := subject.View.Sequence.Uint64(), c.current.Sequence().Uint64()
+	if sequence+1 < current || sequence > current+1 {
+		return
+	}
+	// The rounds of the other sequences are bounded from round 0
+	round, maxRound := subject.View.Round.Uint64(), uint64(evidenceRoundWindow)
+	if sequence == current {
+		maxRound += c.current.Round().Uint64()
+	}
+	if round > maxRound {
+		return
+	}
+
+	key := voteKey{msg.Code, sequence, round, msg.Address}
+	seen, ok := c.votes[key]
+	if !ok {
+		c.votes[key] = &vote{digest: subject.Digest, payload: common.CopyBytes(payload)}
+		return
+	}
+	if seen.digest == subject.Digest || seen.reported {
+		return
+	}
+	seen.reported = true
+
+	c.equivocationMeter.Mark(1)
+	c.logger.Error("Detected an equivocation of a validator", "validator", msg.Address, "code", msg.Code,
+		"sequence", key.sequence, "round", key.round, "first", seen.digest, "second", subject.Digest)
+
+	report := reportKey{sequence, msg.Address}
+	if c.evidence == nil || c.reports[report] >= maxEvidencePerValidator {
+		return
+	}
+	c.reports[report]++
+	evidence := &Evidence{
+		Validator: msg.Address,
+		Code:      msg.Code,
+		Sequence:  key.sequence,
+		Round:     key.round,
+		Time:      uint64(time.Now().UnixNano()),
+		First:     seen.payload,
+		Second:    common.CopyBytes(payload),
+	}
+	if err := c.evidence.Write(evidence); err != nil {
+		c.logger.Error("Failed to write the equivocation evidence", "validator", msg.Address, "err", err)
+	}
+}
+
+// pruneVotes forgets the messages of the sequences lower than the previous one of the given sequence.
+func (c *core) pruneVotes(sequence uint64) {
+	for key := range c.votes {
+		if key.sequence+1 < sequence {
+			delete(c.votes, key)
+		}
+	}
+	for key := range c.reports {
+		if key.sequence+1 < sequence {
+			delete(c.reports, key)
+		}
+	}
+}
+
+// pruneEvidence deletes the evidence of the old heights, keeping evidenceRetainedHeights heights.
+// It runs once every evidencePruneInterval heights since it iterates over the stored evidence.
+func (c *core) pruneEvidence(sequence uint64) {
+	if c.evidence == nil || sequence <= evidenceRetainedHeights || sequence%evidencePruneInterval != 0 {
+		return
+	}
+	if err := c.evidence.Prune(sequence - evidenceRetainedHeights); err != nil {
+		c.logger.Warn("Failed to prune the equivocation evidence", "err", err)
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/consensus/istanbul"
+	"github.com/kaiachain/kaia/fork"
+	"github.com/kaiachain/kaia/params"
+	"github.com/kaiachain/kaia/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCore_checkEquivocation(t *testing.T) {
+	fork.SetHardForkBlockNumberConfig(&params.ChainConfig{})
+	defer fork.ClearHardForkBlockNumberConfig()
+
+	validatorAddrs, validatorKeyMap := genValidators(6)
+	mockBackend, mockCtrl := newMockBackend(t, validatorAddrs)
+	defer mockCtrl.Finish()
+
+	db := database.NewMemoryDBManager().GetMiscDB()
+	store := NewEvidenceStore(db)
+	istConfig := istanbul.DefaultConfig.Copy()
+	istConfig.ProposerPolicy = istanbul.WeightedRandom
+
+	istCore := New(mockBackend, istConfig, db).(*core)
+	require.NoError(t, istCore.Start())
+	defer istCore.Stop()
+
+	lastProposal, _ := mockBackend.LastProposal()
+	lastBlock := lastProposal.(*types.Block)
+	signer := validatorAddrs[1]
+	signerKey := validatorKeyMap[signer]
+
+	proposals := make([]*types.Block, 2)
+	for i := range proposals {
+		proposal, err := genBlockParams(lastBlock, signerKey, uint64(i), 1, 1)
+		require.NoError(t, err)
+		proposals[i] = proposal
+	}
+	payload := func(code uint64, proposal *types.Block) []byte {
+		msg, err := genIstanbulMsg(code, lastBlock.Hash(), proposal, signer, signerKey)
+		require.NoError(t, err)
+		return msg.Payload
+	}
+
+	// The same vote received twice, and votes of different codes are not equivocations
+	istCore.handleMsg(payload(msgPrepare, proposals[0]))
+	istCore.handleMsg(payload(msgPrepare, proposals[0]))
+	istCore.handleMsg(payload(msgCommit, proposals[1]))
+	evidences, err := store.Evidences(0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, evidences)
+
+	// Conflicting prepares in the same view
+	first, second := payload(msgPrepare, proposals[0]), payload(msgPrepare, proposals[1])
+	istCore.handleMsg(second)
+	istCore.handleMsg(second)
+	evidences, err = store.Evidences(0, 10)
+	require.NoError(t, err)
+	require.Len(t, evidences, 1)
+
+	evidence := evidences[0]
+	assert.Equal(t, signer, evidence.Validator)
+	assert.Equal(t, msgPrepare, evidence.Code)
+	assert.Equal(t, uint64(1), evidence.Sequence)
+	assert.Equal(t, uint64(0), evidence.Round)
+	assert.Equal(t, first, evidence.First)
+	assert.Equal(t, second, evidence.Second)
+
+	firstSubject, secondSubject, err := evidence.Subjects()
+	require.NoError(t, err)
+	assert.Equal(t, proposals[0].Hash(), firstSubject.Digest)
+	assert.Equal(t, proposals[1].Hash(), secondSubject.Digest)
+
+	// The evidence is not valid if a message is not signed by the validator
+	evidence.Validator = validatorAddrs[2]
+	_, _, err = evidence.Subjects()
+	assert.Equal(t, errInvalidSigner, err)
+
+	// The votes of the old sequences are forgotten
+	istCore.pruneVotes(3)
+	assert.Empty(t, istCore.votes)
+}
+
+func TestCore_checkEquivocation_Bounds(t *testing.T) {
+	fork.SetHardForkBlockNumberConfig(&params.ChainConfig{})
+	defer fork.ClearHardForkBlockNumberConfig()
+
+	validatorAddrs, _ := genValidators(6)
+	mockBackend, mockCtrl := newMockBackend(t, validatorAddrs)
+	defer mockCtrl.Finish()
+
+	db := database.NewMemoryDBManager().GetMiscDB()
+	store := NewEvidenceStore(db)
+	istCore := New(mockBackend, istanbul.DefaultConfig.Copy(), db).(*core)
+	require.NoError(t, istCore.Start())
+	defer istCore.Stop()
+
+	signer := validatorAddrs[1]
+	vote := func(round uint64, digest common.Hash) *message {
+		subject, err := Encode(&istanbul.Subject{
+			View:   &istanbul.View{Sequence: istCore.current.Sequence(), Round: new(big.Int).SetUint64(round)},
+			Digest: digest,
+		})
+		require.NoError(t, err)
+		return &message{Code: msgCommit, Msg: subject, Address: signer}
+	}
+
+	// The rounds far ahead of the current one are not tracked
+	istCore.checkEquivocation(vote(evidenceRoundWindow+1, common.Hash{1}), nil)
+	assert.Empty(t, istCore.votes)
+
+	// The evidence of a validator equivocating in every round is capped
+	for round := uint64(0); round <= evidenceRoundWindow; round++ {
+		istCore.checkEquivocation(vote(round, common.Hash{1}), nil)
+		istCore.checkEquivocation(vote(round, common.Hash{2}), nil)
+	}
+	evidences, err := store.Evidences(0, 10)
+	require.NoError(t, err)
+	assert.Len(t, evidences, maxEvidencePerValidator)
+}
+
+func TestEvidenceStore_Evidences(t *testing.T) {
+	store := NewEvidenceStore(database.NewMemoryDBManager().GetMiscDB())
+	for seq := uint64(1); seq <= 3; seq++ {
+		for _, addr := range []common.Address{{1}, {2}} {
+			require.NoError(t, store.Write(&Evidence{Validator: addr, Code: msgCommit, Sequence: seq}))
+		}
+	}
+
+	for _, tc := range []struct {
+		from, to uint64
+		expected int
+	}{
+		{0, 10, 6},
+		{2, 2, 2},
+		{2, 3, 4},
+		{4, 10, 0},
+	} {
+		evidences, err := store.Evidences(tc.from, tc.to)
+		require.NoError(t, err)
+		assert.Len(t, evidences, tc.expected, "from %d to %d", tc.from, tc.to)
+		for _, e := range evidences {
+			assert.True(t, tc.from <= e.Sequence && e.Sequence <= tc.to)
+		}
+	}
+}
+
+func TestEvidenceStore_Prune(t *testing.T) {
+	store := NewEvidenceStore(database.NewMemoryDBManager().GetMiscDB())
+	for seq := uint64(1); seq <= 3; seq++ {
+		require.NoError(t, store.Write(&Evidence{Validator: common.Address{1}, Code: msgCommit, Sequence: seq}))
+	}
+
+	require.NoError(t, store.Prune(3))
+	evidences, err := store.Evidences(0, 10)
+	require.NoError(t, err)
+	require.Len(t, evidences, 1)
+	assert.Equal(t, uint64(3), evidences[0].Sequence)
+}
@@ -31,6 +31,11 @@ import (
 func (c *core) Start() error {
 	// Start a new round from last sequence + 1
 	c.startNewRound(common.Big0)
+	// Restore the state of the height from the WAL written before a restart
+	c.replayWAL()
+	if c.wal != nil {
+		c.wal.Start()
+	}
 
 	// Tests will handle events itself, so we have to make subscribeEvents()
 	// be able to call in test.
@@ -48,9 +53,24 @@ func (c *core) Stop() error {
 
 	// Make sure the handler goroutine exits
 	c.handlerWg.Wait()
+
+	c.flush()
 	return nil
 }
 
+// flush writes the consensus state kept in memory: the received messages queued
+// for the WAL and the timeline of the current view.
+func (c *core) flush() {
+	if c.wal != nil {
+		if err := c.wal.Stop(); err != nil {
+			c.logger.Error("Failed to write the consensus WAL", "err", err)
+		}
+	}
+	if c.vrank != nil {
+		c.writeTimeline(c.vrank.Timeline())
+	}
+}
+
 // ----------------------------------------------------------------------------
 
 // Subscribe both internal and external events
@@ -91,36 +111,7 @@ func (c *core) handleEvents() {
 				return
 			}
 			// A real event arrived, process interesting content
-			switch ev := event.Data.(type) {
-			case istanbul.RequestEvent:
-				r := &istanbul.Request{
-					Proposal: ev.Proposal,
-				}
-				err := c.handleRequest(r)
-				if err == errFutureMessage {
-					c.storeRequestMsg(r)
-				}
-			case istanbul.MessageEvent:
-				if err := c.handleMsg(ev.Payload); err == nil {
-					c.backend.GossipSubPeer(ev.Hash, ev.Payload)
-					// c.backend.Gossip(c.valSet, ev.Payload)
-				}
-			case backlogEvent:
-				if !c.currentCommittee.Qualified().Contains(ev.src) {
-					c.logger.Error("Invalid address in valSet", "addr", ev.src)
-					continue
-				}
-				// No need to check signature for internal messages
-				if err := c.handleCheckedMsg(ev.msg, ev.src); err == nil {
-					p, err := ev.msg.Payload()
-					if err != nil {
-						c.logger.Warn("Get message payload failed", "err", err)
-						continue
-					}
-					c.backend.GossipSubPeer(ev.Hash, p)
-					// c.backend.Gossip(c.valSet, p)
-				}
-			}
+			c.handleEvent(event.Data)
 		case ev, ok := <-c.timeoutSub.Chan():
 			if !ok || ev.Data == nil {
 				logger.Error("Drop an empty message from timeout channel")
@@ -144,11 +135,58 @@ func (c *core) handleEvents() {
 	}
 }
 
+// handleEvent handles an external or internal event other than the timeout and the final committed events.
+func (c *core) handleEvent(data interface{}) {
+	switch ev := data.(type) {
+	case istanbul.RequestEvent:
+		r := &istanbul.Request{
+			Proposal: ev.Proposal,
+		}
+		err := c.handleRequest(r)
+		if err == errFutureMessage {
+			c.storeRequestMsg(r)
+		}
+	case istanbul.MessageEvent:
+		if err := c.handleMsg(ev.Payload); err == nil {
+			c.backend.GossipSubPeer(ev.Hash, ev.Payload)
+			// c.backend.Gossip(c.valSet, ev.Payload)
+		}
+	case backlogEvent:
+		if !c.currentCommittee.Qualified().Contains(ev.src) {
+			c.logger.Error("Invalid address in valSet", "addr", ev.src)
+			return
+		}
+		// No need to check signature for internal messages
+		if err := c.handleCheckedMsg(ev.msg, ev.src); err == nil {
+			p, err := ev.msg.Payload()
+			if err != nil {
+				c.logger.Warn("Get message payload failed", "err", err)
+				return
+			}
+			c.backend.GossipSubPeer(ev.Hash, p)
+			// c.backend.Gossip(c.valSet, p)
+		}
+	}
+}
+
 // sendEvent sends events to mux
 func (c *core) sendEvent(ev interface{}) {
+	if c.dispatch != nil {
+		c.dispatch(ev)
+		return
+	}
 	c.backend.EventMux().Post(ev)
 }
 
+// sendEventAsync sends events to mux without blocking the event handler
+func (c *core) sendEventAsync(ev interface{}) {
+	if c.dispatch != nil {
+		c.dispatch(ev)
+		return
+	}
+	go c.sendEvent(ev)
+}
+
 func (c *core) handleMsg(payload []byte) error {
 	logger := c.logger.NewWith()
 
@@ -181,6 +219,9 @@ func (c *core) handleMsg(payload []byte) error {
 		return istanbul.ErrUnauthorizedAddress
 	}
 
+	c.writeReceivedWAL(msg, payload)
+	c.checkEquivocation(msg, payload)
+
 	return c.handleCheckedMsg(msg, msg.Address)
 }
 
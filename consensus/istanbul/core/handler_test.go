@@ -89,6 +89,9 @@ func newMockBackend(t *testing.T, validatorAddrs []common.Address) (*mock_istanb
 		}).AnyTimes()
 	mockBackend.EXPECT().NodeType().Return(common.CONSENSUSNODE).AnyTimes()
 
+	// The round change timeouts are not governed
+	mockBackend.EXPECT().GetRoundTimeouts(gomock.Any()).Return(time.Duration(0), time.Duration(0)).AnyTimes()
+
 	// Set an eventMux in which istanbul core will subscribe istanbul events
 	mockBackend.EXPECT().EventMux().Return(eventMux).AnyTimes()
 
@@ -97,6 +100,7 @@ func newMockBackend(t *testing.T, validatorAddrs []common.Address) (*mock_istanb
 
 	// Always return nil for broadcasting related functions
 	mockBackend.EXPECT().Sign(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockBackend.EXPECT().SignCommittedSeal(gomock.Any()).Return(nil, nil).AnyTimes()
 	mockBackend.EXPECT().Broadcast(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	mockBackend.EXPECT().GossipSubPeer(gomock.Any(), gomock.Any()).Return().AnyTimes()
 
@@ -247,7 +251,7 @@ func TestCore_handleEvents_scenario_invalidSender(t *testing.T) {
 	istConfig.ProposerPolicy = istanbul.WeightedRandom
 
 	// When the istanbul core started, a message handling loop in `handleEvents()` waits istanbul messages
-	istCore := New(mockBackend, istConfig).(*core)
+	istCore := New(mockBackend, istConfig, nil).(*core)
 	if err := istCore.Start(); err != nil {
 		t.Fatal(err)
 	}
@@ -427,7 +431,7 @@ func TestCore_handlerMsg(t *testing.T) {
 	istConfig := istanbul.DefaultConfig.Copy()
 	istConfig.ProposerPolicy = istanbul.WeightedRandom
 
-	istCore := New(mockBackend, istConfig).(*core)
+	istCore := New(mockBackend, istConfig, nil).(*core)
 	if err := istCore.Start(); err != nil {
 		t.Fatal(err)
 	}
@@ -534,7 +538,7 @@ func simulateMaliciousCN(t *testing.T, numValidators int, numMalicious int) Stat
 
 	// Add more EXPECT()s to remove unexpected call error
 	mockBackend, mockCtrl := newMockBackend(t, validatorAddrs)
-	mockBackend.EXPECT().Commit(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockBackend.EXPECT().Commit(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	mockBackend.EXPECT().HasBadProposal(gomock.Any()).Return(true).AnyTimes()
 	defer mockCtrl.Finish()
 
@@ -556,7 +560,7 @@ func simulateMaliciousCN(t *testing.T, numValidators int, numMalicious int) Stat
 	// Start istanbul core
 	istConfig := istanbul.DefaultConfig
 	istConfig.ProposerPolicy = istanbul.WeightedRandom
-	istCore := New(mockBackend, istConfig).(*core)
+	istCore := New(mockBackend, istConfig, nil).(*core)
 	require.Nil(t, istCore.Start())
 	defer istCore.Stop()
 
@@ -628,7 +632,7 @@ func simulateChainSplit(t *testing.T, numValidators int) (State, State) {
 
 	// Add more EXPECT()s to remove unexpected call error
 	mockBackend, mockCtrl := newMockBackend(t, validatorAddrs)
-	mockBackend.EXPECT().Commit(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockBackend.EXPECT().Commit(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	mockBackend.EXPECT().HasBadProposal(gomock.Any()).Return(true).AnyTimes()
 	defer mockCtrl.Finish()
 
@@ -643,9 +647,9 @@ func simulateChainSplit(t *testing.T, numValidators int) (State, State) {
 	// Start istanbul core
 	istConfig := istanbul.DefaultConfig
 	istConfig.ProposerPolicy = istanbul.WeightedRandom
-	coreProposer := New(mockBackend, istConfig).(*core)
-	coreA := New(mockBackend, istConfig).(*core)
-	coreB := New(mockBackend, istConfig).(*core)
+	coreProposer := New(mockBackend, istConfig, nil).(*core)
+	coreA := New(mockBackend, istConfig, nil).(*core)
+	coreB := New(mockBackend, istConfig, nil).(*core)
 	require.Nil(t,
 		coreProposer.Start(),
 		coreA.Start(),
@@ -768,7 +772,7 @@ func TestCore_handleTimeoutMsg_race(t *testing.T) {
 	istConfig := istanbul.DefaultConfig
 	istConfig.ProposerPolicy = istanbul.WeightedRandom
 
-	istCore := New(mockBackend, istConfig).(*core)
+	istCore := New(mockBackend, istConfig, nil).(*core)
 	if err := istCore.Start(); err != nil {
 		t.Fatal(err)
 	}
@@ -849,3 +853,34 @@ func makeRCMsgPayload(t *testing.T, round int64, sequence int64, prevHash common
 
 	return payload
 }
+
+func TestCore_roundChangeTimeout(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockBackend := mock_istanbul.NewMockBackend(mockCtrl)
+	istCore := &core{backend: mockBackend}
+
+	// The governed timeouts are activated at the block 10
+	mockBackend.EXPECT().GetRoundTimeouts(gomock.Any()).DoAndReturn(func(num uint64) (time.Duration, time.Duration) {
+		if num < 10 {
+			return 0, 0
+		}
+		return 5 * time.Second, 500 * time.Millisecond
+	}).AnyTimes()
+
+	local := time.Duration(istanbul.DefaultConfig.Timeout) * time.Millisecond
+	testCases := []struct {
+		num, round uint64
+		expected   time.Duration
+	}{
+		{9, 0, local},
+		{9, 1, local + 2*time.Second},
+		{9, 3, local + 8*time.Second},
+		{10, 0, 5 * time.Second},
+		{10, 1, 6 * time.Second},
+		{10, 3, 9 * time.Second},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, istCore.roundChangeTimeout(tc.num, tc.round), "num=%d round=%d", tc.num, tc.round)
+	}
+}
@@ -58,6 +58,10 @@ func (c *core) handlePrepare(msg *message, src common.Address) error {
 		return errInvalidMessage
 	}
 
+	if c.vrank != nil {
+		c.vrank.AddPrepare(prepare, src)
+	}
+
 	// logger.Error("call receive prepare","num",prepare.View.Sequence)
 	if err := c.checkMessage(msgPrepare, prepare.View); err != nil {
 		return err
@@ -90,7 +94,9 @@ func (c *core) handlePrepare(msg *message, src common.Address) error {
 			logger.Info("received a quorum of the messages and change state to prepared", "msgType", msgPrepare,
 				"prepareMsgNum", c.current.Prepares.Size(), "commitMsgNum", c.current.Commits.Size(),
 				"valSet", c.currentCommittee.Qualified().Len())
-			c.current.LockHash()
+			if err := c.lockHash(); err != nil {
+				return err
+			}
 			c.setState(StatePrepared)
 			c.sendCommit()
 		}
@@ -54,7 +54,7 @@ func TestCore_sendPrepare(t *testing.T) {
 		istConfig := istanbul.DefaultConfig.Copy()
 		istConfig.ProposerPolicy = istanbul.WeightedRandom
 
-		istCore := New(mockBackend, istConfig).(*core)
+		istCore := New(mockBackend, istConfig, nil).(*core)
 		assert.NoError(t, istCore.Start())
 
 		lastProposal, _ := mockBackend.LastProposal()
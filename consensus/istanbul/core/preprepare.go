@@ -100,7 +100,7 @@ func (c *core) handlePreprepare(msg *message, src common.Address) error {
 		// if it's a future block, we will handle it again after the duration
 		if err == consensus.ErrFutureBlock {
 			c.stopFuturePreprepareTimer()
-			c.futurePreprepareTimer = time.AfterFunc(duration, func() {
+			c.futurePreprepareTimer = c.clock.AfterFunc(duration, func() {
 				c.sendEvent(backlogEvent{
 					src:  src,
 					msg:  msg,
@@ -127,10 +127,7 @@ func (c *core) handlePreprepare(msg *message, src common.Address) error {
 				c.setState(StatePrepared)
 				c.sendCommit()
 
-				if vrank != nil {
-					vrank.Log()
-				}
-				vrank = NewVrank(*c.currentView(), c.currentCommittee.Committee().List())
+				c.startVrank(preprepare.Proposal)
 			} else {
 				// Send round change
 				c.sendNextRoundChange("handlePreprepare. HashLocked, but received hash is different from locked hash")
@@ -143,10 +140,7 @@ func (c *core) handlePreprepare(msg *message, src common.Address) error {
 			c.setState(StatePreprepared)
 			c.sendPrepare()
 
-			if vrank != nil {
-				vrank.Log()
-			}
-			vrank = NewVrank(*c.currentView(), c.currentCommittee.Committee().List())
+			c.startVrank(preprepare.Proposal)
 		}
 	}
 
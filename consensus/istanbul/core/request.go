@@ -101,7 +101,7 @@ func (c *core) processPendingRequests() {
 		}
 		c.logger.Trace("Post pending request", "number", r.Proposal.Number(), "hash", r.Proposal.Hash())
 
-		go c.sendEvent(istanbul.RequestEvent{
+		c.sendEventAsync(istanbul.RequestEvent{
 			Proposal: r.Proposal,
 		})
 	}
@@ -0,0 +1,92 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"time"
+
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/consensus/istanbul"
+	"github.com/kaiachain/kaia/storage/database"
+)
+
+// Timer is a timer created by a Clock.
+type Timer interface {
+	Stop() bool
+}
+
+// Clock creates the timers of the core.
+type Clock interface {
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+type systemClock struct{}
+
+func (systemClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+// SyncEngine is an Engine driven by the caller instead of the event mux of the backend.
+// The events are handled in the goroutine of the caller, and the events the core sends
+// to itself are passed to the dispatch function instead of being posted, so that the
+// caller decides the order of every event. With a virtual clock, it makes the consensus
+// deterministic, which is used by the network simulations.
+type SyncEngine interface {
+	Engine
+
+	// HandleEvent handles an istanbul.RequestEvent, istanbul.MessageEvent,
+	// istanbul.FinalCommittedEvent or an event passed to the dispatch function.
+	HandleEvent(ev interface{})
+}
+
+type syncCore struct {
+	*core
+}
+
+// NewSyncEngine creates an Istanbul consensus core driven by the caller. See New for db.
+func NewSyncEngine(backend istanbul.Backend, config *istanbul.Config, db database.Database, clock Clock, dispatch func(ev interface{})) SyncEngine {
+	c := New(backend, config, db).(*core)
+	c.clock = clock
+	c.dispatch = dispatch
+	return &syncCore{c}
+}
+
+// Start implements core.Engine.Start
+func (c *syncCore) Start() error {
+	c.startNewRound(common.Big0)
+	c.replayWAL()
+	return nil
+}
+
+// Stop implements core.Engine.Stop
+func (c *syncCore) Stop() error {
+	c.stopTimer()
+	c.flush()
+	return nil
+}
+
+// HandleEvent implements SyncEngine.HandleEvent
+func (c *syncCore) HandleEvent(ev interface{}) {
+	switch ev := ev.(type) {
+	case timeoutEvent:
+		c.handleTimeoutMsg(ev.nextView)
+	case istanbul.FinalCommittedEvent:
+		c.handleFinalCommitted()
+	default:
+		c.handleEvent(ev)
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"encoding/binary"
+	"sort"
+	"time"
+
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/consensus/istanbul"
+	"github.com/kaiachain/kaia/kaiax/valset"
+	"github.com/kaiachain/kaia/rlp"
+	"github.com/kaiachain/kaia/storage/database"
+)
+
+// timelineCapacity is the number of sequences kept in the ring of the consensus timelines.
+const timelineCapacity = 4096
+
+var timelinePrefix = []byte("istanbul-timeline-")
+
+// ValidatorTimeline is the arrival times of the messages of a committee member.
+// The times are measured in nanoseconds since the preprepare was accepted, and 0 if the message did not arrive.
+type ValidatorTimeline struct {
+	Validator common.Address
+	Prepare   uint64
+	Commit    uint64
+	Late      bool // Whether the commit arrived after the quorum of the commits
+}
+
+// ConsensusTimeline is the record of a view measured by the Vrank.
+type ConsensusTimeline struct {
+	Sequence   uint64
+	Round      uint64 // The number of the round changes before the view
+	Proposer   common.Address
+	Digest     common.Hash
+	Preprepare uint64 // Unix time in nanoseconds when the preprepare was accepted
+	Committed  uint64 // Time to commit the proposal since the preprepare in nanoseconds. 0 if not committed in the view
+	Validators []ValidatorTimeline
+}
+
+// Missing returns the committee members whose commits did not arrive.
+func (t *ConsensusTimeline) Missing() []common.Address {
+	missing := make([]common.Address, 0)
+	for _, v := range t.Validators {
+		if v.Commit == 0 {
+			missing = append(missing, v.Validator)
+		}
+	}
+	return missing
+}
+
+// LateCommits returns the committee members whose commits arrived after the quorum.
+func (t *ConsensusTimeline) LateCommits() []common.Address {
+	late := make([]common.Address, 0)
+	for _, v := range t.Validators {
+		if v.Late {
+			late = append(late, v.Validator)
+		}
+	}
+	return late
+}
+
+// Timeline returns the timeline of the view measured so far. The validators are sorted by address.
+func (v *Vrank) Timeline() *ConsensusTimeline {
+	committee := valset.NewAddressSet(v.committee).List()
+	prepares := serialize(committee, v.prepareArrivalTimeMap)
+	commits := serialize(committee, v.commitArrivalTimeMap)
+
+	arrival := func(t time.Duration) uint64 {
+		if t == vrankNotArrivedPlaceholder {
+			return 0
+		}
+		return uint64(t)
+	}
+	validators := make([]ValidatorTimeline, len(committee))
+	for i, addr := range committee {
+		validators[i] = ValidatorTimeline{
+			Validator: addr,
+			Prepare:   arrival(prepares[i]),
+			Commit:    arrival(commits[i]),
+			Late:      assess(commits[i], v.threshold) == vrankArrivedLate,
+		}
+	}
+	return &ConsensusTimeline{
+		Sequence:   v.view.Sequence.Uint64(),
+		Round:      v.view.Round.Uint64(),
+		Proposer:   v.proposer,
+		Digest:     v.digest,
+		Preprepare: uint64(v.startTime.UnixNano()),
+		Committed:  uint64(v.committed),
+		Validators: validators,
+	}
+}
+
+// TimelineStore keeps the consensus timelines of the recent sequences in a ring of timelineCapacity
+// slots, so that the storage does not grow with the chain. A slot holds the timelines of all the
+// rounds of its sequence.
+type TimelineStore struct {
+	db database.Database
+}
+
+// NewTimelineStore returns a timeline store in the given database.
+func NewTimelineStore(db database.Database) *TimelineStore {
+	return &TimelineStore{db: db}
+}
+
+func timelineKey(sequence uint64) []byte {
+	return binary.BigEndian.AppendUint64(append([]byte{}, timelinePrefix...), sequence%timelineCapacity)
+}
+
+// Write stores the timeline in the slot of its sequence, replacing the timelines of an older sequence.
+// The timeline of the same round is replaced, and the timelines of the other rounds are kept.
+func (s *TimelineStore) Write(t *ConsensusTimeline) error {
+	timelines, err := s.Read(t.Sequence)
+	if err != nil {
+		return err
+	}
+	i := sort.Search(len(timelines), func(i int) bool { return timelines[i].Round >= t.Round })
+	if i < len(timelines) && timelines[i].Round == t.Round {
+		timelines[i] = t
+	} else {
+		timelines = append(timelines[:i], append([]*ConsensusTimeline{t}, timelines[i:]...)...)
+	}
+	enc, err := rlp.EncodeToBytes(timelines)
+	if err != nil {
+		return err
+	}
+	return s.db.Put(timelineKey(t.Sequence), enc)
+}
+
+// Read returns the timelines of the rounds of the given sequence ordered by the round,
+// or nil if the sequence is not in the ring.
+func (s *TimelineStore) Read(sequence uint64) ([]*ConsensusTimeline, error) {
+	enc, _ := s.db.Get(timelineKey(sequence))
+	if len(enc) == 0 {
+		return nil, nil
+	}
+	var timelines []*ConsensusTimeline
+	if err := rlp.DecodeBytes(enc, &timelines); err != nil {
+		return nil, err
+	}
+	if len(timelines) == 0 || timelines[0].Sequence != sequence {
+		return nil, nil
+	}
+	return timelines, nil
+}
+
+// startVrank finalizes the Vrank of the previous view and starts measuring the view of the given proposal.
+// The timeline of the previous view is written again to include the commits arrived after it was committed.
+func (c *core) startVrank(proposal istanbul.Proposal) {
+	if c.vrank != nil {
+		c.vrank.Log()
+		c.writeTimeline(c.vrank.Timeline())
+	}
+	c.vrank = NewVrank(*c.currentView(), c.currentCommittee.Committee().List())
+	c.vrank.proposer, c.vrank.digest = c.currentCommittee.Proposer(), proposal.Hash()
+}
+
+func (c *core) writeTimeline(t *ConsensusTimeline) {
+	if c.timelines == nil {
+		return
+	}
+	if err := c.timelines.Write(t); err != nil {
+		c.logger.Warn("Failed to write the consensus timeline", "sequence", t.Sequence, "err", err)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/consensus/istanbul"
+	"github.com/kaiachain/kaia/kaiax/valset"
+	"github.com/kaiachain/kaia/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVrank_Timeline(t *testing.T) {
+	var (
+		N            = 6
+		quorum       = 4
+		committee, _ = genValidators(N)
+		view         = istanbul.View{Sequence: big.NewInt(3), Round: big.NewInt(1)}
+		msg          = &istanbul.Subject{View: &view}
+		otherRound   = &istanbul.Subject{View: &istanbul.View{Sequence: big.NewInt(3), Round: big.NewInt(0)}}
+		vrank        = NewVrank(view, committee)
+	)
+	vrank.proposer, vrank.digest = committee[0], common.Hash{1}
+
+	committee = valset.NewAddressSet(committee).List() // sort it
+	for i := 0; i < quorum; i++ {
+		vrank.AddPrepare(msg, committee[i])
+		vrank.AddPrepare(otherRound, committee[N-1])
+		vrank.AddCommit(msg, committee[i])
+	}
+	vrank.HandleCommitted(view.Sequence)
+	vrank.AddCommit(msg, committee[quorum])
+
+	timeline := vrank.Timeline()
+	assert.Equal(t, uint64(3), timeline.Sequence)
+	assert.Equal(t, uint64(1), timeline.Round)
+	assert.Equal(t, vrank.proposer, timeline.Proposer)
+	assert.Equal(t, common.Hash{1}, timeline.Digest)
+	assert.NotZero(t, timeline.Committed)
+	require.Len(t, timeline.Validators, N)
+
+	for i, v := range timeline.Validators {
+		assert.Equal(t, committee[i], v.Validator)
+		assert.Equal(t, i < quorum, v.Prepare != 0, "prepare of %d", i)
+		assert.Equal(t, i <= quorum, v.Commit != 0, "commit of %d", i)
+	}
+	assert.Equal(t, []common.Address{committee[quorum]}, timeline.LateCommits())
+	assert.Equal(t, committee[quorum+1:], timeline.Missing())
+}
+
+func TestTimelineStore(t *testing.T) {
+	store := NewTimelineStore(database.NewMemoryDBManager().GetMiscDB())
+
+	// The rounds of a sequence are kept in order, and the same round is replaced
+	require.NoError(t, store.Write(&ConsensusTimeline{Sequence: 1, Round: 2}))
+	require.NoError(t, store.Write(&ConsensusTimeline{Sequence: 1, Round: 0}))
+	require.NoError(t, store.Write(&ConsensusTimeline{Sequence: 1, Round: 2, Committed: 1}))
+	timelines, err := store.Read(1)
+	require.NoError(t, err)
+	require.Len(t, timelines, 2)
+	assert.Equal(t, uint64(0), timelines[0].Round)
+	assert.Equal(t, uint64(2), timelines[1].Round)
+	assert.Equal(t, uint64(1), timelines[1].Committed)
+
+	// The sequence in the same slot of the ring evicts the old one
+	require.NoError(t, store.Write(&ConsensusTimeline{Sequence: 1 + timelineCapacity}))
+	timelines, err = store.Read(1)
+	require.NoError(t, err)
+	assert.Nil(t, timelines)
+	timelines, err = store.Read(1 + timelineCapacity)
+	require.NoError(t, err)
+	assert.Len(t, timelines, 1)
+
+	timelines, err = store.Read(2)
+	require.NoError(t, err)
+	assert.Nil(t, timelines)
+}
+
+func TestCore_flushTimeline(t *testing.T) {
+	var (
+		committee, _ = genValidators(4)
+		store        = NewTimelineStore(database.NewMemoryDBManager().GetMiscDB())
+		c            = &core{timelines: store}
+	)
+	c.vrank = NewVrank(istanbul.View{Sequence: big.NewInt(5), Round: big.NewInt(1)}, committee)
+
+	// The timeline of the current view is written on stop even if it is not committed
+	c.flush()
+	timelines, err := store.Read(5)
+	require.NoError(t, err)
+	require.Len(t, timelines, 1)
+	assert.Equal(t, uint64(1), timelines[0].Round)
+	assert.Zero(t, timelines[0].Committed)
+}
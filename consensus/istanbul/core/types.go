@@ -83,6 +83,22 @@ const (
 	msgAll
 )
 
+// MessageCodeName returns the name of the given consensus message code.
+func MessageCodeName(code uint64) string {
+	switch code {
+	case msgPreprepare:
+		return "preprepare"
+	case msgPrepare:
+		return "prepare"
+	case msgCommit:
+		return "commit"
+	case msgRoundChange:
+		return "roundchange"
+	default:
+		return "unknown"
+	}
+}
+
 type message struct {
 	Hash          common.Hash
 	Code          uint64
@@ -193,6 +209,38 @@ func (m *message) GetView() (*istanbul.View, error) {
 	return msgView, nil
 }
 
+// DecodeVote returns the code, the view and the digest of the given consensus message,
+// which is the payload without the signature. The digest of a PREPREPARE message is the
+// hash of its proposal. The signers use it to refuse signing conflicting messages.
+func DecodeVote(payload []byte) (uint64, *istanbul.View, common.Hash, error) {
+	msg := new(message)
+	if err := msg.FromPayload(payload, nil); err != nil {
+		return 0, nil, common.Hash{}, err
+	}
+	switch msg.Code {
+	case msgPreprepare:
+		var preprepare *istanbul.Preprepare
+		if err := msg.Decode(&preprepare); err != nil {
+			return 0, nil, common.Hash{}, err
+		}
+		if preprepare.View == nil || preprepare.Proposal == nil {
+			return 0, nil, common.Hash{}, errInvalidMessage
+		}
+		return msg.Code, preprepare.View, preprepare.Proposal.Hash(), nil
+	case msgPrepare, msgCommit, msgRoundChange:
+		var subject *istanbul.Subject
+		if err := msg.Decode(&subject); err != nil {
+			return 0, nil, common.Hash{}, err
+		}
+		if subject.View == nil {
+			return 0, nil, common.Hash{}, errInvalidMessage
+		}
+		return msg.Code, subject.View, subject.Digest, nil
+	default:
+		return 0, nil, common.Hash{}, errInvalidMessage
+	}
+}
+
 // ==============================================
 //
 // helper functions
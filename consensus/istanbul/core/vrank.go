@@ -35,11 +35,15 @@ type Vrank struct {
 	startTime             time.Time
 	view                  istanbul.View
 	committee             []common.Address
+	proposer              common.Address
+	digest                common.Hash
 	threshold             time.Duration
 	firstCommit           int64
 	quorumCommit          int64
 	avgCommitWithinQuorum int64
 	lastCommit            int64
+	committed             time.Duration // the time to commit the proposal. 0 if not committed in the view
+	prepareArrivalTimeMap map[common.Address]time.Duration
 	commitArrivalTimeMap  map[common.Address]time.Duration
 }
 
@@ -51,8 +55,6 @@ var (
 	vrankLastCommitArrivalTimeGauge            = metrics.NewRegisteredGauge("vrank/last_commit", nil)
 
 	vrankDefaultThreshold = "300ms" // the time to receive 2f+1 commits in an ideal network
-
-	vrank *Vrank
 )
 
 const (
@@ -76,6 +78,7 @@ func NewVrank(view istanbul.View, committee []common.Address) *Vrank {
 		quorumCommit:          int64(0),
 		avgCommitWithinQuorum: int64(0),
 		lastCommit:            int64(0),
+		prepareArrivalTimeMap: make(map[common.Address]time.Duration),
 		commitArrivalTimeMap:  make(map[common.Address]time.Duration),
 	}
 }
@@ -84,6 +87,13 @@ func (v *Vrank) TimeSinceStart() time.Duration {
 	return time.Now().Sub(v.startTime)
 }
 
+func (v *Vrank) AddPrepare(msg *istanbul.Subject, src common.Address) {
+	if v.isTarget(msg, src, v.prepareArrivalTimeMap) {
+		t := v.TimeSinceStart()
+		v.prepareArrivalTimeMap[src] = t
+	}
+}
+
 func (v *Vrank) AddCommit(msg *istanbul.Subject, src common.Address) {
 	if v.isTargetCommit(msg, src) {
 		t := v.TimeSinceStart()
@@ -95,6 +105,7 @@ func (v *Vrank) HandleCommitted(blockNum *big.Int) {
 	if v.view.Sequence.Cmp(blockNum) != 0 {
 		return
 	}
+	v.committed = v.TimeSinceStart()
 
 	if len(v.commitArrivalTimeMap) != 0 {
 		sum := int64(0)
@@ -178,13 +189,17 @@ func (v *Vrank) updateMetrics() {
 }
 
 func (v *Vrank) isTargetCommit(msg *istanbul.Subject, src common.Address) bool {
+	return v.isTarget(msg, src, v.commitArrivalTimeMap)
+}
+
+func (v *Vrank) isTarget(msg *istanbul.Subject, src common.Address, arrivalTimeMap map[common.Address]time.Duration) bool {
 	if msg.View == nil || msg.View.Sequence == nil || msg.View.Round == nil {
 		return false
 	}
 	if msg.View.Cmp(&v.view) != 0 {
 		return false
 	}
-	_, ok := v.commitArrivalTimeMap[src]
+	_, ok := arrivalTimeMap[src]
 	if ok {
 		return false
 	}
@@ -0,0 +1,455 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"encoding/binary"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/consensus/istanbul"
+	"github.com/kaiachain/kaia/rlp"
+	"github.com/kaiachain/kaia/storage/database"
+)
+
+// WALEntryType is the type of a consensus WAL entry.
+type WALEntryType uint8
+
+const (
+	WALEntrySent     WALEntryType = iota // A consensus message broadcast by the validator
+	WALEntryReceived                     // A consensus message received and authenticated by the validator
+	WALEntryLock                         // The validator locked on the proposal
+	WALEntryUnlock                       // The validator released the lock
+)
+
+func (t WALEntryType) String() string {
+	switch t {
+	case WALEntrySent:
+		return "sent"
+	case WALEntryReceived:
+		return "received"
+	case WALEntryLock:
+		return "lock"
+	case WALEntryUnlock:
+		return "unlock"
+	default:
+		return "unknown"
+	}
+}
+
+// walRetainedHeights is the number of committed heights whose entries are kept
+// for post-mortems. Only the entries of the current height are replayed.
+const walRetainedHeights = 8
+
+var walPrefix = []byte("istanbul-wal-")
+
+// WALEntry is a record of the consensus write-ahead log. The payload is the
+// message payload for sent and received entries, and the RLP-encoded locked
+// preprepare for lock entries.
+type WALEntry struct {
+	Type     WALEntryType
+	Code     uint64
+	Sequence uint64
+	Round    uint64
+	Time     uint64 // Unix time in nanoseconds
+	Payload  []byte
+}
+
+// WAL is the on-disk write-ahead log of the consensus messages and the lock state.
+// The sent messages and the lock state are written before they take effect, so that
+// a restarted validator can restore the lock and never votes twice in the same round.
+// The received messages are only needed to catch up after a restart, so they are
+// queued and written in batches in the background not to delay the consensus.
+type WAL struct {
+	db database.Database
+	mu sync.Mutex
+
+	next    map[uint64]uint64 // Index of the next entry of each sequence
+	pending []*WALEntry       // Entries appended but not written yet
+
+	flushCh chan struct{}
+	quit    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewWAL returns a WAL stored in the given database.
+func NewWAL(db database.Database) *WAL {
+	return &WAL{
+		db:      db,
+		next:    make(map[uint64]uint64),
+		flushCh: make(chan struct{}, 1),
+	}
+}
+
+func walSequencePrefix(sequence uint64) []byte {
+	return binary.BigEndian.AppendUint64(append([]byte{}, walPrefix...), sequence)
+}
+
+func walKey(sequence, index uint64) []byte {
+	return binary.BigEndian.AppendUint64(walSequencePrefix(sequence), index)
+}
+
+// Start starts writing the entries appended by AppendAsync in the background.
+func (w *WAL) Start() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.quit != nil {
+		return
+	}
+	w.quit = make(chan struct{})
+	w.wg.Add(1)
+	go w.loop(w.quit)
+}
+
+// Stop stops the background writer and writes the pending entries.
+func (w *WAL) Stop() error {
+	w.mu.Lock()
+	quit := w.quit
+	w.quit = nil
+	w.mu.Unlock()
+
+	if quit != nil {
+		close(quit)
+		w.wg.Wait()
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.flush()
+}
+
+func (w *WAL) loop(quit chan struct{}) {
+	defer w.wg.Done()
+
+	for {
+		select {
+		case <-w.flushCh:
+			w.mu.Lock()
+			err := w.flush()
+			w.mu.Unlock()
+			if err != nil {
+				logger.Error("Failed to write the consensus WAL", "err", err)
+			}
+		case <-quit:
+			return
+		}
+	}
+}
+
+// Append writes the entry at the end of the log of its sequence, together with
+// the entries queued by AppendAsync before it. If the write fails, the entry is
+// dropped since the caller does not act on it, while the queued ones are kept.
+func (w *WAL) Append(entry *WALEntry) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.queue(entry)
+	if err := w.flush(); err != nil {
+		w.pending = w.pending[:len(w.pending)-1]
+		return err
+	}
+	return nil
+}
+
+// AppendAsync queues the entry to be written at the end of the log of its sequence
+// without waiting for the write. The queued entries are written in a batch.
+func (w *WAL) AppendAsync(entry *WALEntry) {
+	w.mu.Lock()
+	w.queue(entry)
+	w.mu.Unlock()
+
+	select {
+	case w.flushCh <- struct{}{}:
+	default:
+	}
+}
+
+func (w *WAL) queue(entry *WALEntry) {
+	if entry.Time == 0 {
+		entry.Time = uint64(time.Now().UnixNano())
+	}
+	w.pending = append(w.pending, entry)
+}
+
+// nextIndex returns the index of the next entry of the sequence,
+// continuing after the entries written before a restart.
+func (w *WAL) nextIndex(sequence uint64) uint64 {
+	if next, ok := w.next[sequence]; ok {
+		return next
+	}
+	it := w.db.NewIterator(walSequencePrefix(sequence), nil)
+	defer it.Release()
+
+	next := uint64(0)
+	for it.Next() {
+		next++
+	}
+	return next
+}
+
+// flush writes the pending entries in a batch.
+//
+// Note, this method assumes the lock is held!
+func (w *WAL) flush() error {
+	if len(w.pending) == 0 {
+		return nil
+	}
+	batch := w.db.NewBatch()
+	defer batch.Release()
+
+	next := make(map[uint64]uint64)
+	for _, entry := range w.pending {
+		index, ok := next[entry.Sequence]
+		if !ok {
+			index = w.nextIndex(entry.Sequence)
+		}
+		enc, err := rlp.EncodeToBytes(entry)
+		if err != nil {
+			return err
+		}
+		if err := batch.Put(walKey(entry.Sequence, index), enc); err != nil {
+			return err
+		}
+		next[entry.Sequence] = index + 1
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	for sequence, index := range next {
+		w.next[sequence] = index
+	}
+	w.pending = nil
+	return nil
+}
+
+// Entries returns the entries of the given sequence in the order they were written.
+func (w *WAL) Entries(sequence uint64) ([]*WALEntry, error) {
+	w.mu.Lock()
+	err := w.flush()
+	w.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	it := w.db.NewIterator(walSequencePrefix(sequence), nil)
+	defer it.Release()
+
+	var entries []*WALEntry
+	for it.Next() {
+		entry := new(WALEntry)
+		if err := rlp.DecodeBytes(it.Value(), entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, it.Error()
+}
+
+// Prune deletes the entries of the sequences lower than the given one.
+func (w *WAL) Prune(sequence uint64) error {
+	it := w.db.NewIterator(walPrefix, nil)
+	defer it.Release()
+
+	batch := w.db.NewBatch()
+	defer batch.Release()
+	for it.Next() {
+		key := it.Key()
+		if binary.BigEndian.Uint64(key[len(walPrefix):]) >= sequence {
+			break
+		}
+		if err := batch.Delete(common.CopyBytes(key)); err != nil {
+			return err
+		}
+		if batch.ValueSize() > database.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for seq := range w.next {
+		if seq < sequence {
+			delete(w.next, seq)
+		}
+	}
+	return nil
+}
+
+// writeWAL appends an entry of the given view to the WAL, and returns after it is written.
+func (c *core) writeWAL(typ WALEntryType, code uint64, view *istanbul.View, payload []byte) error {
+	if c.wal == nil {
+		return nil
+	}
+	entry := &WALEntry{
+		Type:     typ,
+		Code:     code,
+		Sequence: view.Sequence.Uint64(),
+		Round:    view.Round.Uint64(),
+		Payload:  payload,
+	}
+	if err := c.wal.Append(entry); err != nil {
+		c.logger.Error("Failed to write the consensus WAL", "type", typ, "err", err)
+		return err
+	}
+	return nil
+}
+
+// writeSentWAL appends the message about to be sent to the WAL.
+func (c *core) writeSentWAL(msg *message, payload []byte) error {
+	if c.wal == nil {
+		return nil
+	}
+	view, err := msg.GetView()
+	if err != nil {
+		return err
+	}
+	return c.writeWAL(WALEntrySent, msg.Code, view, payload)
+}
+
+// writeReceivedWAL queues the received message to be written to the WAL of its view.
+// The messages of the past sequences are not written since they are never replayed.
+func (c *core) writeReceivedWAL(msg *message, payload []byte) {
+	if c.wal == nil || c.replaying {
+		return
+	}
+	view, err := msg.GetView()
+	if err != nil || (c.current != nil && view.Sequence.Cmp(c.current.Sequence()) < 0) {
+		return
+	}
+	c.wal.AppendAsync(&WALEntry{
+		Type:     WALEntryReceived,
+		Code:     msg.Code,
+		Sequence: view.Sequence.Uint64(),
+		Round:    view.Round.Uint64(),
+		Payload:  payload,
+	})
+}
+
+// lockHash records the lock on the current proposal in the WAL, and then locks it.
+// If the lock fails to be written, the proposal is not locked and the error is returned,
+// so that the validator does not vote on the proposal without the lock on disk.
+func (c *core) lockHash() error {
+	preprepare := c.current.Preprepare
+	if preprepare == nil || c.current.GetLockedHash() == preprepare.Proposal.Hash() {
+		return nil
+	}
+	payload, err := rlp.EncodeToBytes(preprepare)
+	if err != nil {
+		c.logger.Error("Failed to encode the locked preprepare", "err", err)
+		return err
+	}
+	if err := c.writeWAL(WALEntryLock, msgPreprepare, c.currentView(), payload); err != nil {
+		return err
+	}
+	c.current.LockHash()
+	return nil
+}
+
+// unlockHash records the release of the lock in the WAL, and then releases it.
+// The lock is released even if the WAL fails to be written, since a stale lock
+// entry only makes the restarted validator stick to the proposal.
+func (c *core) unlockHash() {
+	c.writeWAL(WALEntryUnlock, 0, c.currentView(), nil)
+	c.current.UnlockHash()
+}
+
+// pruneWAL deletes the entries of the old heights, keeping walRetainedHeights committed heights.
+func (c *core) pruneWAL(sequence uint64) {
+	if c.wal == nil || sequence <= walRetainedHeights {
+		return
+	}
+	if err := c.wal.Prune(sequence - walRetainedHeights); err != nil {
+		c.logger.Warn("Failed to prune the consensus WAL", "err", err)
+	}
+}
+
+// replayWAL restores the state of the current height from the WAL written before a restart.
+// The lock is restored, and the validator moves to the round next to the last one it voted
+// in, so that it never votes twice in a round. The received messages are then handled again.
+func (c *core) replayWAL() {
+	if c.wal == nil || c.current == nil {
+		return
+	}
+	sequence := c.current.Sequence().Uint64()
+	entries, err := c.wal.Entries(sequence)
+	if err != nil {
+		c.logger.Error("Failed to read the consensus WAL", "sequence", sequence, "err", err)
+		return
+	}
+	if len(entries) == 0 {
+		return
+	}
+
+	var (
+		locked    *istanbul.Preprepare
+		lastRound uint64
+		voted     bool // whether the validator voted in lastRound
+		received  [][]byte
+	)
+	for _, entry := range entries {
+		switch entry.Type {
+		case WALEntrySent:
+			if entry.Round > lastRound {
+				lastRound, voted = entry.Round, false
+			}
+			if entry.Round == lastRound && entry.Code != msgRoundChange {
+				voted = true
+			}
+		case WALEntryReceived:
+			received = append(received, entry.Payload)
+		case WALEntryLock:
+			preprepare := new(istanbul.Preprepare)
+			if err := rlp.DecodeBytes(entry.Payload, preprepare); err != nil {
+				c.logger.Error("Failed to decode the locked preprepare", "err", err)
+				return
+			}
+			locked = preprepare
+		case WALEntryUnlock:
+			locked = nil
+		}
+	}
+
+	if locked != nil {
+		c.current = newRoundState(c.currentView(), c.currentCommittee.ValSet(), locked.Proposal.Hash(), locked, nil, c.backend.HasBadProposal)
+	}
+	round := lastRound
+	if voted {
+		round++
+	}
+	c.logger.Warn("Replay the consensus WAL", "sequence", sequence, "entries", len(entries), "round", round, "locked", locked != nil)
+	if round > c.current.Round().Uint64() {
+		c.sendRoundChange(new(big.Int).SetUint64(round))
+	}
+
+	c.replaying = true
+	defer func() { c.replaying = false }()
+	for _, payload := range received {
+		c.handleMsg(payload)
+	}
+}
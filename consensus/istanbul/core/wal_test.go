@@ -0,0 +1,216 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+package core
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/consensus/istanbul"
+	"github.com/kaiachain/kaia/fork"
+	"github.com/kaiachain/kaia/params"
+	"github.com/kaiachain/kaia/rlp"
+	"github.com/kaiachain/kaia/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWAL_AppendAndPrune(t *testing.T) {
+	db := database.NewMemoryDBManager().GetMiscDB()
+	wal := NewWAL(db)
+
+	for seq := uint64(1); seq <= 3; seq++ {
+		for round := uint64(0); round < 2; round++ {
+			require.NoError(t, wal.Append(&WALEntry{Type: WALEntrySent, Code: msgPrepare, Sequence: seq, Round: round}))
+		}
+	}
+
+	// A restarted WAL continues after the existing entries
+	wal = NewWAL(db)
+	require.NoError(t, wal.Append(&WALEntry{Type: WALEntryReceived, Code: msgCommit, Sequence: 3, Round: 1, Payload: []byte{1}}))
+
+	entries, err := wal.Entries(3)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, uint64(0), entries[0].Round)
+	assert.Equal(t, WALEntryReceived, entries[2].Type)
+	assert.Equal(t, []byte{1}, entries[2].Payload)
+	assert.NotZero(t, entries[2].Time)
+
+	require.NoError(t, wal.Prune(3))
+	for seq, expected := range map[uint64]int{1: 0, 2: 0, 3: 3} {
+		entries, err := wal.Entries(seq)
+		require.NoError(t, err)
+		assert.Len(t, entries, expected, "sequence %d", seq)
+	}
+}
+
+func TestWAL_AppendAsync(t *testing.T) {
+	db := database.NewMemoryDBManager().GetMiscDB()
+	wal := NewWAL(db)
+
+	// The queued entries are written before the entry written synchronously
+	wal.AppendAsync(&WALEntry{Type: WALEntryReceived, Code: msgPrepare, Sequence: 1, Round: 0, Payload: []byte{1}})
+	wal.AppendAsync(&WALEntry{Type: WALEntryReceived, Code: msgPrepare, Sequence: 2, Round: 0, Payload: []byte{2}})
+	require.NoError(t, wal.Append(&WALEntry{Type: WALEntrySent, Code: msgCommit, Sequence: 1, Round: 0}))
+
+	entries, err := wal.Entries(1)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, WALEntryReceived, entries[0].Type)
+	assert.Equal(t, WALEntrySent, entries[1].Type)
+
+	// The entries are kept under the sequence of their own view
+	entries, err = wal.Entries(2)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, []byte{2}, entries[0].Payload)
+
+	// The background writer writes the queued entries, and stopping it writes the rest
+	wal.Start()
+	for i := 0; i < 10; i++ {
+		wal.AppendAsync(&WALEntry{Type: WALEntryReceived, Code: msgCommit, Sequence: 3, Round: uint64(i)})
+	}
+	require.NoError(t, wal.Stop())
+
+	it := db.NewIterator(walSequencePrefix(3), nil)
+	defer it.Release()
+	count := 0
+	for it.Next() {
+		count++
+	}
+	assert.Equal(t, 10, count)
+}
+
+func TestCore_replayWAL(t *testing.T) {
+	fork.SetHardForkBlockNumberConfig(&params.ChainConfig{})
+	defer fork.ClearHardForkBlockNumberConfig()
+
+	validatorAddrs, validatorKeyMap := genValidators(6)
+	mockBackend, mockCtrl := newMockBackend(t, validatorAddrs)
+	defer mockCtrl.Finish()
+	mockBackend.EXPECT().HasBadProposal(gomock.Any()).Return(false).AnyTimes()
+
+	lastProposal, _ := mockBackend.LastProposal()
+	proposal, err := genBlock(lastProposal.(*types.Block), validatorKeyMap[validatorAddrs[0]])
+	require.NoError(t, err)
+
+	// Before the restart, the validator locked on the proposal and sent a commit in round 0
+	db := database.NewMemoryDBManager().GetMiscDB()
+	wal := NewWAL(db)
+	locked, err := rlp.EncodeToBytes(&istanbul.Preprepare{
+		View:     &istanbul.View{Sequence: big.NewInt(1), Round: big.NewInt(0)},
+		Proposal: proposal,
+	})
+	require.NoError(t, err)
+	require.NoError(t, wal.Append(&WALEntry{Type: WALEntrySent, Code: msgPrepare, Sequence: 1, Round: 0}))
+	require.NoError(t, wal.Append(&WALEntry{Type: WALEntryLock, Code: msgPreprepare, Sequence: 1, Round: 0, Payload: locked}))
+	require.NoError(t, wal.Append(&WALEntry{Type: WALEntrySent, Code: msgCommit, Sequence: 1, Round: 0}))
+
+	istConfig := istanbul.DefaultConfig.Copy()
+	istConfig.ProposerPolicy = istanbul.WeightedRandom
+
+	istCore := New(mockBackend, istConfig, db).(*core)
+	require.NoError(t, istCore.Start())
+	defer istCore.Stop()
+
+	// The lock is restored, and the validator moves to the next round not to vote twice in round 0
+	assert.True(t, istCore.current.IsHashLocked())
+	assert.Equal(t, proposal.Hash(), istCore.current.GetLockedHash())
+	assert.Equal(t, int64(1), istCore.current.Round().Int64())
+	assert.True(t, istCore.waitingForRoundChange)
+
+	// The round change is written to the WAL as well
+	entries, err := wal.Entries(1)
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+	assert.Equal(t, WALEntrySent, entries[3].Type)
+	assert.Equal(t, uint64(msgRoundChange), entries[3].Code)
+	assert.Equal(t, uint64(1), entries[3].Round)
+}
+
+// failingWALDB is a database whose batch writes fail while fail is set.
+type failingWALDB struct {
+	database.Database
+	fail bool
+}
+
+func (db *failingWALDB) NewBatch() database.Batch {
+	return &failingWALBatch{Batch: db.Database.NewBatch(), db: db}
+}
+
+type failingWALBatch struct {
+	database.Batch
+	db *failingWALDB
+}
+
+func (b *failingWALBatch) Write() error {
+	if b.db.fail {
+		return errors.New("write failure")
+	}
+	return b.Batch.Write()
+}
+
+func TestCore_lockHash(t *testing.T) {
+	fork.SetHardForkBlockNumberConfig(&params.ChainConfig{})
+	defer fork.ClearHardForkBlockNumberConfig()
+
+	validatorAddrs, validatorKeyMap := genValidators(6)
+	mockBackend, mockCtrl := newMockBackend(t, validatorAddrs)
+	defer mockCtrl.Finish()
+	mockBackend.EXPECT().HasBadProposal(gomock.Any()).Return(false).AnyTimes()
+
+	lastProposal, _ := mockBackend.LastProposal()
+	proposal, err := genBlock(lastProposal.(*types.Block), validatorKeyMap[validatorAddrs[0]])
+	require.NoError(t, err)
+
+	db := &failingWALDB{Database: database.NewMemoryDBManager().GetMiscDB()}
+	istConfig := istanbul.DefaultConfig.Copy()
+	istConfig.ProposerPolicy = istanbul.WeightedRandom
+	istCore := New(mockBackend, istConfig, db).(*core)
+	require.NoError(t, istCore.Start())
+	defer istCore.Stop()
+	istCore.current.Preprepare = &istanbul.Preprepare{View: istCore.currentView(), Proposal: proposal}
+
+	// The proposal is not locked if the lock fails to be written
+	db.fail = true
+	assert.Error(t, istCore.lockHash())
+	assert.False(t, istCore.current.IsHashLocked())
+
+	db.fail = false
+	require.NoError(t, istCore.lockHash())
+	assert.Equal(t, proposal.Hash(), istCore.current.GetLockedHash())
+	entries, err := istCore.wal.Entries(1)
+	require.NoError(t, err)
+	numLocks := 0
+	for _, entry := range entries {
+		if entry.Type == WALEntryLock {
+			numLocks++
+		}
+	}
+	assert.Equal(t, 1, numLocks, "the failed lock entry must not be written later")
+	assert.Equal(t, WALEntryLock, entries[len(entries)-1].Type)
+
+	// The unlock is written to the WAL as well
+	istCore.unlockHash()
+	assert.False(t, istCore.current.IsHashLocked())
+	entries, err = istCore.wal.Entries(1)
+	require.NoError(t, err)
+	assert.Equal(t, WALEntryUnlock, entries[len(entries)-1].Type)
+}
@@ -0,0 +1,295 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package lightclient verifies Kaia block headers without the state of the chain,
+// e.g. for a bridge relayer that follows the chain from outside a full node.
+//
+// A LightClient starts from a trusted Checkpoint: a header at an epoch block, and the council
+// and the governance parameters of the next block. It then verifies the following headers one
+// by one: the link to the parent, the proposer seal and the committed seals. Along the way, it
+// tracks the council by the AddValidator and RemoveValidator votes in the headers, and the header
+// governance parameters by the Governance field of the epoch blocks, as kaiax/valset and kaiax/gov do.
+//
+// Without the state, the light client cannot tell the demoted validators, the committee of a round
+// drawn from the staking amounts, nor the parameters governed by the GovParamContract. Therefore the
+// caller gives the committee of each header, e.g. valset_getCommittee(Header.Number) of a trusted node,
+// in which case the committed seals must be signed by the committee members and reach its quorum.
+// The committee can be omitted only if the council is not larger than the committee size, in which case
+// the light client accepts the committed seals signed by any council member, but requires the quorum of
+// the whole council, which is never smaller than the quorum required by a full node. A header committed
+// by a committee shrunk by the demoted validators may thus be rejected unless its committee is given.
+package lightclient
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/consensus"
+	"github.com/kaiachain/kaia/crypto/bls"
+	"github.com/kaiachain/kaia/kaiax/gov"
+	"github.com/kaiachain/kaia/kaiax/gov/headergov"
+	"github.com/kaiachain/kaia/kaiax/valset"
+	"github.com/kaiachain/kaia/params"
+)
+
+var (
+	ErrInvalidCheckpoint          = errors.New("invalid checkpoint")
+	ErrCheckpointNotEpoch         = errors.New("checkpoint is not an epoch block")
+	ErrUnauthorizedProposer       = errors.New("proposer is not in the council")
+	ErrEmptyCommittedSeals        = errors.New("empty committed seals")
+	ErrInvalidCommittedSeals      = errors.New("invalid committed seals")
+	ErrInsufficientCommittedSeals = errors.New("insufficient committed seals")
+	ErrUnknownCommittee           = errors.New("committee cannot be determined without the state")
+	ErrInvalidCommittee           = errors.New("invalid committee")
+	ErrNoBlsPublicKey             = errors.New("no BLS public key of the committer")
+	ErrInvalidAggregatedSeal      = errors.New("invalid aggregated seal")
+	ErrGovInNonEpochBlock         = errors.New("governance is not allowed in non-epoch block")
+)
+
+// Checkpoint is the trusted state of the chain a LightClient starts from.
+type Checkpoint struct {
+	// Header is the trusted header, which must be at an epoch block so that every governance
+	// parameter change that is not yet effective is found in this or the following headers.
+	Header *types.Header
+
+	// Council is the council of the block next to the Header, e.g. valset_getCouncil(Header.Number+1).
+	Council []common.Address
+
+	// Params is the governance parameter set of the block next to the Header,
+	// e.g. governance_getParams(Header.Number+1).
+	Params gov.ParamSet
+
+	// BlsPublicKeys is the KIP-113 BLS public keys of the council members, e.g. kaia_getBlsInfos.
+	// It is only required to verify the aggregated seals since the BlsSeal hardfork.
+	BlsPublicKeys map[common.Address]bls.PublicKey
+}
+
+// LightClient verifies a chain of headers from a trusted checkpoint. It is safe for concurrent use.
+type LightClient struct {
+	config *params.ChainConfig
+	epoch  uint64
+
+	mu      sync.RWMutex
+	head    *types.Header
+	council *valset.AddressSet // council of the block next to head
+	history headergov.History  // header governance parameters by the gov block numbers
+	blsKeys map[common.Address]bls.PublicKey
+}
+
+// NewLightClient returns a LightClient of the chain with the given config, starting from the given checkpoint.
+func NewLightClient(config *params.ChainConfig, cp *Checkpoint) (*LightClient, error) {
+	if config == nil || cp == nil || cp.Header == nil || cp.Header.Number == nil || len(cp.Council) == 0 ||
+		cp.Params.Epoch == 0 || cp.Params.CommitteeSize == 0 {
+		return nil, ErrInvalidCheckpoint
+	}
+	var (
+		num   = cp.Header.Number.Uint64()
+		epoch = cp.Params.Epoch
+	)
+	if num%epoch != 0 {
+		return nil, ErrCheckpointNotEpoch
+	}
+
+	lc := &LightClient{
+		config:  config,
+		epoch:   epoch,
+		head:    types.CopyHeader(cp.Header),
+		council: valset.NewAddressSet(cp.Council),
+		history: headergov.History{prevEpochStart(num+1, epoch, config.IsKoreForkEnabled(new(big.Int).SetUint64(num+1))): cp.Params},
+		blsKeys: make(map[common.Address]bls.PublicKey),
+	}
+	for addr, pub := range cp.BlsPublicKeys {
+		lc.blsKeys[addr] = pub
+	}
+	// The governance of the checkpoint is not yet effective at its next block
+	if err := lc.applyGovernance(cp.Header); err != nil {
+		return nil, err
+	}
+	return lc, nil
+}
+
+// Head returns the last verified header.
+func (lc *LightClient) Head() *types.Header {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+	return types.CopyHeader(lc.head)
+}
+
+// Council returns the council of the block next to the last verified header.
+func (lc *LightClient) Council() []common.Address {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+	return lc.council.List()
+}
+
+// Params returns the header governance parameters of the block next to the last verified header.
+func (lc *LightClient) Params() gov.ParamSet {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+	return lc.paramsAt(lc.head.Number.Uint64() + 1)
+}
+
+// SetBlsPublicKey sets the BLS public key of a validator, e.g. the one added to the council after the checkpoint.
+func (lc *LightClient) SetBlsPublicKey(addr common.Address, pub bls.PublicKey) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	lc.blsKeys[addr] = pub
+}
+
+// InsertHeaders verifies the given headers in order, each of which must be the child of the previous one,
+// starting from the child of the last verified header. The committees, if not nil, are the committees of the
+// headers in the same order, each of which can be nil if unknown. The headers are inserted up to the first
+// invalid one, whose index is returned with the error. Otherwise, the number of the headers is returned.
+func (lc *LightClient) InsertHeaders(headers []*types.Header, committees [][]common.Address) (int, error) {
+	if committees != nil && len(committees) != len(headers) {
+		return 0, ErrInvalidCommittee
+	}
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	for i, header := range headers {
+		var committee []common.Address
+		if committees != nil {
+			committee = committees[i]
+		}
+		if err := lc.verifyHeader(header, committee); err != nil {
+			return i, err
+		}
+		if err := lc.applyHeader(header); err != nil {
+			return i, err
+		}
+		lc.head = types.CopyHeader(header)
+	}
+	return len(headers), nil
+}
+
+// verifyHeader checks whether the header is the child of the head, and is sealed by the council and committed by the committee.
+func (lc *LightClient) verifyHeader(header *types.Header, committee []common.Address) error {
+	if header == nil || header.Number == nil {
+		return consensus.ErrUnknownAncestor
+	}
+	num := header.Number.Uint64()
+	if num != lc.head.Number.Uint64()+1 {
+		return consensus.ErrInvalidNumber
+	}
+	if header.ParentHash != lc.head.Hash() {
+		return consensus.ErrUnknownAncestor
+	}
+
+	extra, err := types.ExtractIstanbulExtra(header)
+	if err != nil {
+		return err
+	}
+	proposer, err := sealer(header, extra)
+	if err != nil {
+		return err
+	}
+	if !lc.council.Contains(proposer) {
+		return ErrUnauthorizedProposer
+	}
+
+	committeeSize := lc.paramsAt(num).CommitteeSize
+	members, err := lc.committee(committee, committeeSize)
+	if err != nil {
+		return err
+	}
+	if lc.config.IsBlsSealForkEnabled(header.Number) {
+		return lc.verifyAggregatedSeal(header, extra, members, committee != nil)
+	} else if extra.HasAggregatedSeal() {
+		return ErrInvalidCommittedSeals
+	}
+	return lc.verifyCommittedSeals(header, extra, members)
+}
+
+// committee returns the members of the committee who can sign the committed seals and whose quorum is required.
+// If the committee is given by the caller, it must be drawn from the council. Otherwise, the council must not be larger
+// than the committee size, since a council member outside the committee could sign; then the whole council is the committee.
+func (lc *LightClient) committee(committee []common.Address, committeeSize uint64) (*valset.AddressSet, error) {
+	if committee == nil {
+		if uint64(lc.council.Len()) > committeeSize {
+			return nil, ErrUnknownCommittee
+		}
+		return lc.council, nil
+	}
+	if len(committee) == 0 || uint64(len(committee)) > committeeSize {
+		return nil, ErrInvalidCommittee
+	}
+	members := valset.NewAddressSet(nil)
+	for _, addr := range committee {
+		if !lc.council.Contains(addr) || members.Contains(addr) {
+			return nil, ErrInvalidCommittee
+		}
+		members.Add(addr)
+	}
+	return members, nil
+}
+
+// applyHeader applies the governance and the validator vote of the verified header.
+func (lc *LightClient) applyHeader(header *types.Header) error {
+	if err := lc.applyGovernance(header); err != nil {
+		return err
+	}
+	// The validator vote changes the council from the next block, but never removes the governing node.
+	valset.ApplyVote(header, lc.council, lc.paramsAt(header.Number.Uint64()).GoverningNode)
+	return nil
+}
+
+// applyGovernance records the governance of an epoch block, which becomes effective from the next epoch.
+func (lc *LightClient) applyGovernance(header *types.Header) error {
+	if len(header.Governance) == 0 {
+		return nil
+	}
+	num := header.Number.Uint64()
+	if num%lc.epoch != 0 {
+		return ErrGovInNonEpochBlock
+	}
+	gd, err := headergov.GovBytes(header.Governance).ToGovData()
+	if err != nil {
+		return err
+	}
+	pset, err := lc.history.Search(num)
+	if err != nil {
+		return err
+	}
+	if err := pset.SetFromMap(gd.Items()); err != nil {
+		return err
+	}
+	lc.history[num] = pset
+	return nil
+}
+
+// paramsAt returns the header governance parameters of the given block.
+func (lc *LightClient) paramsAt(num uint64) gov.ParamSet {
+	isKore := lc.config.IsKoreForkEnabled(new(big.Int).SetUint64(num))
+	pset, _ := lc.history.Search(prevEpochStart(num, lc.epoch, isKore)) // the checkpoint is always found
+	return pset
+}
+
+// prevEpochStart returns the gov block number whose governance is effective at the given block.
+// It is the same as PrevEpochStart of kaiax/gov/headergov/impl.
+func prevEpochStart(num, epoch uint64, isKore bool) uint64 {
+	if num <= epoch {
+		return 0
+	}
+	if !isKore {
+		num -= 1
+	}
+	return num - num%epoch - epoch
+}
@@ -0,0 +1,390 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package lightclient
+
+import (
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/consensus"
+	istanbulSigner "github.com/kaiachain/kaia/consensus/istanbul/signer"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/crypto/bls"
+	"github.com/kaiachain/kaia/kaiax/gov"
+	"github.com/kaiachain/kaia/kaiax/gov/headergov"
+	"github.com/kaiachain/kaia/params"
+	"github.com/kaiachain/kaia/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testEpoch = 10
+
+type testValidator struct {
+	signer *istanbulSigner.LocalSigner
+	blsPub bls.PublicKey
+}
+
+func newTestValidators(t *testing.T, n int) []*testValidator {
+	vals := make([]*testValidator, n)
+	for i := range vals {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		blsKey, err := bls.RandKey()
+		require.NoError(t, err)
+		vals[i] = &testValidator{istanbulSigner.NewLocalSigner(key, blsKey, nil), blsKey.PublicKey()}
+	}
+	// Sorted like the council, i.e. by the checksummed address
+	sort.Slice(vals, func(i, j int) bool {
+		return vals[i].signer.Address().String() < vals[j].signer.Address().String()
+	})
+	return vals
+}
+
+func addresses(vals []*testValidator) []common.Address {
+	addrs := make([]common.Address, len(vals))
+	for i, val := range vals {
+		addrs[i] = val.signer.Address()
+	}
+	return addrs
+}
+
+func writeExtra(t *testing.T, header *types.Header, extra *types.IstanbulExtra) {
+	payload, err := rlp.EncodeToBytes(extra)
+	require.NoError(t, err)
+	header.Extra = append(header.Extra[:types.IstanbulExtraVanity], payload...)
+}
+
+// makeHeader returns the child of the parent sealed by the proposer, and committed by the committers with the ECDSA seals.
+func makeHeader(t *testing.T, parent *types.Header, proposer *testValidator, committers []*testValidator, vote, governance []byte) *types.Header {
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		Time:       new(big.Int).Add(parent.Time, common.Big1),
+		BlockScore: common.Big1,
+		Extra:      make([]byte, types.IstanbulExtraVanity),
+		Vote:       vote,
+		Governance: governance,
+	}
+	extra := &types.IstanbulExtra{Validators: []common.Address{}, Seal: []byte{}, CommittedSeal: [][]byte{}}
+	writeExtra(t, header, extra)
+
+	var err error
+	extra.Seal, err = proposer.signer.SignSeal(header)
+	require.NoError(t, err)
+	writeExtra(t, header, extra)
+
+	for _, committer := range committers {
+		seal, err := committer.signer.SignCommittedSeal(header)
+		require.NoError(t, err)
+		extra.CommittedSeal = append(extra.CommittedSeal, seal)
+	}
+	writeExtra(t, header, extra)
+	return header
+}
+
+// makeBlsHeader returns the child of the parent sealed by the proposer, and committed by the committers
+// with the aggregated BLS seal and the signer bitmap over the committee.
+func makeBlsHeader(t *testing.T, parent *types.Header, proposer *testValidator, committee, committers []*testValidator, vote []byte) *types.Header {
+	header := makeHeader(t, parent, proposer, nil, vote, nil)
+	extra, err := types.ExtractIstanbulExtra(header)
+	require.NoError(t, err)
+
+	var (
+		seals   [][]byte
+		indices []int
+	)
+	for _, committer := range committers {
+		seal, err := committer.signer.SignBlsCommittedSeal(header)
+		require.NoError(t, err)
+		seals = append(seals, seal)
+		for i, member := range committee {
+			if member == committer {
+				indices = append(indices, i)
+			}
+		}
+	}
+	aggregated, err := bls.AggregateCompressedSignatures(seals)
+	require.NoError(t, err)
+	extra.AggregatedSeal, extra.SignerBitmap = aggregated.Marshal(), types.NewSignerBitmap(len(committee), indices)
+	writeExtra(t, header, extra)
+	return header
+}
+
+func makeVote(t *testing.T, voter *testValidator, name gov.ParamName, value any) []byte {
+	vote, err := headergov.NewVoteData(voter.signer.Address(), string(name), value).ToVoteBytes()
+	require.NoError(t, err)
+	return vote
+}
+
+func newTestCheckpoint(t *testing.T, council []*testValidator, committeeSize uint64) *Checkpoint {
+	header := &types.Header{Number: common.Big0, Time: common.Big0, BlockScore: common.Big1, Extra: make([]byte, types.IstanbulExtraVanity)}
+	writeExtra(t, header, &types.IstanbulExtra{Validators: addresses(council), Seal: []byte{}, CommittedSeal: [][]byte{}})
+
+	blsKeys := make(map[common.Address]bls.PublicKey)
+	for _, val := range council {
+		blsKeys[val.signer.Address()] = val.blsPub
+	}
+	pset := *gov.GetDefaultGovernanceParamSet()
+	pset.Epoch, pset.CommitteeSize = testEpoch, committeeSize
+	return &Checkpoint{Header: header, Council: addresses(council), Params: pset, BlsPublicKeys: blsKeys}
+}
+
+func TestNewLightClient(t *testing.T) {
+	var (
+		vals   = newTestValidators(t, 4)
+		config = &params.ChainConfig{}
+	)
+	_, err := NewLightClient(config, &Checkpoint{})
+	assert.ErrorIs(t, err, ErrInvalidCheckpoint)
+
+	cp := newTestCheckpoint(t, vals, 21)
+	cp.Header.Number = big.NewInt(testEpoch + 1)
+	_, err = NewLightClient(config, cp)
+	assert.ErrorIs(t, err, ErrCheckpointNotEpoch)
+
+	// The governance of the checkpoint is effective from the next epoch,
+	// which starts at the block 2*epoch+1 before the Kore hardfork.
+	cp.Header.Number = big.NewInt(testEpoch)
+	cp.Header.Governance, err = headergov.NewGovData(gov.PartialParamSet{gov.IstanbulCommitteeSize: uint64(7)}).ToGovBytes()
+	require.NoError(t, err)
+	lc, err := NewLightClient(config, cp)
+	require.NoError(t, err)
+	assert.Equal(t, addresses(vals), lc.Council())
+	assert.Equal(t, uint64(21), lc.Params().CommitteeSize)
+	assert.Equal(t, uint64(21), lc.paramsAt(2*testEpoch).CommitteeSize)
+	assert.Equal(t, uint64(7), lc.paramsAt(2*testEpoch+1).CommitteeSize)
+}
+
+func TestLightClient(t *testing.T) {
+	var (
+		vals      = newTestValidators(t, 5)
+		config    = &params.ChainConfig{KoreCompatibleBlock: common.Big0}
+		cp        = newTestCheckpoint(t, vals[:4], 21)
+		headers   []*types.Header
+		parent    = cp.Header
+		committee = vals[:4]
+	)
+	lc, err := NewLightClient(config, cp)
+	require.NoError(t, err)
+
+	for num := 1; num <= 25; num++ {
+		var vote, governance []byte
+		switch num {
+		case 3:
+			// vals[4] joins the council from the block 4
+			vote = makeVote(t, vals[0], gov.AddValidator, vals[4].signer.Address())
+		case 4:
+			committee = vals
+		case testEpoch:
+			// The committee size is reduced from the next epoch, which starts at the block 2*epoch after the Kore hardfork
+			governance, err = headergov.NewGovData(gov.PartialParamSet{gov.IstanbulCommitteeSize: uint64(1)}).ToGovBytes()
+			require.NoError(t, err)
+		}
+		committers := committee[len(committee)-3:] // 3 out of 4 or 5
+		if num >= 2*testEpoch {
+			committers = committee[:1] // 1 out of 1
+		}
+		header := makeHeader(t, parent, committee[num%len(committee)], committers, vote, governance)
+		headers = append(headers, header)
+		parent = header
+	}
+
+	n, err := lc.InsertHeaders(headers[:2*testEpoch-2], nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2*testEpoch-2, n)
+	assert.Equal(t, addresses(vals), lc.Council())
+	assert.Equal(t, uint64(21), lc.Params().CommitteeSize)
+
+	// A single committed seal is insufficient before the committee size changes
+	_, err = lc.InsertHeaders([]*types.Header{makeHeader(t, headers[2*testEpoch-3], vals[0], vals[:1], nil, nil)}, nil)
+	assert.ErrorIs(t, err, ErrInsufficientCommittedSeals)
+	_, err = lc.InsertHeaders(headers[2*testEpoch-2:2*testEpoch-1], nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), lc.Params().CommitteeSize)
+	// The committee must be given once the council is larger than the committee size
+	_, err = lc.InsertHeaders(headers[2*testEpoch-1:], nil)
+	assert.ErrorIs(t, err, ErrUnknownCommittee)
+	committees := make([][]common.Address, len(headers[2*testEpoch-1:]))
+	for i := range committees {
+		committees[i] = addresses(committee[:1])
+	}
+	_, err = lc.InsertHeaders(headers[2*testEpoch-1:], committees)
+	require.NoError(t, err)
+	assert.Equal(t, headers[len(headers)-1].Hash(), lc.Head().Hash())
+
+	// vals[1] leaves the council from the block 27
+	header := makeHeader(t, parent, vals[0], vals[:1], makeVote(t, vals[0], gov.RemoveValidator, vals[1].signer.Address()), nil)
+	_, err = lc.InsertHeaders([]*types.Header{header}, committees[:1])
+	require.NoError(t, err)
+	assert.Equal(t, addresses([]*testValidator{vals[0], vals[2], vals[3], vals[4]}), lc.Council())
+	_, err = lc.InsertHeaders([]*types.Header{makeHeader(t, header, vals[1], vals[:1], nil, nil)}, committees[:1])
+	assert.ErrorIs(t, err, ErrUnauthorizedProposer)
+}
+
+func TestLightClient_Invalid(t *testing.T) {
+	var (
+		vals     = newTestValidators(t, 5)
+		council  = vals[:4]
+		outsider = vals[4]
+		config   = &params.ChainConfig{}
+		cp       = newTestCheckpoint(t, council, 21)
+		valid    = makeHeader(t, cp.Header, council[0], council[:3], nil, nil)
+		sibling  = makeHeader(t, cp.Header, council[1], council[:3], nil, nil)
+	)
+	governance, err := headergov.NewGovData(gov.PartialParamSet{gov.IstanbulCommitteeSize: uint64(1)}).ToGovBytes()
+	require.NoError(t, err)
+
+	testcases := []struct {
+		desc   string
+		header *types.Header
+		err    error
+	}{
+		{"non-contiguous number", makeHeader(t, makeHeader(t, valid, council[0], council[:3], nil, nil), council[0], council[:3], nil, nil), consensus.ErrInvalidNumber},
+		{"unknown parent", makeHeader(t, sibling, council[0], council[:3], nil, nil), consensus.ErrUnknownAncestor},
+		{"proposer not in the council", makeHeader(t, valid, outsider, council[:3], nil, nil), ErrUnauthorizedProposer},
+		{"no committed seal", makeHeader(t, valid, council[0], nil, nil, nil), ErrEmptyCommittedSeals},
+		{"committer not in the council", makeHeader(t, valid, council[0], []*testValidator{council[0], council[1], outsider}, nil, nil), ErrInvalidCommittedSeals},
+		{"duplicated committed seals", makeHeader(t, valid, council[0], []*testValidator{council[0], council[1], council[0]}, nil, nil), ErrInvalidCommittedSeals},
+		{"insufficient committed seals", makeHeader(t, valid, council[0], council[:2], nil, nil), ErrInsufficientCommittedSeals},
+		{"aggregated seal before the BlsSeal hardfork", makeBlsHeader(t, valid, council[0], council, council[:3], nil), ErrInvalidCommittedSeals},
+		{"governance in a non-epoch block", makeHeader(t, valid, council[0], council[:3], nil, governance), ErrGovInNonEpochBlock},
+	}
+	for _, tc := range testcases {
+		lc, err := NewLightClient(config, cp)
+		require.NoError(t, err)
+
+		// The headers are inserted up to the invalid one
+		idx, err := lc.InsertHeaders([]*types.Header{valid, tc.header}, nil)
+		assert.Equal(t, 1, idx, tc.desc)
+		assert.ErrorIs(t, err, tc.err, tc.desc)
+		assert.Equal(t, valid.Hash(), lc.Head().Hash(), tc.desc)
+	}
+}
+
+func TestLightClient_AggregatedSeal(t *testing.T) {
+	var (
+		vals   = newTestValidators(t, 5)
+		config = &params.ChainConfig{BlsSealCompatibleBlock: common.Big0}
+		cp     = newTestCheckpoint(t, vals[:4], 21)
+	)
+	lc, err := NewLightClient(config, cp)
+	require.NoError(t, err)
+
+	// vals[4] joins the council from the block 2
+	vote := makeVote(t, vals[0], gov.AddValidator, vals[4].signer.Address())
+	header := makeBlsHeader(t, cp.Header, vals[0], vals[:4], vals[1:4], vote)
+	_, err = lc.InsertHeaders([]*types.Header{header}, nil)
+	require.NoError(t, err)
+
+	// The signer bitmap that does not match the aggregated seal
+	forged := makeBlsHeader(t, header, vals[0], vals, vals[:3], nil)
+	extra, err := types.ExtractIstanbulExtra(forged)
+	require.NoError(t, err)
+	extra.SignerBitmap = types.NewSignerBitmap(len(vals), []int{1, 2, 3})
+	writeExtra(t, forged, extra)
+
+	testcases := []struct {
+		desc   string
+		header *types.Header
+		err    error
+	}{
+		{"insufficient committers", makeBlsHeader(t, header, vals[0], vals, vals[:2], nil), ErrInsufficientCommittedSeals},
+		{"forged signer bitmap", forged, ErrInvalidAggregatedSeal},
+		{"ECDSA committed seals", makeHeader(t, header, vals[0], vals[:3], nil, nil), ErrInvalidCommittedSeals},
+		{"no BLS public key of the added validator", makeBlsHeader(t, header, vals[0], vals, vals[2:], nil), ErrNoBlsPublicKey},
+	}
+	for _, tc := range testcases {
+		_, err := lc.InsertHeaders([]*types.Header{tc.header}, nil)
+		assert.ErrorIs(t, err, tc.err, tc.desc)
+	}
+
+	lc.SetBlsPublicKey(vals[4].signer.Address(), vals[4].blsPub)
+	_, err = lc.InsertHeaders([]*types.Header{makeBlsHeader(t, header, vals[0], vals, vals[2:], nil)}, nil)
+	assert.NoError(t, err)
+
+	// The committee is unknown if it is sampled from the council
+	cp.Params.CommitteeSize = 3
+	lc, err = NewLightClient(config, cp)
+	require.NoError(t, err)
+	header = makeBlsHeader(t, cp.Header, vals[0], vals[:3], vals[:3], nil)
+	_, err = lc.InsertHeaders([]*types.Header{header}, nil)
+	assert.ErrorIs(t, err, ErrUnknownCommittee)
+
+	// The signer bitmap is over the committee given by the caller
+	_, err = lc.InsertHeaders([]*types.Header{header}, [][]common.Address{addresses(vals[1:4])})
+	assert.ErrorIs(t, err, ErrInvalidAggregatedSeal)
+	n, err := lc.InsertHeaders([]*types.Header{header}, [][]common.Address{addresses(vals[:3])})
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func TestLightClient_Committee(t *testing.T) {
+	var (
+		vals      = newTestValidators(t, 8)
+		council   = vals[:7]
+		outsider  = vals[7]
+		committee = council[:4] // the others are demoted
+		config    = &params.ChainConfig{}
+		cp        = newTestCheckpoint(t, council, 21)
+		header    = makeHeader(t, cp.Header, committee[0], committee[:3], nil, nil)
+	)
+
+	testcases := []struct {
+		desc       string
+		header     *types.Header
+		committees [][]common.Address
+		err        error
+	}{
+		{"quorum of the whole council", header, nil, ErrInsufficientCommittedSeals},
+		{"unknown committee", header, [][]common.Address{nil}, ErrInsufficientCommittedSeals},
+		{"committees not matching the headers", header, [][]common.Address{}, ErrInvalidCommittee},
+		{"empty committee", header, [][]common.Address{{}}, ErrInvalidCommittee},
+		{"committee not in the council", header, [][]common.Address{addresses(append(committee[:3:3], outsider))}, ErrInvalidCommittee},
+		{"duplicated committee members", header, [][]common.Address{addresses(append(committee[:3:3], committee[0]))}, ErrInvalidCommittee},
+		{"committer not in the committee", makeHeader(t, cp.Header, committee[0], council[2:5], nil, nil), [][]common.Address{addresses(committee)}, ErrInvalidCommittedSeals},
+		{"insufficient committed seals", makeHeader(t, cp.Header, committee[0], committee[:2], nil, nil), [][]common.Address{addresses(committee)}, ErrInsufficientCommittedSeals},
+	}
+	for _, tc := range testcases {
+		lc, err := NewLightClient(config, cp)
+		require.NoError(t, err)
+		n, err := lc.InsertHeaders([]*types.Header{tc.header}, tc.committees)
+		assert.Equal(t, 0, n, tc.desc)
+		assert.ErrorIs(t, err, tc.err, tc.desc)
+	}
+
+	// The quorum of the committee shrunk by the demoted validators
+	lc, err := NewLightClient(config, cp)
+	require.NoError(t, err)
+	n, err := lc.InsertHeaders([]*types.Header{header}, [][]common.Address{addresses(committee)})
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, header.Hash(), lc.Head().Hash())
+
+	// A council member outside the committee could sign if the council is larger than the committee size
+	cp = newTestCheckpoint(t, council, uint64(len(committee)))
+	header = makeHeader(t, cp.Header, committee[0], council[4:], nil, nil)
+	lc, err = NewLightClient(config, cp)
+	require.NoError(t, err)
+	_, err = lc.InsertHeaders([]*types.Header{header}, nil)
+	assert.ErrorIs(t, err, ErrUnknownCommittee)
+	_, err = lc.InsertHeaders([]*types.Header{header}, [][]common.Address{addresses(committee)})
+	assert.ErrorIs(t, err, ErrInvalidCommittedSeals)
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package lightclient
+
+import (
+	"math"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/consensus/istanbul"
+	istanbulSigner "github.com/kaiachain/kaia/consensus/istanbul/signer"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/crypto/bls"
+	"github.com/kaiachain/kaia/kaiax/valset"
+)
+
+// sealer returns the proposer who sealed the header.
+func sealer(header *types.Header, extra *types.IstanbulExtra) (common.Address, error) {
+	return istanbul.GetSignatureAddress(istanbulSigner.SealHash(header).Bytes(), extra.Seal)
+}
+
+// quorum returns the number of the committed seals required by a committee of the given size.
+// Like a full node, more than 2F seals are required.
+func quorum(size int) int {
+	f := int(math.Ceil(float64(size)/3)) - 1
+	return 2*f + 1
+}
+
+// verifyCommittedSeals checks whether the ECDSA committed seals are signed by the distinct members,
+// and reach the quorum of the committee.
+func (lc *LightClient) verifyCommittedSeals(header *types.Header, extra *types.IstanbulExtra, members *valset.AddressSet) error {
+	if len(extra.CommittedSeal) == 0 {
+		return ErrEmptyCommittedSeals
+	}
+
+	var (
+		hash      = istanbulSigner.CommittedSealHash(header.Hash())
+		committed = make(map[common.Address]bool)
+	)
+	for _, seal := range extra.CommittedSeal {
+		pub, err := crypto.SigToPub(hash.Bytes(), seal)
+		if err != nil {
+			return ErrInvalidCommittedSeals
+		}
+		addr := crypto.PubkeyToAddress(*pub)
+		// Every validator can have only one seal
+		if !members.Contains(addr) || committed[addr] {
+			return ErrInvalidCommittedSeals
+		}
+		committed[addr] = true
+	}
+	if len(committed) < quorum(members.Len()) {
+		return ErrInsufficientCommittedSeals
+	}
+	return nil
+}
+
+// verifyAggregatedSeal checks whether the aggregated BLS seal is signed by the committers in the signer bitmap,
+// and they reach the quorum. The signer bitmap is over the sorted committee. If the committee is not known,
+// it can be told without the state only if the committee is the whole council.
+func (lc *LightClient) verifyAggregatedSeal(header *types.Header, extra *types.IstanbulExtra, members *valset.AddressSet, known bool) error {
+	if len(extra.CommittedSeal) != 0 {
+		return ErrInvalidCommittedSeals
+	}
+	if len(extra.AggregatedSeal) == 0 {
+		return ErrEmptyCommittedSeals
+	}
+	if len(extra.AggregatedSeal) != types.IstanbulExtraBlsSeal {
+		return ErrInvalidAggregatedSeal
+	}
+	if len(extra.SignerBitmap) != (members.Len()+7)/8 {
+		if known {
+			return ErrInvalidAggregatedSeal
+		}
+		// A shorter bitmap implies a committee shrunk by the demoted validators
+		return ErrUnknownCommittee
+	}
+
+	indices := types.SignerBitmapIndices(extra.SignerBitmap)
+	if len(indices) < quorum(members.Len()) {
+		return ErrInsufficientCommittedSeals
+	}
+	pubs := make([]bls.PublicKey, len(indices))
+	for i, idx := range indices {
+		if idx >= members.Len() {
+			return ErrInvalidAggregatedSeal
+		}
+		pub, ok := lc.blsKeys[members.At(idx)]
+		if !ok {
+			return ErrNoBlsPublicKey
+		}
+		pubs[i] = pub
+	}
+	aggregatedPub, err := bls.AggregateMultiplePubkeys(pubs)
+	if err != nil {
+		return err
+	}
+	ok, err := bls.VerifySignature(extra.AggregatedSeal, istanbulSigner.CommittedSealHash(header.Hash()), aggregatedPub)
+	if err != nil {
+		return err
+	} else if !ok {
+		return ErrInvalidAggregatedSeal
+	}
+	return nil
+}
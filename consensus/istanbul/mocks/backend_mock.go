@@ -81,17 +81,17 @@ func (mr *MockBackendMockRecorder) CheckSignature(arg0, arg1, arg2 interface{})
 }
 
 // Commit mocks base method
-func (m *MockBackend) Commit(arg0 istanbul.Proposal, arg1 [][]byte) error {
+func (m *MockBackend) Commit(arg0 istanbul.Proposal, arg1 [][]byte, arg2 []common.Address) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Commit", arg0, arg1)
+	ret := m.ctrl.Call(m, "Commit", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Commit indicates an expected call of Commit
-func (mr *MockBackendMockRecorder) Commit(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockBackendMockRecorder) Commit(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockBackend)(nil).Commit), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockBackend)(nil).Commit), arg0, arg1, arg2)
 }
 
 // EventMux mocks base method
@@ -166,6 +166,21 @@ func (mr *MockBackendMockRecorder) GetRewardBase() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRewardBase", reflect.TypeOf((*MockBackend)(nil).GetRewardBase))
 }
 
+// GetRoundTimeouts mocks base method
+func (m *MockBackend) GetRoundTimeouts(arg0 uint64) (time.Duration, time.Duration) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoundTimeouts", arg0)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(time.Duration)
+	return ret0, ret1
+}
+
+// GetRoundTimeouts indicates an expected call of GetRoundTimeouts
+func (mr *MockBackendMockRecorder) GetRoundTimeouts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoundTimeouts", reflect.TypeOf((*MockBackend)(nil).GetRoundTimeouts), arg0)
+}
+
 // GetValidatorSet mocks base method
 func (m *MockBackend) GetValidatorSet(arg0 uint64) (*istanbul.BlockValSet, error) {
 	m.ctrl.T.Helper()
@@ -291,6 +306,21 @@ func (mr *MockBackendMockRecorder) Sign(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sign", reflect.TypeOf((*MockBackend)(nil).Sign), arg0)
 }
 
+// SignCommittedSeal mocks base method
+func (m *MockBackend) SignCommittedSeal(arg0 istanbul.Proposal) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignCommittedSeal", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignCommittedSeal indicates an expected call of SignCommittedSeal
+func (mr *MockBackendMockRecorder) SignCommittedSeal(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignCommittedSeal", reflect.TypeOf((*MockBackend)(nil).SignCommittedSeal), arg0)
+}
+
 // Verify mocks base method
 func (m *MockBackend) Verify(arg0 istanbul.Proposal) (time.Duration, error) {
 	m.ctrl.T.Helper()
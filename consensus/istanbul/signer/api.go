@@ -0,0 +1,126 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"crypto/subtle"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/networks/rpc"
+	"github.com/kaiachain/kaia/rlp"
+)
+
+// APIs returns the RPC services of the given signer, which the remote signers connect to.
+func APIs(signer Signer) []rpc.API {
+	return []rpc.API{
+		{
+			Namespace: "signer",
+			Version:   "1.0",
+			Service:   NewAPI(signer),
+			Public:    true,
+		},
+	}
+}
+
+// NewTokenHandler serves the requests presenting the given token in the bearer authorization header
+// with the given handler, and rejects the others. The HTTP endpoint of a signing process is served with it.
+func NewTokenHandler(token string, next http.Handler) http.Handler {
+	return &tokenHandler{token: []byte(token), next: next}
+}
+
+type tokenHandler struct {
+	token []byte
+	next  http.Handler
+}
+
+func (h *tokenHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), h.token) != 1 {
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+// API serves a signer in the "signer" RPC namespace. The headers are passed in RLP.
+type API struct {
+	signer Signer
+}
+
+func NewAPI(signer Signer) *API {
+	return &API{signer}
+}
+
+// Address returns the address of the ECDSA key.
+func (api *API) Address() common.Address {
+	return api.signer.Address()
+}
+
+// BlsPublicKey returns the public key of the BLS key.
+func (api *API) BlsPublicKey() (hexutil.Bytes, error) {
+	pub, err := api.signer.BlsPublicKey()
+	if err != nil {
+		return nil, err
+	}
+	return pub.Marshal(), nil
+}
+
+// SignSeal signs the proposer seal of the given header.
+func (api *API) SignSeal(header hexutil.Bytes) (hexutil.Bytes, error) {
+	return api.signHeader(header, api.signer.SignSeal)
+}
+
+// SignCommittedSeal signs the committed seal of the given header with the ECDSA key.
+func (api *API) SignCommittedSeal(header hexutil.Bytes) (hexutil.Bytes, error) {
+	return api.signHeader(header, api.signer.SignCommittedSeal)
+}
+
+// SignBlsCommittedSeal signs the committed seal of the given header with the BLS key.
+func (api *API) SignBlsCommittedSeal(header hexutil.Bytes) (hexutil.Bytes, error) {
+	return api.signHeader(header, api.signer.SignBlsCommittedSeal)
+}
+
+func (api *API) signHeader(enc hexutil.Bytes, sign func(*types.Header) ([]byte, error)) (hexutil.Bytes, error) {
+	header := new(types.Header)
+	if err := rlp.DecodeBytes(enc, header); err != nil {
+		return nil, err
+	}
+	return sign(header)
+}
+
+// SignMessage signs the given consensus message without the signature.
+func (api *API) SignMessage(payload hexutil.Bytes) (hexutil.Bytes, error) {
+	return api.signer.SignMessage(payload)
+}
+
+// SignNode signs the binding of the given p2p node address to the consensus address.
+func (api *API) SignNode(node common.Address) (hexutil.Bytes, error) {
+	return api.signer.SignNode(node)
+}
+
+// SignRandao signs the random reveal of the given block number.
+func (api *API) SignRandao(number *hexutil.Big) (hexutil.Bytes, error) {
+	if number == nil {
+		return nil, errNoBlockNumber
+	}
+	return api.signer.SignRandao((*big.Int)(number))
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	istanbulCore "github.com/kaiachain/kaia/consensus/istanbul/core"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/crypto/bls"
+)
+
+// LocalSigner signs with the keys held in memory.
+type LocalSigner struct {
+	privateKey   *ecdsa.PrivateKey
+	address      common.Address
+	blsSecretKey bls.SecretKey
+	protection   *Protection
+}
+
+// NewLocalSigner returns a signer of the given keys. The BLS key is optional before the Randao fork.
+// If the protection is nil, the signer signs any data.
+func NewLocalSigner(privateKey *ecdsa.PrivateKey, blsSecretKey bls.SecretKey, protection *Protection) *LocalSigner {
+	return &LocalSigner{
+		privateKey:   privateKey,
+		address:      crypto.PubkeyToAddress(privateKey.PublicKey),
+		blsSecretKey: blsSecretKey,
+		protection:   protection,
+	}
+}
+
+// Address implements Signer.Address
+func (s *LocalSigner) Address() common.Address {
+	return s.address
+}
+
+// BlsPublicKey implements Signer.BlsPublicKey
+func (s *LocalSigner) BlsPublicKey() (bls.PublicKey, error) {
+	if s.blsSecretKey == nil {
+		return nil, ErrNoBlsKey
+	}
+	return s.blsSecretKey.PublicKey(), nil
+}
+
+// SignSeal implements Signer.SignSeal. Every validator seals its own candidate blocks,
+// and the seal alone does not finalize a block, so it is not protected.
+func (s *LocalSigner) SignSeal(header *types.Header) ([]byte, error) {
+	return crypto.Sign(crypto.Keccak256(SealHash(header).Bytes()), s.privateKey)
+}
+
+// SignCommittedSeal implements Signer.SignCommittedSeal
+func (s *LocalSigner) SignCommittedSeal(header *types.Header) ([]byte, error) {
+	hash := header.Hash()
+	if err := s.checkCommittedSeal(header, hash); err != nil {
+		return nil, err
+	}
+	return crypto.Sign(CommittedSealHash(hash).Bytes(), s.privateKey)
+}
+
+// SignBlsCommittedSeal implements Signer.SignBlsCommittedSeal
+func (s *LocalSigner) SignBlsCommittedSeal(header *types.Header) ([]byte, error) {
+	if s.blsSecretKey == nil {
+		return nil, ErrNoBlsKey
+	}
+	hash := header.Hash()
+	if err := s.checkCommittedSeal(header, hash); err != nil {
+		return nil, err
+	}
+	msg := CommittedSealHash(hash)
+	return bls.Sign(s.blsSecretKey, msg[:]).Marshal(), nil
+}
+
+// checkCommittedSeal protects the committed seals by the round in the header, which is set by the proposer.
+// The ECDSA and the BLS committed seals share the records, as they sign the same hash.
+func (s *LocalSigner) checkCommittedSeal(header *types.Header, hash common.Hash) error {
+	if s.protection == nil {
+		return nil
+	}
+	return s.protection.Check(committedSealCode, header.Number.Uint64(), uint64(header.Round()), hash)
+}
+
+// SignMessage implements Signer.SignMessage
+func (s *LocalSigner) SignMessage(payload []byte) ([]byte, error) {
+	if s.protection != nil {
+		code, view, digest, err := istanbulCore.DecodeVote(payload)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.protection.Check(code, view.Sequence.Uint64(), view.Round.Uint64(), digest); err != nil {
+			return nil, err
+		}
+	}
+	return crypto.Sign(crypto.Keccak256(payload), s.privateKey)
+}
+
+// SignNode implements Signer.SignNode. The binding of a p2p node does not conflict with other data,
+// so it is not protected.
+func (s *LocalSigner) SignNode(node common.Address) ([]byte, error) {
+	return crypto.Sign(NodeHash(node).Bytes(), s.privateKey)
+}
+
+// SignRandao implements Signer.SignRandao. The random reveal of a block number is deterministic,
+// so it is not protected.
+func (s *LocalSigner) SignRandao(number *big.Int) ([]byte, error) {
+	if s.blsSecretKey == nil {
+		return nil, ErrNoBlsKey
+	}
+	msg := RandaoMsg(number)
+	return bls.Sign(s.blsSecretKey, msg[:]).Marshal(), nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/storage/database"
+)
+
+const (
+	// protectionRetention is the number of the latest sequences whose signed digests are kept.
+	protectionRetention = 1024
+
+	// committedSealCode distinguishes the committed seals from the consensus message codes.
+	committedSealCode = 0xff
+)
+
+var (
+	protectionPrefix    = []byte("istanbul-signed-")
+	protectionLowestKey = []byte("istanbul-signer-lowest")
+)
+
+// Protection is the slashing protection of a signer. It records the digests signed in every view,
+// and refuses to sign a different digest for the same view. The records are persisted in a database,
+// so that the protection holds across the restarts. Only the records of the latest sequences are kept,
+// and the views of the pruned sequences are refused.
+type Protection struct {
+	db     database.Database
+	lowest uint64 // The lowest sequence whose records are kept
+	mu     sync.Mutex
+}
+
+// NewProtection returns a slashing protection whose records are kept in the given database.
+func NewProtection(db database.Database) *Protection {
+	p := &Protection{db: db}
+	if enc, err := db.Get(protectionLowestKey); err == nil && len(enc) == 8 {
+		p.lowest = binary.BigEndian.Uint64(enc)
+	}
+	return p
+}
+
+// The records are ordered by the sequence, so that the old records can be pruned by an iteration.
+func protectionKey(sequence, round, code uint64) []byte {
+	key := binary.BigEndian.AppendUint64(append([]byte{}, protectionPrefix...), sequence)
+	key = binary.BigEndian.AppendUint64(key, round)
+	return binary.BigEndian.AppendUint64(key, code)
+}
+
+// Check records the digest to sign for the given message code and view. It returns ErrConflict
+// if a different digest has already been signed for them, or ErrPrunedView if the view is pruned.
+// Signing the same digest again is allowed, e.g. to resend the messages after a restart.
+func (p *Protection) Check(code, sequence, round uint64, digest common.Hash) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if sequence < p.lowest {
+		return fmt.Errorf("%w: sequence %d is lower than %d", ErrPrunedView, sequence, p.lowest)
+	}
+	key := protectionKey(sequence, round, code)
+	if signed, _ := p.db.Get(key); len(signed) > 0 {
+		if !bytes.Equal(signed, digest.Bytes()) {
+			return fmt.Errorf("%w: code %d, sequence %d, round %d, signed %s, requested %s",
+				ErrConflict, code, sequence, round, common.BytesToHash(signed).Hex(), digest.Hex())
+		}
+		return nil
+	}
+	if err := p.db.Put(key, digest.Bytes()); err != nil {
+		return err
+	}
+
+	// Prune the records once in a retention to bound the database
+	if sequence >= p.lowest+2*protectionRetention {
+		p.prune(sequence - protectionRetention)
+	}
+	return nil
+}
+
+// prune deletes the records of the sequences lower than the given one.
+func (p *Protection) prune(lowest uint64) {
+	if err := p.db.Put(protectionLowestKey, binary.BigEndian.AppendUint64(nil, lowest)); err != nil {
+		logger.Error("Failed to write the lowest protected sequence", "sequence", lowest, "err", err)
+		return
+	}
+	p.lowest = lowest
+
+	it := p.db.NewIterator(protectionPrefix, nil)
+	defer it.Release()
+	for it.Next() {
+		key := it.Key()
+		if binary.BigEndian.Uint64(key[len(protectionPrefix):]) >= lowest {
+			break
+		}
+		if err := p.db.Delete(common.CopyBytes(key)); err != nil {
+			logger.Error("Failed to prune the slashing protection", "err", err)
+			return
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/crypto/bls"
+	"github.com/kaiachain/kaia/networks/rpc"
+	"github.com/kaiachain/kaia/rlp"
+)
+
+// remoteTimeout bounds a signing request, which blocks the consensus.
+const remoteTimeout = 3 * time.Second
+
+// RemoteSigner signs with the keys held by a signing process, which serves the "signer"
+// RPC namespace over IPC or HTTP. The signing process applies the slashing protection.
+type RemoteSigner struct {
+	client       *rpc.Client
+	address      common.Address
+	blsPublicKey bls.PublicKey // nil if the signing process has no BLS key
+}
+
+// NewRemoteSigner connects to the signing process at the given endpoint, which is either
+// an IPC path or an HTTP URL, and fetches its public keys. The token authenticates the node
+// to the HTTP endpoint, and is ignored over IPC.
+func NewRemoteSigner(endpoint string, token string) (*RemoteSigner, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), remoteTimeout)
+	defer cancel()
+
+	client, err := rpc.DialContext(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		client.SetHeader("Authorization", "Bearer "+token)
+	}
+	s := &RemoteSigner{client: client}
+	if err := client.CallContext(ctx, &s.address, "signer_address"); err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	var pub hexutil.Bytes
+	if err := client.CallContext(ctx, &pub, "signer_blsPublicKey"); err != nil {
+		if remoteError(err) != ErrNoBlsKey {
+			client.Close()
+			return nil, err
+		}
+	} else if s.blsPublicKey, err = bls.PublicKeyFromBytes(pub); err != nil {
+		client.Close()
+		return nil, err
+	}
+	logger.Info("Connected to the remote signer", "endpoint", endpoint, "address", s.address, "bls", s.blsPublicKey != nil)
+	return s, nil
+}
+
+// Close disconnects from the signing process.
+func (s *RemoteSigner) Close() {
+	s.client.Close()
+}
+
+// remoteError restores the errors of the signer returned by the signing process.
+func remoteError(err error) error {
+	for _, e := range []error{ErrNoBlsKey, ErrConflict, ErrPrunedView} {
+		if msg := err.Error(); strings.HasPrefix(msg, e.Error()) {
+			if msg == e.Error() {
+				return e
+			}
+			return fmt.Errorf("%w%s", e, strings.TrimPrefix(msg, e.Error()))
+		}
+	}
+	return err
+}
+
+func (s *RemoteSigner) call(method string, args ...interface{}) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), remoteTimeout)
+	defer cancel()
+
+	var sig hexutil.Bytes
+	if err := s.client.CallContext(ctx, &sig, method, args...); err != nil {
+		return nil, remoteError(err)
+	}
+	return sig, nil
+}
+
+func (s *RemoteSigner) signHeader(method string, header *types.Header) ([]byte, error) {
+	enc, err := rlp.EncodeToBytes(header)
+	if err != nil {
+		return nil, err
+	}
+	return s.call(method, hexutil.Bytes(enc))
+}
+
+// Address implements Signer.Address
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+// BlsPublicKey implements Signer.BlsPublicKey
+func (s *RemoteSigner) BlsPublicKey() (bls.PublicKey, error) {
+	if s.blsPublicKey == nil {
+		return nil, ErrNoBlsKey
+	}
+	return s.blsPublicKey, nil
+}
+
+// SignSeal implements Signer.SignSeal
+func (s *RemoteSigner) SignSeal(header *types.Header) ([]byte, error) {
+	return s.signHeader("signer_signSeal", header)
+}
+
+// SignCommittedSeal implements Signer.SignCommittedSeal
+func (s *RemoteSigner) SignCommittedSeal(header *types.Header) ([]byte, error) {
+	return s.signHeader("signer_signCommittedSeal", header)
+}
+
+// SignBlsCommittedSeal implements Signer.SignBlsCommittedSeal
+func (s *RemoteSigner) SignBlsCommittedSeal(header *types.Header) ([]byte, error) {
+	if s.blsPublicKey == nil {
+		return nil, ErrNoBlsKey
+	}
+	return s.signHeader("signer_signBlsCommittedSeal", header)
+}
+
+// SignMessage implements Signer.SignMessage
+func (s *RemoteSigner) SignMessage(payload []byte) ([]byte, error) {
+	return s.call("signer_signMessage", hexutil.Bytes(payload))
+}
+
+// SignNode implements Signer.SignNode
+func (s *RemoteSigner) SignNode(node common.Address) ([]byte, error) {
+	return s.call("signer_signNode", node)
+}
+
+// SignRandao implements Signer.SignRandao
+func (s *RemoteSigner) SignRandao(number *big.Int) ([]byte, error) {
+	if s.blsPublicKey == nil {
+		return nil, ErrNoBlsKey
+	}
+	return s.call("signer_signRandao", (*hexutil.Big)(number))
+}
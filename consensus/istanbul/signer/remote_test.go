@@ -0,0 +1,113 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/crypto/bls"
+	"github.com/kaiachain/kaia/networks/rpc"
+	"github.com/kaiachain/kaia/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func startTestSigner(t *testing.T, s Signer) string {
+	endpoint := filepath.Join(t.TempDir(), "signer.ipc")
+	listener, _, err := rpc.StartIPCEndpoint(endpoint, APIs(s))
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	return endpoint
+}
+
+func TestRemoteSigner(t *testing.T) {
+	var (
+		key, _    = crypto.GenerateKey()
+		blsKey, _ = bls.RandKey()
+		local     = NewLocalSigner(key, blsKey, NewProtection(database.NewMemoryDBManager().GetMiscDB()))
+		header    = makeTestHeader(t, 10, 0)
+	)
+	remote, err := NewRemoteSigner(startTestSigner(t, local), "")
+	require.NoError(t, err)
+	defer remote.Close()
+
+	assert.Equal(t, local.Address(), remote.Address())
+	pub, err := remote.BlsPublicKey()
+	require.NoError(t, err)
+	assert.Equal(t, blsKey.PublicKey().Marshal(), pub.Marshal())
+
+	// The remote signer signs as the local one
+	for _, sign := range []func(Signer) ([]byte, error){
+		func(s Signer) ([]byte, error) { return s.SignSeal(header) },
+		func(s Signer) ([]byte, error) { return s.SignCommittedSeal(header) },
+		func(s Signer) ([]byte, error) { return s.SignBlsCommittedSeal(header) },
+		func(s Signer) ([]byte, error) { return s.SignMessage(makeTestMessage(t, 2, 10, 0, header.Hash())) },
+		func(s Signer) ([]byte, error) { return s.SignRandao(header.Number) },
+		func(s Signer) ([]byte, error) { return s.SignNode(common.Address{1}) },
+	} {
+		expected, err := sign(local)
+		require.NoError(t, err)
+		actual, err := sign(remote)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	}
+
+	// The signing process protects the keys
+	conflict := makeTestHeader(t, 10, 0)
+	conflict.GasUsed = 1
+	_, err = remote.SignCommittedSeal(conflict)
+	assert.ErrorIs(t, err, ErrConflict)
+	_, err = remote.SignMessage(makeTestMessage(t, 2, 10, 0, conflict.Hash()))
+	assert.ErrorIs(t, err, ErrConflict)
+
+	// The signing process without a BLS key
+	remote, err = NewRemoteSigner(startTestSigner(t, NewLocalSigner(key, nil, nil)), "")
+	require.NoError(t, err)
+	defer remote.Close()
+	_, err = remote.BlsPublicKey()
+	assert.ErrorIs(t, err, ErrNoBlsKey)
+	_, err = remote.SignRandao(header.Number)
+	assert.ErrorIs(t, err, ErrNoBlsKey)
+
+	_, err = NewRemoteSigner(filepath.Join(t.TempDir(), "none.ipc"), "")
+	assert.Error(t, err)
+}
+
+func TestRemoteSigner_Token(t *testing.T) {
+	var (
+		key, _  = crypto.GenerateKey()
+		handler = rpc.NewServer()
+	)
+	require.NoError(t, handler.RegisterName("signer", NewAPI(NewLocalSigner(key, nil, nil))))
+	server := httptest.NewServer(NewTokenHandler("secret", handler))
+	defer server.Close()
+
+	remote, err := NewRemoteSigner(server.URL, "secret")
+	require.NoError(t, err)
+	defer remote.Close()
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), remote.Address())
+
+	// The requests without the token are rejected
+	for _, token := range []string{"", "wrong"} {
+		_, err = NewRemoteSigner(server.URL, token)
+		assert.Error(t, err, "token %q", token)
+	}
+}
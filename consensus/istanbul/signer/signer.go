@@ -0,0 +1,107 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package signer signs the consensus data of a validator: the block seals, the committed seals,
+// the consensus messages and the Randao reveals.
+//
+// The signing keys are either held by the node itself (LocalSigner), or by a separate signing
+// process which the node reaches over IPC or HTTP (RemoteSigner). A signing process serves the
+// "signer" RPC namespace of a LocalSigner with NewAPI.
+//
+// The signers receive the structured data to sign instead of the digests, so that they can
+// refuse to sign conflicting data for the same view with a Protection even if the node is compromised.
+package signer
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	istanbulCore "github.com/kaiachain/kaia/consensus/istanbul/core"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/crypto/bls"
+	"github.com/kaiachain/kaia/crypto/sha3"
+	"github.com/kaiachain/kaia/log"
+	"github.com/kaiachain/kaia/rlp"
+)
+
+var logger = log.NewModuleLogger(log.ConsensusIstanbulSigner)
+
+var (
+	// ErrNoBlsKey is returned if the BLS secret key is not configured.
+	ErrNoBlsKey = errors.New("bls key not configured")
+	// ErrConflict is returned if the signer has already signed different data for the same view.
+	ErrConflict = errors.New("refused to sign conflicting data for the same view")
+	// ErrPrunedView is returned if the view is older than the views the slashing protection keeps.
+	ErrPrunedView = errors.New("refused to sign data of a pruned view")
+
+	errNoBlockNumber = errors.New("block number not given")
+)
+
+// Signer signs the consensus data of a validator.
+type Signer interface {
+	// Address returns the address of the ECDSA key, which is the consensus address of the validator.
+	Address() common.Address
+
+	// BlsPublicKey returns the public key of the BLS key. ErrNoBlsKey is returned if there is no BLS key.
+	BlsPublicKey() (bls.PublicKey, error)
+
+	// SignSeal signs the proposer seal of the given header with the ECDSA key.
+	SignSeal(header *types.Header) ([]byte, error)
+
+	// SignCommittedSeal signs the committed seal of the given header with the ECDSA key.
+	SignCommittedSeal(header *types.Header) ([]byte, error)
+
+	// SignBlsCommittedSeal signs the committed seal of the given header with the BLS key, since the BlsSeal fork.
+	SignBlsCommittedSeal(header *types.Header) ([]byte, error)
+
+	// SignMessage signs the given consensus message, which is the payload without the signature, with the ECDSA key.
+	SignMessage(payload []byte) ([]byte, error)
+
+	// SignRandao signs the KIP-114 random reveal of the given block number with the BLS key.
+	SignRandao(number *big.Int) ([]byte, error)
+
+	// SignNode signs the binding of the given p2p node address to the consensus address with the ECDSA key.
+	SignNode(node common.Address) ([]byte, error)
+}
+
+// SealHash returns the hash signed by the proposer seal of the given header.
+func SealHash(header *types.Header) (hash common.Hash) {
+	hasher := sha3.NewKeccak256()
+
+	// Clean seal is required for calculating proposer seal.
+	rlp.Encode(hasher, types.IstanbulFilteredHeader(header, false))
+	hasher.Sum(hash[:0])
+	return hash
+}
+
+// CommittedSealHash returns the hash signed by the committed seals of the given proposal hash.
+func CommittedSealHash(hash common.Hash) common.Hash {
+	return crypto.Keccak256Hash(istanbulCore.PrepareCommittedSeal(hash))
+}
+
+// NodeHash returns the hash signed by the binding of the given p2p node address to the consensus address.
+// The validators whose p2p node key differs from the consensus key prove their p2p nodes with it.
+func NodeHash(node common.Address) common.Hash {
+	return crypto.Keccak256Hash([]byte("kaia validator node"), node.Bytes())
+}
+
+// RandaoMsg returns the message signed by the KIP-114 random reveal of the given block number.
+// block_num_to_bytes() = num.to_bytes(32, byteorder="big")
+func RandaoMsg(number *big.Int) common.Hash {
+	return common.BytesToHash(number.Bytes())
+}
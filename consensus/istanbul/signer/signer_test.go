@@ -0,0 +1,168 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/consensus/istanbul"
+	istanbulCore "github.com/kaiachain/kaia/consensus/istanbul/core"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/crypto/bls"
+	"github.com/kaiachain/kaia/rlp"
+	"github.com/kaiachain/kaia/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeTestHeader(t *testing.T, num uint64, round int64) *types.Header {
+	extra, err := rlp.EncodeToBytes(&types.IstanbulExtra{})
+	require.NoError(t, err)
+	header := &types.Header{
+		Number: new(big.Int).SetUint64(num),
+		Extra:  append(make([]byte, types.IstanbulExtraVanity), extra...),
+	}
+	return types.SetRoundToHeader(header, round)
+}
+
+func makeTestMessage(t *testing.T, code uint64, sequence, round int64, digest common.Hash) []byte {
+	view := &istanbul.View{Sequence: big.NewInt(sequence), Round: big.NewInt(round)}
+	subject, err := rlp.EncodeToBytes(&istanbul.Subject{View: view, Digest: digest, PrevHash: common.Hash{}})
+	require.NoError(t, err)
+	msg, err := rlp.EncodeToBytes([]interface{}{common.Hash{}, code, subject, common.Address{}, []byte{}, []byte{}})
+	require.NoError(t, err)
+	return msg
+}
+
+func TestLocalSigner(t *testing.T) {
+	var (
+		key, _    = crypto.GenerateKey()
+		blsKey, _ = bls.RandKey()
+		addr      = crypto.PubkeyToAddress(key.PublicKey)
+		s         = NewLocalSigner(key, blsKey, NewProtection(database.NewMemoryDBManager().GetMiscDB()))
+		header    = makeTestHeader(t, 10, 0)
+	)
+	assert.Equal(t, addr, s.Address())
+	pub, err := s.BlsPublicKey()
+	require.NoError(t, err)
+	assert.Equal(t, blsKey.PublicKey().Marshal(), pub.Marshal())
+
+	// The seals are verified as the backend does
+	seal, err := s.SignSeal(header)
+	require.NoError(t, err)
+	signer, err := istanbul.GetSignatureAddress(SealHash(header).Bytes(), seal)
+	require.NoError(t, err)
+	assert.Equal(t, addr, signer)
+
+	seal, err = s.SignCommittedSeal(header)
+	require.NoError(t, err)
+	signer, err = istanbul.GetSignatureAddress(istanbulCore.PrepareCommittedSeal(header.Hash()), seal)
+	require.NoError(t, err)
+	assert.Equal(t, addr, signer)
+
+	seal, err = s.SignBlsCommittedSeal(header)
+	require.NoError(t, err)
+	ok, err := bls.VerifySignature(seal, CommittedSealHash(header.Hash()), pub)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	reveal, err := s.SignRandao(header.Number)
+	require.NoError(t, err)
+	ok, err = bls.VerifySignature(reveal, RandaoMsg(header.Number), pub)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	msg := makeTestMessage(t, 1, 10, 0, header.Hash())
+	sig, err := s.SignMessage(msg)
+	require.NoError(t, err)
+	signer, err = istanbul.GetSignatureAddress(msg, sig)
+	require.NoError(t, err)
+	assert.Equal(t, addr, signer)
+
+	node := common.Address{1}
+	sig, err = s.SignNode(node)
+	require.NoError(t, err)
+	pubKey, err := crypto.SigToPub(NodeHash(node).Bytes(), sig)
+	require.NoError(t, err)
+	assert.Equal(t, addr, crypto.PubkeyToAddress(*pubKey))
+
+	// A conflicting proposal of the same round is refused, while the one of the next round is not
+	conflict := makeTestHeader(t, 10, 0)
+	conflict.GasUsed = 1
+	_, err = s.SignCommittedSeal(conflict)
+	assert.ErrorIs(t, err, ErrConflict)
+	_, err = s.SignBlsCommittedSeal(conflict)
+	assert.ErrorIs(t, err, ErrConflict)
+	_, err = s.SignMessage(makeTestMessage(t, 1, 10, 0, conflict.Hash()))
+	assert.ErrorIs(t, err, ErrConflict)
+	_, err = s.SignSeal(conflict)
+	assert.NoError(t, err)
+
+	types.SetRoundToHeader(conflict, 1)
+	_, err = s.SignCommittedSeal(conflict)
+	assert.NoError(t, err)
+	_, err = s.SignMessage(makeTestMessage(t, 1, 10, 1, conflict.Hash()))
+	assert.NoError(t, err)
+
+	// The data other than the consensus messages are refused
+	_, err = s.SignMessage([]byte("dummy data"))
+	assert.Error(t, err)
+
+	// The BLS key is optional
+	s = NewLocalSigner(key, nil, nil)
+	_, err = s.BlsPublicKey()
+	assert.ErrorIs(t, err, ErrNoBlsKey)
+	_, err = s.SignBlsCommittedSeal(header)
+	assert.ErrorIs(t, err, ErrNoBlsKey)
+	_, err = s.SignRandao(header.Number)
+	assert.ErrorIs(t, err, ErrNoBlsKey)
+}
+
+func TestProtection(t *testing.T) {
+	var (
+		db     = database.NewMemoryDBManager().GetMiscDB()
+		p      = NewProtection(db)
+		digest = common.HexToHash("0x1")
+		other  = common.HexToHash("0x2")
+	)
+	require.NoError(t, p.Check(2, 1, 0, digest))
+	require.NoError(t, p.Check(2, 1, 0, digest))
+	assert.ErrorIs(t, p.Check(2, 1, 0, other), ErrConflict)
+	assert.NoError(t, p.Check(1, 1, 0, other))
+	assert.NoError(t, p.Check(2, 1, 1, other))
+	assert.NoError(t, p.Check(2, 2, 0, other))
+
+	// The records persist across the restarts
+	p = NewProtection(db)
+	assert.ErrorIs(t, p.Check(2, 1, 0, other), ErrConflict)
+
+	// The old records are pruned, and their views are refused
+	require.NoError(t, p.Check(2, 2*protectionRetention, 0, digest))
+	assert.Equal(t, uint64(protectionRetention), p.lowest)
+	has, _ := db.Has(protectionKey(1, 0, 2))
+	assert.False(t, has)
+	has, _ = db.Has(protectionKey(2*protectionRetention, 0, 2))
+	assert.True(t, has)
+	assert.ErrorIs(t, p.Check(2, 1, 0, digest), ErrPrunedView)
+
+	p = NewProtection(db)
+	assert.Equal(t, uint64(protectionRetention), p.lowest)
+	assert.ErrorIs(t, p.Check(2, 2*protectionRetention, 0, other), ErrConflict)
+}
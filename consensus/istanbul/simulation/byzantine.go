@@ -0,0 +1,104 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package simulation
+
+import (
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/consensus/istanbul"
+	istanbulCore "github.com/kaiachain/kaia/consensus/istanbul/core"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/rlp"
+)
+
+// Codes of the consensus messages
+const (
+	MsgPreprepare uint64 = iota
+	MsgPrepare
+	MsgCommit
+	MsgRoundChange
+)
+
+// Byzantine rewrites a message the byzantine node sends to a peer. It returns the payloads
+// sent instead, which can be empty to drop the message or conflicting to equivocate.
+type Byzantine func(from *Node, to common.Address, payload []byte) [][]byte
+
+// Silent is a byzantine node that receives the messages but never sends one.
+func Silent(from *Node, to common.Address, payload []byte) [][]byte {
+	return nil
+}
+
+// EquivocatingVotes is a byzantine node that sends the PREPARE and COMMIT messages of a
+// random digest after the honest ones to the peers of odd addresses.
+func EquivocatingVotes(from *Node, to common.Address, payload []byte) [][]byte {
+	msg, err := DecodeMessage(payload)
+	if err != nil || (msg.Code != MsgPrepare && msg.Code != MsgCommit) || to[len(to)-1]%2 == 0 {
+		return [][]byte{payload}
+	}
+	var subject *istanbul.Subject
+	if err := rlp.DecodeBytes(msg.Msg, &subject); err != nil {
+		return [][]byte{payload}
+	}
+	from.network.rand.Read(subject.Digest[:])
+	if msg.Msg, err = rlp.EncodeToBytes(subject); err != nil {
+		return [][]byte{payload}
+	}
+	if msg.Code == MsgCommit {
+		seal, err := crypto.Sign(crypto.Keccak256(istanbulCore.PrepareCommittedSeal(subject.Digest)), from.key)
+		if err != nil {
+			return [][]byte{payload}
+		}
+		msg.CommittedSeal = seal
+	}
+	equivocated, err := msg.Sign(from)
+	if err != nil {
+		return [][]byte{payload}
+	}
+	return [][]byte{payload, equivocated}
+}
+
+// Message is a consensus message as encoded on the wire.
+type Message struct {
+	Hash          common.Hash
+	Code          uint64
+	Msg           []byte
+	Address       common.Address
+	Signature     []byte
+	CommittedSeal []byte
+}
+
+// DecodeMessage decodes the payload of a consensus message without verifying it.
+func DecodeMessage(payload []byte) (*Message, error) {
+	msg := new(Message)
+	if err := rlp.DecodeBytes(payload, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// Sign signs the message with the key of the node and returns the payload.
+func (m *Message) Sign(node *Node) ([]byte, error) {
+	m.Address = node.address
+	m.Signature = []byte{}
+	data, err := rlp.EncodeToBytes(m)
+	if err != nil {
+		return nil, err
+	}
+	if m.Signature, err = crypto.Sign(crypto.Keccak256(data), node.key); err != nil {
+		return nil, err
+	}
+	return rlp.EncodeToBytes(m)
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package simulation
+
+import (
+	"container/heap"
+	"time"
+
+	istanbulCore "github.com/kaiachain/kaia/consensus/istanbul/core"
+)
+
+// VirtualClock is a clock whose time advances only when the scheduled functions are run.
+// The functions scheduled at the same time are run in the order they were scheduled.
+type VirtualClock struct {
+	now   time.Duration
+	seq   uint64
+	queue scheduleQueue
+}
+
+// NewVirtualClock returns a virtual clock at time zero.
+func NewVirtualClock() *VirtualClock {
+	return &VirtualClock{}
+}
+
+// Now returns the time elapsed since the start of the simulation.
+func (c *VirtualClock) Now() time.Duration {
+	return c.now
+}
+
+// AfterFunc implements istanbulCore.Clock.AfterFunc
+func (c *VirtualClock) AfterFunc(d time.Duration, f func()) istanbulCore.Timer {
+	if d < 0 {
+		d = 0
+	}
+	t := &virtualTimer{at: c.now + d, seq: c.seq, fn: f}
+	c.seq++
+	heap.Push(&c.queue, t)
+	return t
+}
+
+// Step runs the earliest scheduled function. It returns false if nothing is scheduled before the deadline.
+func (c *VirtualClock) Step(deadline time.Duration) bool {
+	for c.queue.Len() > 0 {
+		t := c.queue[0]
+		if t.at > deadline {
+			return false
+		}
+		heap.Pop(&c.queue)
+		if t.stopped {
+			continue
+		}
+		t.fired = true
+		c.now = t.at
+		t.fn()
+		return true
+	}
+	return false
+}
+
+type virtualTimer struct {
+	at      time.Duration
+	seq     uint64
+	fn      func()
+	fired   bool
+	stopped bool
+}
+
+// Stop implements istanbulCore.Timer.Stop
+func (t *virtualTimer) Stop() bool {
+	if t.fired || t.stopped {
+		return false
+	}
+	t.stopped = true
+	return true
+}
+
+type scheduleQueue []*virtualTimer
+
+func (q scheduleQueue) Len() int { return len(q) }
+
+func (q scheduleQueue) Less(i, j int) bool {
+	if q[i].at != q[j].at {
+		return q[i].at < q[j].at
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q scheduleQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *scheduleQueue) Push(x interface{}) { *q = append(*q, x.(*virtualTimer)) }
+
+func (q *scheduleQueue) Pop() interface{} {
+	old := *q
+	t := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return t
+}
@@ -0,0 +1,344 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package simulation runs a network of Istanbul validators in a single process.
+//
+// Every validator runs the Istanbul core over a simulated backend, and the messages
+// are exchanged over an in-memory network scheduled by a virtual clock. All the events
+// are handled in the goroutine of the caller in the order of the virtual time, so
+// that a simulation with the same seed is reproducible. The tests can inject message
+// delays, drops, partitions, crashed and byzantine validators and validator set changes,
+// and check the safety and the liveness of the consensus.
+package simulation
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"time"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/consensus/istanbul"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/event"
+	"github.com/kaiachain/kaia/rlp"
+	"github.com/kaiachain/kaia/storage/database"
+)
+
+// Config is the configuration of a simulated network.
+type Config struct {
+	Nodes          int           // Number of the nodes
+	CommitteeSize  int           // Size of the committee. 0 for the whole qualified validators
+	BlockInterval  time.Duration // Interval between a commit and the next proposal. Default 1s
+	BaseTimeout    time.Duration // Governed round change timeout of the round 0. 0 for the node-local one
+	TimeoutBackoff time.Duration // Governed timeout backoff of the later rounds. 0 for the default one
+	Seed           int64         // Seed of the randomness of the links
+	Valset         Valset        // Validator set of the network. Default a Schedule of all the nodes
+}
+
+// Link is the behavior of the messages sent from a node to another.
+type Link struct {
+	Delay    time.Duration // Delay of every message
+	Jitter   time.Duration // Random delay added to every message
+	DropRate float64       // Probability to drop a message
+}
+
+// Network is a simulated network of Istanbul validators.
+type Network struct {
+	config  Config
+	clock   *VirtualClock
+	rand    *rand.Rand
+	valset  Valset
+	genesis *types.Block
+	nodes   []*Node
+
+	defaultLink Link
+	links       map[[2]int]Link
+	groups      []int // Partition group of each node. nil if not partitioned
+
+	sent, dropped int
+}
+
+// NewNetwork creates a network of validators with the same genesis block. The network is
+// not started until Start is called.
+func NewNetwork(config Config) *Network {
+	if config.BlockInterval == 0 {
+		config.BlockInterval = time.Second
+	}
+	n := &Network{
+		config: config,
+		clock:  NewVirtualClock(),
+		rand:   rand.New(rand.NewSource(config.Seed)),
+		links:  make(map[[2]int]Link),
+	}
+
+	addrs := make([]common.Address, config.Nodes)
+	for i := 0; i < config.Nodes; i++ {
+		// Deterministic keys, so that the proposers and the committees are reproducible
+		key, err := crypto.ToECDSA(crypto.Keccak256(big.NewInt(int64(i + 1)).Bytes()))
+		if err != nil {
+			panic(err)
+		}
+		node := &Node{
+			index:    i,
+			key:      key,
+			address:  crypto.PubkeyToAddress(key.PublicKey),
+			network:  n,
+			db:       database.NewMemoryDBManager().GetMiscDB(),
+			eventMux: new(event.TypeMux),
+			crashed:  true,
+		}
+		n.nodes = append(n.nodes, node)
+		addrs[i] = node.address
+	}
+	n.valset = config.Valset
+	if n.valset == nil {
+		n.valset = NewSchedule(addrs, config.CommitteeSize)
+	}
+	n.genesis = newGenesis(addrs)
+	for _, node := range n.nodes {
+		node.chain = []*types.Block{n.genesis}
+	}
+	return n
+}
+
+func newGenesis(validators []common.Address) *types.Block {
+	extra, err := rlp.EncodeToBytes(&types.IstanbulExtra{
+		Validators:    validators,
+		Seal:          []byte{},
+		CommittedSeal: [][]byte{},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return types.NewBlockWithHeader(&types.Header{
+		Number:     common.Big0,
+		Extra:      append(make([]byte, types.IstanbulExtraVanity), extra...),
+		Time:       common.Big0,
+		BlockScore: common.Big1,
+	})
+}
+
+// newBlock returns a proposal of the given proposer on the parent.
+func (n *Network) newBlock(parent *types.Block, proposer common.Address) *types.Block {
+	return types.NewBlockWithHeader(&types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number(), common.Big1),
+		Rewardbase: proposer,
+		Extra:      common.CopyBytes(n.genesis.Extra()),
+		Time:       big.NewInt(int64(n.clock.Now() / time.Second)),
+		BlockScore: common.Big1,
+	})
+}
+
+// Start starts all the nodes and the block synchronization between them.
+func (n *Network) Start() {
+	for _, node := range n.nodes {
+		node.start()
+	}
+	n.clock.AfterFunc(n.config.BlockInterval, n.sync)
+}
+
+// Stop stops all the running nodes.
+func (n *Network) Stop() {
+	for _, node := range n.nodes {
+		if !node.crashed {
+			node.stop()
+		}
+	}
+}
+
+// Clock returns the virtual clock of the network.
+func (n *Network) Clock() *VirtualClock { return n.clock }
+
+// Nodes returns the nodes of the network.
+func (n *Network) Nodes() []*Node { return n.nodes }
+
+// Node returns the node of the given index.
+func (n *Network) Node(i int) *Node { return n.nodes[i] }
+
+// Stats returns the number of the messages sent and dropped by the links.
+func (n *Network) Stats() (sent, dropped int) { return n.sent, n.dropped }
+
+// SetDefaultLink sets the behavior of the links without a specific behavior.
+func (n *Network) SetDefaultLink(link Link) { n.defaultLink = link }
+
+// SetLink sets the behavior of the link from a node to another.
+func (n *Network) SetLink(from, to int, link Link) { n.links[[2]int{from, to}] = link }
+
+// Partition splits the network into the given groups. The nodes in different groups,
+// and the nodes in no group, cannot exchange messages nor blocks.
+func (n *Network) Partition(groups ...[]int) {
+	n.groups = make([]int, len(n.nodes))
+	for i := range n.groups {
+		n.groups[i] = -1 - i
+	}
+	for g, group := range groups {
+		for _, i := range group {
+			n.groups[i] = g
+		}
+	}
+}
+
+// Heal removes the partitions.
+func (n *Network) Heal() { n.groups = nil }
+
+// Crash stops the node. The messages sent to the node while it is crashed are lost.
+func (n *Network) Crash(i int) {
+	if !n.nodes[i].crashed {
+		n.nodes[i].stop()
+	}
+}
+
+// Restart starts a crashed node with a new core, which replays the consensus WAL of the node.
+func (n *Network) Restart(i int) {
+	if n.nodes[i].crashed {
+		n.nodes[i].start()
+	}
+}
+
+// SetByzantine makes the node byzantine. A nil byzantine makes it honest again.
+func (n *Network) SetByzantine(i int, byzantine Byzantine) { n.nodes[i].byzantine = byzantine }
+
+func (n *Network) connected(from, to int) bool {
+	return n.groups == nil || n.groups[from] == n.groups[to]
+}
+
+func (n *Network) link(from, to int) Link {
+	if link, ok := n.links[[2]int{from, to}]; ok {
+		return link
+	}
+	return n.defaultLink
+}
+
+// gossip sends the payload from the node to all the other nodes.
+func (n *Network) gossip(from *Node, prevHash common.Hash, payload []byte) {
+	for _, to := range n.nodes {
+		if to == from {
+			continue
+		}
+		payloads := [][]byte{payload}
+		if from.byzantine != nil {
+			payloads = from.byzantine(from, to.address, payload)
+		}
+		for _, p := range payloads {
+			n.sent++
+			link := n.link(from.index, to.index)
+			if !n.connected(from.index, to.index) || (link.DropRate > 0 && n.rand.Float64() < link.DropRate) {
+				n.dropped++
+				continue
+			}
+			delay := link.Delay
+			if link.Jitter > 0 {
+				delay += time.Duration(n.rand.Int63n(int64(link.Jitter)))
+			}
+			to.deliver(delay, istanbul.MessageEvent{Hash: prevHash, Payload: p})
+		}
+	}
+}
+
+// sync lets the running nodes catch up the blocks of the connected nodes, as the downloader does.
+func (n *Network) sync() {
+	for _, node := range n.nodes {
+		if node.crashed {
+			continue
+		}
+		var best *Node
+		for _, peer := range n.nodes {
+			if peer.crashed || !n.connected(peer.index, node.index) || peer.Height() <= node.Height() {
+				continue
+			}
+			if best == nil || peer.Height() > best.Height() {
+				best = peer
+			}
+		}
+		if best == nil {
+			continue
+		}
+		for num := node.Height() + 1; num <= best.Height(); num++ {
+			if err := node.appendBlock(best.Block(num)); err != nil {
+				break
+			}
+		}
+	}
+	n.clock.AfterFunc(n.config.BlockInterval, n.sync)
+}
+
+// RunFor runs the network for the given duration of the virtual time.
+func (n *Network) RunFor(d time.Duration) {
+	deadline := n.clock.Now() + d
+	for n.clock.Step(deadline) {
+	}
+	n.clock.now = deadline
+}
+
+// RunUntilHeight runs the network until all the running honest nodes commit the block of the given
+// height, or the timeout of the virtual time passes. It returns whether the height is reached.
+func (n *Network) RunUntilHeight(height uint64, timeout time.Duration) bool {
+	deadline := n.clock.Now() + timeout
+	for {
+		if n.minHeight() >= height {
+			return true
+		}
+		if !n.clock.Step(deadline) {
+			n.clock.now = deadline
+			return n.minHeight() >= height
+		}
+	}
+}
+
+func (n *Network) minHeight() uint64 {
+	height := ^uint64(0)
+	for _, node := range n.nodes {
+		if !node.crashed && node.byzantine == nil && node.Height() < height {
+			height = node.Height()
+		}
+	}
+	return height
+}
+
+// CheckSafety returns an error if the honest nodes committed different blocks at the same height.
+func (n *Network) CheckSafety() error {
+	committed := make(map[uint64]*Node)
+	for _, node := range n.nodes {
+		if node.byzantine != nil {
+			continue
+		}
+		for num := uint64(1); num <= node.Height(); num++ {
+			other, ok := committed[num]
+			if !ok {
+				committed[num] = node
+				continue
+			}
+			if other.Block(num).Hash() != node.Block(num).Hash() {
+				return fmt.Errorf("node %d and node %d committed different blocks at %d: %s != %s",
+					other.index, node.index, num, other.Block(num).Hash().Hex(), node.Block(num).Hash().Hex())
+			}
+		}
+	}
+	return nil
+}
+
+// CheckLiveness returns an error if a running honest node did not commit the block of the given height.
+func (n *Network) CheckLiveness(height uint64) error {
+	for _, node := range n.nodes {
+		if !node.crashed && node.byzantine == nil && node.Height() < height {
+			return fmt.Errorf("node %d is at %d, expected %d", node.index, node.Height(), height)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaiachain/kaia/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetwork_Liveness(t *testing.T) {
+	network := NewNetwork(Config{Nodes: 4})
+	network.Start()
+	defer network.Stop()
+
+	assert.True(t, network.RunUntilHeight(10, time.Minute))
+	assert.NoError(t, network.CheckSafety())
+	assert.NoError(t, network.CheckLiveness(10))
+	for _, node := range network.Nodes() {
+		assert.Equal(t, uint8(0), node.Block(10).Header().Round())
+	}
+}
+
+func TestNetwork_Committee(t *testing.T) {
+	network := NewNetwork(Config{Nodes: 7, CommitteeSize: 4})
+	network.Start()
+	defer network.Stop()
+
+	// The validators out of the committee are not needed for the quorum
+	network.Crash(6)
+	assert.True(t, network.RunUntilHeight(20, 10*time.Minute))
+	assert.NoError(t, network.CheckSafety())
+
+	committee, err := network.valset.GetCommittee(20, 0)
+	require.NoError(t, err)
+	assert.Len(t, committee, 4)
+	proposer, err := network.valset.GetProposer(20, 0)
+	require.NoError(t, err)
+	assert.Equal(t, proposer, committee[0])
+}
+
+func TestNetwork_Deterministic(t *testing.T) {
+	run := func() []*Node {
+		network := NewNetwork(Config{Nodes: 4, Seed: 7})
+		network.SetDefaultLink(Link{Delay: 50 * time.Millisecond, Jitter: 200 * time.Millisecond, DropRate: 0.05})
+		network.Start()
+		defer network.Stop()
+		require.True(t, network.RunUntilHeight(10, 5*time.Minute))
+		return network.Nodes()
+	}
+
+	first, second := run(), run()
+	for i := range first {
+		for num := uint64(1); num <= 10; num++ {
+			assert.Equal(t, first[i].Block(num).Hash(), second[i].Block(num).Hash())
+		}
+	}
+}
+
+func TestNetwork_CrashedValidators(t *testing.T) {
+	network := NewNetwork(Config{Nodes: 4})
+	network.Start()
+	defer network.Stop()
+	require.True(t, network.RunUntilHeight(3, time.Minute))
+
+	// The network tolerates f crashed validators
+	network.Crash(1)
+	assert.True(t, network.RunUntilHeight(8, 5*time.Minute))
+
+	// The network halts with more than f crashed validators
+	network.Crash(2)
+	height := network.Node(0).Height()
+	network.RunFor(5 * time.Minute)
+	assert.Equal(t, height, network.Node(0).Height())
+
+	// The restarted validators catch up, and the network makes progress again
+	network.Restart(1)
+	network.Restart(2)
+	assert.True(t, network.RunUntilHeight(height+5, 10*time.Minute))
+	assert.NoError(t, network.CheckSafety())
+}
+
+func TestNetwork_Partition(t *testing.T) {
+	network := NewNetwork(Config{Nodes: 4})
+	network.Start()
+	defer network.Stop()
+	require.True(t, network.RunUntilHeight(3, time.Minute))
+
+	// No group has a quorum
+	network.Partition([]int{0, 1}, []int{2, 3})
+	height := network.Node(0).Height()
+	network.RunFor(5 * time.Minute)
+	for _, node := range network.Nodes() {
+		assert.LessOrEqual(t, node.Height(), height+1)
+	}
+	assert.NoError(t, network.CheckSafety())
+
+	network.Heal()
+	assert.True(t, network.RunUntilHeight(height+5, 10*time.Minute))
+	assert.NoError(t, network.CheckSafety())
+
+	// The majority makes progress, and the minority catches up after the partition heals
+	network.Partition([]int{0, 1, 2}, []int{3})
+	height = network.Node(3).Height()
+	network.RunFor(time.Minute)
+	assert.Greater(t, network.Node(0).Height(), height+5)
+	assert.Equal(t, height, network.Node(3).Height())
+
+	network.Heal()
+	assert.True(t, network.RunUntilHeight(network.Node(0).Height()+3, 5*time.Minute))
+	assert.NoError(t, network.CheckSafety())
+}
+
+func TestNetwork_LossyLinks(t *testing.T) {
+	network := NewNetwork(Config{Nodes: 7, Seed: 1})
+	network.SetDefaultLink(Link{Delay: 100 * time.Millisecond, Jitter: 500 * time.Millisecond, DropRate: 0.1})
+	network.SetLink(0, 1, Link{DropRate: 1})
+	network.Start()
+	defer network.Stop()
+
+	assert.True(t, network.RunUntilHeight(20, 10*time.Minute))
+	assert.NoError(t, network.CheckSafety())
+	sent, dropped := network.Stats()
+	assert.Greater(t, dropped, 0)
+	assert.Less(t, dropped, sent)
+}
+
+func TestNetwork_ByzantineValidators(t *testing.T) {
+	network := NewNetwork(Config{Nodes: 4})
+	network.SetByzantine(3, EquivocatingVotes)
+	network.Start()
+	defer network.Stop()
+
+	assert.True(t, network.RunUntilHeight(10, 5*time.Minute))
+	assert.NoError(t, network.CheckSafety())
+
+	// The honest validators receiving the conflicting votes record the evidence
+	recorded := 0
+	for _, node := range network.Nodes()[:3] {
+		evidences, err := node.Evidences()
+		require.NoError(t, err)
+		for _, evidence := range evidences {
+			assert.Equal(t, network.Node(3).Address(), evidence.Validator)
+			_, _, err := evidence.Subjects()
+			assert.NoError(t, err)
+		}
+		recorded += len(evidences)
+	}
+	assert.Greater(t, recorded, 0)
+
+	// A silent byzantine validator is tolerated as a crashed one
+	network.SetByzantine(3, Silent)
+	assert.True(t, network.RunUntilHeight(20, 5*time.Minute))
+	assert.NoError(t, network.CheckSafety())
+}
+
+func TestNetwork_ValidatorSetChange(t *testing.T) {
+	network := NewNetwork(Config{Nodes: 5})
+	schedule := NewSchedule(nil, 0)
+	addrs := make([]common.Address, 5)
+	for i, node := range network.Nodes() {
+		addrs[i] = node.Address()
+	}
+	// Node 4 joins the council at block 5, and node 0 is demoted at block 10
+	schedule.Set(0, addrs[:4], nil)
+	schedule.Set(5, addrs, nil)
+	schedule.Set(10, addrs, addrs[:1])
+	network.valset = schedule
+
+	network.Start()
+	defer network.Stop()
+	require.True(t, network.RunUntilHeight(15, 5*time.Minute))
+	assert.NoError(t, network.CheckSafety())
+
+	proposers := make(map[common.Address]bool)
+	for num := uint64(10); num <= 15; num++ {
+		proposers[network.Node(1).Block(num).Rewardbase()] = true
+	}
+	assert.True(t, proposers[addrs[4]])
+	assert.False(t, proposers[addrs[0]])
+
+	// The demoted validator does not count for the quorum of the 4 qualified validators
+	network.Crash(3)
+	network.Crash(4)
+	height := network.Node(1).Height()
+	network.RunFor(5 * time.Minute)
+	assert.Equal(t, height, network.Node(1).Height())
+	network.Restart(4)
+	assert.True(t, network.RunUntilHeight(height+5, 10*time.Minute))
+}
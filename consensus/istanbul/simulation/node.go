@@ -0,0 +1,268 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package simulation
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/consensus/istanbul"
+	istanbulCore "github.com/kaiachain/kaia/consensus/istanbul/core"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/event"
+	"github.com/kaiachain/kaia/storage/database"
+)
+
+var (
+	errInvalidSignature = errors.New("invalid signature")
+	errUnknownBlock     = errors.New("unknown block")
+	errNotChained       = errors.New("proposal does not extend the chain")
+)
+
+// Node is a validator of the simulated network running an Istanbul core.
+type Node struct {
+	index   int
+	key     *ecdsa.PrivateKey
+	address common.Address
+	network *Network
+
+	db         database.Database // Persisted across the restarts
+	engine     istanbulCore.SyncEngine
+	eventMux   *event.TypeMux
+	chain      []*types.Block
+	byzantine  Byzantine
+	crashed    bool
+	generation uint64 // Incremented on a crash to drop the events scheduled before
+}
+
+// Index returns the index of the node in the network.
+func (n *Node) Index() int { return n.index }
+
+// Address returns the validator address of the node.
+func (n *Node) Address() common.Address { return n.address }
+
+// Height returns the number of the last committed block of the node.
+func (n *Node) Height() uint64 { return uint64(len(n.chain) - 1) }
+
+// Block returns the committed block of the given number, or nil if it is not committed.
+func (n *Node) Block(num uint64) *types.Block {
+	if num >= uint64(len(n.chain)) {
+		return nil
+	}
+	return n.chain[num]
+}
+
+// Crashed returns whether the node is crashed.
+func (n *Node) Crashed() bool { return n.crashed }
+
+// Byzantine returns whether the node is byzantine.
+func (n *Node) Byzantine() bool { return n.byzantine != nil }
+
+// Evidences returns the equivocation evidence recorded by the node.
+func (n *Node) Evidences() ([]*istanbulCore.Evidence, error) {
+	return istanbulCore.NewEvidenceStore(n.db).Evidences(0, math.MaxUint64)
+}
+
+func (n *Node) head() *types.Block { return n.chain[len(n.chain)-1] }
+
+// start creates a new core over the database of the node and starts it.
+func (n *Node) start() {
+	n.crashed = false
+	generation := n.generation
+	dispatch := func(ev interface{}) {
+		n.network.clock.AfterFunc(0, func() { n.handle(generation, ev) })
+	}
+	n.engine = istanbulCore.NewSyncEngine(&backend{n}, istanbul.DefaultConfig.Copy(), n.db, n.network.clock, dispatch)
+	n.engine.Start()
+	n.scheduleRequest()
+}
+
+// stop stops the core and drops the events scheduled for it.
+func (n *Node) stop() {
+	n.engine.Stop()
+	n.crashed = true
+	n.generation++
+}
+
+// handle handles the event if the node has not crashed since the event was scheduled.
+func (n *Node) handle(generation uint64, ev interface{}) {
+	if n.crashed || n.generation != generation {
+		return
+	}
+	n.engine.HandleEvent(ev)
+}
+
+// deliver schedules the event to the node after the delay.
+func (n *Node) deliver(delay time.Duration, ev interface{}) {
+	generation := n.generation
+	n.network.clock.AfterFunc(delay, func() { n.handle(generation, ev) })
+}
+
+// scheduleRequest requests a proposal on the head of the node after the block interval, as the miner does.
+func (n *Node) scheduleRequest() {
+	generation := n.generation
+	n.network.clock.AfterFunc(n.network.config.BlockInterval, func() {
+		n.handle(generation, istanbul.RequestEvent{Proposal: n.network.newBlock(n.head(), n.address)})
+	})
+}
+
+// appendBlock appends the committed block to the chain and moves the core to the next height.
+func (n *Node) appendBlock(block *types.Block) error {
+	if known := n.Block(block.NumberU64()); known != nil && known.Hash() == block.Hash() {
+		return nil
+	}
+	if block.NumberU64() != uint64(len(n.chain)) || block.ParentHash() != n.head().Hash() {
+		return errNotChained
+	}
+	n.chain = append(n.chain, block)
+	n.deliver(0, istanbul.FinalCommittedEvent{})
+	n.scheduleRequest()
+	return nil
+}
+
+// backend is the istanbul.Backend of a simulated node.
+type backend struct {
+	node *Node
+}
+
+func (b *backend) Address() common.Address { return b.node.address }
+
+func (b *backend) EventMux() *event.TypeMux { return b.node.eventMux }
+
+func (b *backend) Broadcast(prevHash common.Hash, payload []byte) error {
+	b.node.deliver(0, istanbul.MessageEvent{Hash: prevHash, Payload: payload})
+	b.node.network.gossip(b.node, prevHash, payload)
+	return nil
+}
+
+func (b *backend) Gossip(payload []byte) error {
+	b.node.network.gossip(b.node, common.Hash{}, payload)
+	return nil
+}
+
+// GossipSubPeer is a no-op since every node is connected to every other node.
+func (b *backend) GossipSubPeer(prevHash common.Hash, payload []byte) {}
+
+func (b *backend) Commit(proposal istanbul.Proposal, seals [][]byte, committers []common.Address) error {
+	block, ok := proposal.(*types.Block)
+	if !ok {
+		return errUnknownBlock
+	}
+	return b.node.appendBlock(block)
+}
+
+func (b *backend) Verify(proposal istanbul.Proposal) (time.Duration, error) {
+	if proposal.ParentHash() != b.node.head().Hash() {
+		return 0, errNotChained
+	}
+	return 0, nil
+}
+
+func (b *backend) Sign(data []byte) ([]byte, error) {
+	return crypto.Sign(crypto.Keccak256(data), b.node.key)
+}
+
+func (b *backend) SignCommittedSeal(proposal istanbul.Proposal) ([]byte, error) {
+	return b.Sign(istanbulCore.PrepareCommittedSeal(proposal.Hash()))
+}
+
+func (b *backend) CheckSignature(data []byte, addr common.Address, sig []byte) error {
+	signer, err := istanbul.GetSignatureAddress(data, sig)
+	if err != nil {
+		return err
+	}
+	if signer != addr {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+func (b *backend) LastProposal() (istanbul.Proposal, common.Address) {
+	head := b.node.head()
+	return head, head.Rewardbase()
+}
+
+func (b *backend) HasPropsal(hash common.Hash, number *big.Int) bool {
+	block := b.node.Block(number.Uint64())
+	return block != nil && block.Hash() == hash
+}
+
+func (b *backend) GetProposer(number uint64) common.Address {
+	if block := b.node.Block(number); block != nil {
+		return block.Rewardbase()
+	}
+	return common.Address{}
+}
+
+func (b *backend) HasBadProposal(hash common.Hash) bool { return false }
+
+func (b *backend) GetRewardBase() common.Address { return b.node.address }
+
+func (b *backend) SetCurrentView(view *istanbul.View) {}
+
+func (b *backend) NodeType() common.ConnType { return common.CONSENSUSNODE }
+
+func (b *backend) GetValidatorSet(num uint64) (*istanbul.BlockValSet, error) {
+	valset := b.node.network.valset
+	council, err := valset.GetCouncil(num)
+	if err != nil {
+		return nil, err
+	}
+	demoted, err := valset.GetDemotedValidators(num)
+	if err != nil {
+		return nil, err
+	}
+	return istanbul.NewBlockValSet(council, demoted), nil
+}
+
+func (b *backend) GetCommitteeState(num uint64) (*istanbul.RoundCommitteeState, error) {
+	block := b.node.Block(num)
+	if block == nil {
+		return nil, errUnknownBlock
+	}
+	return b.GetCommitteeStateByRound(num, uint64(block.Header().Round()))
+}
+
+func (b *backend) GetCommitteeStateByRound(num uint64, round uint64) (*istanbul.RoundCommitteeState, error) {
+	valset := b.node.network.valset
+	blockValSet, err := b.GetValidatorSet(num)
+	if err != nil {
+		return nil, err
+	}
+	committee, err := valset.GetCommittee(num, round)
+	if err != nil {
+		return nil, err
+	}
+	proposer, err := valset.GetProposer(num, round)
+	if err != nil {
+		return nil, err
+	}
+	committeeSize := uint64(len(committee))
+	if b.node.network.config.CommitteeSize > 0 {
+		committeeSize = uint64(b.node.network.config.CommitteeSize)
+	}
+	return istanbul.NewRoundCommitteeState(blockValSet, committeeSize, committee, proposer), nil
+}
+
+func (b *backend) GetRoundTimeouts(num uint64) (time.Duration, time.Duration) {
+	return b.node.network.config.BaseTimeout, b.node.network.config.TimeoutBackoff
+}
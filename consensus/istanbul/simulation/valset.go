@@ -0,0 +1,123 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package simulation
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/kaiax/valset"
+)
+
+var errNoValidator = errors.New("no qualified validator")
+
+// Valset is the part of valset.ValsetModule used by the simulated backends.
+// A valset.ValsetModule can be used as it is.
+type Valset interface {
+	GetCouncil(num uint64) ([]common.Address, error)
+	GetCommittee(num uint64, round uint64) ([]common.Address, error)
+	GetDemotedValidators(num uint64) ([]common.Address, error)
+	GetProposer(num uint64, round uint64) (common.Address, error)
+}
+
+// Schedule is a Valset whose council and demoted validators change at the scheduled block numbers.
+// The proposer rotates over the sorted qualified validators by block number and round, and the
+// committee is the proposer and the other validators shuffled by the block number and round.
+type Schedule struct {
+	committeeSize int
+	changes       []scheduleChange // sorted by the block number
+}
+
+type scheduleChange struct {
+	from    uint64
+	council *valset.AddressSet
+	demoted *valset.AddressSet
+}
+
+// NewSchedule returns a schedule of the given council from the genesis.
+// If committeeSize is 0, the committee is the whole qualified validators.
+func NewSchedule(council []common.Address, committeeSize int) *Schedule {
+	s := &Schedule{committeeSize: committeeSize}
+	s.Set(0, council, nil)
+	return s
+}
+
+// Set changes the council and the demoted validators from the given block number.
+func (s *Schedule) Set(from uint64, council, demoted []common.Address) {
+	change := scheduleChange{from, valset.NewAddressSet(council), valset.NewAddressSet(demoted)}
+	idx := sort.Search(len(s.changes), func(i int) bool { return s.changes[i].from >= from })
+	if idx < len(s.changes) && s.changes[idx].from == from {
+		s.changes[idx] = change
+		return
+	}
+	s.changes = append(s.changes, scheduleChange{})
+	copy(s.changes[idx+1:], s.changes[idx:])
+	s.changes[idx] = change
+}
+
+func (s *Schedule) at(num uint64) scheduleChange {
+	idx := sort.Search(len(s.changes), func(i int) bool { return s.changes[i].from > num })
+	return s.changes[idx-1]
+}
+
+func (s *Schedule) qualified(num uint64) *valset.AddressSet {
+	change := s.at(num)
+	return change.council.Subtract(change.demoted)
+}
+
+// GetCouncil implements Valset.GetCouncil
+func (s *Schedule) GetCouncil(num uint64) ([]common.Address, error) {
+	return s.at(num).council.List(), nil
+}
+
+// GetDemotedValidators implements Valset.GetDemotedValidators
+func (s *Schedule) GetDemotedValidators(num uint64) ([]common.Address, error) {
+	return s.at(num).demoted.List(), nil
+}
+
+// GetProposer implements Valset.GetProposer
+func (s *Schedule) GetProposer(num uint64, round uint64) (common.Address, error) {
+	qualified := s.qualified(num)
+	if qualified.Len() == 0 {
+		return common.Address{}, errNoValidator
+	}
+	return qualified.At(int((num + round) % uint64(qualified.Len()))), nil
+}
+
+// GetCommittee implements Valset.GetCommittee
+func (s *Schedule) GetCommittee(num uint64, round uint64) ([]common.Address, error) {
+	proposer, err := s.GetProposer(num, round)
+	if err != nil {
+		return nil, err
+	}
+	qualified := s.qualified(num)
+	if s.committeeSize == 0 || qualified.Len() <= s.committeeSize {
+		return qualified.List(), nil
+	}
+
+	committee := []common.Address{proposer}
+	for _, addr := range qualified.ShuffledList(int64(num<<16 + round)) {
+		if len(committee) == s.committeeSize {
+			break
+		}
+		if addr != proposer {
+			committee = append(committee, addr)
+		}
+	}
+	return committee, nil
+}
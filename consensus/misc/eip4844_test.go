@@ -0,0 +1,92 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+package misc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalcExcessBlobGas(t *testing.T) {
+	config := params.TestChainConfig.Copy()
+	config.BlobTxCompatibleBlock = big.NewInt(10)
+
+	tests := []struct {
+		number  int64
+		excess  uint64
+		blobs   uint64
+		want    uint64
+		comment string
+	}{
+		// The excess blob gas starts from zero at the fork block
+		{9, 0, 6, 0, "parent before fork"},
+		// Blob gas below the target is not accumulated
+		{10, 0, 0, 0, "no blobs"},
+		{10, 0, 3, 0, "target blobs"},
+		{10, 0, 6, 3 * params.BlobTxBlobGasPerBlob, "max blobs"},
+		// The excess blob gas is reduced below the target
+		{10, 5 * params.BlobTxBlobGasPerBlob, 1, 3 * params.BlobTxBlobGasPerBlob, "one blob with excess"},
+		{10, params.BlobTxBlobGasPerBlob, 0, 0, "excess less than target"},
+	}
+	for _, tc := range tests {
+		blobGasUsed := tc.blobs * params.BlobTxBlobGasPerBlob
+		parent := &types.Header{
+			Number:        big.NewInt(tc.number),
+			ExcessBlobGas: &tc.excess,
+			BlobGasUsed:   &blobGasUsed,
+		}
+		assert.Equal(t, tc.want, CalcExcessBlobGas(config, parent), tc.comment)
+	}
+}
+
+func TestCalcBlobFee(t *testing.T) {
+	tests := []struct {
+		excessBlobGas uint64
+		blobfee       int64
+	}{
+		{0, 1},
+		{2314057, 1},
+		{2314058, 2},
+		{10 * 1024 * 1024, 23},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, big.NewInt(tc.blobfee), CalcBlobFee(tc.excessBlobGas), "excess %d", tc.excessBlobGas)
+	}
+}
+
+func TestVerifyEIP4844Header(t *testing.T) {
+	config := params.TestChainConfig.Copy()
+	config.BlobTxCompatibleBlock = big.NewInt(0)
+
+	var (
+		zero     = uint64(0)
+		excess   = 3 * uint64(params.BlobTxBlobGasPerBlob)
+		overMax  = uint64(params.MaxBlobGasPerBlock + params.BlobTxBlobGasPerBlob)
+		notBlobs = uint64(params.BlobTxBlobGasPerBlob - 1)
+		maxBlobs = uint64(params.MaxBlobGasPerBlock)
+		parent   = &types.Header{Number: big.NewInt(1), ExcessBlobGas: &zero, BlobGasUsed: &maxBlobs}
+	)
+	assert.NoError(t, VerifyEIP4844Header(config, parent, &types.Header{Number: big.NewInt(2), ExcessBlobGas: &excess, BlobGasUsed: &zero}))
+	assert.Error(t, VerifyEIP4844Header(config, parent, &types.Header{Number: big.NewInt(2), BlobGasUsed: &zero}))
+	assert.Error(t, VerifyEIP4844Header(config, parent, &types.Header{Number: big.NewInt(2), ExcessBlobGas: &excess}))
+	assert.Error(t, VerifyEIP4844Header(config, parent, &types.Header{Number: big.NewInt(2), ExcessBlobGas: &zero, BlobGasUsed: &zero}))
+	assert.Error(t, VerifyEIP4844Header(config, parent, &types.Header{Number: big.NewInt(2), ExcessBlobGas: &excess, BlobGasUsed: &overMax}))
+	assert.Error(t, VerifyEIP4844Header(config, parent, &types.Header{Number: big.NewInt(2), ExcessBlobGas: &excess, BlobGasUsed: &notBlobs}))
+}
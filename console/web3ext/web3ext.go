@@ -36,6 +36,7 @@ var Modules = map[string]string{
 	"subbridge":        SubBridge_JS,
 	"clique":           CliqueJs,
 	"governance":       Governance_JS,
+	"valset":           Valset_JS,
 	"bootnode":         Bootnode_JS,
 	"chaindatafetcher": ChainDataFetcher_JS,
 	"eth":              Eth_JS,
@@ -153,6 +154,11 @@ web3._extend({
 				return receipts.map(web3._extend.formatters.outputTransactionReceiptFormatter);
 			}
 		}),
+		new web3._extend.Method({
+			name: 'getBlobSidecars',
+			call: 'eth_getBlobSidecars',
+			params: 1
+		}),
 		new web3._extend.Method({
 			name: 'getRawTransaction',
 			call: 'eth_getRawTransactionByHash',
@@ -408,6 +414,44 @@ web3._extend({
 });
 `
 
+const Valset_JS = `
+web3._extend({
+	property: 'valset',
+	methods: [
+		new web3._extend.Method({
+			name: 'getCouncil',
+			call: 'valset_getCouncil',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getDemotedValidators',
+			call: 'valset_getDemotedValidators',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getCommittee',
+			call: 'valset_getCommittee',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'getProposer',
+			call: 'valset_getProposer',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'getSchedule',
+			call: 'valset_getSchedule',
+			params: 2,
+			inputFormatter: [null, null]
+		})
+	]
+});
+`
+
 const Admin_JS = `
 web3._extend({
 	property: 'admin',
@@ -483,6 +527,16 @@ web3._extend({
 			name: 'stopStateMigration',
 			call: 'admin_stopStateMigration',
 		}),
+		new web3._extend.Method({
+			name: 'startCompaction',
+			call: 'admin_startCompaction',
+			params: 3,
+			inputFormatter: [null, null, null]
+		}),
+		new web3._extend.Method({
+			name: 'stopCompaction',
+			call: 'admin_stopCompaction',
+		}),
 		new web3._extend.Method({
 			name: 'saveTrieNodeCacheToDisk',
 			call: 'admin_saveTrieNodeCacheToDisk',
@@ -546,6 +600,10 @@ web3._extend({
 			name: 'stateMigrationStatus',
 			getter: 'admin_stateMigrationStatus'
 		}),
+		new web3._extend.Property({
+			name: 'compactionStatus',
+			getter: 'admin_compactionStatus'
+		}),
 		new web3._extend.Property({
 			name: 'spamThrottlerConfig',
 			getter: 'admin_spamThrottlerConfig'
@@ -572,6 +630,12 @@ web3._extend({
 			call: 'debug_dumpStateTrie',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'accountRange',
+			call: 'debug_accountRange',
+			params: 6,
+			inputFormatter: [web3._extend.formatters.inputDefaultBlockNumberFormatter, null, null, null, null, null]
+		}),
 		new web3._extend.Method({
 			name: 'getBlockRlp',
 			call: 'debug_getBlockRlp',
@@ -883,6 +947,11 @@ var klayMethods = [
 			return receipts.map(web3._extend.formatters.outputTransactionReceiptFormatter);
 		}
 	}),
+	new web3._extend.Method({
+		name: 'getBlobSidecars',
+		call: 'klay_getBlobSidecars',
+		params: 1
+	}),
 	new web3._extend.Method({
 		name: 'sign',
 		call: 'klay_sign',
@@ -895,6 +964,38 @@ var klayMethods = [
 		params: 3,
 		inputFormatter: [web3._extend.formatters.inputTransactionFormatter, web3._extend.utils.fromDecimal, web3._extend.utils.fromDecimal]
 	}),
+	new web3._extend.Method({
+		name: 'replaceTransaction',
+		call: 'klay_replaceTransaction',
+		params: 2,
+		inputFormatter: [null, web3._extend.utils.fromDecimal]
+	}),
+	new web3._extend.Method({
+		name: 'cancelTransaction',
+		call: 'klay_cancelTransaction',
+		params: 1
+	}),
+	new web3._extend.Method({
+		name: 'sendBundle',
+		call: 'klay_sendBundle',
+		params: 1
+	}),
+	new web3._extend.Method({
+		name: 'getPendingBlockPreview',
+		call: 'klay_getPendingBlockPreview',
+		params: 0
+	}),
+	new web3._extend.Method({
+		name: 'suggestFees',
+		call: 'klay_suggestFees',
+		params: 0
+	}),
+	new web3._extend.Method({
+		name: 'sendPrivateRawTransaction',
+		call: 'klay_sendPrivateRawTransaction',
+		params: 2,
+		inputFormatter: [null, web3._extend.utils.fromDecimal]
+	}),
 	new web3._extend.Method({
 		name: 'signTransaction',
 		call: 'klay_signTransaction',
@@ -1330,7 +1431,18 @@ web3._extend({
 const TxPool_JS = `
 web3._extend({
 	property: 'txpool',
-	methods: [],
+	methods: [
+		new web3._extend.Method({
+			name: 'contentFrom',
+			call: 'txpool_contentFrom',
+			params: 1,
+		}),
+		new web3._extend.Method({
+			name: 'txStatus',
+			call: 'txpool_txStatus',
+			params: 1,
+		}),
+	],
 	properties:
 	[
 		new web3._extend.Property({
@@ -1396,6 +1508,23 @@ web3._extend({
 			name: 'discard',
 			call: 'istanbul_discard',
 			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'getEvidence',
+			call: 'istanbul_getEvidence',
+			params: 2,
+			inputFormatter: [null, null]
+		}),
+		new web3._extend.Method({
+			name: 'getConsensusTimeline',
+			call: 'istanbul_getConsensusTimeline',
+			params: 2,
+			inputFormatter: [null, null]
+		}),
+		new web3._extend.Method({
+			name: 'myStatus',
+			call: 'istanbul_myStatus',
+			params: 0
 		})
 	],
 	properties:
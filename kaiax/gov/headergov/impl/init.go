@@ -347,6 +347,16 @@ func getGenesisParamNames(config *params.ChainConfig) []gov.ParamName {
 		genesisParamNames = append(genesisParamNames, []gov.ParamName{
 			gov.IstanbulEpoch, gov.IstanbulPolicy, gov.IstanbulCommitteeSize,
 		}...)
+		// The round change timeouts and the block interval are included only if set.
+		if config.Istanbul.BaseTimeout != 0 {
+			genesisParamNames = append(genesisParamNames, gov.IstanbulBaseTimeout)
+		}
+		if config.Istanbul.TimeoutBackoff != 0 {
+			genesisParamNames = append(genesisParamNames, gov.IstanbulTimeoutBackoff)
+		}
+		if config.Istanbul.BlockInterval != 0 {
+			genesisParamNames = append(genesisParamNames, gov.IstanbulBlockInterval)
+		}
 	}
 
 	if config.IsMagmaForkEnabled(common.Big0) &&
@@ -237,6 +237,10 @@ func TestGetGenesisParamNames(t *testing.T) {
 				}
 			}
 		}
+		// Set the optional parameters.
+		latestGenesisConfig.Istanbul.BaseTimeout = 5000
+		latestGenesisConfig.Istanbul.TimeoutBackoff = 500
+		latestGenesisConfig.Istanbul.BlockInterval = 2
 
 		assert.Equal(t, len(gov.Params), len(getGenesisParamNames(latestGenesisConfig)))
 	})
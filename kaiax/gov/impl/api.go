@@ -107,6 +107,8 @@ func getChainConfig(g *GovModule, num *rpc.BlockNumber) *params.ChainConfig {
 	config.Kip160ContractAddress = latestConfig.Kip160ContractAddress
 	config.RandaoCompatibleBlock = latestConfig.RandaoCompatibleBlock
 	config.PragueCompatibleBlock = latestConfig.PragueCompatibleBlock
+	config.BlobTxCompatibleBlock = latestConfig.BlobTxCompatibleBlock
+	config.BlsSealCompatibleBlock = latestConfig.BlsSealCompatibleBlock
 	return config
 }
 
@@ -160,6 +160,27 @@ func noopFormatChecker(cv any) bool {
 	return true
 }
 
+// The bounds of the Istanbul timing parameters, which keep the timers and the block timestamps from overflowing.
+const (
+	minIstanbulBaseTimeout    = uint64(100)   // 100 milliseconds
+	maxIstanbulBaseTimeout    = uint64(60000) // 1 minute
+	minIstanbulBlockInterval  = uint64(1)     // 1 second
+	maxIstanbulBlockInterval  = uint64(60)    // 1 minute
+	minIstanbulTimeoutBackoff = uint64(100)   // 100 milliseconds
+	maxIstanbulTimeoutBackoff = uint64(60000) // 1 minute
+)
+
+// optionalRangeFormatChecker accepts zero, which means the parameter is unset, or a uint64 in [min, max].
+func optionalRangeFormatChecker(min, max uint64) func(cv any) bool {
+	return func(cv any) bool {
+		v, ok := cv.(uint64)
+		if !ok {
+			return false
+		}
+		return v == 0 || (min <= v && v <= max)
+	}
+}
+
 type ParamName string
 
 // alphabetically sorted. These are only used in-memory, so the order does not matter.
@@ -169,9 +190,12 @@ const (
 	GovernanceGoverningNode        ParamName = "governance.governingnode"
 	GovernanceGovParamContract     ParamName = "governance.govparamcontract"
 	GovernanceUnitPrice            ParamName = "governance.unitprice"
+	IstanbulBaseTimeout            ParamName = "istanbul.basetimeout"
+	IstanbulBlockInterval          ParamName = "istanbul.blockinterval"
 	IstanbulCommitteeSize          ParamName = "istanbul.committeesize"
 	IstanbulEpoch                  ParamName = "istanbul.epoch"
 	IstanbulPolicy                 ParamName = "istanbul.policy"
+	IstanbulTimeoutBackoff         ParamName = "istanbul.timeoutbackoff"
 	Kip71BaseFeeDenominator        ParamName = "kip71.basefeedenominator"
 	Kip71GasTarget                 ParamName = "kip71.gastarget"
 	Kip71LowerBoundBaseFee         ParamName = "kip71.lowerboundbasefee"
@@ -264,6 +288,34 @@ var Params = map[ParamName]*Param{
 		DefaultValue:     uint64(250e9),
 		VoteForbidden:    false,
 	},
+	// IstanbulBaseTimeout is the round change timeout of the round 0 in milliseconds, between 100 and 60000.
+	// Zero means the node-local timeout is used.
+	IstanbulBaseTimeout: {
+		Canonicalizer: uint64Canonicalizer,
+		FormatChecker: optionalRangeFormatChecker(minIstanbulBaseTimeout, maxIstanbulBaseTimeout),
+		ChainConfigValue: func(c *params.ChainConfig) (any, error) {
+			if c.Istanbul == nil {
+				return nil, errors.New("istanbul is not set")
+			}
+			return c.Istanbul.BaseTimeout, nil
+		},
+		DefaultValue:  uint64(0),
+		VoteForbidden: false,
+	},
+	// IstanbulBlockInterval is the target interval between blocks in seconds, between 1 and 60.
+	// Zero means the node-local block generation interval is used.
+	IstanbulBlockInterval: {
+		Canonicalizer: uint64Canonicalizer,
+		FormatChecker: optionalRangeFormatChecker(minIstanbulBlockInterval, maxIstanbulBlockInterval),
+		ChainConfigValue: func(c *params.ChainConfig) (any, error) {
+			if c.Istanbul == nil {
+				return nil, errors.New("istanbul is not set")
+			}
+			return c.Istanbul.BlockInterval, nil
+		},
+		DefaultValue:  uint64(0),
+		VoteForbidden: false,
+	},
 	IstanbulCommitteeSize: {
 		Canonicalizer: uint64Canonicalizer,
 		FormatChecker: func(cv any) bool {
@@ -312,6 +364,20 @@ var Params = map[ParamName]*Param{
 		DefaultValue:  uint64(RoundRobin),
 		VoteForbidden: true,
 	},
+	// IstanbulTimeoutBackoff is the unit of the extra timeout of the round r > 0 in milliseconds, between 100
+	// and 60000, which is timeoutBackoff * 2^r. Zero means the default backoff of one second is used.
+	IstanbulTimeoutBackoff: {
+		Canonicalizer: uint64Canonicalizer,
+		FormatChecker: optionalRangeFormatChecker(minIstanbulTimeoutBackoff, maxIstanbulTimeoutBackoff),
+		ChainConfigValue: func(c *params.ChainConfig) (any, error) {
+			if c.Istanbul == nil {
+				return nil, errors.New("istanbul is not set")
+			}
+			return c.Istanbul.TimeoutBackoff, nil
+		},
+		DefaultValue:  uint64(0),
+		VoteForbidden: false,
+	},
 	Kip71BaseFeeDenominator: {
 		Canonicalizer: uint64Canonicalizer,
 		FormatChecker: func(cv any) bool {
@@ -17,6 +17,31 @@ func TestParam(t *testing.T) {
 	}
 }
 
+func TestIstanbulTimingFormatChecker(t *testing.T) {
+	tcs := []struct {
+		name  ParamName
+		valid []uint64
+		wrong []uint64
+	}{
+		{name: IstanbulBaseTimeout, valid: []uint64{0, 100, 10000, 60000}, wrong: []uint64{1, 99, 60001, 1 << 63}},
+		{name: IstanbulBlockInterval, valid: []uint64{0, 1, 60}, wrong: []uint64{61, 1 << 63}},
+		{name: IstanbulTimeoutBackoff, valid: []uint64{0, 100, 1000, 60000}, wrong: []uint64{99, 60001, 1 << 63}},
+	}
+
+	for _, tc := range tcs {
+		t.Run(string(tc.name), func(t *testing.T) {
+			for _, v := range tc.valid {
+				assert.True(t, Params[tc.name].FormatChecker(v), v)
+			}
+			for _, v := range tc.wrong {
+				assert.False(t, Params[tc.name].FormatChecker(v), v)
+				assert.ErrorIs(t, PartialParamSet{}.Add(string(tc.name), v), ErrInvalidParamValue)
+			}
+			assert.False(t, Params[tc.name].FormatChecker("100"))
+		})
+	}
+}
+
 func TestAddressCanonicalizer(t *testing.T) {
 	tcs := []struct {
 		desc          string
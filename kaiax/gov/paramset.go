@@ -16,7 +16,8 @@ type ParamSet struct {
 	GoverningNode, GovParamContract common.Address
 
 	// istanbul
-	CommitteeSize, ProposerPolicy, Epoch uint64
+	CommitteeSize, ProposerPolicy, Epoch       uint64
+	BaseTimeout, TimeoutBackoff, BlockInterval uint64 // zero if not governed
 
 	// reward
 	Ratio, Kip82Ratio                             string
@@ -57,12 +58,18 @@ func (p *ParamSet) Set(name ParamName, cv any) error {
 		p.GovParamContract, ok = cv.(common.Address)
 	case GovernanceUnitPrice:
 		p.UnitPrice, ok = cv.(uint64)
+	case IstanbulBaseTimeout:
+		p.BaseTimeout, ok = cv.(uint64)
+	case IstanbulBlockInterval:
+		p.BlockInterval, ok = cv.(uint64)
 	case IstanbulCommitteeSize:
 		p.CommitteeSize, ok = cv.(uint64)
 	case IstanbulEpoch:
 		p.Epoch, ok = cv.(uint64)
 	case IstanbulPolicy:
 		p.ProposerPolicy, ok = cv.(uint64)
+	case IstanbulTimeoutBackoff:
+		p.TimeoutBackoff, ok = cv.(uint64)
 	case Kip71BaseFeeDenominator:
 		p.BaseFeeDenominator, ok = cv.(uint64)
 	case Kip71GasTarget:
@@ -134,12 +141,18 @@ func (p *ParamSet) ToMap() map[ParamName]any {
 			ret[name] = p.GovParamContract
 		case GovernanceUnitPrice:
 			ret[name] = p.UnitPrice
+		case IstanbulBaseTimeout:
+			ret[name] = p.BaseTimeout
+		case IstanbulBlockInterval:
+			ret[name] = p.BlockInterval
 		case IstanbulCommitteeSize:
 			ret[name] = p.CommitteeSize
 		case IstanbulEpoch:
 			ret[name] = p.Epoch
 		case IstanbulPolicy:
 			ret[name] = p.ProposerPolicy
+		case IstanbulTimeoutBackoff:
+			ret[name] = p.TimeoutBackoff
 		case Kip71BaseFeeDenominator:
 			ret[name] = p.BaseFeeDenominator
 		case Kip71GasTarget:
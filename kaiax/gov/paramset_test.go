@@ -18,9 +18,12 @@ func TestParametersSetAndAdd(t *testing.T) {
 		{name: GovernanceGoverningNode, value: common.HexToAddress("0x000000000000000000000000000abcd000000000")},
 		{name: GovernanceGovParamContract, value: common.HexToAddress("000000000000000000000000000abcd000000000")},
 		{name: GovernanceUnitPrice, value: uint64(25e9)},
+		{name: IstanbulBaseTimeout, value: uint64(5000)},
+		{name: IstanbulBlockInterval, value: uint64(2)},
 		{name: IstanbulCommitteeSize, value: uint64(7)},
 		{name: IstanbulEpoch, value: uint64(406800)},
 		{name: IstanbulPolicy, value: uint64(2)},
+		{name: IstanbulTimeoutBackoff, value: uint64(500)},
 		{name: Kip71BaseFeeDenominator, value: uint64(64)},
 		{name: Kip71GasTarget, value: uint64(15000000)},
 		{name: Kip71LowerBoundBaseFee, value: uint64(25000000000)},
@@ -138,10 +138,32 @@ type TxProcessModuleHost interface {
 
 // TxPoolModule can intervene how the txpool handles transactions
 // from the inception (e.g. AddLocal) to termination (e.g. drop).
+//
+// The lifecycle callbacks are invoked while the txpool lock is held,
+// therefore these methods MUST NOT call back into the txpool.
 type TxPoolModule interface {
 	// Additional actions to be taken when a new tx arrives at txpool
 	PreAddLocal(*types.Transaction) error
 	PreAddRemote(*types.Transaction) error
+
+	// Actions to be taken when queued txs become executable and move to the pending list.
+	PostPromoteTxs(txs []*types.Transaction)
+
+	// Actions to be taken when pending txs become unexecutable and move back to the queue.
+	PostDemoteTxs(txs []*types.Transaction)
+
+	// Actions to be taken when txs leave the txpool, either because they are
+	// included in a block or because they are dropped (e.g. low balance, pool overflow).
+	// Replaced txs are reported via PostReplaceTx instead.
+	PostRemoveTxs(txs []*types.Transaction)
+
+	// Actions to be taken when a tx is replaced by another tx of the same sender and nonce.
+	PostReplaceTx(oldTx, newTx *types.Transaction)
+
+	// Filters or reorders the executable txs before the block builder commits them to a new block.
+	// Return the given set as-is to keep the original order.
+	// The returned set must honour the nonce order of each account.
+	PreCommitTxs(header *types.Header, txs types.TransactionSet) types.TransactionSet
 }
 
 // Any component or module that accomodate txpool modules.
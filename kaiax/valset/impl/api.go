@@ -0,0 +1,133 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/networks/rpc"
+)
+
+func (v *ValsetModule) APIs() []rpc.API {
+	return []rpc.API{
+		{
+			Namespace: "valset",
+			Version:   "1.0",
+			Service:   newValsetAPI(v),
+			Public:    true,
+		},
+	}
+}
+
+type valsetAPI struct {
+	v *ValsetModule
+}
+
+func newValsetAPI(v *ValsetModule) *valsetAPI {
+	return &valsetAPI{v}
+}
+
+// blockNumber resolves the requested block number. The block next to the current block is
+// allowed, as its validators are already determined.
+func (api *valsetAPI) blockNumber(num rpc.BlockNumber) (uint64, error) {
+	current := api.v.Chain.CurrentBlock().NumberU64()
+	switch {
+	case num == rpc.LatestBlockNumber:
+		return current, nil
+	case num == rpc.PendingBlockNumber:
+		return 0, errPendingNotAllowed
+	case num.Uint64() > current+1:
+		return 0, errUnknownBlock
+	default:
+		return num.Uint64(), nil
+	}
+}
+
+// round returns the requested round, or the round the block is committed at if not requested.
+func (api *valsetAPI) round(num uint64, round *uint64) uint64 {
+	if round != nil {
+		return *round
+	}
+	if header := api.v.Chain.GetHeaderByNumber(num); header != nil {
+		return uint64(header.Round())
+	}
+	return 0
+}
+
+// GetCouncil returns the council of the given block.
+func (api *valsetAPI) GetCouncil(num rpc.BlockNumber) ([]common.Address, error) {
+	bn, err := api.blockNumber(num)
+	if err != nil {
+		return nil, err
+	}
+	return api.v.GetCouncil(bn)
+}
+
+// GetDemotedValidators returns the demoted validators of the given block.
+func (api *valsetAPI) GetDemotedValidators(num rpc.BlockNumber) ([]common.Address, error) {
+	bn, err := api.blockNumber(num)
+	if err != nil {
+		return nil, err
+	}
+	return api.v.GetDemotedValidators(bn)
+}
+
+// GetCommittee returns the committee of the given block and round.
+// If the round is not given, the round the block is committed at is used.
+func (api *valsetAPI) GetCommittee(num rpc.BlockNumber, round *uint64) ([]common.Address, error) {
+	bn, err := api.blockNumber(num)
+	if err != nil {
+		return nil, err
+	}
+	return api.v.GetCommittee(bn, api.round(bn, round))
+}
+
+// GetProposer returns the proposer of the given block and round.
+// If the round is not given, the round the block is committed at is used.
+func (api *valsetAPI) GetProposer(num rpc.BlockNumber, round *uint64) (common.Address, error) {
+	bn, err := api.blockNumber(num)
+	if err != nil {
+		return common.Address{}, err
+	}
+	proposer, err := api.v.GetProposer(bn, api.round(bn, round))
+	if err != nil {
+		return common.Address{}, err
+	}
+	if proposer == (common.Address{}) {
+		return common.Address{}, errUnknownProposer
+	}
+	return proposer, nil
+}
+
+// GetSchedule returns the proposers and the committees of the given number of rounds of the upcoming blocks,
+// as far as they are deterministic. See getSchedule for the window of the schedule.
+// By default, the round 0 of every block in the window is returned.
+func (api *valsetAPI) GetSchedule(blocks *uint64, rounds *uint64) ([]*BlockSchedule, error) {
+	numBlocks, numRounds := uint64(maxScheduleBlocks), uint64(1)
+	if blocks != nil {
+		numBlocks = *blocks
+	}
+	if rounds != nil {
+		numRounds = *rounds
+	}
+	if numBlocks == 0 || numBlocks > maxScheduleBlocks {
+		return nil, errInvalidScheduleBlocks
+	}
+	if numRounds == 0 || numRounds > maxScheduleRounds {
+		return nil, errInvalidScheduleRounds
+	}
+	return api.v.getSchedule(numBlocks, numRounds)
+}
@@ -34,6 +34,9 @@ var (
 	errPendingNotAllowed = errors.New("pending is not allowed")
 	errUnknownBlock      = errors.New("unknown block")
 	errUnknownProposer   = errors.New("unknown proposer")
+
+	errInvalidScheduleBlocks = fmt.Errorf("number of blocks should be between 1 and %d", maxScheduleBlocks)
+	errInvalidScheduleRounds = fmt.Errorf("number of rounds should be between 1 and %d", maxScheduleRounds)
 )
 
 func ErrNoIstanbulSnapshot(num uint64) error {
@@ -19,6 +19,7 @@ package impl
 import (
 	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/kaiax/valset"
 )
 
 func (v *ValsetModule) PostInsertBlock(block *types.Block) error {
@@ -34,7 +35,7 @@ func (v *ValsetModule) PostInsertBlock(block *types.Block) error {
 		return err
 	}
 	governingNode := v.GovModule.GetParamSet(num).GoverningNode
-	if applyVote(header, council, governingNode) {
+	if valset.ApplyVote(header, council, governingNode) {
 		insertValidatorVoteBlockNums(v.ChainKv, num)
 		writeCouncil(v.ChainKv, num, council.List())
 		v.validatorVoteBlockNumsCache = nil
@@ -20,9 +20,6 @@ import (
 	"sort"
 
 	"github.com/kaiachain/kaia/blockchain/types"
-	"github.com/kaiachain/kaia/common"
-	"github.com/kaiachain/kaia/kaiax/gov"
-	"github.com/kaiachain/kaia/kaiax/gov/headergov"
 	"github.com/kaiachain/kaia/kaiax/valset"
 )
 
@@ -158,7 +155,7 @@ func (v *ValsetModule) applyBlock(council *valset.AddressSet, num uint64, write
 		return errNoHeader
 	}
 	governingNode := v.GovModule.GetParamSet(num).GoverningNode
-	if applyVote(header, council, governingNode) && write {
+	if valset.ApplyVote(header, council, governingNode) && write {
 		insertValidatorVoteBlockNums(v.ChainKv, num)
 		writeCouncil(v.ChainKv, num, council.List())
 		v.validatorVoteBlockNumsCache = nil
@@ -166,64 +163,6 @@ func (v *ValsetModule) applyBlock(council *valset.AddressSet, num uint64, write
 	return nil
 }
 
-// applyVote modifies the given council *in-place* by the validator vote in the given header.
-// governingNode, if specified, is not affected by the vote.
-// Returns true if the council is modified, false otherwise.
-func applyVote(header *types.Header, council *valset.AddressSet, governingNode common.Address) bool {
-	voteKey, addresses, ok := parseValidatorVote(header)
-	if !ok {
-		return false
-	}
-
-	originalSize := council.Len()
-	for _, address := range addresses {
-		if address == governingNode {
-			continue
-		}
-		switch voteKey {
-		case gov.AddValidator:
-			if !council.Contains(address) {
-				council.Add(address)
-			}
-		case gov.RemoveValidator:
-			if council.Contains(address) {
-				council.Remove(address)
-			}
-		}
-	}
-	return originalSize != council.Len()
-}
-
-func parseValidatorVote(header *types.Header) (gov.ParamName, []common.Address, bool) {
-	// Check that a vote exists and is a validator vote.
-	voteBytes := headergov.VoteBytes(header.Vote)
-	if len(voteBytes) == 0 {
-		return "", nil, false
-	}
-	vote, err := voteBytes.ToVoteData()
-	if err != nil {
-		return "", nil, false
-	}
-	voteKey := vote.Name()
-	_, isValidatorVote := gov.ValidatorParams[voteKey]
-	if !isValidatorVote {
-		return "", nil, false
-	}
-
-	// Type cast the vote value. It can be a single address or a list of addresses.
-	var addresses []common.Address
-	switch voteValue := vote.Value().(type) {
-	case common.Address:
-		addresses = []common.Address{voteValue}
-	case []common.Address:
-		addresses = voteValue
-	default:
-		return "", nil, false
-	}
-
-	return voteKey, addresses, true
-}
-
 func roundDown(n, p uint64) uint64 {
 	return n - (n % p)
 }
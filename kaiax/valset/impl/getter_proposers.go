@@ -71,7 +71,7 @@ func (v *ValsetModule) getRemoveVotesInInterval(pUpdateNum, pUpdateInterval uint
 		if header == nil || len(header.Vote) == 0 {
 			continue // skip nil header or vote
 		}
-		voteKey, addresses, ok := parseValidatorVote(header)
+		voteKey, addresses, ok := valset.ParseValidatorVote(header)
 		if !ok {
 			logger.Error("Failed to parse validator vote", "block", num)
 			return nil
@@ -0,0 +1,112 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"math/big"
+	"slices"
+
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/consensus/istanbul"
+)
+
+const (
+	maxScheduleBlocks = 3600
+	maxScheduleRounds = 64
+)
+
+// RoundSchedule is the proposer and the committee of a round of an upcoming block.
+// The committee is nil if it depends on the parent block, which is not yet known.
+type RoundSchedule struct {
+	Round     uint64           `json:"round"`
+	Proposer  common.Address   `json:"proposer"`
+	Committee []common.Address `json:"committee"`
+}
+
+// BlockSchedule is the schedule of the rounds of an upcoming block.
+type BlockSchedule struct {
+	Number uint64           `json:"number"`
+	Rounds []*RoundSchedule `json:"rounds"`
+}
+
+// usesProposerList returns true if the proposers are picked from the proposer list,
+// i.e. WeightedRandom policy before the Randao fork.
+func (c *blockContext) usesProposerList() bool {
+	return istanbul.ProposerPolicy(c.pset.ProposerPolicy) == istanbul.WeightedRandom && !c.rules.IsRandao
+}
+
+// getSchedule returns the schedule of the upcoming blocks whose proposers are deterministic.
+//
+// The proposers and the committees of the next block are determined by its parent, the current block.
+// For the further blocks, the proposers are only determined if they are picked from the proposer list,
+// which does not change until the next proposer update interval. The schedule also stops where the
+// council or the parameters change, e.g. at the next epoch. The committees of the further blocks are
+// only determined if every qualified validator is in the committee. Otherwise they are shuffled by
+// the parent hash (or the parent mixHash after the Randao fork), which is not yet known.
+func (v *ValsetModule) getSchedule(numBlocks, numRounds uint64) ([]*BlockSchedule, error) {
+	next := v.Chain.CurrentBlock().NumberU64() + 1
+	c, err := v.getBlockContext(next)
+	if err != nil {
+		return nil, err
+	}
+
+	last := next + numBlocks - 1
+	if !c.usesProposerList() {
+		last = next
+	} else if end := roundDown(next-1, c.pset.ProposerUpdateInterval) + c.pset.ProposerUpdateInterval; last > end {
+		last = end
+	}
+
+	schedules := make([]*BlockSchedule, 0, last-next+1)
+	for num := next; num <= last; num++ {
+		bc := c
+		if num != next {
+			// The staking information of the further blocks may not be available yet
+			qualified, err := v.getQualifiedValidators(num)
+			if err != nil {
+				break
+			}
+			bc = &blockContext{
+				num:       num,
+				qualified: qualified,
+				rules:     v.Chain.Config().Rules(new(big.Int).SetUint64(num)),
+				pset:      v.GovModule.GetParamSet(num),
+			}
+			if !bc.usesProposerList() || !slices.Equal(qualified.List(), c.qualified.List()) ||
+				bc.pset.ProposerUpdateInterval != c.pset.ProposerUpdateInterval || bc.pset.CommitteeSize != c.pset.CommitteeSize {
+				break
+			}
+		}
+
+		schedule := &BlockSchedule{Number: num, Rounds: make([]*RoundSchedule, numRounds)}
+		for round := uint64(0); round < numRounds; round++ {
+			proposer, err := v.getProposer(bc, round)
+			if err != nil {
+				return nil, err
+			}
+			var committee []common.Address
+			if num == next || bc.qualified.Len() <= int(bc.pset.CommitteeSize) {
+				if committee, err = v.getCommittee(bc, round); err != nil {
+					return nil, err
+				}
+			}
+			schedule.Rounds[round] = &RoundSchedule{Round: round, Proposer: proposer, Committee: committee}
+		}
+		schedules = append(schedules, schedule)
+	}
+	return schedules, nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package impl
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/consensus/istanbul"
+	consensus_mock "github.com/kaiachain/kaia/consensus/mocks"
+	"github.com/kaiachain/kaia/kaiax/gov"
+	gov_mock "github.com/kaiachain/kaia/kaiax/gov/mock"
+	"github.com/kaiachain/kaia/kaiax/staking"
+	staking_mock "github.com/kaiachain/kaia/kaiax/staking/mock"
+	"github.com/kaiachain/kaia/params"
+	"github.com/kaiachain/kaia/storage/database"
+	chain_mock "github.com/kaiachain/kaia/work/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSchedule(t *testing.T) {
+	var (
+		council = numsToAddrs(0, 1, 2, 3)
+		aM      = uint64(2000000)
+		si      = &staking.StakingInfo{
+			NodeIds:          council,
+			StakingContracts: council,
+			RewardAddrs:      council,
+			StakingAmounts:   []uint64{aM, aM, aM, aM},
+		}
+		pset = gov.ParamSet{
+			CommitteeSize:          3,
+			ProposerUpdateInterval: 100,
+			MinimumStake:           new(big.Int).SetUint64(aM),
+		}
+		current = makeEmptyBlock(150)
+	)
+
+	testcases := []struct {
+		desc     string
+		policy   istanbul.ProposerPolicy
+		isRandao bool
+		expected uint64 // number of the scheduled blocks
+	}{
+		{"RoundRobin", istanbul.RoundRobin, false, 1},
+		{"Sticky", istanbul.Sticky, false, 1},
+		{"WeightedRandom, before Randao", istanbul.WeightedRandom, false, 50}, // [151, 200] until the next proposer update
+		{"WeightedRandom, after Randao", istanbul.WeightedRandom, true, 1},
+	}
+
+	for _, tc := range testcases {
+		var (
+			ctrl        = gomock.NewController(t)
+			db          = database.NewMemoryDBManager().GetMiscDB()
+			mockChain   = chain_mock.NewMockBlockChain(ctrl)
+			mockEngine  = consensus_mock.NewMockEngine(ctrl)
+			mockGov     = gov_mock.NewMockGovModule(ctrl)
+			mockStaking = staking_mock.NewMockStakingModule(ctrl)
+			v           = NewValsetModule()
+			config      = &params.ChainConfig{IstanbulCompatibleBlock: big.NewInt(0)}
+		)
+		if tc.isRandao {
+			config.KoreCompatibleBlock = big.NewInt(0)
+			config.ShanghaiCompatibleBlock = big.NewInt(0)
+			config.CancunCompatibleBlock = big.NewInt(0)
+			config.RandaoCompatibleBlock = big.NewInt(0)
+		}
+		pset.ProposerPolicy = uint64(tc.policy)
+		writeValidatorVoteBlockNums(db, []uint64{0})
+		writeCouncil(db, 0, council)
+		writeLowestScannedVoteNum(db, 0)
+		v.InitOpts = InitOpts{ChainKv: db, Chain: mockChain, GovModule: mockGov, StakingModule: mockStaking}
+
+		mockChain.EXPECT().CurrentBlock().Return(current).AnyTimes()
+		mockChain.EXPECT().GetHeaderByNumber(gomock.Any()).DoAndReturn(func(num uint64) *types.Header {
+			if num > current.NumberU64() {
+				return nil
+			}
+			return &types.Header{Number: new(big.Int).SetUint64(num)}
+		}).AnyTimes()
+		mockChain.EXPECT().Config().Return(config).AnyTimes()
+		mockChain.EXPECT().Engine().Return(mockEngine).AnyTimes()
+		mockEngine.EXPECT().Author(gomock.Any()).Return(numToAddr(1), nil).AnyTimes()
+		mockGov.EXPECT().GetParamSet(gomock.Any()).Return(pset).AnyTimes()
+		mockStaking.EXPECT().GetStakingInfo(gomock.Any()).Return(si, nil).AnyTimes()
+
+		schedules, err := v.getSchedule(maxScheduleBlocks, 3)
+		require.NoError(t, err, tc.desc)
+		require.Len(t, schedules, int(tc.expected), tc.desc)
+
+		c, err := v.getBlockContext(151)
+		require.NoError(t, err)
+		for i, schedule := range schedules {
+			assert.Equal(t, uint64(151+i), schedule.Number, tc.desc)
+			require.Len(t, schedule.Rounds, 3, tc.desc)
+			for round, rs := range schedule.Rounds {
+				assert.Equal(t, uint64(round), rs.Round, tc.desc)
+				if i == 0 {
+					proposer, err := v.getProposer(c, uint64(round))
+					require.NoError(t, err)
+					assert.Equal(t, proposer, rs.Proposer, tc.desc)
+
+					committee, err := v.getCommittee(c, uint64(round))
+					require.NoError(t, err)
+					assert.Equal(t, committee, rs.Committee, tc.desc)
+				} else {
+					// The proposer of a block is the one of the previous block at the next round
+					if round+1 < len(schedule.Rounds) {
+						assert.Equal(t, schedules[i-1].Rounds[round+1].Proposer, rs.Proposer, tc.desc)
+					}
+					// The committees are shuffled by the unknown parent hash
+					assert.Nil(t, rs.Committee, tc.desc)
+				}
+			}
+		}
+
+		// The number of blocks is limited
+		api := newValsetAPI(v)
+		blocks, rounds := uint64(5), uint64(1)
+		schedules, err = api.GetSchedule(&blocks, &rounds)
+		require.NoError(t, err)
+		assert.Len(t, schedules, min(5, int(tc.expected)), tc.desc)
+
+		rounds = 0
+		_, err = api.GetSchedule(&blocks, &rounds)
+		assert.Equal(t, errInvalidScheduleRounds, err)
+		blocks, rounds = maxScheduleBlocks+1, 1
+		_, err = api.GetSchedule(&blocks, &rounds)
+		assert.Equal(t, errInvalidScheduleBlocks, err)
+		ctrl.Finish()
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package valset
+
+import (
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/kaiax/gov"
+	"github.com/kaiachain/kaia/kaiax/gov/headergov"
+)
+
+// ApplyVote modifies the given council *in-place* by the validator vote in the given header.
+// governingNode, if specified, is not affected by the vote.
+// Returns true if the council is modified, false otherwise.
+func ApplyVote(header *types.Header, council *AddressSet, governingNode common.Address) bool {
+	voteKey, addresses, ok := ParseValidatorVote(header)
+	if !ok {
+		return false
+	}
+
+	originalSize := council.Len()
+	for _, address := range addresses {
+		if address == governingNode {
+			continue
+		}
+		switch voteKey {
+		case gov.AddValidator:
+			if !council.Contains(address) {
+				council.Add(address)
+			}
+		case gov.RemoveValidator:
+			if council.Contains(address) {
+				council.Remove(address)
+			}
+		}
+	}
+	return originalSize != council.Len()
+}
+
+// ParseValidatorVote returns the AddValidator or RemoveValidator vote in the given header, if any.
+func ParseValidatorVote(header *types.Header) (gov.ParamName, []common.Address, bool) {
+	// Check that a vote exists and is a validator vote.
+	voteBytes := headergov.VoteBytes(header.Vote)
+	if len(voteBytes) == 0 {
+		return "", nil, false
+	}
+	vote, err := voteBytes.ToVoteData()
+	if err != nil {
+		return "", nil, false
+	}
+	voteKey := vote.Name()
+	_, isValidatorVote := gov.ValidatorParams[voteKey]
+	if !isValidatorVote {
+		return "", nil, false
+	}
+
+	// Type cast the vote value. It can be a single address or a list of addresses.
+	var addresses []common.Address
+	switch voteValue := vote.Value().(type) {
+	case common.Address:
+		addresses = []common.Address{voteValue}
+	case []common.Address:
+		addresses = voteValue
+	default:
+		return "", nil, false
+	}
+
+	return voteKey, addresses, true
+}
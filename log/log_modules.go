@@ -136,6 +136,9 @@ const (
 	KaiaxGov
 	KaiaxValset
 	KaiaxRandao
+	BlockchainStatePruner
+	ConsensusIstanbulSigner
+	CMDKSIGNER
 
 	// ModuleNameLen should be placed at the end of the list.
 	ModuleNameLen
@@ -221,4 +224,7 @@ var moduleNames = [ModuleNameLen]string{
 	"kaiax/gov",
 	"kaiax/valset",
 	"kaiax/randao",
+	"blockchain/state/pruner",
+	"consensus/istanbul/signer",
+	"cmd/ksigner",
 }
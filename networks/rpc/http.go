@@ -46,6 +46,11 @@ import (
 
 const (
 	contentType = "application/json"
+
+	// HealthCheckPath is the path of the HTTP endpoint reporting that the node is alive.
+	HealthCheckPath = "/health"
+	// ReadyCheckPath is the path of the HTTP endpoint reporting whether the node is ready to serve its role.
+	ReadyCheckPath = "/ready"
 )
 
 // https://www.jsonrpc.org/historical/json-rpc-over-http.html#id13
@@ -345,8 +350,42 @@ func NewFastHTTPServer(cors []string, vhosts []string, timeouts HTTPTimeouts, sr
 	}
 }
 
+// SetReadyCheck sets the check reported on ReadyCheckPath of the HTTP endpoint.
+func (s *Server) SetReadyCheck(check func() error) {
+	s.readyCheck.Store(&check)
+}
+
+// serveHealth responds 200 as long as the server is serving requests.
+func (s *Server) serveHealth(w http.ResponseWriter) {
+	w.Header().Set("content-type", contentType)
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+}
+
+// serveReady responds 200 if the ready check passes, and 503 with the error otherwise.
+func (s *Server) serveReady(w http.ResponseWriter) {
+	var err error
+	if check := s.readyCheck.Load(); check != nil {
+		err = (*check)()
+	}
+	w.Header().Set("content-type", contentType)
+	if err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode(map[string]string{"status": "not ready", "error": err.Error()})
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+}
+
 // ServeHTTP serves JSON-RPC requests over HTTP.
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet && r.URL.Path == HealthCheckPath {
+		s.serveHealth(w)
+		return
+	}
+	if r.Method == http.MethodGet && r.URL.Path == ReadyCheckPath {
+		s.serveReady(w)
+		return
+	}
 	// Permit dumb empty requests for remote health-checks (AWS)
 	if r.Method == http.MethodGet && r.ContentLength == 0 && r.URL.RawQuery == "" {
 		return
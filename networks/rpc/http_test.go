@@ -17,6 +17,7 @@
 package rpc
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -54,3 +55,29 @@ func testHTTPErrorResponse(t *testing.T, method, contentType, body string, expec
 		t.Fatalf("response code should be %d not %d", expected, code)
 	}
 }
+
+func TestHTTPHealthCheck(t *testing.T) {
+	server := NewServer()
+	defer server.Stop()
+
+	check := func(path string, expected int) {
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://url.com"+path, nil))
+		if recorder.Code != expected {
+			t.Fatalf("response code of %s should be %d not %d", path, expected, recorder.Code)
+		}
+	}
+
+	// Ready without a check
+	check(HealthCheckPath, http.StatusOK)
+	check(ReadyCheckPath, http.StatusOK)
+
+	var err error
+	server.SetReadyCheck(func() error { return err })
+	check(ReadyCheckPath, http.StatusOK)
+
+	// The node is alive even if it is not ready
+	err = errors.New("not ready")
+	check(HealthCheckPath, http.StatusOK)
+	check(ReadyCheckPath, http.StatusServiceUnavailable)
+}
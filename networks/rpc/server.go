@@ -84,6 +84,8 @@ type Server struct {
 	codecs      mapset.Set
 	run         int32
 	wsConnCount int32
+
+	readyCheck atomic.Pointer[func() error] // Check reported on ReadyCheckPath of the HTTP endpoint
 }
 
 // NewServer creates a new server instance with no registered handlers.
@@ -33,14 +33,19 @@ import (
 	"strings"
 	"time"
 
+	kaiaapi "github.com/kaiachain/kaia/api"
 	"github.com/kaiachain/kaia/blockchain"
 	"github.com/kaiachain/kaia/blockchain/state"
 	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/crypto"
 	"github.com/kaiachain/kaia/networks/rpc"
+	"github.com/kaiachain/kaia/node/cn/gasprice"
 	"github.com/kaiachain/kaia/params"
 	"github.com/kaiachain/kaia/rlp"
+	"github.com/kaiachain/kaia/snapshot"
+	"github.com/kaiachain/kaia/storage/database"
 	"github.com/kaiachain/kaia/storage/statedb"
 	"github.com/kaiachain/kaia/work"
 )
@@ -61,6 +66,118 @@ func (api *PublicKaiaAPI) Rewardbase() (common.Address, error) {
 	return api.cn.Rewardbase()
 }
 
+// RPCSkippedTx is a transaction excluded from the pending block preview.
+type RPCSkippedTx struct {
+	Hash   common.Hash    `json:"hash"`
+	From   common.Address `json:"from"`
+	Nonce  hexutil.Uint64 `json:"nonce"`
+	Reason string         `json:"reason"`
+}
+
+// RPCPendingBlockPreview is the result of simulating the next block with the
+// transactions of the txpool.
+type RPCPendingBlockPreview struct {
+	Number       *hexutil.Big             `json:"number"`
+	BaseFee      *hexutil.Big             `json:"baseFeePerGas,omitempty"`
+	GasUsed      hexutil.Uint64           `json:"gasUsed"`
+	Transactions []map[string]interface{} `json:"transactions"`
+	Skipped      []*RPCSkippedTx          `json:"skipped"`
+}
+
+// GetPendingBlockPreview runs the transactions of the txpool in the order the next
+// block would be built, on a throwaway state. It returns the expected receipt of
+// each included transaction, and the transactions skipped with the reasons.
+func (api *PublicKaiaAPI) GetPendingBlockPreview() (*RPCPendingBlockPreview, error) {
+	preview, err := api.cn.miner.PendingBlockPreview()
+	if err != nil {
+		return nil, err
+	}
+	if preview == nil {
+		return nil, errors.New("pending block preview is not available")
+	}
+	var (
+		header = preview.Header
+		config = api.cn.chainConfig
+		signer = types.MakeSigner(config, header.Number)
+		number = header.Number.Uint64()
+	)
+	result := &RPCPendingBlockPreview{
+		Number:       (*hexutil.Big)(header.Number),
+		GasUsed:      hexutil.Uint64(header.GasUsed),
+		Transactions: make([]map[string]interface{}, len(preview.Txs)),
+		Skipped:      make([]*RPCSkippedTx, len(preview.Skipped)),
+	}
+	if header.BaseFee != nil {
+		result.BaseFee = (*hexutil.Big)(header.BaseFee)
+	}
+	// The preview is never sealed, so the block hash is left empty.
+	for i, tx := range preview.Txs {
+		result.Transactions[i] = kaiaapi.RpcOutputReceipt(header, tx, common.Hash{}, number, uint64(i), preview.Receipts[i], config)
+	}
+	for i, skipped := range preview.Skipped {
+		from, _ := types.Sender(signer, skipped.Tx)
+		result.Skipped[i] = &RPCSkippedTx{
+			Hash:   skipped.Tx.Hash(),
+			From:   from,
+			Nonce:  hexutil.Uint64(skipped.Tx.Nonce()),
+			Reason: skipped.Err.Error(),
+		}
+	}
+	return result, nil
+}
+
+// RPCFeeTier is a fee recommendation returned by kaia_suggestFees.
+type RPCFeeTier struct {
+	MaxFeePerGas         *hexutil.Big   `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big   `json:"maxPriorityFeePerGas"`
+	Blocks               hexutil.Uint64 `json:"blocks"`
+	Confidence           float64        `json:"confidence"`
+}
+
+// RPCSuggestedFees is the result of kaia_suggestFees.
+type RPCSuggestedFees struct {
+	BaseFee           *hexutil.Big   `json:"baseFeePerGas,omitempty"`
+	ProjectedBaseFees []*hexutil.Big `json:"projectedBaseFeePerGas,omitempty"`
+	PendingGas        hexutil.Uint64 `json:"pendingGas"`
+	Slow              *RPCFeeTier    `json:"slow"`
+	Standard          *RPCFeeTier    `json:"standard"`
+	Fast              *RPCFeeTier    `json:"fast"`
+}
+
+func newRPCFeeTier(tier *gasprice.FeeTier) *RPCFeeTier {
+	return &RPCFeeTier{
+		MaxFeePerGas:         (*hexutil.Big)(tier.MaxFeePerGas),
+		MaxPriorityFeePerGas: (*hexutil.Big)(tier.MaxPriorityFeePerGas),
+		Blocks:               hexutil.Uint64(tier.Blocks),
+		Confidence:           tier.Confidence,
+	}
+}
+
+// SuggestFees returns the slow, standard and fast recommendations of
+// maxFeePerGas and maxPriorityFeePerGas with the number of blocks within which
+// a transaction is expected to be included and the confidence of the estimation.
+// The base fees of the next blocks are projected with the KIP-71 parameters and
+// the pending transactions of the txpool.
+func (api *PublicKaiaAPI) SuggestFees(ctx context.Context) (*RPCSuggestedFees, error) {
+	fees, err := api.cn.APIBackend.gpo.SuggestFees(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := &RPCSuggestedFees{
+		PendingGas: hexutil.Uint64(fees.PendingGas),
+		Slow:       newRPCFeeTier(fees.Slow),
+		Standard:   newRPCFeeTier(fees.Standard),
+		Fast:       newRPCFeeTier(fees.Fast),
+	}
+	if fees.BaseFee != nil {
+		result.BaseFee = (*hexutil.Big)(fees.BaseFee)
+	}
+	for _, baseFee := range fees.ProjectedBaseFees {
+		result.ProjectedBaseFees = append(result.ProjectedBaseFees, (*hexutil.Big)(baseFee))
+	}
+	return result, nil
+}
+
 // PrivateAdminAPI is the collection of CN full node-related APIs
 // exposed over the private admin endpoint.
 type PrivateAdminAPI struct {
@@ -211,6 +328,38 @@ func (api *PrivateAdminAPI) StateMigrationStatus() map[string]interface{} {
 	}
 }
 
+// StartCompaction starts compacting the key range [start, limit) of the given databases in background.
+// All databases are compacted if entries is empty, and the whole key space is compacted if the range is not given.
+// The progress can be checked with CompactionStatus.
+func (api *PrivateAdminAPI) StartCompaction(entries []string, start, limit *hexutil.Bytes) error {
+	ets := make([]database.DBEntryType, 0, len(entries))
+	for _, name := range entries {
+		et, err := database.ParseDBEntryType(name)
+		if err != nil {
+			return err
+		}
+		ets = append(ets, et)
+	}
+	var startKey, limitKey []byte
+	if start != nil {
+		startKey = *start
+	}
+	if limit != nil {
+		limitKey = *limit
+	}
+	return api.cn.compactionScheduler.Compact(ets, startKey, limitKey)
+}
+
+// StopCompaction cancels the running database compaction.
+func (api *PrivateAdminAPI) StopCompaction() error {
+	return api.cn.compactionScheduler.Cancel()
+}
+
+// CompactionStatus returns the progress of the running or the last database compaction.
+func (api *PrivateAdminAPI) CompactionStatus() database.CompactionProgress {
+	return api.cn.compactionScheduler.Progress()
+}
+
 func (api *PrivateAdminAPI) SaveTrieNodeCacheToDisk() error {
 	return api.cn.BlockChain().SaveTrieNodeCacheToDisk()
 }
@@ -316,6 +465,52 @@ func (api *PublicDebugAPI) DumpBlock(ctx context.Context, blockNrOrHash rpc.Bloc
 	return stateDb.RawDump(), nil
 }
 
+// AccountRangeMaxResults is the maximum number of results to be returned per call
+const AccountRangeMaxResults = 256
+
+// AccountRange enumerates all accounts in the given block and start point in paging request.
+// The accounts are served from the snapshot if it is available for the state, or from the state trie otherwise.
+func (api *PublicDebugAPI) AccountRange(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, start hexutil.Bytes, maxResults int, nocode, nostorage, incompletes bool) (state.IteratorDump, error) {
+	var stateDb *state.StateDB
+	if number, ok := blockNrOrHash.Number(); ok && number == rpc.PendingBlockNumber {
+		// If we're dumping the pending state, we need to request
+		// both the pending block as well as the pending state from
+		// the miner and operate on those
+		_, stateDb = api.cn.miner.Pending()
+		if stateDb == nil {
+			return state.IteratorDump{}, fmt.Errorf("pending block is not prepared yet")
+		}
+	} else {
+		var block *types.Block
+		if number, ok := blockNrOrHash.Number(); ok && number == rpc.LatestBlockNumber {
+			block = api.cn.APIBackend.CurrentBlock()
+		} else {
+			var err error
+			block, err = api.cn.APIBackend.BlockByNumberOrHash(ctx, blockNrOrHash)
+			if err != nil {
+				blockNrOrHashString, _ := blockNrOrHash.NumberOrHashString()
+				return state.IteratorDump{}, fmt.Errorf("block %v not found", blockNrOrHashString)
+			}
+		}
+		var err error
+		stateDb, err = api.cn.BlockChain().StateAt(block.Root())
+		if err != nil {
+			return state.IteratorDump{}, err
+		}
+	}
+
+	if maxResults > AccountRangeMaxResults || maxResults <= 0 {
+		maxResults = AccountRangeMaxResults
+	}
+	return stateDb.IteratorDump(&state.DumpConfig{
+		SkipCode:          nocode,
+		SkipStorage:       nostorage,
+		OnlyWithAddresses: !incompletes,
+		Start:             start,
+		Max:               uint64(maxResults),
+	})
+}
+
 type Trie struct {
 	Type   string `json:"type"`
 	Hash   string `json:"hash"`
@@ -433,6 +628,15 @@ func (api *PrivateDebugAPI) StorageRangeAt(ctx context.Context, blockHash common
 	if block == nil {
 		return StorageRangeResult{}, fmt.Errorf("block %#x not found", blockHash)
 	}
+	// The storage before the first transaction is the one of the parent state,
+	// which can be served from the snapshot without re-executing the block.
+	if txIndex == 0 {
+		if parent := api.cn.blockchain.GetHeaderByHash(block.ParentHash()); parent != nil {
+			if result, ok, err := snapshotStorageRangeAt(api.cn.blockchain.Snapshots(), api.cn.ChainDB(), parent.Root, contractAddress, keyStart, maxResult); ok {
+				return result, err
+			}
+		}
+	}
 	_, _, _, statedb, release, err := api.cn.stateAtTransaction(block, txIndex, 0, nil, true, false)
 	if err != nil {
 		return StorageRangeResult{}, err
@@ -469,6 +673,56 @@ func storageRangeAt(st state.Trie, start []byte, maxResult int) (StorageRangeRes
 	return result, nil
 }
 
+// snapshotStorageRangeAt returns the storage of the contract at the given state root from the snapshot.
+// It returns false if the snapshot is not available for the state root.
+func snapshotStorageRangeAt(snaps *snapshot.Tree, db database.DBManager, root common.Hash, contractAddress common.Address, start []byte, maxResult int) (StorageRangeResult, bool, error) {
+	if snaps == nil {
+		return StorageRangeResult{}, false, nil
+	}
+	snap := snaps.Snapshot(root)
+	if snap == nil {
+		return StorageRangeResult{}, false, nil
+	}
+	accountHash := crypto.Keccak256Hash(contractAddress.Bytes())
+	acc, err := snap.Account(accountHash)
+	if err != nil {
+		return StorageRangeResult{}, false, nil
+	}
+	if acc == nil {
+		return StorageRangeResult{}, true, fmt.Errorf("account %x doesn't exist", contractAddress)
+	}
+	var seek common.Hash
+	copy(seek[:], start)
+	it, err := snaps.StorageIterator(root, accountHash, seek)
+	if err != nil {
+		return StorageRangeResult{}, false, nil
+	}
+	defer it.Release()
+
+	result := StorageRangeResult{Storage: storageMap{}}
+	for i := 0; i < maxResult && it.Next(); i++ {
+		_, content, _, err := rlp.Split(it.Slot())
+		if err != nil {
+			return StorageRangeResult{}, true, err
+		}
+		e := storageEntry{Value: common.BytesToHash(content)}
+		if preimage := db.ReadPreimage(it.Hash()); preimage != nil {
+			preimage := common.BytesToHash(preimage)
+			e.Key = &preimage
+		}
+		result.Storage[it.Hash()] = e
+	}
+	// Add the 'next key' so clients can continue downloading.
+	if it.Next() {
+		next := it.Hash()
+		result.NextKey = &next
+	}
+	if err := it.Error(); err != nil {
+		return StorageRangeResult{}, true, err
+	}
+	return result, true, nil
+}
+
 // TODO-Kaia: Rearrange PublicDebugAPI and PrivateDebugAPI receivers
 // GetModifiedAccountsByNumber returns all accounts that have changed between the
 // two blocks specified. A change is defined as a difference in nonce, balance,
@@ -592,12 +846,28 @@ func (api *PublicDebugAPI) getModifiedStorageNodes(contractAddr common.Address,
 		return 0, err
 	}
 
-	trieDB := api.cn.blockchain.StateCache().TrieDB()
-	oldTrie, err := statedb.NewSecureStorageTrie(startBlockRoot, trieDB, nil)
+	logger.Info("Start collecting the modified storage nodes", "contractAddr", contractAddr.String(),
+		"startBlock", startBlock.NumberU64(), "endBlock", endBlock.NumberU64())
+	start := time.Now()
+	numModifiedNodes, err := countModifiedStorageNodes(api.cn.blockchain.StateCache().TrieDB(), contractAddr, startBlockRoot, endBlockRoot, printDetail != nil && *printDetail)
 	if err != nil {
 		return 0, err
 	}
-	newTrie, err := statedb.NewSecureStorageTrie(endBlockRoot, trieDB, nil)
+	logger.Info("Finished collecting the modified storage nodes", "contractAddr", contractAddr.String(),
+		"startBlock", startBlock.NumberU64(), "endBlock", endBlock.NumberU64(), "numModifiedNodes", numModifiedNodes, "elapsed", time.Since(start))
+	return numModifiedNodes, nil
+}
+
+// countModifiedStorageNodes returns the number of the storage trie nodes of the contract which are in the
+// trie of the new root but not in the trie of the old root. The tries are opened with the account hash as
+// their owner, which locates the storage trie nodes in the path-based scheme.
+func countModifiedStorageNodes(trieDB *statedb.Database, contractAddr common.Address, oldRoot, newRoot common.ExtHash, printDetail bool) (int, error) {
+	opts := &statedb.TrieOpts{Owner: crypto.Keccak256Hash(contractAddr.Bytes())}
+	oldTrie, err := statedb.NewSecureStorageTrie(oldRoot, trieDB, opts)
+	if err != nil {
+		return 0, err
+	}
+	newTrie, err := statedb.NewSecureStorageTrie(newRoot, trieDB, opts)
 	if err != nil {
 		return 0, err
 	}
@@ -605,20 +875,15 @@ func (api *PublicDebugAPI) getModifiedStorageNodes(contractAddr common.Address,
 	diff, _ := statedb.NewDifferenceIterator(oldTrie.NodeIterator([]byte{}), newTrie.NodeIterator([]byte{}))
 	iter := statedb.NewIterator(diff)
 
-	logger.Info("Start collecting the modified storage nodes", "contractAddr", contractAddr.String(),
-		"startBlock", startBlock.NumberU64(), "endBlock", endBlock.NumberU64())
-	start := time.Now()
 	numModifiedNodes := 0
 	for iter.Next() {
 		numModifiedNodes++
-		if printDetail != nil && *printDetail {
+		if printDetail {
 			logger.Info("modified storage trie nodes", "contractAddr", contractAddr.String(),
 				"nodeHash", common.BytesToHash(iter.Key).String())
 		}
 	}
-	logger.Info("Finished collecting the modified storage nodes", "contractAddr", contractAddr.String(),
-		"startBlock", startBlock.NumberU64(), "endBlock", endBlock.NumberU64(), "numModifiedNodes", numModifiedNodes, "elapsed", time.Since(start))
-	return numModifiedNodes, nil
+	return numModifiedNodes, iter.Err
 }
 
 func (s *PrivateAdminAPI) NodeConfig(ctx context.Context) interface{} {
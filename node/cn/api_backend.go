@@ -24,6 +24,7 @@ package cn
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"time"
@@ -46,6 +47,8 @@ import (
 	"github.com/kaiachain/kaia/work"
 )
 
+var errBundleNotConsensusNode = errors.New("bundles are only accepted by consensus nodes")
+
 // CNAPIBackend implements api.Backend for full nodes
 type CNAPIBackend struct {
 	cn  *CN
@@ -223,6 +226,11 @@ func (b *CNAPIBackend) GetBlockReceipts(ctx context.Context, hash common.Hash) t
 	return b.cn.blockchain.GetReceiptsByBlockHash(hash)
 }
 
+// GetBlobSidecars retrieves the blob sidecars of the transactions in the block of the given hash and number.
+func (b *CNAPIBackend) GetBlobSidecars(ctx context.Context, hash common.Hash, number uint64) []*types.BlobTxSidecarWithHash {
+	return b.cn.blockchain.GetBlobSidecars(hash, number)
+}
+
 func (b *CNAPIBackend) GetLogs(ctx context.Context, hash common.Hash) ([][]*types.Log, error) {
 	return b.cn.blockchain.GetLogsByHash(hash), nil
 }
@@ -260,8 +268,21 @@ func (b *CNAPIBackend) SendTx(ctx context.Context, signedTx *types.Transaction)
 	return b.cn.txPool.AddLocal(signedTx)
 }
 
+func (b *CNAPIBackend) SendPrivateTx(ctx context.Context, signedTx *types.Transaction, expiryBlocks uint64) error {
+	return b.cn.txPool.AddPrivate(signedTx, expiryBlocks)
+}
+
+// SendBundle adds the bundle to the txpool. Bundles are not propagated to the peers,
+// so the bundle is rejected unless the node is a consensus node which can propose it.
+func (b *CNAPIBackend) SendBundle(ctx context.Context, bundle *types.Bundle) error {
+	if b.cn.protocolManager.NodeType() != common.CONSENSUSNODE {
+		return errBundleNotConsensusNode
+	}
+	return b.cn.txPool.AddBundle(bundle)
+}
+
 func (b *CNAPIBackend) GetPoolTransactions() (types.Transactions, error) {
-	pending, err := b.cn.txPool.Pending()
+	pending, err := b.cn.txPool.PublicPending()
 	if err != nil {
 		return nil, err
 	}
@@ -288,10 +309,30 @@ func (b *CNAPIBackend) TxPoolContent() (map[common.Address]types.Transactions, m
 	return b.cn.TxPool().Content()
 }
 
+func (b *CNAPIBackend) TxPoolContentFrom(addr common.Address) (types.Transactions, types.Transactions) {
+	return b.cn.TxPool().ContentFrom(addr)
+}
+
+func (b *CNAPIBackend) TxPoolStatus(hash common.Hash) blockchain.TxStatus {
+	return b.cn.TxPool().Status([]common.Hash{hash})[0]
+}
+
+func (b *CNAPIBackend) TxPoolDroppedTx(hash common.Hash) *blockchain.DroppedTx {
+	return b.cn.TxPool().DroppedTx(hash)
+}
+
+func (b *CNAPIBackend) TxPoolPriceBump() uint64 {
+	return b.cn.TxPool().PriceBump()
+}
+
 func (b *CNAPIBackend) SubscribeNewTxsEvent(ch chan<- blockchain.NewTxsEvent) event.Subscription {
 	return b.cn.TxPool().SubscribeNewTxsEvent(ch)
 }
 
+func (b *CNAPIBackend) SubscribeDroppedTxsEvent(ch chan<- blockchain.DroppedTxsEvent) event.Subscription {
+	return b.cn.TxPool().SubscribeDroppedTxsEvent(ch)
+}
+
 func (b *CNAPIBackend) Progress() kaia.SyncProgress {
 	return b.cn.Progress()
 }
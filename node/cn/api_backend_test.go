@@ -586,11 +586,32 @@ func TestCNAPIBackend_SendTx(t *testing.T) {
 	assert.Equal(t, expectedErr, api.SendTx(context.Background(), tx1))
 }
 
+func TestCNAPIBackend_SendBundle(t *testing.T) {
+	mockCtrl, _, _, api := newCNAPIBackend(t)
+	defer mockCtrl.Finish()
+
+	mockPM := NewMockBackendProtocolManager(mockCtrl)
+	mockTxPool := mocks.NewMockTxPool(mockCtrl)
+	api.cn.protocolManager = mockPM
+	api.cn.txPool = mockTxPool
+	bundle := types.NewBundle(types.Transactions{tx1}, 1)
+
+	// A bundle sent to an EN or a PN is rejected, since it would never reach a proposer.
+	for _, nodeType := range []common.ConnType{common.ENDPOINTNODE, common.PROXYNODE} {
+		mockPM.EXPECT().NodeType().Return(nodeType).Times(1)
+		assert.Equal(t, errBundleNotConsensusNode, api.SendBundle(context.Background(), bundle))
+	}
+
+	mockPM.EXPECT().NodeType().Return(common.CONSENSUSNODE).Times(1)
+	mockTxPool.EXPECT().AddBundle(bundle).Return(expectedErr).Times(1)
+	assert.Equal(t, expectedErr, api.SendBundle(context.Background(), bundle))
+}
+
 func TestCNAPIBackend_GetPoolTransactions(t *testing.T) {
 	{
 		mockCtrl, _, _, api := newCNAPIBackend(t)
 		mockTxPool := mocks.NewMockTxPool(mockCtrl)
-		mockTxPool.EXPECT().Pending().Return(nil, expectedErr).Times(1)
+		mockTxPool.EXPECT().PublicPending().Return(nil, expectedErr).Times(1)
 		api.cn.txPool = mockTxPool
 
 		txs, ReturnedErr := api.GetPoolTransactions()
@@ -603,7 +624,7 @@ func TestCNAPIBackend_GetPoolTransactions(t *testing.T) {
 		mockTxPool := mocks.NewMockTxPool(mockCtrl)
 
 		pendingTxs := map[common.Address]types.Transactions{addrs[0]: {tx1}}
-		mockTxPool.EXPECT().Pending().Return(pendingTxs, nil).Times(1)
+		mockTxPool.EXPECT().PublicPending().Return(pendingTxs, nil).Times(1)
 		api.cn.txPool = mockTxPool
 
 		txs, ReturnedErr := api.GetPoolTransactions()
@@ -22,13 +22,26 @@
 package cn
 
 import (
+	"math/big"
 	"reflect"
 	"testing"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/golang/mock/gomock"
+	"github.com/kaiachain/kaia/blockchain"
 	"github.com/kaiachain/kaia/blockchain/state"
+	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/node/cn/mocks"
+	"github.com/kaiachain/kaia/params"
+	"github.com/kaiachain/kaia/snapshot"
 	"github.com/kaiachain/kaia/storage/database"
+	"github.com/kaiachain/kaia/storage/statedb"
+	"github.com/kaiachain/kaia/work"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var dumper = spew.ConfigState{Indent: "    "}
@@ -93,3 +106,131 @@ func TestStorageRangeAt(t *testing.T) {
 		}
 	}
 }
+
+func TestSnapshotStorageRangeAt(t *testing.T) {
+	var (
+		db       = database.NewMemoryDBManager()
+		sdb, _   = state.New(types.EmptyRootHash, state.NewDatabase(db), nil, nil)
+		contract = common.Address{0x01}
+	)
+	sdb.CreateSmartContractAccount(contract, params.CodeFormatEVM, params.Rules{})
+	for i := byte(1); i <= 4; i++ {
+		sdb.SetState(contract, common.Hash{i}, common.Hash{i})
+	}
+	root, err := sdb.Commit(false)
+	require.NoError(t, err)
+	require.NoError(t, sdb.Database().TrieDB().Commit(root, false, 0))
+
+	snaps, err := snapshot.New(db, statedb.NewDatabase(db), 256, root, false, true, false)
+	require.NoError(t, err)
+	trieState, err := state.New(root, state.NewDatabase(db), nil, nil)
+	require.NoError(t, err)
+
+	// The snapshot serves the same ranges as the storage trie.
+	for _, test := range []struct {
+		start []byte
+		limit int
+	}{
+		{[]byte{}, 0},
+		{[]byte{}, 100},
+		{[]byte{}, 2},
+		{[]byte{0x40}, 2},
+	} {
+		want, err := storageRangeAt(trieState.StorageTrie(contract), test.start, test.limit)
+		require.NoError(t, err)
+		result, ok, err := snapshotStorageRangeAt(snaps, db, root, contract, test.start, test.limit)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, want, result, "range 0x%x.., limit %d", test.start, test.limit)
+	}
+
+	// A missing account is reported from the snapshot.
+	_, ok, err := snapshotStorageRangeAt(snaps, db, root, common.Address{0x02}, nil, 10)
+	assert.True(t, ok)
+	assert.Error(t, err)
+
+	// The trie is used if the snapshot is not available for the state root.
+	_, ok, _ = snapshotStorageRangeAt(snaps, db, common.Hash{0xff}, contract, nil, 10)
+	assert.False(t, ok)
+	_, ok, _ = snapshotStorageRangeAt(nil, db, root, contract, nil, 10)
+	assert.False(t, ok)
+}
+
+func TestCountModifiedStorageNodes(t *testing.T) {
+	for _, scheme := range []string{database.HashScheme, database.PathScheme} {
+		t.Run(scheme, func(t *testing.T) {
+			var (
+				dbm      = database.NewMemoryDBManager()
+				contract = common.Address{0x01}
+			)
+			dbm.WriteStateScheme(scheme)
+			stateDB := state.NewDatabase(dbm)
+			sdb, _ := state.New(types.EmptyRootHash, stateDB, nil, nil)
+			sdb.CreateSmartContractAccount(contract, params.CodeFormatEVM, params.Rules{})
+			for i := byte(1); i <= 4; i++ {
+				sdb.SetState(contract, common.Hash{i}, common.Hash{i})
+			}
+			root1, err := sdb.Commit(false)
+			require.NoError(t, err)
+			require.NoError(t, stateDB.TrieDB().Commit(root1, false, 1))
+			oldRoot := sdb.StorageTrie(contract).HashExt()
+
+			sdb, err = state.New(root1, stateDB, nil, nil)
+			require.NoError(t, err)
+			sdb.SetState(contract, common.Hash{5}, common.Hash{5})
+			root2, err := sdb.Commit(false)
+			require.NoError(t, err)
+			require.NoError(t, stateDB.TrieDB().Commit(root2, false, 2))
+			newRoot := sdb.StorageTrie(contract).HashExt()
+
+			count, err := countModifiedStorageNodes(stateDB.TrieDB(), contract, oldRoot, newRoot, false)
+			require.NoError(t, err)
+			assert.Equal(t, 1, count)
+		})
+	}
+}
+
+func TestGetPendingBlockPreview(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	var (
+		config = params.TestChainConfig
+		signer = types.LatestSignerForChainID(config.ChainID)
+		key, _ = crypto.GenerateKey()
+		from   = crypto.PubkeyToAddress(key.PublicKey)
+	)
+	included, err := types.SignTx(types.NewTransaction(0, common.Address{0x01}, big.NewInt(1), 21000, big.NewInt(1), nil), signer, key)
+	require.NoError(t, err)
+	skipped, err := types.SignTx(types.NewTransaction(2, common.Address{0x01}, big.NewInt(1), 21000, big.NewInt(1), nil), signer, key)
+	require.NoError(t, err)
+
+	mockMiner := mocks.NewMockMiner(mockCtrl)
+	mockMiner.EXPECT().PendingBlockPreview().Return(&work.PendingBlockPreview{
+		Header:   &types.Header{Number: big.NewInt(10), GasUsed: 21000, BaseFee: big.NewInt(1)},
+		Txs:      types.Transactions{included},
+		Receipts: types.Receipts{{Status: types.ReceiptStatusSuccessful, GasUsed: 21000}},
+		Skipped:  []*work.SkippedTx{{Tx: skipped, Err: blockchain.ErrNonceTooHigh}},
+	}, nil)
+
+	api := NewPublicKaiaAPI(&CN{miner: mockMiner, chainConfig: config})
+	preview, err := api.GetPendingBlockPreview()
+	require.NoError(t, err)
+
+	assert.Equal(t, (*hexutil.Big)(big.NewInt(10)), preview.Number)
+	assert.Equal(t, hexutil.Uint64(21000), preview.GasUsed)
+	require.Len(t, preview.Transactions, 1)
+	assert.Equal(t, included.Hash(), preview.Transactions[0]["transactionHash"])
+	assert.Equal(t, hexutil.Uint(types.ReceiptStatusSuccessful), preview.Transactions[0]["status"])
+	assert.Equal(t, []*RPCSkippedTx{{
+		Hash:   skipped.Hash(),
+		From:   from,
+		Nonce:  2,
+		Reason: blockchain.ErrNonceTooHigh.Error(),
+	}}, preview.Skipped)
+
+	// The preview is not available without a worker.
+	mockMiner.EXPECT().PendingBlockPreview().Return(nil, nil)
+	_, err = api.GetPendingBlockPreview()
+	assert.Error(t, err)
+}
@@ -25,8 +25,10 @@ package cn
 import (
 	"errors"
 	"fmt"
+	"os"
 	"os/exec"
 	"runtime"
+	"strings"
 	"sync"
 	"time"
 
@@ -36,11 +38,13 @@ import (
 	"github.com/kaiachain/kaia/blockchain"
 	"github.com/kaiachain/kaia/blockchain/bloombits"
 	"github.com/kaiachain/kaia/blockchain/state"
+	"github.com/kaiachain/kaia/blockchain/state/pruner"
 	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/common/hexutil"
 	"github.com/kaiachain/kaia/consensus"
 	istanbulBackend "github.com/kaiachain/kaia/consensus/istanbul/backend"
+	istanbulSigner "github.com/kaiachain/kaia/consensus/istanbul/signer"
 	"github.com/kaiachain/kaia/crypto"
 	"github.com/kaiachain/kaia/datasync/downloader"
 	"github.com/kaiachain/kaia/event"
@@ -87,7 +91,9 @@ type Miner interface {
 	SetExtra(extra []byte) error
 	Pending() (*types.Block, *state.StateDB)
 	PendingBlock() *types.Block
+	PendingBlockPreview() (*work.PendingBlockPreview, error)
 	kaiax.ExecutionModuleHost // Because miner executes blocks, inject ExecutionModule.
+	kaiax.TxPoolModuleHost    // Because miner picks txs from the txpool, inject TxPoolModule.
 }
 
 // BackendProtocolManager is an interface of cn.ProtocolManager used from cn.CN and cn.ServiceChain.
@@ -119,7 +125,8 @@ type CN struct {
 	lesServer       LesServer
 
 	// DB interfaces
-	chainDB database.DBManager // Block chain database
+	chainDB             database.DBManager // Block chain database
+	compactionScheduler *database.CompactionScheduler
 
 	eventMux       *event.TypeMux
 	engine         consensus.Engine
@@ -220,6 +227,13 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 	}
 
 	chainDB := CreateDB(ctx, config, "chaindata")
+	if err := setupStateScheme(chainDB, config); err != nil {
+		return nil, err
+	}
+	// Try to recover offline state pruning.
+	if err := pruner.RecoverPruning(ctx.ResolvePath(""), chainDB); err != nil {
+		logger.Error("Failed to recover state pruning", "err", err)
+	}
 
 	chainConfig, genesisHash, genesisErr := blockchain.SetupGenesisBlock(chainDB, config.Genesis, config.NetworkId, config.IsPrivate, false)
 	if _, ok := genesisErr.(*params.ConfigCompatError); genesisErr != nil && !ok {
@@ -233,25 +247,34 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 	// latest values will be applied to chainConfig after NewMixedEngine call
 	logger.Info("Initialised chain configuration", "config", chainConfig)
 
+	signer, err := CreateConsensusSigner(config, ctx.NodeType())
+	if err != nil {
+		return nil, err
+	}
 	mGov := gov_impl.NewGovModule()
+	engine := CreateConsensusEngine(ctx, config, chainConfig, chainDB, mGov, ctx.NodeType(), signer)
 	cn := &CN{
-		config:            config,
-		chainDB:           chainDB,
-		chainConfig:       chainConfig,
-		eventMux:          ctx.EventMux,
-		accountManager:    ctx.AccountManager,
-		engine:            CreateConsensusEngine(ctx, config, chainConfig, chainDB, mGov, ctx.NodeType()),
-		networkId:         config.NetworkId,
-		rewardbase:        config.Rewardbase,
-		bloomRequests:     make(chan chan *bloombits.Retrieval),
-		bloomIndexer:      NewBloomIndexer(chainDB, params.BloomBitsBlocks),
-		closeBloomHandler: make(chan struct{}),
-		govModule:         mGov,
-	}
-
-	// istanbul BFT. Derive and set node's address using nodekey
+		config:              config,
+		chainDB:             chainDB,
+		compactionScheduler: database.NewCompactionScheduler(chainDB, config.DBCompaction),
+		chainConfig:         chainConfig,
+		eventMux:            ctx.EventMux,
+		accountManager:      ctx.AccountManager,
+		engine:              engine,
+		networkId:           config.NetworkId,
+		rewardbase:          config.Rewardbase,
+		bloomRequests:       make(chan chan *bloombits.Retrieval),
+		bloomIndexer:        NewBloomIndexer(chainDB, params.BloomBitsBlocks),
+		closeBloomHandler:   make(chan struct{}),
+		govModule:           mGov,
+	}
+
+	// istanbul BFT. Derive and set node's address using nodekey, or the consensus key of the remote signer
 	if cn.chainConfig.Istanbul != nil {
 		cn.nodeAddress = crypto.PubkeyToAddress(ctx.NodeKey().PublicKey)
+		if signer != nil {
+			cn.nodeAddress = signer.Address()
+		}
 	}
 
 	logger.Info("Initialising Klaytn protocol", "versions", cn.engine.Protocol().Versions, "network", config.NetworkId)
@@ -271,6 +294,7 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 			LivePruningRetention: config.LivePruningRetention,
 			TrieNodeCacheConfig:  &config.TrieNodeCacheConfig,
 			SenderTxHashIndexing: config.SenderTxHashIndexing,
+			BlobSidecarRetention: config.BlobSidecarRetention,
 			SnapshotCacheSize:    config.SnapshotCacheSize,
 			SnapshotAsyncGen:     config.SnapshotAsyncGen,
 		}
@@ -342,6 +366,9 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 	if config.TxPool.Journal != "" {
 		config.TxPool.Journal = ctx.ResolvePath(config.TxPool.Journal)
 	}
+	if config.TxPool.Snapshot != "" {
+		config.TxPool.Snapshot = ctx.ResolvePath(config.TxPool.Snapshot)
+	}
 	// TODO-Kaia-ServiceChain: add account creation prevention in the txPool if TxTypeAccountCreation is supported.
 	config.TxPool.NoAccountCreation = config.NoAccountCreation
 	cn.txPool = blockchain.NewTxPool(config.TxPool, cn.chainConfig, bc, mGov)
@@ -359,10 +386,21 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 	cn.protocolManager.SetWsEndPoint(config.WsEndpoint)
 
 	if ctx.NodeType() == common.CONSENSUSNODE {
+		blsPublicKey := ctx.BlsNodeKey().PublicKey()
+		if signer != nil {
+			if blsPublicKey, err = signer.BlsPublicKey(); err != nil {
+				logger.Warn("The remote signer has no BLS key", "err", err)
+			}
+		}
+		var blsPublicKeyHex string
+		if blsPublicKey != nil {
+			blsPublicKeyHex = hexutil.Encode(blsPublicKey.Marshal())
+		}
 		logger.Info("Loaded node keys",
-			"nodeAddress", crypto.PubkeyToAddress(ctx.NodeKey().PublicKey),
+			"nodeAddress", cn.nodeAddress,
+			"p2pAddress", crypto.PubkeyToAddress(ctx.NodeKey().PublicKey),
 			"nodePublicKey", hexutil.Encode(crypto.FromECDSAPub(&ctx.NodeKey().PublicKey)),
-			"blsPublicKey", hexutil.Encode(ctx.BlsNodeKey().PublicKey().Marshal()))
+			"blsPublicKey", blsPublicKeyHex)
 
 		if _, err := cn.Rewardbase(); err != nil {
 			logger.Error("Cannot determine the rewardbase address", "err", err)
@@ -379,8 +417,16 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 			istBackend.SetChain(cn.blockchain)
 		}
 	} else {
+		txOrdering, err := work.NewTxOrderingPolicy(config.TxOrdering)
+		if err != nil {
+			return nil, err
+		}
 		// TODO-Kaia improve to handle drop transaction on network traffic in PN and EN
-		cn.miner = work.New(cn, cn.chainConfig, cn.EventMux(), cn.engine, ctx.NodeType(), crypto.PubkeyToAddress(ctx.NodeKey().PublicKey), cn.config.TxResendUseLegacy, cn.govModule)
+		nodeAddress := crypto.PubkeyToAddress(ctx.NodeKey().PublicKey)
+		if cn.chainConfig.Istanbul != nil {
+			nodeAddress = cn.nodeAddress
+		}
+		cn.miner = work.New(cn, cn.chainConfig, cn.EventMux(), cn.engine, ctx.NodeType(), nodeAddress, cn.config.TxResendUseLegacy, cn.govModule, txOrdering)
 	}
 
 	// istanbul BFT
@@ -541,7 +587,7 @@ func (s *CN) SetupKaiaxModules() error {
 	// Register modules to respective components
 	// TODO-kaiax: Organize below lines.
 	s.RegisterBaseModules(mStaking, mReward, mSupply, mGov, mValset, mRandao)
-	s.RegisterJsonRpcModules(mStaking, mReward, mSupply, mGov, mRandao)
+	s.RegisterJsonRpcModules(mStaking, mReward, mSupply, mGov, mValset, mRandao)
 	s.miner.RegisterExecutionModule(mStaking, mSupply, mGov, mValset, mRandao)
 	s.blockchain.RegisterExecutionModule(mStaking, mSupply, mGov, mValset, mRandao)
 	s.blockchain.RegisterRewindableModule(mStaking, mSupply, mGov, mValset, mRandao)
@@ -583,6 +629,24 @@ func makeExtraData(extra []byte) []byte {
 	return extra
 }
 
+// setupStateScheme writes the configured state scheme to a database without the genesis block,
+// or checks if it matches the database. The scheme cannot be changed after the genesis state is written.
+func setupStateScheme(chainDB database.DBManager, config *Config) error {
+	scheme := chainDB.ReadStateScheme()
+	if config.StateScheme != "" && config.StateScheme != scheme {
+		if chainDB.ReadHeadBlockHash() != (common.Hash{}) {
+			return fmt.Errorf("cannot change the state scheme from %s to %s after the genesis state is written", scheme, config.StateScheme)
+		}
+		chainDB.WriteStateScheme(config.StateScheme)
+		scheme = config.StateScheme
+	}
+	if scheme == database.PathScheme && (config.LivePruning || chainDB.ReadPruningEnabled()) {
+		return errors.New("live pruning is not supported with the path-based state scheme")
+	}
+	logger.Info("Using the state scheme", "scheme", scheme)
+	return nil
+}
+
 // CreateDB creates the chain database.
 func CreateDB(ctx *node.ServiceContext, config *Config, name string) database.DBManager {
 	dbc := &database.DBConfig{
@@ -594,8 +658,31 @@ func CreateDB(ctx *node.ServiceContext, config *Config, name string) database.DB
 	return ctx.OpenDatabase(dbc)
 }
 
-// CreateConsensusEngine creates the required type of consensus engine instance for a Kaia service
-func CreateConsensusEngine(ctx *node.ServiceContext, config *Config, chainConfig *params.ChainConfig, db database.DBManager, govModule gov.GovModule, nodetype common.ConnType) consensus.Engine {
+// CreateConsensusSigner connects to the remote signer of the consensus data if configured for a consensus node.
+// If nil, the consensus engine signs with the node key and the BLS node key. The remote signer holds
+// the consensus key of the validator, which may differ from the p2p node key.
+func CreateConsensusSigner(config *Config, nodetype common.ConnType) (istanbulSigner.Signer, error) {
+	if config.RemoteSigner == "" || nodetype != common.CONSENSUSNODE {
+		return nil, nil
+	}
+	var token string
+	if config.RemoteSignerTokenFile != "" {
+		data, err := os.ReadFile(config.RemoteSignerTokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the token of the remote signer: %w", err)
+		}
+		token = strings.TrimSpace(string(data))
+	}
+	signer, err := istanbulSigner.NewRemoteSigner(config.RemoteSigner, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the remote signer: %w", err)
+	}
+	return signer, nil
+}
+
+// CreateConsensusEngine creates the required type of consensus engine instance for a Kaia service.
+// If the signer is nil, the consensus data are signed by the node keys.
+func CreateConsensusEngine(ctx *node.ServiceContext, config *Config, chainConfig *params.ChainConfig, db database.DBManager, govModule gov.GovModule, nodetype common.ConnType, signer istanbulSigner.Signer) consensus.Engine {
 	// Only istanbul  BFT is allowed in the main net. PoA is supported by service chain
 	if chainConfig.Governance == nil {
 		chainConfig.Governance = params.GetDefaultGovernanceConfig()
@@ -605,6 +692,7 @@ func CreateConsensusEngine(ctx *node.ServiceContext, config *Config, chainConfig
 		Rewardbase:     config.Rewardbase,
 		PrivateKey:     ctx.NodeKey(),
 		BlsSecretKey:   ctx.BlsNodeKey(),
+		Signer:         signer,
 		DB:             db,
 		GovModule:      govModule,
 		NodeType:       nodetype,
@@ -756,6 +844,14 @@ func (s *CN) APIs() []rpc.API {
 	return apis
 }
 
+// ReadyCheck implements node.ReadyChecker. It reports whether the consensus engine is ready to validate.
+func (s *CN) ReadyCheck() error {
+	if checker, ok := s.engine.(node.ReadyChecker); ok {
+		return checker.ReadyCheck()
+	}
+	return nil
+}
+
 func (s *CN) ResetWithGenesisBlock(gb *types.Block) {
 	s.blockchain.ResetWithGenesisBlock(gb)
 }
@@ -838,6 +934,9 @@ func (s *CN) Start(srvr p2p.Server) error {
 	// Start the bloom bits servicing goroutines
 	s.startBloomHandlers()
 
+	// Start the periodic database compaction if enabled
+	s.compactionScheduler.Start()
+
 	// Start the RPC service
 	s.netRPCService = api.NewPublicNetAPI(srvr, s.NetVersion())
 
@@ -870,6 +969,7 @@ func (s *CN) Stop() error {
 	s.txPool.Stop()
 	s.miner.Stop()
 	s.blockchain.Stop()
+	s.compactionScheduler.Stop()
 	s.chainDB.Close()
 	s.eventMux.Stop()
 
@@ -62,6 +62,7 @@ func NewChannelManager(channelSize int) *ChannelManager {
 	channelMgr.RegisterMsgCode(BlockChannel, NewBlockMsg)
 
 	channelMgr.RegisterMsgCode(TxChannel, TxMsg)
+	channelMgr.RegisterMsgCode(TxChannel, PrivateTxMsg)
 
 	channelMgr.RegisterMsgCode(MiscChannel, ReceiptsRequestMsg)
 	channelMgr.RegisterMsgCode(MiscChannel, ReceiptsMsg)
@@ -70,6 +71,8 @@ func NewChannelManager(channelSize int) *ChannelManager {
 	channelMgr.RegisterMsgCode(MiscChannel, NodeDataMsg)
 	channelMgr.RegisterMsgCode(MiscChannel, StakingInfoRequestMsg)
 	channelMgr.RegisterMsgCode(MiscChannel, StakingInfoMsg)
+	channelMgr.RegisterMsgCode(MiscChannel, BlobSidecarsRequestMsg)
+	channelMgr.RegisterMsgCode(MiscChannel, BlobSidecarsMsg)
 
 	return channelMgr
 }
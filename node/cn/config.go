@@ -39,6 +39,7 @@ import (
 	"github.com/kaiachain/kaia/params"
 	"github.com/kaiachain/kaia/storage/database"
 	"github.com/kaiachain/kaia/storage/statedb"
+	"github.com/kaiachain/kaia/work"
 )
 
 var logger = log.NewModuleLogger(log.NodeCN)
@@ -56,8 +57,11 @@ func GetDefaultConfig() *Config {
 		TrieNodeCacheConfig:  *statedb.GetEmptyTrieNodeCacheConfig(),
 		TriesInMemory:        blockchain.DefaultTriesInMemory,
 		LivePruningRetention: blockchain.DefaultLivePruningRetention,
+		BlobSidecarRetention: blockchain.DefaultBlobSidecarRetention,
+		DBCompaction:         *database.GetDefaultCompactionConfig(),
 
-		TxPool: blockchain.DefaultTxPoolConfig,
+		TxPool:     blockchain.DefaultTxPoolConfig,
+		TxOrdering: work.DefaultTxOrdering,
 		GPO: gasprice.Config{
 			Blocks:           20,
 			Percentile:       60,
@@ -128,11 +132,14 @@ type Config struct {
 	TriesInMemory        uint64
 	LivePruning          bool
 	LivePruningRetention uint64
+	StateScheme          string // Storage scheme of the state trie nodes. The scheme stored in the database is used if empty.
 	SenderTxHashIndexing bool
+	BlobSidecarRetention uint64
 	ParallelDBWrite      bool
 	TrieNodeCacheConfig  statedb.TrieNodeCacheConfig
 	SnapshotCacheSize    int
 	SnapshotAsyncGen     bool
+	DBCompaction         database.CompactionConfig
 
 	// Mining-related options
 	ServiceChainSigner common.Address `toml:",omitempty"`
@@ -141,6 +148,12 @@ type Config struct {
 	// Reward
 	Rewardbase common.Address `toml:",omitempty"`
 
+	// IPC path or HTTP URL of the remote signer of the consensus data.
+	// If empty, the node key and the BLS node key sign locally
+	RemoteSigner string `toml:",omitempty"`
+	// File containing the token to authenticate to the HTTP endpoint of the remote signer
+	RemoteSignerTokenFile string `toml:",omitempty"`
+
 	// Transaction pool options
 	TxPool blockchain.TxPoolConfig
 
@@ -167,6 +180,9 @@ type Config struct {
 	TxResendCount     int
 	TxResendUseLegacy bool
 
+	// TxOrdering is the ordering policy of the transactions in a new block
+	TxOrdering string
+
 	// Service Chain
 	NoAccountCreation bool
 
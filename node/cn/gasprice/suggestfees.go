@@ -0,0 +1,217 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package gasprice
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/consensus/misc"
+	"github.com/kaiachain/kaia/networks/rpc"
+	"github.com/kaiachain/kaia/params"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// feeProjectionBlocks is the number of the next blocks whose base fee is projected.
+	feeProjectionBlocks = 10
+
+	// baseFeeBufferPercent is the buffer added to the projected base fee to cover
+	// the transactions arriving after the suggestion.
+	baseFeeBufferPercent = 10
+
+	slowTipPercentile = 25
+	fastTipPercentile = 90
+)
+
+var errNoTxPool = errors.New("txpool is not available")
+
+// FeeTier is a recommendation of the fees to be included within the given number of blocks.
+type FeeTier struct {
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	Blocks               uint64  // Number of blocks within which the transaction is expected to be included
+	Confidence           float64 // Estimated probability of the inclusion within Blocks, between 0 and 1
+}
+
+// SuggestedFees is the tiered fee recommendations for the next blocks.
+type SuggestedFees struct {
+	BaseFee           *big.Int   // Base fee of the next block, nil before Magma
+	ProjectedBaseFees []*big.Int // Projected base fees of the next blocks, nil before Magma
+	PendingGas        uint64     // Sum of the gas limits of the pending transactions
+	Slow              *FeeTier
+	Standard          *FeeTier
+	Fast              *FeeTier
+}
+
+// SuggestFees returns the slow, standard and fast fee recommendations. The base
+// fees of the next blocks are projected with the KIP-71 parameters, assuming
+// that the pending transactions of the txpool fill the blocks up to the gas used
+// limit for the base fee calculation. The tips are the percentiles of the recent
+// blocks, and the confidence is estimated from the pending gas paying higher tips.
+func (oracle *Oracle) SuggestFees(ctx context.Context) (*SuggestedFees, error) {
+	if oracle.txPool == nil {
+		return nil, errNoTxPool
+	}
+	var (
+		config  = oracle.backend.ChainConfig()
+		head    = oracle.backend.CurrentBlock().Header()
+		nextNum = new(big.Int).Add(head.Number, common.Big1)
+		tiers   = []*FeeTier{{Blocks: 10}, {Blocks: 3}, {Blocks: 1}}
+	)
+	pending, err := oracle.txPool.Pending()
+	if err != nil {
+		return nil, err
+	}
+	var pendingGas uint64
+	for _, txs := range pending {
+		for _, tx := range txs {
+			pendingGas += tx.Gas()
+		}
+	}
+
+	// Before Magma, the gas price is fixed to the unit price.
+	if !config.IsMagmaForkEnabled(nextNum) {
+		unitPrice := oracle.txPool.GasPrice()
+		for _, tier := range tiers {
+			tier.MaxFeePerGas = unitPrice
+			tier.MaxPriorityFeePerGas = unitPrice
+			tier.Confidence = 1
+		}
+		return &SuggestedFees{PendingGas: pendingGas, Slow: tiers[0], Standard: tiers[1], Fast: tiers[2]}, nil
+	}
+
+	pset := oracle.govModule.GetParamSet(nextNum.Uint64())
+	kip71 := pset.ToKip71Config()
+	projected := projectBaseFees(head, kip71, pendingGas, feeProjectionBlocks)
+
+	// The tips are ignored before the Kaia hardfork.
+	tips := []*big.Int{common.Big0, common.Big0, common.Big0}
+	if config.IsKaiaForkEnabled(nextNum) {
+		if tips, err = oracle.suggestTieredTips(ctx); err != nil {
+			return nil, err
+		}
+		// Paying a tip is unnecessary for the slow and standard tiers if the network
+		// is relaxed and the pending transactions fit in the next block.
+		if oracle.isRelaxedNetwork(head) && pendingGas <= kip71.GasTarget {
+			tips[0], tips[1] = common.Big0, common.Big0
+		}
+	}
+
+	for i, tier := range tiers {
+		maxBaseFee := new(big.Int)
+		for _, baseFee := range projected[:tier.Blocks] {
+			if baseFee.Cmp(maxBaseFee) > 0 {
+				maxBaseFee = baseFee
+			}
+		}
+		maxFee := new(big.Int).Mul(maxBaseFee, big.NewInt(100+baseFeeBufferPercent))
+		maxFee.Div(maxFee, big.NewInt(100))
+
+		tier.MaxPriorityFeePerGas = tips[i]
+		tier.MaxFeePerGas = maxFee.Add(maxFee, tips[i])
+		tier.Confidence = inclusionConfidence(pending, projected[0], tips[i], tier.Blocks*kip71.MaxBlockGasUsedForBaseFee)
+	}
+	return &SuggestedFees{
+		BaseFee:           projected[0],
+		ProjectedBaseFees: projected,
+		PendingGas:        pendingGas,
+		Slow:              tiers[0],
+		Standard:          tiers[1],
+		Fast:              tiers[2],
+	}, nil
+}
+
+// suggestTieredTips returns the slow, standard and fast tips, which are the
+// medians of the tip percentiles of the recent blocks. The standard tier uses the
+// configured percentile, bounded by the ones of the other tiers.
+func (oracle *Oracle) suggestTieredTips(ctx context.Context) ([]*big.Int, error) {
+	standard := oracle.percentile
+	if standard < slowTipPercentile {
+		standard = slowTipPercentile
+	} else if standard > fastTipPercentile {
+		standard = fastTipPercentile
+	}
+	percentiles := []float64{slowTipPercentile, float64(standard), fastTipPercentile}
+
+	_, rewards, _, _, err := oracle.FeeHistory(ctx, oracle.checkBlocks, rpc.LatestBlockNumber, percentiles)
+	if err != nil {
+		return nil, err
+	}
+	tips := make([]*big.Int, len(percentiles))
+	for i := range percentiles {
+		var values []*big.Int
+		for _, reward := range rewards {
+			if len(reward) > i {
+				values = append(values, reward[i])
+			}
+		}
+		tips[i] = new(big.Int)
+		if len(values) > 0 {
+			slices.SortFunc(values, func(a, b *big.Int) int { return a.Cmp(b) })
+			tips[i].Set(values[(len(values)-1)/2])
+		}
+		if tips[i].Cmp(oracle.maxPrice) > 0 {
+			tips[i].Set(oracle.maxPrice)
+		}
+	}
+	return tips, nil
+}
+
+// projectBaseFees returns the base fees of the given number of blocks following
+// the head, assuming that the pending gas is consumed in order as much as the gas
+// used limit for the base fee calculation allows.
+func projectBaseFees(head *types.Header, kip71 *params.KIP71Config, pendingGas uint64, blocks int) []*big.Int {
+	projected := make([]*big.Int, blocks)
+	parent := head
+	for i := range projected {
+		projected[i] = misc.NextMagmaBlockBaseFee(parent, kip71)
+
+		gasUsed := pendingGas
+		if gasUsed > kip71.MaxBlockGasUsedForBaseFee {
+			gasUsed = kip71.MaxBlockGasUsedForBaseFee
+		}
+		pendingGas -= gasUsed
+		parent = &types.Header{
+			Number:  new(big.Int).Add(parent.Number, common.Big1),
+			BaseFee: projected[i],
+			GasUsed: gasUsed,
+		}
+	}
+	return projected
+}
+
+// inclusionConfidence estimates the probability that a transaction paying the
+// given tip is included within the given gas capacity, considering the pending
+// transactions paying the same or higher tip are processed first.
+func inclusionConfidence(pending map[common.Address]types.Transactions, baseFee, tip *big.Int, capacity uint64) float64 {
+	var gasAhead uint64
+	for _, txs := range pending {
+		for _, tx := range txs {
+			if tx.EffectiveGasTip(baseFee).Cmp(tip) >= 0 {
+				gasAhead += tx.Gas()
+			}
+		}
+	}
+	if gasAhead <= capacity {
+		return 1
+	}
+	return float64(capacity) / float64(gasAhead)
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package gasprice
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/kaiachain/kaia/blockchain"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/log"
+	"github.com/kaiachain/kaia/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectBaseFees(t *testing.T) {
+	kip71 := &params.KIP71Config{
+		LowerBoundBaseFee:         25 * params.Gkei,
+		UpperBoundBaseFee:         750 * params.Gkei,
+		GasTarget:                 30000000,
+		MaxBlockGasUsedForBaseFee: 60000000,
+		BaseFeeDenominator:        20,
+	}
+	head := &types.Header{Number: big.NewInt(10), BaseFee: big.NewInt(100 * params.Gkei), GasUsed: kip71.GasTarget}
+
+	// Without pending gas, the base fee keeps decreasing to the lower bound.
+	projected := projectBaseFees(head, kip71, 0, 10)
+	assert.Len(t, projected, 10)
+	assert.Equal(t, big.NewInt(100*params.Gkei), projected[0])
+	for i := 1; i < len(projected); i++ {
+		assert.True(t, projected[i].Cmp(projected[i-1]) < 0)
+	}
+
+	// The pending gas increases the base fee while it fills the blocks beyond the gas target.
+	projected = projectBaseFees(head, kip71, 2*kip71.MaxBlockGasUsedForBaseFee+kip71.GasTarget, 5)
+	assert.True(t, projected[1].Cmp(projected[0]) > 0)
+	assert.True(t, projected[2].Cmp(projected[1]) > 0)
+	assert.Equal(t, projected[2], projected[3])
+	assert.True(t, projected[4].Cmp(projected[3]) < 0)
+}
+
+func TestInclusionConfidence(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	signer := types.LatestSignerForChainID(params.TestChainConfig.ChainID)
+	baseFee := big.NewInt(25 * params.Gkei)
+
+	var txs types.Transactions
+	for i := 0; i < 4; i++ {
+		tx, err := types.SignTx(types.NewTx(&types.TxInternalDataEthereumDynamicFee{
+			ChainID:      params.TestChainConfig.ChainID,
+			AccountNonce: uint64(i),
+			Recipient:    &common.Address{},
+			GasLimit:     100000,
+			GasFeeCap:    big.NewInt(100 * params.Gkei),
+			GasTipCap:    big.NewInt(int64(i+1) * params.Gkei),
+			Amount:       big.NewInt(0),
+		}), signer, key)
+		require.NoError(t, err)
+		txs = append(txs, tx)
+	}
+	pending := map[common.Address]types.Transactions{crypto.PubkeyToAddress(key.PublicKey): txs}
+
+	// Only the transactions paying the same or higher tip are ahead.
+	assert.Equal(t, 1.0, inclusionConfidence(pending, baseFee, big.NewInt(4*params.Gkei), 100000))
+	assert.Equal(t, 0.5, inclusionConfidence(pending, baseFee, big.NewInt(3*params.Gkei), 100000))
+	assert.Equal(t, 0.25, inclusionConfidence(pending, baseFee, common.Big0, 100000))
+	assert.Equal(t, 1.0, inclusionConfidence(pending, baseFee, common.Big0, 400000))
+}
+
+func TestSuggestFees(t *testing.T) {
+	log.EnableLogForTest(log.LvlCrit, log.LvlError)
+	config := Config{
+		Blocks:           3,
+		Percentile:       60,
+		MaxHeaderHistory: 30,
+		MaxBlockHistory:  30,
+	}
+
+	cases := []struct {
+		magmaBlock *big.Int
+		kaiaBlock  *big.Int
+	}{
+		{nil, nil},
+		{big.NewInt(0), nil},
+		{big.NewInt(0), big.NewInt(0)},
+	}
+	for _, c := range cases {
+		testBackend, testGov := newTestBackend(t, c.magmaBlock, c.kaiaBlock)
+		chainConfig := testBackend.ChainConfig()
+		txPool := blockchain.NewTxPool(blockchain.DefaultTxPoolConfig, chainConfig, testBackend.chain, testGov)
+		oracle := NewOracle(testBackend, config, txPool, testGov)
+
+		fees, err := oracle.SuggestFees(context.Background())
+		txPool.Stop()
+		testBackend.teardown()
+		require.NoError(t, err)
+
+		tiers := []*FeeTier{fees.Slow, fees.Standard, fees.Fast}
+		for _, tier := range tiers {
+			assert.Equal(t, 1.0, tier.Confidence)
+		}
+		assert.Equal(t, uint64(10), fees.Slow.Blocks)
+		assert.Equal(t, uint64(3), fees.Standard.Blocks)
+		assert.Equal(t, uint64(1), fees.Fast.Blocks)
+
+		if c.magmaBlock == nil {
+			// Before Magma, every tier is the fixed unit price.
+			assert.Nil(t, fees.BaseFee)
+			for _, tier := range tiers {
+				assert.Equal(t, big.NewInt(1), tier.MaxFeePerGas)
+				assert.Equal(t, big.NewInt(1), tier.MaxPriorityFeePerGas)
+			}
+			continue
+		}
+
+		assert.Len(t, fees.ProjectedBaseFees, feeProjectionBlocks)
+		assert.Equal(t, fees.BaseFee, fees.ProjectedBaseFees[0])
+		for _, tier := range tiers {
+			// maxFeePerGas covers the buffered base fee and the tip.
+			minFee := new(big.Int).Mul(fees.BaseFee, big.NewInt(100+baseFeeBufferPercent))
+			minFee.Div(minFee, big.NewInt(100))
+			assert.True(t, tier.MaxFeePerGas.Cmp(new(big.Int).Add(minFee, tier.MaxPriorityFeePerGas)) >= 0)
+		}
+		if c.kaiaBlock == nil {
+			// Before Kaia, the tips are ignored.
+			for _, tier := range tiers {
+				assert.Equal(t, common.Big0, tier.MaxPriorityFeePerGas)
+			}
+		} else {
+			assert.True(t, fees.Slow.MaxPriorityFeePerGas.Cmp(fees.Standard.MaxPriorityFeePerGas) <= 0)
+			assert.True(t, fees.Standard.MaxPriorityFeePerGas.Cmp(fees.Fast.MaxPriorityFeePerGas) <= 0)
+			assert.True(t, fees.Fast.MaxPriorityFeePerGas.Sign() > 0)
+		}
+	}
+}
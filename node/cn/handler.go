@@ -62,6 +62,15 @@ const (
 	// The number is referenced from the size of tx pool.
 	txChanSize = 4096
 
+	// missingBlobChanSize is the size of channel listening to MissingBlobSidecarsEvent.
+	missingBlobChanSize = 64
+
+	// blobSidecarsFetchPeers is the number of peers asked for the blob sidecars of a block.
+	blobSidecarsFetchPeers = 2
+
+	// maxBlobSidecarsFetch is the number of blocks whose blob sidecars are served per request.
+	maxBlobSidecarsFetch = 16
+
 	concurrentPerPeer  = 3
 	channelSizePerPeer = 20
 
@@ -114,6 +123,12 @@ type ProtocolManager struct {
 	txsSub        event.Subscription
 	minedBlockSub *event.TypeMuxSubscription
 
+	privateTxsCh  chan blockchain.NewPrivateTxsEvent
+	privateTxsSub event.Subscription
+
+	missingBlobCh  chan blockchain.MissingBlobSidecarsEvent
+	missingBlobSub event.Subscription
+
 	// channels for fetcher, syncer, txsyncLoop
 	newPeerCh   chan Peer
 	txsyncCh    chan *txsync
@@ -391,6 +406,16 @@ func (pm *ProtocolManager) Start(maxPeers int) {
 	pm.txsSub = pm.txpool.SubscribeNewTxsEvent(pm.txsCh)
 	go pm.txBroadcastLoop()
 
+	// broadcast private transactions only to CNs and PNs
+	pm.privateTxsCh = make(chan blockchain.NewPrivateTxsEvent, txChanSize)
+	pm.privateTxsSub = pm.txpool.SubscribeNewPrivateTxsEvent(pm.privateTxsCh)
+	go pm.privateTxBroadcastLoop()
+
+	// fetch the blob sidecars the txpool has not seen
+	pm.missingBlobCh = make(chan blockchain.MissingBlobSidecarsEvent, missingBlobChanSize)
+	pm.missingBlobSub = pm.txpool.SubscribeMissingBlobSidecarsEvent(pm.missingBlobCh)
+	go pm.blobSidecarsFetchLoop()
+
 	// broadcast mined blocks
 	pm.minedBlockSub = pm.eventMux.Subscribe(blockchain.NewMinedBlockEvent{})
 	go pm.minedBroadcastLoop()
@@ -403,8 +428,10 @@ func (pm *ProtocolManager) Start(maxPeers int) {
 func (pm *ProtocolManager) Stop() {
 	logger.Info("Stopping Kaia protocol")
 
-	pm.txsSub.Unsubscribe()        // quits txBroadcastLoop
-	pm.minedBlockSub.Unsubscribe() // quits blockBroadcastLoop
+	pm.txsSub.Unsubscribe()         // quits txBroadcastLoop
+	pm.privateTxsSub.Unsubscribe()  // quits privateTxBroadcastLoop
+	pm.missingBlobSub.Unsubscribe() // quits blobSidecarsFetchLoop
+	pm.minedBlockSub.Unsubscribe()  // quits blockBroadcastLoop
 
 	// Quit the sync loop.
 	// After this send has completed, no new peers will be accepted.
@@ -500,7 +527,7 @@ func (pm *ProtocolManager) handle(p Peer) error {
 		td      = pm.blockchain.GetTd(hash, number)
 	)
 
-	if err := p.Handshake(pm.networkId, pm.getChainID(), td, hash, genesis.Hash()); err != nil {
+	if err := p.Handshake(pm.networkId, pm.getChainID(), td, hash, genesis.Hash(), pm.nodeBinder(p)); err != nil {
 		p.GetP2PPeer().Log().Debug("Kaia peer handshake failed", "err", err)
 		return err
 	}
@@ -551,11 +578,8 @@ func (pm *ProtocolManager) handle(p Peer) error {
 
 	p.GetP2PPeer().Log().Info("Added a single channel P2P Peer", "peerID", p.GetP2PPeerID())
 
-	pubKey, err := p.GetP2PPeerID().Pubkey()
-	if err != nil {
-		return err
-	}
-	addr := crypto.PubkeyToAddress(*pubKey)
+	// The address of the peer is its consensus address if bound in the handshake
+	addr := p.GetAddr()
 
 	// TODO-Kaia check global worker and peer worker
 	messageChannel := make(chan p2p.Msg, channelSizePerPeer)
@@ -724,6 +748,16 @@ func (pm *ProtocolManager) handleMsg(p Peer, addr common.Address, msg p2p.Msg) e
 			return err
 		}
 
+	case p.GetVersion() >= kaia66 && msg.Code == BlobSidecarsRequestMsg:
+		if err := handleBlobSidecarsRequestMsg(pm, p, msg); err != nil {
+			return err
+		}
+
+	case p.GetVersion() >= kaia66 && msg.Code == BlobSidecarsMsg:
+		if err := handleBlobSidecarsMsg(pm, p, msg); err != nil {
+			return err
+		}
+
 	case msg.Code == NewBlockHashesMsg:
 		if err := handleNewBlockHashesMsg(pm, p, msg); err != nil {
 			return err
@@ -759,6 +793,11 @@ func (pm *ProtocolManager) handleMsg(p Peer, addr common.Address, msg p2p.Msg) e
 			return err
 		}
 
+	case p.GetVersion() >= kaia66 && msg.Code == PrivateTxMsg:
+		if err := handlePrivateTxMsg(pm, p, msg); err != nil {
+			return err
+		}
+
 	default:
 		return errResp(ErrInvalidMsgCode, "%v", msg.Code)
 	}
@@ -1098,6 +1137,97 @@ func handleStakingInfoMsg(pm *ProtocolManager, p Peer, msg p2p.Msg) error {
 	return nil
 }
 
+// handleBlobSidecarsRequestMsg handles blob sidecars request message.
+func handleBlobSidecarsRequestMsg(pm *ProtocolManager, p Peer, msg p2p.Msg) error {
+	// Decode the retrieval message
+	msgStream := rlp.NewStream(msg.Payload, uint64(msg.Size))
+	if _, err := msgStream.List(); err != nil {
+		return err
+	}
+	// Gather blob sidecars until the fetch or network limits is reached
+	var (
+		hash     common.Hash
+		bytes    int
+		sidecars []*blobSidecarsData
+	)
+	for bytes < softResponseLimit && len(sidecars) < maxBlobSidecarsFetch {
+		// Retrieve the hash of the next block
+		if err := msgStream.Decode(&hash); err == rlp.EOL {
+			break
+		} else if err != nil {
+			return errResp(ErrDecode, "msg %v: %v", msg, err)
+		}
+		// Retrieve the requested block's blob sidecars, skipping if unknown to us
+		header := pm.blockchain.GetHeaderByHash(hash)
+		if header == nil {
+			continue
+		}
+		results := pm.blockchain.GetBlobSidecars(hash, header.Number.Uint64())
+		if len(results) == 0 {
+			continue
+		}
+		sidecars = append(sidecars, &blobSidecarsData{BlockHash: hash, Sidecars: results})
+		for _, result := range results {
+			for i := range result.Sidecar.Blobs {
+				bytes += len(result.Sidecar.Blobs[i])
+			}
+		}
+	}
+	return p.SendBlobSidecars(sidecars)
+}
+
+// handleBlobSidecarsMsg handles blob sidecars response message.
+// The sidecars are verified against the blob hashes of the transactions
+// in the block before being merged into the stored ones.
+func handleBlobSidecarsMsg(pm *ProtocolManager, p Peer, msg p2p.Msg) error {
+	// A batch of blob sidecars arrived to one of our previous requests
+	var packets []*blobSidecarsData
+	if err := msg.Decode(&packets); err != nil {
+		return errResp(ErrDecode, "msg %v: %v", msg, err)
+	}
+	for _, packet := range packets {
+		block := pm.blockchain.GetBlockByHash(packet.BlockHash)
+		if block == nil {
+			continue
+		}
+		stored := pm.blockchain.GetBlobSidecars(block.Hash(), block.NumberU64())
+		known := make(map[common.Hash]struct{}, len(stored))
+		for _, sidecar := range stored {
+			known[sidecar.TxHash] = struct{}{}
+		}
+		blobTxs := make(map[common.Hash]*types.Transaction)
+		for _, tx := range block.Transactions() {
+			if len(tx.BlobHashes()) > 0 {
+				blobTxs[tx.Hash()] = tx
+			}
+		}
+		added := 0
+		for _, sidecar := range packet.Sidecars {
+			if sidecar == nil || sidecar.Sidecar == nil {
+				return errResp(ErrDecode, "nil blob sidecar of block %x", packet.BlockHash)
+			}
+			if _, ok := known[sidecar.TxHash]; ok {
+				continue
+			}
+			tx, ok := blobTxs[sidecar.TxHash]
+			if !ok {
+				return errResp(ErrDecode, "blob sidecar of tx %x not in block %x", sidecar.TxHash, packet.BlockHash)
+			}
+			if err := sidecar.Sidecar.Verify(tx.BlobHashes()); err != nil {
+				return errResp(ErrDecode, "invalid blob sidecar of tx %x: %v", sidecar.TxHash, err)
+			}
+			known[sidecar.TxHash] = struct{}{}
+			stored = append(stored, sidecar)
+			added++
+		}
+		if added > 0 {
+			pm.blockchain.WriteBlobSidecars(block.Hash(), block.NumberU64(), stored)
+			logger.Debug("Stored fetched blob sidecars", "peer", p.GetID(), "number", block.NumberU64(), "count", added)
+		}
+	}
+	return nil
+}
+
 // handleNewBlockHashesMsg handles new block hashes message.
 func handleNewBlockHashesMsg(pm *ProtocolManager, p Peer, msg p2p.Msg) error {
 	var (
@@ -1257,6 +1387,32 @@ func handleTxMsg(pm *ProtocolManager, p Peer, msg p2p.Msg) error {
 	return err
 }
 
+// handlePrivateTxMsg handles private transaction-propagating message. The transactions
+// are added to the pool as private ones, so that they are never propagated to ENs.
+func handlePrivateTxMsg(pm *ProtocolManager, p Peer, msg p2p.Msg) error {
+	// Transactions arrived, make sure we have a valid and fresh chain to handle them
+	if atomic.LoadUint32(&pm.acceptTxs) == 0 {
+		return nil
+	}
+	var txs []*privateTxData
+	if err := msg.Decode(&txs); err != nil {
+		return errResp(ErrDecode, "msg %v: %v", msg, err)
+	}
+	var err error
+	for i, data := range txs {
+		if data == nil || data.Tx == nil {
+			err = errResp(ErrDecode, "private transaction %d is nil", i)
+			continue
+		}
+		p.AddToKnownTxs(data.Tx.Hash())
+		txReceiveCounter.Inc(1)
+		if addErr := pm.txpool.AddPrivateRemote(data.Tx, data.Expiry); addErr != nil {
+			logger.Trace("Failed to add private transaction", "hash", data.Tx.Hash(), "err", addErr)
+		}
+	}
+	return err
+}
+
 // sampleSize calculates the number of peers to send block.
 // If calcSampleSize is smaller than minNumPeersToSendBlock, it returns minNumPeersToSendBlock.
 // Otherwise, it returns calcSampleSize.
@@ -1319,7 +1475,9 @@ func (pm *ProtocolManager) BroadcastTxs(txs types.Transactions) {
 	if pm.blockchain != nil && pm.blockchain.CurrentHeader() != nil && pm.blockchain.CurrentHeader().BaseFee != nil {
 		baseFee = pm.blockchain.CurrentHeader().BaseFee
 	}
-	txs = types.SortTxsByPriceAndTime(txs, baseFee)
+	txs = types.SortTxsByPriceAndTime(pm.withBlobTxSidecars(txs), baseFee)
+	txs, privateTxs := pm.splitPrivateTxs(txs)
+	pm.broadcastPrivateTxs(privateTxs)
 	switch pm.nodetype {
 	case common.CONSENSUSNODE:
 		pm.broadcastTxsFromCN(txs)
@@ -1406,7 +1564,8 @@ func (pm *ProtocolManager) ReBroadcastTxs(txs types.Transactions) {
 	if pm.blockchain != nil && pm.blockchain.CurrentHeader() != nil && pm.blockchain.CurrentHeader().BaseFee != nil {
 		baseFee = pm.blockchain.CurrentHeader().BaseFee
 	}
-	txs = types.SortTxsByPriceAndTime(txs, baseFee)
+	txs = types.SortTxsByPriceAndTime(pm.withBlobTxSidecars(txs), baseFee)
+	txs, privateTxs := pm.splitPrivateTxs(txs)
 
 	peersWithoutTxs := make(map[Peer]types.Transactions)
 	for _, tx := range txs {
@@ -1417,8 +1576,100 @@ func (pm *ProtocolManager) ReBroadcastTxs(txs types.Transactions) {
 		txResendCounter.Inc(1)
 	}
 
-	propTxPeersGauge.Update(int64(len(peersWithoutTxs)))
+	// Sampling peers for a PN only returns CNs or PNs.
+	peersWithoutPrivateTxs := make(map[Peer][]*privateTxData)
+	for _, tx := range privateTxs {
+		peers := pm.peers.SampleResendPeersByType(common.PROXYNODE)
+		for _, peer := range peers {
+			peersWithoutPrivateTxs[peer] = append(peersWithoutPrivateTxs[peer], tx)
+		}
+		txResendCounter.Inc(1)
+	}
+
+	propTxPeersGauge.Update(int64(len(peersWithoutTxs) + len(peersWithoutPrivateTxs)))
 	sendTransactions(peersWithoutTxs)
+	sendPrivateTransactions(peersWithoutPrivateTxs)
+}
+
+// broadcastPrivateTxs propagates the private transactions to the CNs, and also to
+// the PNs unless the current node is a CN, but never to the ENs. They are sent with
+// the private transaction message, so that the receivers keep them away from ENs too.
+func (pm *ProtocolManager) broadcastPrivateTxs(txs []*privateTxData) {
+	if len(txs) == 0 {
+		return
+	}
+	peersWithoutTxs := make(map[Peer][]*privateTxData)
+	for _, tx := range txs {
+		peers := pm.peers.TypePeersWithoutTx(tx.Tx.Hash(), common.CONSENSUSNODE)
+		if pm.nodetype != common.CONSENSUSNODE {
+			peers = append(peers, pm.peers.TypePeersWithoutTx(tx.Tx.Hash(), common.PROXYNODE)...)
+		}
+		for _, peer := range peers {
+			peersWithoutTxs[peer] = append(peersWithoutTxs[peer], tx)
+		}
+		logger.Trace("Broadcast private transaction", "hash", tx.Tx.Hash(), "recipients", len(peers))
+		txSendCounter.Inc(1)
+	}
+	sendPrivateTransactions(peersWithoutTxs)
+}
+
+// splitPrivateTxs separates the private transactions, which should only be propagated
+// to CNs and PNs, from the given transactions with their expiry block numbers.
+func (pm *ProtocolManager) splitPrivateTxs(txs types.Transactions) (types.Transactions, []*privateTxData) {
+	if pm.txpool == nil {
+		return txs, nil
+	}
+	var (
+		public  = make(types.Transactions, 0, len(txs))
+		private []*privateTxData
+	)
+	for _, tx := range txs {
+		if expiry, ok := pm.txpool.PrivateTxExpiry(tx.Hash()); ok {
+			private = append(private, &privateTxData{Tx: tx, Expiry: expiry})
+		} else {
+			public = append(public, tx)
+		}
+	}
+	return public, private
+}
+
+// withBlobTxSidecars attaches the sidecars kept by the txpool to the blob
+// transactions, since peers accept a blob transaction only with its sidecar.
+// A blob transaction whose sidecar is unknown is left out.
+func (pm *ProtocolManager) withBlobTxSidecars(txs types.Transactions) types.Transactions {
+	var result types.Transactions
+	for i, tx := range txs {
+		if tx.Type() != types.TxTypeEthereumBlob || tx.BlobTxSidecar() != nil {
+			if result != nil {
+				result = append(result, tx)
+			}
+			continue
+		}
+		if result == nil {
+			result = append(make(types.Transactions, 0, len(txs)), txs[:i]...)
+		}
+		if sidecar := pm.txpool.GetBlobSidecar(tx.Hash()); sidecar != nil {
+			result = append(result, tx.WithBlobTxSidecar(sidecar))
+		}
+	}
+	if result == nil {
+		return txs
+	}
+	return result
+}
+
+// sendPrivateTransactions sends the paired private transactions to each peer of the given
+// map in synchronised way. The peers not knowing the private transaction message are
+// skipped, since they would propagate the transactions as public ones.
+func sendPrivateTransactions(txsSet map[Peer][]*privateTxData) {
+	for peer, txs := range txsSet {
+		if peer.GetVersion() < kaia66 {
+			continue
+		}
+		if err := peer.SendPrivateTransactions(txs); err != nil {
+			logger.Error("Failed to send private txs", "peer", peer.GetAddr(), "peerType", peer.ConnType(), "numTxs", len(txs), "err", err)
+		}
+	}
 }
 
 // sendTransactions iterates the given map with the key-value pair of Peer and Transactions
@@ -1470,6 +1721,50 @@ func (pm *ProtocolManager) txBroadcastLoop() {
 	}
 }
 
+// privateTxBroadcastLoop propagates the private transactions, which the txpool
+// never posts with NewTxsEvent.
+func (pm *ProtocolManager) privateTxBroadcastLoop() {
+	for {
+		select {
+		case event := <-pm.privateTxsCh:
+			pm.BroadcastTxs(event.Txs)
+			// Err() channel will be closed when unsubscribing.
+		case <-pm.privateTxsSub.Err():
+			return
+		}
+	}
+}
+
+// blobSidecarsFetchLoop requests the blob sidecars of the blocks the txpool
+// has not seen from the kaia/66 peers.
+func (pm *ProtocolManager) blobSidecarsFetchLoop() {
+	for {
+		select {
+		case event := <-pm.missingBlobCh:
+			pm.fetchBlobSidecars(event.Block.Hash())
+			// Err() channel will be closed when unsubscribing.
+		case <-pm.missingBlobSub.Err():
+			return
+		}
+	}
+}
+
+// fetchBlobSidecars requests the blob sidecars of the given block from a few
+// randomly chosen peers supporting kaia/66.
+func (pm *ProtocolManager) fetchBlobSidecars(hash common.Hash) {
+	var peers []Peer
+	for _, peer := range pm.peers.Peers() {
+		if peer.GetVersion() >= kaia66 {
+			peers = append(peers, peer)
+		}
+	}
+	for _, peer := range samplingPeers(peers, blobSidecarsFetchPeers) {
+		if err := peer.RequestBlobSidecars([]common.Hash{hash}); err != nil {
+			logger.Debug("Failed to request blob sidecars", "peer", peer.GetID(), "hash", hash, "err", err)
+		}
+	}
+}
+
 func (pm *ProtocolManager) txResendLoop(period uint64, maxTxCount int) {
 	tick := time.Duration(period) * time.Second
 	resend := time.NewTicker(tick)
@@ -1529,6 +1824,16 @@ func (pm *ProtocolManager) NodeInfo() *NodeInfo {
 	}
 }
 
+// nodeBinder returns the binder of the consensus engine to exchange the bindings of the p2p nodes
+// to the consensus addresses with the given peer, if both are CNs.
+func (pm *ProtocolManager) nodeBinder(p Peer) consensus.NodeBinder {
+	if pm.nodetype != common.CONSENSUSNODE || p.ConnType() != common.CONSENSUSNODE {
+		return nil
+	}
+	binder, _ := pm.engine.(consensus.NodeBinder)
+	return binder
+}
+
 // Below functions are used in Istanbul BFT consensus.
 // Enqueue wraps fetcher's Enqueue function to insert the given block.
 func (pm *ProtocolManager) Enqueue(id string, block *types.Block) {
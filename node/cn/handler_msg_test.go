@@ -29,6 +29,7 @@ import (
 	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/consensus/istanbul"
+	"github.com/kaiachain/kaia/crypto/kzg4844"
 	"github.com/kaiachain/kaia/kaiax/staking"
 	staking_mock "github.com/kaiachain/kaia/kaiax/staking/mock"
 	"github.com/kaiachain/kaia/networks/p2p"
@@ -215,6 +216,121 @@ func TestHandleReceiptsRequestMsg(t *testing.T) {
 	}
 }
 
+func newBlobSidecar(t *testing.T) *types.BlobTxSidecar {
+	blob := kzg4844.Blob{}
+	commitment, err := kzg4844.BlobToCommitment(blob)
+	assert.NoError(t, err)
+	proof, err := kzg4844.ComputeBlobProof(blob, commitment)
+	assert.NoError(t, err)
+
+	return &types.BlobTxSidecar{
+		Blobs:       []kzg4844.Blob{blob},
+		Commitments: []kzg4844.Commitment{commitment},
+		Proofs:      []kzg4844.Proof{proof},
+	}
+}
+
+func newBlobTx(t *testing.T, nonce uint64, blobHashes []common.Hash) *types.Transaction {
+	d, err := types.NewTxInternalDataWithMap(types.TxTypeEthereumBlob, map[types.TxValueKeyType]interface{}{
+		types.TxValueKeyNonce:      nonce,
+		types.TxValueKeyTo:         addrs[1],
+		types.TxValueKeyAmount:     big.NewInt(100),
+		types.TxValueKeyGasLimit:   uint64(21000),
+		types.TxValueKeyGasFeeCap:  big.NewInt(1),
+		types.TxValueKeyGasTipCap:  big.NewInt(1),
+		types.TxValueKeyData:       []byte{},
+		types.TxValueKeyAccessList: types.AccessList{},
+		types.TxValueKeyBlobFeeCap: big.NewInt(params.BlobTxMinBlobGasprice),
+		types.TxValueKeyBlobHashes: blobHashes,
+		types.TxValueKeyChainID:    params.TestChainConfig.ChainID,
+	})
+	assert.NoError(t, err)
+	return types.NewTx(d)
+}
+
+func TestHandleBlobSidecarsRequestMsg(t *testing.T) {
+	// The message is not understood by the peers of the older versions.
+	{
+		mockCtrl, _, mockPeer, pm := prepareBlockChain(t)
+		msg := generateMsg(t, BlobSidecarsRequestMsg, []common.Hash{hashes[0]})
+
+		mockPeer.EXPECT().GetVersion().Return(kaia65).AnyTimes()
+		assert.Error(t, pm.handleMsg(mockPeer, addrs[0], msg))
+		mockCtrl.Finish()
+	}
+	// Only the blob sidecars of the known blocks are returned.
+	{
+		sidecars := []*types.BlobTxSidecarWithHash{{TxHash: hashes[2], Sidecar: newBlobSidecar(t)}}
+
+		mockCtrl, mockBlockChain, mockPeer, pm := prepareBlockChain(t)
+		msg := generateMsg(t, BlobSidecarsRequestMsg, []common.Hash{hashes[0], hashes[1]})
+
+		mockBlockChain.EXPECT().GetHeaderByHash(hashes[0]).Return(&types.Header{Number: big.NewInt(blockNum1)}).Times(1)
+		mockBlockChain.EXPECT().GetBlobSidecars(hashes[0], uint64(blockNum1)).Return(sidecars).Times(1)
+		mockBlockChain.EXPECT().GetHeaderByHash(hashes[1]).Return(nil).Times(1)
+		mockPeer.EXPECT().SendBlobSidecars([]*blobSidecarsData{{BlockHash: hashes[0], Sidecars: sidecars}}).Return(nil).Times(1)
+
+		mockPeer.EXPECT().GetVersion().Return(kaia66).AnyTimes()
+		assert.NoError(t, pm.handleMsg(mockPeer, addrs[0], msg))
+		mockCtrl.Finish()
+	}
+}
+
+func TestHandleBlobSidecarsMsg(t *testing.T) {
+	sidecar := newBlobSidecar(t)
+	tx0 := newBlobTx(t, 0, sidecar.BlobHashes())
+	tx1 := newBlobTx(t, 1, sidecar.BlobHashes())
+	block := newBlock(blockNum1).WithBody(types.Transactions{tx0, tx1})
+
+	stored := []*types.BlobTxSidecarWithHash{{TxHash: tx0.Hash(), Sidecar: sidecar}}
+	fetched := &types.BlobTxSidecarWithHash{TxHash: tx1.Hash(), Sidecar: sidecar}
+
+	// The verified sidecars are merged into the stored ones.
+	{
+		mockCtrl, mockBlockChain, mockPeer, pm := prepareBlockChain(t)
+		msg := generateMsg(t, BlobSidecarsMsg, []*blobSidecarsData{{BlockHash: block.Hash(), Sidecars: []*types.BlobTxSidecarWithHash{stored[0], fetched}}})
+
+		mockBlockChain.EXPECT().GetBlockByHash(block.Hash()).Return(block).Times(1)
+		mockBlockChain.EXPECT().GetBlobSidecars(block.Hash(), block.NumberU64()).Return(stored).Times(1)
+		mockBlockChain.EXPECT().WriteBlobSidecars(block.Hash(), block.NumberU64(), gomock.Any()).DoAndReturn(
+			func(hash common.Hash, number uint64, sidecars []*types.BlobTxSidecarWithHash) {
+				assert.Equal(t, 2, len(sidecars))
+				assert.Equal(t, tx0.Hash(), sidecars[0].TxHash)
+				assert.Equal(t, tx1.Hash(), sidecars[1].TxHash)
+			}).Times(1)
+
+		mockPeer.EXPECT().GetVersion().Return(kaia66).AnyTimes()
+		assert.NoError(t, pm.handleMsg(mockPeer, addrs[0], msg))
+		mockCtrl.Finish()
+	}
+	// The sidecars not matching the blob hashes of the transaction are rejected.
+	{
+		mockCtrl, mockBlockChain, mockPeer, pm := prepareBlockChain(t)
+		invalid := &types.BlobTxSidecarWithHash{TxHash: tx1.Hash(), Sidecar: &types.BlobTxSidecar{}}
+		msg := generateMsg(t, BlobSidecarsMsg, []*blobSidecarsData{{BlockHash: block.Hash(), Sidecars: []*types.BlobTxSidecarWithHash{invalid}}})
+
+		mockBlockChain.EXPECT().GetBlockByHash(block.Hash()).Return(block).Times(1)
+		mockBlockChain.EXPECT().GetBlobSidecars(block.Hash(), block.NumberU64()).Return(stored).Times(1)
+
+		mockPeer.EXPECT().GetVersion().Return(kaia66).AnyTimes()
+		assert.Error(t, pm.handleMsg(mockPeer, addrs[0], msg))
+		mockCtrl.Finish()
+	}
+	// The sidecars of the transactions not in the block are rejected.
+	{
+		mockCtrl, mockBlockChain, mockPeer, pm := prepareBlockChain(t)
+		unknown := &types.BlobTxSidecarWithHash{TxHash: hashes[0], Sidecar: sidecar}
+		msg := generateMsg(t, BlobSidecarsMsg, []*blobSidecarsData{{BlockHash: block.Hash(), Sidecars: []*types.BlobTxSidecarWithHash{unknown}}})
+
+		mockBlockChain.EXPECT().GetBlockByHash(block.Hash()).Return(block).Times(1)
+		mockBlockChain.EXPECT().GetBlobSidecars(block.Hash(), block.NumberU64()).Return(stored).Times(1)
+
+		mockPeer.EXPECT().GetVersion().Return(kaia66).AnyTimes()
+		assert.Error(t, pm.handleMsg(mockPeer, addrs[0], msg))
+		mockCtrl.Finish()
+	}
+}
+
 func TestHandleNewBlockMsg_LargeLocalPeerBlockScore(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -280,6 +396,38 @@ func TestHandleTxMsg(t *testing.T) {
 	}
 }
 
+func TestHandlePrivateTxMsg(t *testing.T) {
+	pm := &ProtocolManager{}
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	msg := func() p2p.Msg {
+		return generateMsg(t, PrivateTxMsg, []*privateTxData{{Tx: tx1, Expiry: 10}})
+	}
+	atomic.StoreUint32(&pm.acceptTxs, 1)
+	mockTxPool := mocks.NewMockTxPool(mockCtrl)
+	pm.txpool = mockTxPool
+
+	// The message is not understood by the peers of the older versions.
+	{
+		mockPeer := NewMockPeer(mockCtrl)
+		mockPeer.EXPECT().GetVersion().Return(kaia65).AnyTimes()
+		assert.Error(t, pm.handleMsg(mockPeer, addrs[0], msg()))
+	}
+	// The transactions are added as private ones with the expiry block number.
+	{
+		mockPeer := NewMockPeer(mockCtrl)
+		mockPeer.EXPECT().GetVersion().Return(kaia66).AnyTimes()
+		mockPeer.EXPECT().AddToKnownTxs(tx1.Hash()).Times(1)
+		mockTxPool.EXPECT().AddPrivateRemote(gomock.Any(), uint64(10)).DoAndReturn(
+			func(tx *types.Transaction, expiry uint64) error {
+				assert.Equal(t, tx1.Hash(), tx.Hash())
+				return nil
+			}).Times(1)
+		assert.NoError(t, pm.handleMsg(mockPeer, addrs[0], msg()))
+	}
+}
+
 func prepareTestHandleBlockHeaderFetchRequestMsg(t *testing.T) (*gomock.Controller, *MockPeer, *mocks.MockBlockChain, *ProtocolManager) {
 	mockCtrl := gomock.NewController(t)
 	mockPeer := NewMockPeer(mockCtrl)
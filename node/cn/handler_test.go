@@ -554,6 +554,126 @@ func TestBroadcastTxsFromEN_ALL_Exists(t *testing.T) {
 	pm.BroadcastTxs(txs)
 }
 
+func TestBroadcastTxs_PrivateTx(t *testing.T) {
+	for _, nodetype := range []common.ConnType{common.CONSENSUSNODE, common.PROXYNODE, common.ENDPOINTNODE} {
+		pm := &ProtocolManager{}
+		pm.nodetype = nodetype
+		mockCtrl, _, _, mockTxPool := newMocks(t)
+
+		pm.txpool = mockTxPool
+		mockTxPool.EXPECT().PrivateTxExpiry(tx1.Hash()).Return(uint64(10), true).AnyTimes()
+
+		peers := newPeerSet()
+		pm.peers = peers
+		cnPeer, pnPeer, enPeer := createAndRegisterPeers(mockCtrl, peers)
+
+		cnPeer.EXPECT().ConnType().Return(common.CONSENSUSNODE).AnyTimes()
+		pnPeer.EXPECT().ConnType().Return(common.PROXYNODE).AnyTimes()
+		enPeer.EXPECT().ConnType().Return(common.ENDPOINTNODE).AnyTimes()
+		cnPeer.EXPECT().GetVersion().Return(kaia66).AnyTimes()
+		pnPeer.EXPECT().GetVersion().Return(kaia66).AnyTimes()
+
+		cnPeer.EXPECT().KnowsTx(tx1.Hash()).Return(false).AnyTimes()
+		pnPeer.EXPECT().KnowsTx(tx1.Hash()).Return(false).AnyTimes()
+
+		// A private transaction is sent to CNs, and also to PNs unless sent by a CN,
+		// with its expiry block number. It is never sent to ENs.
+		private := []*privateTxData{{Tx: tx1, Expiry: 10}}
+		cnPeer.EXPECT().SendPrivateTransactions(gomock.Eq(private)).Times(1)
+		if nodetype == common.CONSENSUSNODE {
+			pnPeer.EXPECT().SendPrivateTransactions(gomock.Any()).Times(0)
+		} else {
+			pnPeer.EXPECT().SendPrivateTransactions(gomock.Eq(private)).Times(1)
+		}
+		enPeer.EXPECT().SendPrivateTransactions(gomock.Any()).Times(0)
+		cnPeer.EXPECT().SendTransactions(gomock.Any()).Times(0)
+		pnPeer.EXPECT().SendTransactions(gomock.Any()).Times(0)
+		enPeer.EXPECT().SendTransactions(gomock.Any()).Times(0)
+
+		pm.BroadcastTxs(txs)
+		mockCtrl.Finish()
+	}
+}
+
+func TestProtocolManager_privateTxBroadcastLoop(t *testing.T) {
+	pm := &ProtocolManager{}
+	pm.nodetype = common.ENDPOINTNODE
+	mockCtrl, _, _, mockTxPool := newMocks(t)
+	defer mockCtrl.Finish()
+
+	pm.txpool = mockTxPool
+	mockTxPool.EXPECT().PrivateTxExpiry(tx1.Hash()).Return(uint64(10), true).AnyTimes()
+
+	privateTxsCh := make(chan blockchain.NewPrivateTxsEvent, txChanSize)
+	pm.privateTxsCh = privateTxsCh
+	feed := &event.Feed{}
+	pm.privateTxsSub = feed.Subscribe(privateTxsCh)
+
+	peers := newPeerSet()
+	pm.peers = peers
+	cnPeer, pnPeer, enPeer := createAndRegisterPeers(mockCtrl, peers)
+	cnPeer.EXPECT().ConnType().Return(common.CONSENSUSNODE).AnyTimes()
+	pnPeer.EXPECT().ConnType().Return(common.PROXYNODE).AnyTimes()
+	enPeer.EXPECT().ConnType().Return(common.ENDPOINTNODE).AnyTimes()
+	cnPeer.EXPECT().GetVersion().Return(kaia66).AnyTimes()
+	pnPeer.EXPECT().GetVersion().Return(kaia66).AnyTimes()
+	cnPeer.EXPECT().KnowsTx(tx1.Hash()).Return(false).AnyTimes()
+	pnPeer.EXPECT().KnowsTx(tx1.Hash()).Return(false).AnyTimes()
+
+	// The private transactions posted apart from NewTxsEvent are sent only to CNs and PNs.
+	var (
+		private = []*privateTxData{{Tx: tx1, Expiry: 10}}
+		sent    = make(chan struct{}, 2)
+		done    = func([]*privateTxData) { sent <- struct{}{} }
+	)
+	cnPeer.EXPECT().SendPrivateTransactions(gomock.Eq(private)).Do(done).Times(1)
+	pnPeer.EXPECT().SendPrivateTransactions(gomock.Eq(private)).Do(done).Times(1)
+	enPeer.EXPECT().SendPrivateTransactions(gomock.Any()).Times(0)
+	enPeer.EXPECT().SendTransactions(gomock.Any()).Times(0)
+
+	go pm.privateTxBroadcastLoop()
+	defer pm.privateTxsSub.Unsubscribe()
+
+	privateTxsCh <- blockchain.NewPrivateTxsEvent{Txs: types.Transactions{tx1}}
+	for i := 0; i < 2; i++ {
+		select {
+		case <-sent:
+		case <-time.After(time.Second):
+			t.Fatal("private transaction not sent")
+		}
+	}
+}
+
+func TestBroadcastTxs_PrivateTx_OldVersion(t *testing.T) {
+	pm := &ProtocolManager{}
+	pm.nodetype = common.ENDPOINTNODE
+	mockCtrl, _, _, mockTxPool := newMocks(t)
+	defer mockCtrl.Finish()
+
+	pm.txpool = mockTxPool
+	mockTxPool.EXPECT().PrivateTxExpiry(tx1.Hash()).Return(uint64(10), true).AnyTimes()
+
+	peers := newPeerSet()
+	pm.peers = peers
+	cnPeer, pnPeer, enPeer := createAndRegisterPeers(mockCtrl, peers)
+
+	cnPeer.EXPECT().ConnType().Return(common.CONSENSUSNODE).AnyTimes()
+	pnPeer.EXPECT().ConnType().Return(common.PROXYNODE).AnyTimes()
+	enPeer.EXPECT().ConnType().Return(common.ENDPOINTNODE).AnyTimes()
+	cnPeer.EXPECT().KnowsTx(tx1.Hash()).Return(false).AnyTimes()
+	pnPeer.EXPECT().KnowsTx(tx1.Hash()).Return(false).AnyTimes()
+	cnPeer.EXPECT().GetVersion().Return(kaia66).AnyTimes()
+	pnPeer.EXPECT().GetVersion().Return(kaia65).AnyTimes()
+
+	// A peer which doesn't know the private transaction message is skipped,
+	// since it would propagate the transaction as a public one.
+	cnPeer.EXPECT().SendPrivateTransactions(gomock.Any()).Times(1)
+	pnPeer.EXPECT().SendPrivateTransactions(gomock.Any()).Times(0)
+	pnPeer.EXPECT().SendTransactions(gomock.Any()).Times(0)
+
+	pm.BroadcastTxs(txs)
+}
+
 func TestBroadcastTxsFrom_DefaultCase(t *testing.T) {
 	pm := &ProtocolManager{}
 	pm.nodetype = common.BOOTNODE
@@ -709,6 +829,34 @@ func TestReBroadcastTxs_EN(t *testing.T) {
 	}
 }
 
+func TestReBroadcastTxs_EN_PrivateTx(t *testing.T) {
+	pm := &ProtocolManager{}
+	pm.nodetype = common.ENDPOINTNODE
+	mockCtrl, _, _, mockTxPool := newMocks(t)
+	defer mockCtrl.Finish()
+
+	pm.txpool = mockTxPool
+	mockTxPool.EXPECT().PrivateTxExpiry(tx1.Hash()).Return(uint64(10), true).AnyTimes()
+
+	peers := newPeerSet()
+	pm.peers = peers
+	_, pnPeer, enPeer := createAndRegisterPeers(mockCtrl, peers)
+	delete(peers.cnpeers, addrs[0])
+	delete(peers.peers, fmt.Sprintf("%x", nodeids[0][:8]))
+
+	pnPeer.EXPECT().ConnType().Return(common.PROXYNODE).AnyTimes()
+	enPeer.EXPECT().ConnType().Return(common.ENDPOINTNODE).AnyTimes()
+	pnPeer.EXPECT().GetVersion().Return(kaia66).AnyTimes()
+
+	// A private transaction is resent to PNs only, even without CNs.
+	pnPeer.EXPECT().SendPrivateTransactions(gomock.Eq([]*privateTxData{{Tx: tx1, Expiry: 10}})).Times(1)
+	pnPeer.EXPECT().SendTransactions(gomock.Any()).Times(0)
+	enPeer.EXPECT().SendPrivateTransactions(gomock.Any()).Times(0)
+	enPeer.EXPECT().SendTransactions(gomock.Any()).Times(0)
+
+	pm.ReBroadcastTxs(txs)
+}
+
 func TestUseTxResend(t *testing.T) {
 	testSet := [...]struct {
 		pm     *ProtocolManager
@@ -94,6 +94,21 @@ func (mr *MockMinerMockRecorder) PendingBlock() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingBlock", reflect.TypeOf((*MockMiner)(nil).PendingBlock))
 }
 
+// PendingBlockPreview mocks base method.
+func (m *MockMiner) PendingBlockPreview() (*work.PendingBlockPreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingBlockPreview")
+	ret0, _ := ret[0].(*work.PendingBlockPreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingBlockPreview indicates an expected call of PendingBlockPreview.
+func (mr *MockMinerMockRecorder) PendingBlockPreview() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingBlockPreview", reflect.TypeOf((*MockMiner)(nil).PendingBlockPreview))
+}
+
 // Register mocks base method.
 func (m *MockMiner) Register(arg0 work.Agent) {
 	m.ctrl.T.Helper()
@@ -122,6 +137,22 @@ func (mr *MockMinerMockRecorder) RegisterExecutionModule(arg0 ...interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterExecutionModule", reflect.TypeOf((*MockMiner)(nil).RegisterExecutionModule), arg0...)
 }
 
+// RegisterTxPoolModule mocks base method.
+func (m *MockMiner) RegisterTxPoolModule(arg0 ...kaiax.TxPoolModule) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "RegisterTxPoolModule", varargs...)
+}
+
+// RegisterTxPoolModule indicates an expected call of RegisterTxPoolModule.
+func (mr *MockMinerMockRecorder) RegisterTxPoolModule(arg0 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterTxPoolModule", reflect.TypeOf((*MockMiner)(nil).RegisterTxPoolModule), arg0...)
+}
+
 // SetExtra mocks base method.
 func (m *MockMiner) SetExtra(arg0 []byte) error {
 	m.ctrl.T.Helper()
@@ -125,6 +125,10 @@ type Peer interface {
 	// AsyncSendTransactions sends transactions asynchronously to the peer.
 	AsyncSendTransactions(txs types.Transactions)
 
+	// SendPrivateTransactions sends private transactions to the peer and includes
+	// the hashes in its transaction hash set for future reference.
+	SendPrivateTransactions(txs []*privateTxData) error
+
 	// SendNewBlockHashes announces the availability of a number of blocks through
 	// a hash notification.
 	SendNewBlockHashes(hashes []common.Hash, numbers []uint64) error
@@ -170,6 +174,14 @@ type Peer interface {
 	// ones requested from an already RLP encoded format.
 	SendStakingInfoRLP(stakingInfos []rlp.RawValue) error
 
+	// SendBlobSidecars sends a batch of blob sidecars of blocks, corresponding to
+	// the ones requested.
+	SendBlobSidecars(sidecars []*blobSidecarsData) error
+
+	// RequestBlobSidecars fetches a batch of blob sidecars of the blocks
+	// corresponding to the hashes from a remote node.
+	RequestBlobSidecars(hashes []common.Hash) error
+
 	// FetchBlockHeader is a wrapper around the header query functions to fetch a
 	// single header. It is used solely by the fetcher.
 	FetchBlockHeader(hash common.Hash) error
@@ -181,7 +193,9 @@ type Peer interface {
 
 	// Handshake executes the Kaia protocol handshake, negotiating version number,
 	// network IDs, difficulties, head, and genesis blocks and returning error.
-	Handshake(network uint64, chainID, td *big.Int, head common.Hash, genesis common.Hash) error
+	// If the binder is given, the CNs exchange the bindings of their p2p nodes to their
+	// consensus addresses, and the address of the peer is set to its consensus address.
+	Handshake(network uint64, chainID, td *big.Int, head common.Hash, genesis common.Hash, binder consensus.NodeBinder) error
 
 	// ConnType returns the conntype of the peer.
 	ConnType() common.ConnType
@@ -326,6 +340,11 @@ var ChannelOfMessage = map[uint64]int{
 	// Protocol messages belonging to kaia/65
 	StakingInfoRequestMsg: p2p.ConnDefault,
 	StakingInfoMsg:        p2p.ConnDefault,
+
+	// Protocol messages belonging to kaia/66
+	PrivateTxMsg:           p2p.ConnTxMsg,
+	BlobSidecarsRequestMsg: p2p.ConnDefault,
+	BlobSidecarsMsg:        p2p.ConnDefault,
 }
 
 var ConcurrentOfChannel = []int{
@@ -467,6 +486,15 @@ func (p *basePeer) ReSendTransactions(txs types.Transactions) error {
 	return p2p.Send(p.rw, TxMsg, txs)
 }
 
+// SendPrivateTransactions sends private transactions to the peer and includes
+// the hashes in its transaction hash set for future reference.
+func (p *basePeer) SendPrivateTransactions(txs []*privateTxData) error {
+	for _, tx := range txs {
+		p.AddToKnownTxs(tx.Tx.Hash())
+	}
+	return p2p.Send(p.rw, PrivateTxMsg, txs)
+}
+
 func (p *basePeer) AsyncSendTransactions(txs types.Transactions) {
 	select {
 	case p.queuedTxs <- txs:
@@ -620,9 +648,30 @@ func (p *basePeer) RequestStakingInfo(hashes []common.Hash) error {
 	return p2p.Send(p.rw, StakingInfoRequestMsg, hashes)
 }
 
+// RequestBlobSidecars fetches a batch of blob sidecars of the blocks
+// corresponding to the hashes from a remote node.
+func (p *basePeer) RequestBlobSidecars(hashes []common.Hash) error {
+	p.Log().Debug("Fetching batch of blob sidecars", "count", len(hashes))
+	return p2p.Send(p.rw, BlobSidecarsRequestMsg, hashes)
+}
+
+// SendBlobSidecars sends a batch of blob sidecars of blocks, corresponding to
+// the ones requested.
+func (p *basePeer) SendBlobSidecars(sidecars []*blobSidecarsData) error {
+	return p2p.Send(p.rw, BlobSidecarsMsg, sidecars)
+}
+
 // Handshake executes the Kaia protocol handshake, negotiating version number,
 // network IDs, difficulties, head and genesis blocks.
-func (p *basePeer) Handshake(network uint64, chainID, td *big.Int, head common.Hash, genesis common.Hash) error {
+func (p *basePeer) Handshake(network uint64, chainID, td *big.Int, head common.Hash, genesis common.Hash, binder consensus.NodeBinder) error {
+	var binding *consensus.NodeBinding
+	if binder != nil && p.version >= kaia66 {
+		var err error
+		if binding, err = binder.NodeBinding(); err != nil {
+			return err
+		}
+	}
+
 	// Send out own handshake in a new thread
 	errc := make(chan error, 2)
 	var status statusData // safe to read after two values have been received from errc
@@ -635,6 +684,7 @@ func (p *basePeer) Handshake(network uint64, chainID, td *big.Int, head common.H
 			CurrentBlock:    head,
 			GenesisBlock:    genesis,
 			ChainID:         chainID,
+			Validator:       binding,
 		})
 	}()
 	go func() {
@@ -653,6 +703,18 @@ func (p *basePeer) Handshake(network uint64, chainID, td *big.Int, head common.H
 		}
 	}
 	p.td, p.head, p.chainID = status.TD, status.CurrentBlock, status.ChainID
+
+	if binder != nil && status.Validator != nil {
+		pubKey, err := p.ID().Pubkey()
+		if err != nil {
+			return err
+		}
+		addr, err := binder.VerifyNodeBinding(crypto.PubkeyToAddress(*pubKey), status.Validator)
+		if err != nil {
+			return errResp(ErrInvalidNodeBinding, "%v", err)
+		}
+		p.SetAddr(addr)
+	}
 	return nil
 }
 
@@ -858,6 +920,15 @@ func (p *multiChannelPeer) ReSendTransactions(txs types.Transactions) error {
 	return p.msgSender(TxMsg, txs)
 }
 
+// SendPrivateTransactions sends private transactions to the peer and includes
+// the hashes in its transaction hash set for future reference.
+func (p *multiChannelPeer) SendPrivateTransactions(txs []*privateTxData) error {
+	for _, tx := range txs {
+		p.AddToKnownTxs(tx.Tx.Hash())
+	}
+	return p.msgSender(PrivateTxMsg, txs)
+}
+
 // SendNewBlockHashes announces the availability of a number of blocks through
 // a hash notification.
 func (p *multiChannelPeer) SendNewBlockHashes(hashes []common.Hash, numbers []uint64) error {
@@ -977,6 +1048,19 @@ func (p *multiChannelPeer) RequestStakingInfo(hashes []common.Hash) error {
 	return p.msgSender(StakingInfoRequestMsg, hashes)
 }
 
+// RequestBlobSidecars fetches a batch of blob sidecars of the blocks
+// corresponding to the hashes from a remote node.
+func (p *multiChannelPeer) RequestBlobSidecars(hashes []common.Hash) error {
+	p.Log().Debug("Fetching batch of blob sidecars", "count", len(hashes))
+	return p.msgSender(BlobSidecarsRequestMsg, hashes)
+}
+
+// SendBlobSidecars sends a batch of blob sidecars of blocks, corresponding to
+// the ones requested.
+func (p *multiChannelPeer) SendBlobSidecars(sidecars []*blobSidecarsData) error {
+	return p.msgSender(BlobSidecarsMsg, sidecars)
+}
+
 // msgSender sends data to the peer.
 func (p *multiChannelPeer) msgSender(msgcode uint64, data interface{}) error {
 	if ch, ok := ChannelOfMessage[msgcode]; ok && len(p.rws) > ch {
@@ -1089,7 +1173,7 @@ func (p *multiChannelPeer) Handle(pm *ProtocolManager) error {
 		td      = pm.blockchain.GetTd(hash, number)
 	)
 
-	if err := p.Handshake(pm.networkId, pm.getChainID(), td, hash, genesis.Hash()); err != nil {
+	if err := p.Handshake(pm.networkId, pm.getChainID(), td, hash, genesis.Hash(), pm.nodeBinder(p)); err != nil {
 		p.GetP2PPeer().Log().Debug("Kaia peer handshake failed", "err", err)
 		return err
 	}
@@ -1138,11 +1222,8 @@ func (p *multiChannelPeer) Handle(pm *ProtocolManager) error {
 
 	p.GetP2PPeer().Log().Info("Added a multichannel P2P Peer", "peerID", p.GetP2PPeerID())
 
-	pubKey, err := p.GetP2PPeerID().Pubkey()
-	if err != nil {
-		return err
-	}
-	addr := crypto.PubkeyToAddress(*pubKey)
+	// The address of the peer is its consensus address if bound in the handshake
+	addr := p.GetAddr()
 	lenRWs := len(p.rws)
 
 	var wg sync.WaitGroup
@@ -11,6 +11,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	types "github.com/kaiachain/kaia/blockchain/types"
 	common "github.com/kaiachain/kaia/common"
+	consensus "github.com/kaiachain/kaia/consensus"
 	p2p "github.com/kaiachain/kaia/networks/p2p"
 	discover "github.com/kaiachain/kaia/networks/p2p/discover"
 	snap "github.com/kaiachain/kaia/node/cn/snap"
@@ -317,17 +318,17 @@ func (mr *MockPeerMockRecorder) Handle(arg0 interface{}) *gomock.Call {
 }
 
 // Handshake mocks base method
-func (m *MockPeer) Handshake(arg0 uint64, arg1, arg2 *big.Int, arg3, arg4 common.Hash) error {
+func (m *MockPeer) Handshake(arg0 uint64, arg1, arg2 *big.Int, arg3, arg4 common.Hash, arg5 consensus.NodeBinder) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Handshake", arg0, arg1, arg2, arg3, arg4)
+	ret := m.ctrl.Call(m, "Handshake", arg0, arg1, arg2, arg3, arg4, arg5)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Handshake indicates an expected call of Handshake
-func (mr *MockPeerMockRecorder) Handshake(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+func (mr *MockPeerMockRecorder) Handshake(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handshake", reflect.TypeOf((*MockPeer)(nil).Handshake), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handshake", reflect.TypeOf((*MockPeer)(nil).Handshake), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
 // Head mocks base method
@@ -415,6 +416,20 @@ func (mr *MockPeerMockRecorder) RegisterConsensusMsgCode(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterConsensusMsgCode", reflect.TypeOf((*MockPeer)(nil).RegisterConsensusMsgCode), arg0)
 }
 
+// RequestBlobSidecars mocks base method
+func (m *MockPeer) RequestBlobSidecars(arg0 []common.Hash) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestBlobSidecars", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestBlobSidecars indicates an expected call of RequestBlobSidecars
+func (mr *MockPeerMockRecorder) RequestBlobSidecars(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestBlobSidecars", reflect.TypeOf((*MockPeer)(nil).RequestBlobSidecars), arg0)
+}
+
 // RequestBodies mocks base method
 func (m *MockPeer) RequestBodies(arg0 []common.Hash) error {
 	m.ctrl.T.Helper()
@@ -527,6 +542,20 @@ func (mr *MockPeerMockRecorder) Send(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockPeer)(nil).Send), arg0, arg1)
 }
 
+// SendBlobSidecars mocks base method
+func (m *MockPeer) SendBlobSidecars(arg0 []*blobSidecarsData) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendBlobSidecars", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendBlobSidecars indicates an expected call of SendBlobSidecars
+func (mr *MockPeerMockRecorder) SendBlobSidecars(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendBlobSidecars", reflect.TypeOf((*MockPeer)(nil).SendBlobSidecars), arg0)
+}
+
 // SendBlockBodies mocks base method
 func (m *MockPeer) SendBlockBodies(arg0 []*blockBody) error {
 	m.ctrl.T.Helper()
@@ -639,6 +668,20 @@ func (mr *MockPeerMockRecorder) SendNodeData(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendNodeData", reflect.TypeOf((*MockPeer)(nil).SendNodeData), arg0)
 }
 
+// SendPrivateTransactions mocks base method
+func (m *MockPeer) SendPrivateTransactions(arg0 []*privateTxData) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPrivateTransactions", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendPrivateTransactions indicates an expected call of SendPrivateTransactions
+func (mr *MockPeerMockRecorder) SendPrivateTransactions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPrivateTransactions", reflect.TypeOf((*MockPeer)(nil).SendPrivateTransactions), arg0)
+}
+
 // SendReceiptsRLP mocks base method
 func (m *MockPeer) SendReceiptsRLP(arg0 []rlp.RawValue) error {
 	m.ctrl.T.Helper()
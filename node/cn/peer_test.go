@@ -19,14 +19,18 @@
 package cn
 
 import (
+	"bytes"
+	"errors"
 	"math/big"
 	"strings"
 	"testing"
 
 	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/consensus"
 	"github.com/kaiachain/kaia/networks/p2p"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var version = 63
@@ -166,6 +170,55 @@ func TestBasePeer_GetAndSetAddr(t *testing.T) {
 	assert.Equal(t, addrs[1], basePeer.GetAddr())
 }
 
+// testNodeBinder binds the p2p nodes with the signatures of their addresses in plain.
+type testNodeBinder struct {
+	binding *consensus.NodeBinding
+}
+
+func (b *testNodeBinder) NodeBinding() (*consensus.NodeBinding, error) {
+	return b.binding, nil
+}
+
+func (b *testNodeBinder) VerifyNodeBinding(node common.Address, binding *consensus.NodeBinding) (common.Address, error) {
+	if !bytes.Equal(binding.Signature, node.Bytes()) {
+		return common.Address{}, errors.New("invalid signature")
+	}
+	return binding.Address, nil
+}
+
+func TestBasePeer_Handshake_NodeBinding(t *testing.T) {
+	handshake := func(binding *consensus.NodeBinding) (Peer, error) {
+		pipe1, pipe2 := p2p.MsgPipe()
+		defer pipe1.Close()
+		// The node of p2pPeers[0] with the binding, and its peer seen by the node of p2pPeers[1]
+		local, remote := newPeer(kaia66, p2pPeers[1], pipe1), newPeer(kaia66, p2pPeers[0], pipe2)
+		remote.SetAddr(addrs[0])
+
+		errc := make(chan error, 1)
+		go func() {
+			errc <- local.Handshake(1, big.NewInt(1), big.NewInt(1), hash1, hash1, &testNodeBinder{binding})
+		}()
+		err := remote.Handshake(1, big.NewInt(1), big.NewInt(1), hash1, hash1, &testNodeBinder{})
+		<-errc
+		return remote, err
+	}
+
+	// The peer is identified by its consensus address
+	validator := common.HexToAddress("0x1234")
+	peer, err := handshake(&consensus.NodeBinding{Address: validator, Signature: addrs[0].Bytes()})
+	require.NoError(t, err)
+	assert.Equal(t, validator, peer.GetAddr())
+
+	// The peer without a binding is identified by its node key
+	peer, err = handshake(nil)
+	require.NoError(t, err)
+	assert.Equal(t, addrs[0], peer.GetAddr())
+
+	// The binding of another p2p node is rejected
+	_, err = handshake(&consensus.NodeBinding{Address: validator, Signature: addrs[1].Bytes()})
+	assert.Error(t, err)
+}
+
 func TestBasePeer_GetVersion(t *testing.T) {
 	basePeer, _, _ := newBasePeer()
 	assert.Equal(t, version, basePeer.GetVersion())
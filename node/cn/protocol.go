@@ -31,6 +31,7 @@ import (
 	"github.com/kaiachain/kaia"
 	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/consensus"
 	"github.com/kaiachain/kaia/datasync/downloader"
 	"github.com/kaiachain/kaia/datasync/fetcher"
 	"github.com/kaiachain/kaia/kaiax/staking"
@@ -43,6 +44,7 @@ import (
 const (
 	kaia63 = 63
 	kaia65 = 65
+	kaia66 = 66
 )
 
 const ProtocolMaxMsgSize = 12 * 1024 * 1024 // Maximum cap on the size of a protocol message
@@ -78,7 +80,12 @@ const (
 	StakingInfoRequestMsg = 0x12
 	StakingInfoMsg        = 0x13
 
-	MsgCodeEnd = 0x14
+	// Protocol messages belonging to kaia/66
+	PrivateTxMsg           = 0x14
+	BlobSidecarsRequestMsg = 0x15
+	BlobSidecarsMsg        = 0x16
+
+	MsgCodeEnd = 0x17
 )
 
 type errCode int
@@ -97,6 +104,7 @@ const (
 	ErrUnexpectedTxType
 	ErrFailedToGetStateDB
 	ErrUnsupportedEnginePolicy
+	ErrInvalidNodeBinding
 )
 
 func (e errCode) String() string {
@@ -118,6 +126,7 @@ var errorToString = map[int]string{
 	ErrUnexpectedTxType:        "Unexpected tx type",
 	ErrFailedToGetStateDB:      "Failed to get stateDB",
 	ErrUnsupportedEnginePolicy: "Unsupported engine or policy",
+	ErrInvalidNodeBinding:      "Invalid node binding",
 }
 
 // ProtocolManagerDownloader is an interface of downloader.Downloader used by ProtocolManager.
@@ -165,6 +174,8 @@ type statusData struct {
 	CurrentBlock    common.Hash
 	GenesisBlock    common.Hash
 	ChainID         *big.Int // ChainID to sign a transaction.
+
+	Validator *consensus.NodeBinding `rlp:"optional"` // Consensus address of the CN with a separate node key, since kaia/66
 }
 
 // newBlockHashesData is the network packet for the block announcements.
@@ -223,6 +234,21 @@ type newBlockData struct {
 	TD    *big.Int
 }
 
+// privateTxData is the network packet for the private transaction propagation
+// message. It carries the expiry block number, so that the receivers keep the
+// transaction away from endpoint nodes until it expires.
+type privateTxData struct {
+	Tx     *types.Transaction
+	Expiry uint64
+}
+
+// blobSidecarsData is the network packet for the blob sidecars of the
+// transactions in a block.
+type blobSidecarsData struct {
+	BlockHash common.Hash
+	Sidecars  []*types.BlobTxSidecarWithHash
+}
+
 // blockBody represents the data content of a single block.
 type blockBody struct {
 	Transactions []*types.Transaction // Transactions contained within a block
@@ -381,7 +381,7 @@ func ServiceGetStorageRangesQuery(chain SnapshotReader, req *GetStorageRangesPac
 				// TODO-Kaia-SnapSync it would be better to continue rather than return. Do not waste the completed job until now.
 				return nil, nil
 			}
-			stTrie, err := statedb.NewStorageTrie(pacc.GetStorageRoot(), chain.StateCache().TrieDB(), nil)
+			stTrie, err := statedb.NewStorageTrie(pacc.GetStorageRoot(), chain.StateCache().TrieDB(), &statedb.TrieOpts{Owner: accountHash})
 			if err != nil {
 				return nil, nil
 			}
@@ -493,7 +493,7 @@ func ServiceGetTrieNodesQuery(chain SnapshotReader, req *GetTrieNodesPacket, sta
 			if pacc == nil {
 				break
 			}
-			stTrie, err := statedb.NewSecureStorageTrie(pacc.GetStorageRoot(), triedb, nil)
+			stTrie, err := statedb.NewSecureStorageTrie(pacc.GetStorageRoot(), triedb, &statedb.TrieOpts{Owner: common.BytesToHash(pathset[0])})
 			loads++ // always account database reads, even for failures
 			if err != nil {
 				break
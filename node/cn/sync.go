@@ -56,6 +56,8 @@ func (pm *ProtocolManager) syncTransactions(p Peer) {
 	for _, batch := range pending {
 		txs = append(txs, batch...)
 	}
+	// Private transactions are only propagated with the private transaction message
+	txs, _ = pm.splitPrivateTxs(txs)
 	if len(txs) == 0 {
 		return
 	}
@@ -63,7 +65,7 @@ func (pm *ProtocolManager) syncTransactions(p Peer) {
 	if pm.blockchain != nil && pm.blockchain.CurrentHeader() != nil && pm.blockchain.CurrentHeader().BaseFee != nil {
 		baseFee = pm.blockchain.CurrentHeader().BaseFee
 	}
-	txs = types.SortTxsByPriceAndTime(txs, baseFee)
+	txs = types.SortTxsByPriceAndTime(pm.withBlobTxSidecars(txs), baseFee)
 	select {
 	case pm.txsyncCh <- &txsync{p, txs}:
 	case <-pm.quitSync:
@@ -359,6 +359,9 @@ func (n *Node) startRPC(services map[reflect.Type]Service) error {
 		n.stopInProc()
 		return err
 	}
+	if n.httpHandler != nil {
+		n.httpHandler.SetReadyCheck(readyCheck(services))
+	}
 	if err := n.startWS(n.wsEndpoint, apis, n.config.WSModules, n.config.WSOrigins, n.config.WSExposeAll); err != nil {
 		n.stopHTTP()
 		n.stopIPC()
@@ -379,6 +382,19 @@ func (n *Node) startRPC(services map[reflect.Type]Service) error {
 	return nil
 }
 
+// readyCheck returns a check joining the errors of the services implementing ReadyChecker.
+func readyCheck(services map[reflect.Type]Service) func() error {
+	return func() error {
+		var errs []error
+		for _, service := range services {
+			if checker, ok := service.(ReadyChecker); ok {
+				errs = append(errs, checker.ReadyCheck())
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
+
 // startInProc initializes an in-process RPC endpoint.
 func (n *Node) startInProc(apis []rpc.API) error {
 	// Register all the APIs exposed by the services
@@ -122,3 +122,10 @@ type Service interface {
 	// set components (blockchain, txpool, ..) in core service
 	SetComponents(components []interface{})
 }
+
+// ReadyChecker is implemented by the services reporting their readiness on the
+// /ready path of the HTTP endpoint.
+type ReadyChecker interface {
+	// ReadyCheck returns an error if the service is not ready to serve its role.
+	ReadyCheck() error
+}
@@ -217,6 +217,8 @@ type ChainConfig struct {
 	CancunCompatibleBlock    *big.Int `json:"cancunCompatibleBlock,omitempty"`    // CancunCompatible switch block (nil = no fork, 0 already on Cancun)
 	KaiaCompatibleBlock      *big.Int `json:"kaiaCompatibleBlock,omitempty"`      // KaiaCompatible switch block (nil = no fork, 0 already on Kaia)
 	PragueCompatibleBlock    *big.Int `json:"pragueCompatibleBlock,omitempty"`    // PragueCompatible switch block (nil = no fork)
+	BlobTxCompatibleBlock    *big.Int `json:"blobTxCompatibleBlock,omitempty"`    // BlobTxCompatible switch block (nil = no fork)
+	BlsSealCompatibleBlock   *big.Int `json:"blsSealCompatibleBlock,omitempty"`   // BlsSealCompatible switch block (nil = no fork)
 
 	// Kip103 is a special purpose hardfork feature that can be executed only once
 	// Both Kip103CompatibleBlock and Kip103ContractAddress should be specified to enable KIP103
@@ -282,6 +284,11 @@ type IstanbulConfig struct {
 	Epoch          uint64 `json:"epoch"`  // Epoch length to reset votes and checkpoint
 	ProposerPolicy uint64 `json:"policy"` // The policy for proposer selection; 0: Round Robin, 1: Sticky, 2: Weighted Random
 	SubGroupSize   uint64 `json:"sub"`
+
+	// Optional round change timeout and block interval. Zero to use the node-local values.
+	BaseTimeout    uint64 `json:"basetimeout,omitempty"`    // The timeout of the round 0 in milliseconds
+	TimeoutBackoff uint64 `json:"timeoutbackoff,omitempty"` // The unit of the extra timeout of the later rounds in milliseconds
+	BlockInterval  uint64 `json:"blockinterval,omitempty"`  // The target block interval in seconds
 }
 
 // RegistryConfig is the initial KIP-149 system contract registry states.
@@ -340,7 +347,7 @@ func (c *ChainConfig) String() string {
 	kip160 := fmt.Sprintf("KIP160CompatibleBlock: %v KIP160ContractAddress %s", c.Kip160CompatibleBlock, c.Kip160ContractAddress.String())
 
 	if c.Istanbul != nil {
-		return fmt.Sprintf("{ChainID: %v IstanbulCompatibleBlock: %v LondonCompatibleBlock: %v EthTxTypeCompatibleBlock: %v MagmaCompatibleBlock: %v KoreCompatibleBlock: %v ShanghaiCompatibleBlock: %v CancunCompatibleBlock: %v KaiaCompatibleBlock: %v RandaoCompatibleBlock: %v PragueCompatibleBlock: %v %s %s SubGroupSize: %d UnitPrice: %d DeriveShaImpl: %d Engine: %v}",
+		return fmt.Sprintf("{ChainID: %v IstanbulCompatibleBlock: %v LondonCompatibleBlock: %v EthTxTypeCompatibleBlock: %v MagmaCompatibleBlock: %v KoreCompatibleBlock: %v ShanghaiCompatibleBlock: %v CancunCompatibleBlock: %v KaiaCompatibleBlock: %v RandaoCompatibleBlock: %v PragueCompatibleBlock: %v BlobTxCompatibleBlock: %v BlsSealCompatibleBlock: %v %s %s SubGroupSize: %d UnitPrice: %d DeriveShaImpl: %d Engine: %v}",
 			c.ChainID,
 			c.IstanbulCompatibleBlock,
 			c.LondonCompatibleBlock,
@@ -352,6 +359,8 @@ func (c *ChainConfig) String() string {
 			c.KaiaCompatibleBlock,
 			c.RandaoCompatibleBlock,
 			c.PragueCompatibleBlock,
+			c.BlobTxCompatibleBlock,
+			c.BlsSealCompatibleBlock,
 			kip103,
 			kip160,
 			c.Istanbul.SubGroupSize,
@@ -360,7 +369,7 @@ func (c *ChainConfig) String() string {
 			engine,
 		)
 	} else {
-		return fmt.Sprintf("{ChainID: %v IstanbulCompatibleBlock: %v LondonCompatibleBlock: %v EthTxTypeCompatibleBlock: %v MagmaCompatibleBlock: %v KoreCompatibleBlock: %v ShanghaiCompatibleBlock: %v CancunCompatibleBlock: %v KaiaCompatibleBlock: %v RandaoCompatibleBlock: %v PragueCompatibleBlock: %v %s %s UnitPrice: %d DeriveShaImpl: %d Engine: %v }",
+		return fmt.Sprintf("{ChainID: %v IstanbulCompatibleBlock: %v LondonCompatibleBlock: %v EthTxTypeCompatibleBlock: %v MagmaCompatibleBlock: %v KoreCompatibleBlock: %v ShanghaiCompatibleBlock: %v CancunCompatibleBlock: %v KaiaCompatibleBlock: %v RandaoCompatibleBlock: %v PragueCompatibleBlock: %v BlobTxCompatibleBlock: %v BlsSealCompatibleBlock: %v %s %s UnitPrice: %d DeriveShaImpl: %d Engine: %v }",
 			c.ChainID,
 			c.IstanbulCompatibleBlock,
 			c.LondonCompatibleBlock,
@@ -372,6 +381,8 @@ func (c *ChainConfig) String() string {
 			c.KaiaCompatibleBlock,
 			c.RandaoCompatibleBlock,
 			c.PragueCompatibleBlock,
+			c.BlobTxCompatibleBlock,
+			c.BlsSealCompatibleBlock,
 			kip103,
 			kip160,
 			c.UnitPrice,
@@ -438,6 +449,16 @@ func (c *ChainConfig) IsPragueForkEnabled(num *big.Int) bool {
 	return isForked(c.PragueCompatibleBlock, num)
 }
 
+// IsBlobTxForkEnabled returns whether num is either equal to the blobTx block or greater.
+func (c *ChainConfig) IsBlobTxForkEnabled(num *big.Int) bool {
+	return isForked(c.BlobTxCompatibleBlock, num)
+}
+
+// IsBlsSealForkEnabled returns whether num is either equal to the blsSeal block or greater.
+func (c *ChainConfig) IsBlsSealForkEnabled(num *big.Int) bool {
+	return isForked(c.BlsSealCompatibleBlock, num)
+}
+
 // IsKIP103ForkBlock returns whether num is equal to the kip103 block.
 func (c *ChainConfig) IsKIP103ForkBlock(num *big.Int) bool {
 	return isForkBlock(c.Kip103CompatibleBlock, num)
@@ -501,6 +522,8 @@ func (c *ChainConfig) CheckConfigForkOrder() error {
 		{name: "randaoBlock", block: c.RandaoCompatibleBlock, optional: true},
 		{name: "kaiaBlock", block: c.KaiaCompatibleBlock},
 		{name: "pragueBlock", block: c.PragueCompatibleBlock},
+		{name: "blobTxBlock", block: c.BlobTxCompatibleBlock, optional: true},
+		{name: "blsSealBlock", block: c.BlsSealCompatibleBlock, optional: true},
 	} {
 		if lastFork.name != "" {
 			// Next one must be higher number
@@ -520,6 +543,16 @@ func (c *ChainConfig) CheckConfigForkOrder() error {
 			lastFork = cur
 		}
 	}
+	// The blob gas fields follow the Randao fields in the header, which are thus encoded even if empty
+	if c.BlobTxCompatibleBlock != nil && (c.RandaoCompatibleBlock == nil || c.RandaoCompatibleBlock.Cmp(c.BlobTxCompatibleBlock) > 0) {
+		return fmt.Errorf("unsupported fork ordering: randaoBlock enabled at %v, but blobTxBlock enabled at %v",
+			c.RandaoCompatibleBlock, c.BlobTxCompatibleBlock)
+	}
+	// The BLS committed seals are verified with the BLS public keys registered since Randao
+	if c.BlsSealCompatibleBlock != nil && (c.RandaoCompatibleBlock == nil || c.RandaoCompatibleBlock.Cmp(c.BlsSealCompatibleBlock) > 0) {
+		return fmt.Errorf("unsupported fork ordering: randaoBlock enabled at %v, but blsSealBlock enabled at %v",
+			c.RandaoCompatibleBlock, c.BlsSealCompatibleBlock)
+	}
 	return nil
 }
 
@@ -556,6 +589,12 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, head *big.Int) *Confi
 	if isForkIncompatible(c.PragueCompatibleBlock, newcfg.PragueCompatibleBlock, head) {
 		return newCompatError("Prague Block", c.PragueCompatibleBlock, newcfg.PragueCompatibleBlock)
 	}
+	if isForkIncompatible(c.BlobTxCompatibleBlock, newcfg.BlobTxCompatibleBlock, head) {
+		return newCompatError("BlobTx Block", c.BlobTxCompatibleBlock, newcfg.BlobTxCompatibleBlock)
+	}
+	if isForkIncompatible(c.BlsSealCompatibleBlock, newcfg.BlsSealCompatibleBlock, head) {
+		return newCompatError("BlsSeal Block", c.BlsSealCompatibleBlock, newcfg.BlsSealCompatibleBlock)
+	}
 	return nil
 }
 
@@ -695,6 +734,7 @@ type Rules struct {
 	IsKaia      bool
 	IsRandao    bool
 	IsPrague    bool
+	IsBlobTx    bool
 }
 
 // Rules ensures c's ChainID is not nil.
@@ -715,6 +755,7 @@ func (c *ChainConfig) Rules(num *big.Int) Rules {
 		IsKaia:      c.IsKaiaForkEnabled(num),
 		IsRandao:    c.IsRandaoForkEnabled(num),
 		IsPrague:    c.IsPragueForkEnabled(num),
+		IsBlobTx:    c.IsBlobTxForkEnabled(num),
 	}
 }
 
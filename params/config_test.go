@@ -30,6 +30,48 @@ func TestChainConfig_CheckConfigForkOrder(t *testing.T) {
 	assert.Nil(t, MainnetChainConfig.CheckConfigForkOrder())
 }
 
+func TestChainConfig_CheckConfigForkOrder_BlsSeal(t *testing.T) {
+	config := &ChainConfig{
+		IstanbulCompatibleBlock:  big.NewInt(0),
+		LondonCompatibleBlock:    big.NewInt(0),
+		EthTxTypeCompatibleBlock: big.NewInt(0),
+		MagmaCompatibleBlock:     big.NewInt(0),
+		KoreCompatibleBlock:      big.NewInt(0),
+		ShanghaiCompatibleBlock:  big.NewInt(0),
+		CancunCompatibleBlock:    big.NewInt(0),
+		RandaoCompatibleBlock:    big.NewInt(0),
+		KaiaCompatibleBlock:      big.NewInt(0),
+		PragueCompatibleBlock:    big.NewInt(0),
+		BlsSealCompatibleBlock:   big.NewInt(10),
+	}
+	assert.Nil(t, config.CheckConfigForkOrder())
+
+	// The BLS committed seals require the BLS public keys registered since Randao.
+	config.RandaoCompatibleBlock = nil
+	assert.NotNil(t, config.CheckConfigForkOrder())
+}
+
+func TestChainConfig_CheckConfigForkOrder_BlobTx(t *testing.T) {
+	config := &ChainConfig{
+		IstanbulCompatibleBlock:  big.NewInt(0),
+		LondonCompatibleBlock:    big.NewInt(0),
+		EthTxTypeCompatibleBlock: big.NewInt(0),
+		MagmaCompatibleBlock:     big.NewInt(0),
+		KoreCompatibleBlock:      big.NewInt(0),
+		ShanghaiCompatibleBlock:  big.NewInt(0),
+		CancunCompatibleBlock:    big.NewInt(0),
+		RandaoCompatibleBlock:    big.NewInt(10),
+		KaiaCompatibleBlock:      big.NewInt(10),
+		PragueCompatibleBlock:    big.NewInt(10),
+		BlobTxCompatibleBlock:    big.NewInt(10),
+	}
+	assert.Nil(t, config.CheckConfigForkOrder())
+
+	// The blob gas fields of the header require the Randao fields before them.
+	config.RandaoCompatibleBlock = nil
+	assert.NotNil(t, config.CheckConfigForkOrder())
+}
+
 func TestChainConfig_Copy(t *testing.T) {
 	// Temporarily modify MainnetChainConfig to simulate copying `nil` field.
 	savedBlock := MainnetChainConfig.LondonCompatibleBlock
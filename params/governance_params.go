@@ -70,6 +70,9 @@ const (
 	GovParamContract
 	Kip82Ratio
 	DeriveShaImpl
+	BaseTimeout
+	TimeoutBackoff
+	BlockInterval
 )
 
 const (
@@ -256,6 +256,9 @@ var govParamTypes = map[int]*govParamType{
 	BaseFeeDenominator:        govParamTypeUint64,
 	GovParamContract:          govParamTypeAddress,
 	DeriveShaImpl:             govParamTypeUint64,
+	BaseTimeout:               govParamTypeUint64,
+	TimeoutBackoff:            govParamTypeUint64,
+	BlockInterval:             govParamTypeUint64,
 }
 
 var govParamNames = map[string]int{
@@ -280,6 +283,9 @@ var govParamNames = map[string]int{
 	"kip71.maxblockgasusedforbasefee": MaxBlockGasUsedForBaseFee,
 	"kip71.basefeedenominator":        BaseFeeDenominator,
 	"governance.deriveshaimpl":        DeriveShaImpl,
+	"istanbul.basetimeout":            BaseTimeout,
+	"istanbul.timeoutbackoff":         TimeoutBackoff,
+	"istanbul.blockinterval":          BlockInterval,
 }
 
 var govParamNamesReverse = map[int]string{}
@@ -158,6 +158,16 @@ const (
 	MaxCodeSize     = 24576           // Maximum bytecode to permit for a contract
 	MaxInitCodeSize = 2 * MaxCodeSize // Maximum initcode to permit in a creation transaction and create instructions
 
+	// eip-4844: shard blob transactions (BlobTx)
+	BlobTxBytesPerFieldElement       = 32                       // Size in bytes of a field element
+	BlobTxFieldElementsPerBlob       = 4096                     // Number of field elements stored in a single data blob
+	BlobTxBlobGasPerBlob             = 1 << 17                  // Gas consumption of a single data blob (== blob byte size)
+	BlobTxMinBlobGasprice            = 1                        // Minimum gas price for data blobs
+	BlobTxBlobGaspriceUpdateFraction = 3338477                  // Controls the maximum rate of change for blob gas price
+	BlobTxTargetBlobGasPerBlock      = 3 * BlobTxBlobGasPerBlob // Target consumable blob gas for data blobs per block (for 1559-like pricing)
+	MaxBlobGasPerBlock               = 6 * BlobTxBlobGasPerBlob // Maximum consumable blob gas for data blobs per block
+	BlobTxHashVersion                = 0x01                     // Version byte of the commitment hash
+
 	// istanbul BFT
 	BFTMaximumExtraDataSize uint64 = 65 // Maximum size extra data may be after Genesis.
 
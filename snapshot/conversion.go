@@ -24,12 +24,14 @@ package snapshot
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"math"
 	"runtime"
 	"sync"
 	"time"
 
+	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/blockchain/types/account"
 	"github.com/kaiachain/kaia/common"
 	"github.com/kaiachain/kaia/rlp"
@@ -53,7 +55,46 @@ type (
 	leafCallbackFn func(accountHash, codeHash common.Hash, stat *generateStats) (common.Hash, error)
 )
 
-// TODO-Kaia-Snapshot port GenerateAccountTrieRoot/GenerateStorageTrieRoot/GenerateTrie
+// TODO-Kaia-Snapshot port GenerateAccountTrieRoot/GenerateStorageTrieRoot
+
+// GenerateTrie takes the whole snapshot tree as the input, traverses all the
+// accounts as well as the corresponding storages and regenerate the whole state
+// (account trie + all storage tries). The regenerated trie nodes and the contract
+// codes are written into dst, and the codes are read from src.
+func GenerateTrie(snaptree *Tree, root common.Hash, src database.DBManager, dst database.DBManager) error {
+	// Traverse all state by snapshot, re-generate the whole state trie
+	acctIt, err := snaptree.AccountIterator(root, common.Hash{})
+	if err != nil {
+		return err // The required snapshot might not exist.
+	}
+	defer acctIt.Release()
+
+	got, err := generateTrieRoot(acctIt, common.Hash{}, stackTrieGenerate(dst), func(accountHash, codeHash common.Hash, stat *generateStats) (common.Hash, error) {
+		// Migrate the code first, commit the contract code into the dst db.
+		if codeHash != types.EmptyCodeHash {
+			code := src.ReadCode(codeHash)
+			if len(code) == 0 {
+				return common.Hash{}, errors.New("failed to read contract code")
+			}
+			dst.WriteCode(codeHash, code)
+		}
+		// Then migrate all storage trie nodes into the dst db.
+		storageIt, err := snaptree.StorageIterator(root, accountHash, common.Hash{})
+		if err != nil {
+			return common.Hash{}, err
+		}
+		defer storageIt.Release()
+
+		return generateTrieRoot(storageIt, accountHash, stackTrieGenerate(dst), nil, stat, false)
+	}, newGenerateStats(), true)
+	if err != nil {
+		return err
+	}
+	if got != root {
+		return fmt.Errorf("state root hash mismatch: got %x, want %x", got, root)
+	}
+	return nil
+}
 
 // generateStats is a collection of statistics gathered by the trie generator
 // for logging purposes.
@@ -331,3 +372,21 @@ func trieGenerate(in chan trieKV, out chan common.Hash) {
 	}
 	out <- root
 }
+
+// stackTrieGenerate returns a trie generator which commits the trie nodes into the given db.
+// Unlike trieGenerate, the nodes are not kept in memory, so the whole state can be regenerated.
+func stackTrieGenerate(db database.DBManager) trieGeneratorFn {
+	return func(in chan trieKV, out chan common.Hash) {
+		t := statedb.NewStackTrie(db)
+		for leaf := range in {
+			t.TryUpdate(leaf.key[:], leaf.value)
+		}
+		var root common.Hash
+		if db == nil {
+			root = t.Hash()
+		} else {
+			root, _ = t.Commit()
+		}
+		out <- root
+	}
+}
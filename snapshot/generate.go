@@ -243,6 +243,14 @@ func (result *proofResult) forEach(callback func(key []byte, val []byte) error)
 	return nil
 }
 
+// trieOwner returns the owner of the trie of the snapshot prefix, which is the account hash for a storage trie.
+func trieOwner(prefix []byte) common.Hash {
+	if len(prefix) == len(database.SnapshotStoragePrefix)+common.HashLength && bytes.HasPrefix(prefix, database.SnapshotStoragePrefix) {
+		return common.BytesToHash(prefix[len(database.SnapshotStoragePrefix):])
+	}
+	return common.Hash{}
+}
+
 // proveRange proves the snapshot segment with particular prefix is "valid".
 // The iteration start point will be assigned if the iterator is restored from
 // the last interruption. Max will be assigned in order to limit the maximum
@@ -327,7 +335,7 @@ func (dl *diskLayer) proveRange(stats *generatorStats, root common.Hash, prefix
 		return &proofResult{keys: keys, vals: vals}, nil
 	}
 	// Snap state is chunked, generate edge proofs for verification.
-	tr, err := statedb.NewTrie(root, dl.triedb, nil)
+	tr, err := statedb.NewTrie(root, dl.triedb, &statedb.TrieOpts{Owner: trieOwner(prefix)})
 	if err != nil {
 		stats.Log("Trie missing, state snapshotting paused", dl.root, dl.genMarker)
 		return nil, errMissingTrie
@@ -450,7 +458,7 @@ func (dl *diskLayer) generateRange(root common.Hash, prefix []byte, kind string,
 	}
 	tr := result.tr
 	if tr == nil {
-		tr, err = statedb.NewTrie(root, dl.triedb, nil)
+		tr, err = statedb.NewTrie(root, dl.triedb, &statedb.TrieOpts{Owner: trieOwner(prefix)})
 		if err != nil {
 			stats.Log("Trie missing, state snapshotting paused", dl.root, dl.genMarker)
 			return false, nil, errMissingTrie
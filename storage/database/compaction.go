@@ -0,0 +1,370 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+)
+
+var (
+	errCompactionRunning    = errors.New("compaction is already running")
+	errCompactionNotRunning = errors.New("compaction is not running")
+	errCompactionCanceled   = errors.New("compaction canceled")
+	errCompactionStopped    = errors.New("compaction scheduler is stopped")
+)
+
+// periodicCompactionCheckInterval is the interval to check whether a periodic compaction should be triggered.
+const periodicCompactionCheckInterval = 5 * time.Minute
+
+// CompactionConfig configures the CompactionScheduler.
+type CompactionConfig struct {
+	Interval    time.Duration // Minimum interval between periodic compactions. Periodic compaction is disabled if zero.
+	WindowStart int           // Start hour (UTC, inclusive) of the low-load window in which periodic compaction starts.
+	WindowEnd   int           // End hour (UTC, exclusive) of the low-load window. The window is the whole day if it equals WindowStart.
+	Throttle    time.Duration // Pause between the compactions of two consecutive key ranges.
+	Entries     []DBEntryType // Databases compacted by periodic compaction. All databases if empty.
+}
+
+// GetDefaultCompactionConfig returns the default compaction config, which disables periodic compaction.
+func GetDefaultCompactionConfig() *CompactionConfig {
+	return &CompactionConfig{
+		Interval:    0,
+		WindowStart: 2,
+		WindowEnd:   6,
+		Throttle:    time.Second,
+	}
+}
+
+// inWindow reports whether the given time is in the low-load window.
+func (c *CompactionConfig) inWindow(t time.Time) bool {
+	hour := t.UTC().Hour()
+	switch {
+	case c.WindowStart == c.WindowEnd:
+		return true
+	case c.WindowStart < c.WindowEnd:
+		return c.WindowStart <= hour && hour < c.WindowEnd
+	default: // the window wraps around midnight (e.g. 22-4)
+		return c.WindowStart <= hour || hour < c.WindowEnd
+	}
+}
+
+// CompactionProgress reports the status of the running or the last compaction.
+type CompactionProgress struct {
+	Running    bool      `json:"running"`
+	Periodic   bool      `json:"periodic"`
+	Entry      string    `json:"entry"`     // Database entry being compacted
+	Range      string    `json:"range"`     // Key range being compacted
+	Total      int       `json:"total"`     // Number of key ranges to compact
+	Compacted  int       `json:"compacted"` // Number of key ranges compacted
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// compactionTask is a unit of compaction, a key range of a database (or a shard).
+type compactionTask struct {
+	entry DBEntryType
+	db    Database
+	start []byte
+	limit []byte
+}
+
+// CompactionScheduler compacts the databases per DBEntryType and per key range.
+// Ranges are compacted one by one with a pause in between, so that the node keeps
+// serving while compacting. A compaction can be triggered manually or periodically
+// within the configured low-load window, and can be canceled at any time.
+type CompactionScheduler struct {
+	dbm    DBManager
+	config CompactionConfig
+
+	mu       sync.Mutex
+	progress CompactionProgress
+	cancelCh chan struct{} // closed to cancel the running compaction
+	lastRun  time.Time     // start time of the last periodic compaction
+	stopped  bool          // set by Stop; no compaction is started afterwards
+
+	quitCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewCompactionScheduler returns a CompactionScheduler of the given database manager.
+func NewCompactionScheduler(dbm DBManager, config CompactionConfig) *CompactionScheduler {
+	return &CompactionScheduler{
+		dbm:     dbm,
+		config:  config,
+		lastRun: time.Now(),
+		quitCh:  make(chan struct{}),
+	}
+}
+
+// Start starts the periodic compaction loop if the interval is configured.
+func (cs *CompactionScheduler) Start() {
+	if cs.config.Interval == 0 {
+		return
+	}
+	logger.Info("Periodic database compaction is enabled", "interval", cs.config.Interval,
+		"window", fmt.Sprintf("%02d-%02d UTC", cs.config.WindowStart, cs.config.WindowEnd), "throttle", cs.config.Throttle)
+
+	cs.wg.Add(1)
+	go cs.loop()
+}
+
+// Stop cancels the running compaction and terminates the periodic compaction loop.
+// It is safe to call Stop more than once.
+func (cs *CompactionScheduler) Stop() {
+	cs.stopOnce.Do(func() {
+		cs.mu.Lock()
+		cs.stopped = true
+		cs.mu.Unlock()
+
+		close(cs.quitCh)
+		cs.Cancel()
+	})
+	cs.wg.Wait()
+}
+
+func (cs *CompactionScheduler) loop() {
+	defer cs.wg.Done()
+
+	ticker := time.NewTicker(periodicCompactionCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			cs.mu.Lock()
+			due := now.Sub(cs.lastRun) >= cs.config.Interval && cs.config.inWindow(now)
+			cs.mu.Unlock()
+			if !due {
+				continue
+			}
+			if err := cs.compact(cs.config.Entries, nil, nil, true); err != nil && err != errCompactionRunning {
+				logger.Error("Failed to start periodic database compaction", "err", err)
+			}
+		case <-cs.quitCh:
+			return
+		}
+	}
+}
+
+// Compact starts compacting the key range [start, limit) of the given databases in background.
+// All databases are compacted if entries is empty, and the whole key space is compacted if
+// both start and limit are nil. Use Progress to check the status.
+func (cs *CompactionScheduler) Compact(entries []DBEntryType, start, limit []byte) error {
+	return cs.compact(entries, start, limit, false)
+}
+
+func (cs *CompactionScheduler) compact(entries []DBEntryType, start, limit []byte, periodic bool) error {
+	tasks, err := cs.makeTasks(entries, start, limit)
+	if err != nil {
+		return err
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if cs.stopped {
+		return errCompactionStopped
+	}
+	if cs.progress.Running {
+		return errCompactionRunning
+	}
+	cs.cancelCh = make(chan struct{})
+	cs.progress = CompactionProgress{
+		Running:   true,
+		Periodic:  periodic,
+		Total:     len(tasks),
+		StartedAt: time.Now(),
+	}
+	if periodic {
+		cs.lastRun = cs.progress.StartedAt
+	}
+
+	cs.wg.Add(1)
+	go cs.run(tasks, cs.cancelCh)
+	return nil
+}
+
+// Cancel cancels the running compaction. The key range being compacted is completed before canceling.
+func (cs *CompactionScheduler) Cancel() error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if !cs.progress.Running {
+		return errCompactionNotRunning
+	}
+	select {
+	case <-cs.cancelCh:
+	default:
+		close(cs.cancelCh)
+	}
+	return nil
+}
+
+// Progress returns the status of the running or the last compaction.
+func (cs *CompactionScheduler) Progress() CompactionProgress {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	return cs.progress
+}
+
+func (cs *CompactionScheduler) run(tasks []compactionTask, cancelCh chan struct{}) {
+	defer cs.wg.Done()
+
+	err := cs.runTasks(tasks, cancelCh)
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	cs.progress.Running = false
+	cs.progress.FinishedAt = time.Now()
+	elapsed := common.PrettyDuration(cs.progress.FinishedAt.Sub(cs.progress.StartedAt))
+	if err != nil {
+		cs.progress.Error = err.Error()
+		logger.Warn("Database compaction stopped", "compacted", cs.progress.Compacted, "total", cs.progress.Total, "elapsed", elapsed, "err", err)
+	} else {
+		logger.Info("Database compaction completed", "total", cs.progress.Total, "elapsed", elapsed)
+	}
+}
+
+func (cs *CompactionScheduler) runTasks(tasks []compactionTask, cancelCh chan struct{}) error {
+	for i, task := range tasks {
+		if i > 0 && cs.config.Throttle > 0 {
+			select {
+			case <-time.After(cs.config.Throttle):
+			case <-cancelCh:
+				return errCompactionCanceled
+			}
+		}
+		select {
+		case <-cancelCh:
+			return errCompactionCanceled
+		default:
+		}
+
+		rangeStr := fmt.Sprintf("%s-%s", hexutil.Encode(task.start), hexutil.Encode(task.limit))
+		cs.mu.Lock()
+		cs.progress.Entry, cs.progress.Range = task.entry.String(), rangeStr
+		cs.mu.Unlock()
+
+		cstart := time.Now()
+		if err := task.db.Compact(task.start, task.limit); err != nil {
+			return fmt.Errorf("failed to compact %s (range: %s): %w", task.entry, rangeStr, err)
+		}
+		logger.Debug("Compacted database range", "entry", task.entry, "range", rangeStr, "elapsed", common.PrettyDuration(time.Since(cstart)))
+
+		cs.mu.Lock()
+		cs.progress.Compacted++
+		cs.mu.Unlock()
+	}
+	return nil
+}
+
+// makeTasks splits the compaction of the given databases into tasks per shard and per key range.
+// A database shared by several entries (e.g. single DB) is compacted only once.
+func (cs *CompactionScheduler) makeTasks(entries []DBEntryType, start, limit []byte) ([]compactionTask, error) {
+	if len(entries) == 0 {
+		for et := MiscDB; et < databaseEntryTypeSize; et++ {
+			entries = append(entries, et)
+		}
+	}
+	// An empty start or limit is the same as nil, i.e. the beginning or the end of the key space.
+	if len(start) == 0 {
+		start = nil
+	}
+	if len(limit) == 0 {
+		limit = nil
+	}
+	if limit != nil && start != nil && string(start) >= string(limit) {
+		return nil, fmt.Errorf("invalid compaction range: start %s >= limit %s", hexutil.Encode(start), hexutil.Encode(limit))
+	}
+
+	var (
+		tasks []compactionTask
+		seen  []Database
+	)
+	for _, et := range entries {
+		db := cs.dbm.GetDatabase(et)
+		if db == nil || containsDatabase(seen, db) {
+			continue
+		}
+		seen = append(seen, db)
+
+		for _, shard := range shardsOf(db) {
+			for _, r := range splitCompactionRange(start, limit) {
+				tasks = append(tasks, compactionTask{entry: et, db: shard, start: r[0], limit: r[1]})
+			}
+		}
+	}
+	return tasks, nil
+}
+
+func containsDatabase(dbs []Database, db Database) bool {
+	for _, d := range dbs {
+		if d == db {
+			return true
+		}
+	}
+	return false
+}
+
+// shardsOf returns the shards of the database, or the database itself if it is not sharded.
+func shardsOf(db Database) []Database {
+	if sdb, ok := db.(*shardedDB); ok {
+		return sdb.shards
+	}
+	return []Database{db}
+}
+
+// splitCompactionRange splits [start, limit) by the first byte of the keys.
+// A nil start means the beginning of the key space, and a nil limit means the end.
+func splitCompactionRange(start, limit []byte) [][2][]byte {
+	first, last := 0, 255
+	if len(start) > 0 {
+		first = int(start[0])
+	}
+	if len(limit) > 0 {
+		last = int(limit[0])
+	}
+
+	var ranges [][2][]byte
+	for b := first; b <= last; b++ {
+		s, e := []byte{byte(b)}, []byte{byte(b + 1)}
+		if b == 255 {
+			e = nil
+		}
+		if b == first {
+			s = start
+		}
+		if b == last && limit != nil {
+			if len(limit) == 1 && b > first {
+				break // [limit[0], limit) is empty
+			}
+			e = limit
+		}
+		ranges = append(ranges, [2][]byte{s, e})
+	}
+	return ranges
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitCompactionRange(t *testing.T) {
+	ranges := splitCompactionRange(nil, nil)
+	assert.Equal(t, 256, len(ranges))
+	assert.Nil(t, ranges[0][0])
+	assert.Equal(t, []byte{0x01}, ranges[0][1])
+	assert.Equal(t, []byte{0xff}, ranges[255][0])
+	assert.Nil(t, ranges[255][1])
+
+	ranges = splitCompactionRange([]byte{0x10, 0x01}, []byte{0x12})
+	assert.Equal(t, [][2][]byte{
+		{{0x10, 0x01}, {0x11}},
+		{{0x11}, {0x12}},
+	}, ranges)
+
+	ranges = splitCompactionRange([]byte{0x10, 0x01}, []byte{0x10, 0x05})
+	assert.Equal(t, [][2][]byte{{{0x10, 0x01}, {0x10, 0x05}}}, ranges)
+}
+
+func TestCompactionConfig_InWindow(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2024, 1, 1, hour, 30, 0, 0, time.UTC) }
+
+	c := CompactionConfig{WindowStart: 2, WindowEnd: 5}
+	assert.False(t, c.inWindow(at(1)))
+	assert.True(t, c.inWindow(at(2)))
+	assert.True(t, c.inWindow(at(4)))
+	assert.False(t, c.inWindow(at(5)))
+
+	c = CompactionConfig{WindowStart: 22, WindowEnd: 3}
+	assert.True(t, c.inWindow(at(23)))
+	assert.True(t, c.inWindow(at(0)))
+	assert.False(t, c.inWindow(at(3)))
+	assert.False(t, c.inWindow(at(12)))
+
+	c = CompactionConfig{}
+	assert.True(t, c.inWindow(at(12)))
+}
+
+func TestCompactionScheduler_Compact(t *testing.T) {
+	cs := NewCompactionScheduler(NewMemoryDBManager(), CompactionConfig{})
+	defer cs.Stop()
+
+	// All entries share a single memory database, so it is compacted only once.
+	assert.NoError(t, cs.Compact(nil, nil, nil))
+	assert.Eventually(t, func() bool { return !cs.Progress().Running }, time.Second, 10*time.Millisecond)
+
+	progress := cs.Progress()
+	assert.Equal(t, 256, progress.Total)
+	assert.Equal(t, 256, progress.Compacted)
+	assert.Empty(t, progress.Error)
+
+	assert.Error(t, cs.Compact(nil, []byte{0x02}, []byte{0x01}))
+	assert.Equal(t, errCompactionNotRunning, cs.Cancel())
+}
+
+func TestCompactionScheduler_Cancel(t *testing.T) {
+	cs := NewCompactionScheduler(NewMemoryDBManager(), CompactionConfig{Throttle: time.Minute})
+	defer cs.Stop()
+
+	assert.NoError(t, cs.Compact([]DBEntryType{StateTrieDB}, nil, nil))
+	assert.Equal(t, errCompactionRunning, cs.Compact(nil, nil, nil))
+	assert.Eventually(t, func() bool { return cs.Progress().Compacted == 1 }, time.Second, 10*time.Millisecond)
+
+	assert.NoError(t, cs.Cancel())
+	assert.Eventually(t, func() bool { return !cs.Progress().Running }, time.Second, 10*time.Millisecond)
+
+	progress := cs.Progress()
+	assert.Equal(t, 1, progress.Compacted)
+	assert.Equal(t, errCompactionCanceled.Error(), progress.Error)
+}
+
+func TestCompactionScheduler_Stop(t *testing.T) {
+	cs := NewCompactionScheduler(NewMemoryDBManager(), CompactionConfig{Throttle: time.Minute})
+
+	// An empty limit means the end of the key space.
+	assert.NoError(t, cs.Compact(nil, []byte{0xff}, []byte{}))
+
+	cs.Stop()
+	cs.Stop()
+	assert.False(t, cs.Progress().Running)
+	assert.Equal(t, errCompactionStopped, cs.Compact(nil, nil, nil))
+}
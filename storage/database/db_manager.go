@@ -41,6 +41,12 @@ import (
 	"github.com/syndtr/goleveldb/leveldb"
 )
 
+// The storage schemes of the state trie nodes.
+const (
+	HashScheme = "hash" // trie nodes are keyed by their hashes
+	PathScheme = "path" // trie nodes are keyed by their owners and paths
+)
+
 var (
 	logger = log.NewModuleLogger(log.StorageDatabase)
 
@@ -69,6 +75,7 @@ type DBManager interface {
 	GetMemDB() *MemDB
 	GetDBConfig() *DBConfig
 	getDatabase(DBEntryType) Database
+	GetDatabase(DBEntryType) Database
 	CreateMigrationDBAndSetStatus(blockNum uint64) error
 	FinishStateMigration(succeed bool) chan struct{}
 	GetStateTrieDB() Database
@@ -123,6 +130,10 @@ type DBManager interface {
 	PutReceiptsToBatch(batch Batch, hash common.Hash, number uint64, receipts types.Receipts)
 	DeleteReceipts(hash common.Hash, number uint64)
 
+	ReadBlobSidecars(hash common.Hash, number uint64) []*types.BlobTxSidecarWithHash
+	WriteBlobSidecars(hash common.Hash, number uint64, sidecars []*types.BlobTxSidecarWithHash)
+	PruneBlobSidecars(limit uint64) int
+
 	ReadBlock(hash common.Hash, number uint64) *types.Block
 	ReadBlockByHash(hash common.Hash) *types.Block
 	ReadBlockByNumber(number uint64) *types.Block
@@ -187,6 +198,21 @@ type DBManager interface {
 	WriteLastPrunedBlockNumber(blockNumber uint64)
 	ReadLastPrunedBlockNumber() (uint64, error)
 
+	// Path-based state scheme
+	ReadStateScheme() string
+	WriteStateScheme(scheme string)
+	ReadAccountTrieNode(path []byte) []byte
+	ReadStorageTrieNode(owner common.Hash, path []byte) []byte
+	PutAccountTrieNodeToBatch(batch Batch, path []byte, node []byte)
+	PutStorageTrieNodeToBatch(batch Batch, owner common.Hash, path []byte, node []byte)
+	DeleteAccountTrieNodeFromBatch(batch Batch, path []byte)
+	DeleteStorageTrieNodeFromBatch(batch Batch, owner common.Hash, path []byte)
+	ReadPersistentStateID() uint64
+	WritePersistentStateID(id uint64)
+	ReadTrieHistory(id uint64) []byte
+	WriteTrieHistory(id uint64, blob []byte)
+	DeleteTrieHistory(id uint64)
+
 	// from accessors_indexes.go
 	ReadTxLookupEntry(hash common.Hash) (common.Hash, uint64, uint64)
 	WriteTxLookupEntries(block *types.Block)
@@ -348,6 +374,17 @@ func (et DBEntryType) String() string {
 	return dbBaseDirs[et]
 }
 
+// ParseDBEntryType returns the DBEntryType whose base directory name matches the given name.
+// The comparison is case-insensitive (e.g. "statetrie", "StateTrie").
+func ParseDBEntryType(name string) (DBEntryType, error) {
+	for et, dir := range dbBaseDirs {
+		if strings.EqualFold(dir, name) {
+			return DBEntryType(et), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown database entry type %q (available: %s)", name, strings.Join(dbBaseDirs[:], ", "))
+}
+
 const (
 	notInMigrationFlag = 0
 	inMigrationFlag    = 1
@@ -458,6 +495,7 @@ type DBConfig struct {
 	ParallelDBWrite     bool
 	OpenFilesLimit      int
 	EnableDBPerfMetrics bool // If true, read and write performance will be logged
+	ReadOnly            bool // If true, LevelDB and PebbleDB are opened in read-only mode
 
 	// LevelDB related configurations.
 	LevelDBCacheSize   int // LevelDBCacheSize = BlockCacheCapacity + WriteBuffer
@@ -985,6 +1023,15 @@ func (dbm *databaseManager) GetDBConfig() *DBConfig {
 	return dbm.config
 }
 
+// GetDatabase returns the database of the given entry type.
+// It returns nil if the entry type is invalid or the database is not opened (e.g. StateTrieMigrationDB).
+func (dbm *databaseManager) GetDatabase(dbEntryType DBEntryType) Database {
+	if dbEntryType >= databaseEntryTypeSize {
+		return nil
+	}
+	return dbm.getDatabase(dbEntryType)
+}
+
 func (dbm *databaseManager) getDatabase(dbEntryType DBEntryType) Database {
 	if dbm.config.DBType == MemoryDB {
 		return dbm.dbs[0]
@@ -1551,6 +1598,66 @@ func (dbm *databaseManager) DeleteReceipts(hash common.Hash, number uint64) {
 	}
 }
 
+// ReadBlobSidecars retrieves the blob sidecars of the transactions belonging to a block.
+func (dbm *databaseManager) ReadBlobSidecars(hash common.Hash, number uint64) []*types.BlobTxSidecarWithHash {
+	db := dbm.getDatabase(MiscDB)
+	data, _ := db.Get(blobSidecarsKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+	var sidecars []*types.BlobTxSidecarWithHash
+	if err := rlp.DecodeBytes(data, &sidecars); err != nil {
+		logger.Error("Invalid blob sidecars RLP", "blockHash", hash, "err", err)
+		return nil
+	}
+	return sidecars
+}
+
+// WriteBlobSidecars stores the blob sidecars of the transactions belonging to a block.
+func (dbm *databaseManager) WriteBlobSidecars(hash common.Hash, number uint64, sidecars []*types.BlobTxSidecarWithHash) {
+	bytes, err := rlp.EncodeToBytes(sidecars)
+	if err != nil {
+		logger.Crit("Failed to encode blob sidecars", "err", err)
+	}
+	db := dbm.getDatabase(MiscDB)
+	if err := db.Put(blobSidecarsKey(number, hash), bytes); err != nil {
+		logger.Crit("Failed to store blob sidecars", "err", err)
+	}
+}
+
+// PruneBlobSidecars deletes the blob sidecars of the blocks below the given
+// block number and returns the number of deleted blocks.
+func (dbm *databaseManager) PruneBlobSidecars(limit uint64) int {
+	db := dbm.getDatabase(MiscDB)
+	it := db.NewIterator(blobSidecarsPrefix, nil)
+	defer it.Release()
+
+	batch := dbm.NewBatch(MiscDB)
+	defer batch.Release()
+
+	count := 0
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(blobSidecarsPrefix)+8+common.HashLength {
+			continue
+		}
+		if binary.BigEndian.Uint64(key[len(blobSidecarsPrefix):]) >= limit {
+			break
+		}
+		if err := batch.Delete(common.CopyBytes(key)); err != nil {
+			logger.Crit("Failed to delete blob sidecars", "err", err)
+		}
+		if _, err := WriteBatchesOverThreshold(batch); err != nil {
+			logger.Crit("Failed to delete blob sidecars", "err", err)
+		}
+		count++
+	}
+	if err := batch.Write(); err != nil {
+		logger.Crit("Failed to batch delete blob sidecars", "err", err)
+	}
+	return count
+}
+
 // Block operations.
 // ReadBlock retrieves an entire block corresponding to the hash, assembling it
 // back from the stored header and body. If either the header or body could not
@@ -2125,6 +2232,96 @@ func (dbm *databaseManager) ReadLastPrunedBlockNumber() (uint64, error) {
 	return binary.LittleEndian.Uint64(lastPruned), nil
 }
 
+// ReadStateScheme returns the storage scheme of the state trie nodes.
+// The hash-based scheme is returned if no scheme is stored in the database.
+func (dbm *databaseManager) ReadStateScheme() string {
+	scheme, _ := dbm.getDatabase(MiscDB).Get(stateSchemeKey)
+	if len(scheme) == 0 {
+		return HashScheme
+	}
+	return string(scheme)
+}
+
+// WriteStateScheme writes the storage scheme of the state trie nodes.
+// It should be written before the genesis state is committed.
+func (dbm *databaseManager) WriteStateScheme(scheme string) {
+	if err := dbm.getDatabase(MiscDB).Put(stateSchemeKey, []byte(scheme)); err != nil {
+		logger.Crit("Failed to store state scheme", "err", err)
+	}
+}
+
+// ReadAccountTrieNode retrieves the account trie node at the given path.
+// It returns nil if the node is not found.
+func (dbm *databaseManager) ReadAccountTrieNode(path []byte) []byte {
+	node, _ := dbm.getDatabase(StateTrieDB).Get(AccountTrieNodeKey(path))
+	return node
+}
+
+// ReadStorageTrieNode retrieves the storage trie node of the owner at the given path.
+// It returns nil if the node is not found.
+func (dbm *databaseManager) ReadStorageTrieNode(owner common.Hash, path []byte) []byte {
+	node, _ := dbm.getDatabase(StateTrieDB).Get(StorageTrieNodeKey(owner, path))
+	return node
+}
+
+func (dbm *databaseManager) PutAccountTrieNodeToBatch(batch Batch, path []byte, node []byte) {
+	if err := batch.Put(AccountTrieNodeKey(path), node); err != nil {
+		logger.Crit("Failed to store account trie node", "err", err)
+	}
+}
+
+func (dbm *databaseManager) PutStorageTrieNodeToBatch(batch Batch, owner common.Hash, path []byte, node []byte) {
+	if err := batch.Put(StorageTrieNodeKey(owner, path), node); err != nil {
+		logger.Crit("Failed to store storage trie node", "err", err)
+	}
+}
+
+func (dbm *databaseManager) DeleteAccountTrieNodeFromBatch(batch Batch, path []byte) {
+	if err := batch.Delete(AccountTrieNodeKey(path)); err != nil {
+		logger.Crit("Failed to delete account trie node", "err", err)
+	}
+}
+
+func (dbm *databaseManager) DeleteStorageTrieNodeFromBatch(batch Batch, owner common.Hash, path []byte) {
+	if err := batch.Delete(StorageTrieNodeKey(owner, path)); err != nil {
+		logger.Crit("Failed to delete storage trie node", "err", err)
+	}
+}
+
+// ReadPersistentStateID returns the id of the state persisted by the path-based scheme.
+// It returns zero if no state is persisted.
+func (dbm *databaseManager) ReadPersistentStateID() uint64 {
+	data, _ := dbm.getDatabase(MiscDB).Get(persistentStateIDKey)
+	if len(data) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(data)
+}
+
+func (dbm *databaseManager) WritePersistentStateID(id uint64) {
+	if err := dbm.getDatabase(MiscDB).Put(persistentStateIDKey, common.Int64ToByteBigEndian(id)); err != nil {
+		logger.Crit("Failed to store persistent state id", "err", err)
+	}
+}
+
+// ReadTrieHistory retrieves the reverse diff which reverts the state of the given id to its parent.
+func (dbm *databaseManager) ReadTrieHistory(id uint64) []byte {
+	blob, _ := dbm.getDatabase(MiscDB).Get(trieHistoryKey(id))
+	return blob
+}
+
+func (dbm *databaseManager) WriteTrieHistory(id uint64, blob []byte) {
+	if err := dbm.getDatabase(MiscDB).Put(trieHistoryKey(id), blob); err != nil {
+		logger.Crit("Failed to store trie history", "err", err)
+	}
+}
+
+func (dbm *databaseManager) DeleteTrieHistory(id uint64) {
+	if err := dbm.getDatabase(MiscDB).Delete(trieHistoryKey(id)); err != nil {
+		logger.Crit("Failed to delete trie history", "err", err)
+	}
+}
+
 // ReadTxLookupEntry retrieves the positional metadata associated with a transaction
 // hash to allow retrieving the transaction or receipt by hash.
 func (dbm *databaseManager) ReadTxLookupEntry(hash common.Hash) (common.Hash, uint64, uint64) {
@@ -144,6 +144,7 @@ func getLevelDBOptions(dbc *DBConfig) *opt.Options {
 		CompactionTableSize:           2 * opt.MiB,
 		CompactionTableSizeMultiplier: 1.0,
 		DisableSeeksCompaction:        true,
+		ReadOnly:                      dbc.ReadOnly,
 	}
 
 	return newOption
@@ -170,7 +171,7 @@ func NewLevelDB(dbc *DBConfig, entryType DBEntryType) (*levelDB, error) {
 
 	// Open the db and recover any potential corruptions
 	db, err := leveldb.OpenFile(dbc.Dir, ldbOpts)
-	if _, corrupted := err.(*errors.ErrCorrupted); corrupted {
+	if _, corrupted := err.(*errors.ErrCorrupted); corrupted && !dbc.ReadOnly {
 		db, err = leveldb.RecoverFile(dbc.Dir, nil)
 	}
 	// (Re)check for errors and abort if opening of the db failed
@@ -154,7 +154,7 @@ func (l panicLogger) Fatalf(format string, args ...interface{}) {
 func NewPebbleDB(dbc *DBConfig, file string) (*pebbleDB, error) {
 	// Ensure we have some minimal caching and file guarantees
 	ephemeral := false
-	readonly := false
+	readonly := dbc.ReadOnly
 	if dbc.PebbleDBCacheSize < minCache {
 		dbc.PebbleDBCacheSize = minCache
 	}
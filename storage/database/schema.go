@@ -25,6 +25,7 @@ package database
 import (
 	"bytes"
 	"encoding/binary"
+	"fmt"
 	"math/big"
 
 	"github.com/kaiachain/kaia/common"
@@ -88,6 +89,8 @@ var (
 	blockBodyPrefix     = []byte("b") // blockBodyPrefix + num (uint64 big endian) + hash -> block body
 	blockReceiptsPrefix = []byte("r") // blockReceiptsPrefix + num (uint64 big endian) + hash -> block receipts
 
+	blobSidecarsPrefix = []byte("blobSidecars-") // blobSidecarsPrefix + num (uint64 big endian) + hash -> blob sidecars of a block
+
 	txLookupPrefix        = []byte("l") // txLookupPrefix + hash -> transaction/receipt lookup metadata
 	SnapshotAccountPrefix = []byte("a") // SnapshotAccountPrefix + account hash -> account trie value
 	SnapshotStoragePrefix = []byte("o") // SnapshotStoragePrefix + account hash + storage hash -> storage trie value
@@ -102,6 +105,12 @@ var (
 	pruningMarkKeyLen        = len(pruningMarkPrefix) + 8 + common.ExtHashLength // prefix + num (uint64) + node hash
 	lastPrunedBlockNumberKey = []byte("lastPrunedBlockNumber")
 
+	stateSchemeKey        = []byte("StateScheme")       // the storage scheme of the state trie nodes
+	persistentStateIDKey  = []byte("PersistentStateID") // the id of the state persisted by the path-based scheme
+	trieNodeAccountPrefix = []byte("A")                 // shard byte + trieNodeAccountPrefix + hex path -> account trie node
+	trieNodeStoragePrefix = []byte("O")                 // owner hash + trieNodeStoragePrefix + hex path -> storage trie node
+	trieHistoryPrefix     = []byte("TrieHistory-")      // trieHistoryPrefix + state id (uint64 big endian) -> reverse diff
+
 	// Chain index prefixes (use `i` + single byte to avoid mixing data types).
 	BloomBitsIndexPrefix = []byte("iB") // BloomBitsIndexPrefix is the data table of a chain indexer to track its progress
 
@@ -178,6 +187,11 @@ func blockReceiptsKey(number uint64, hash common.Hash) []byte {
 	return append(append(blockReceiptsPrefix, common.Int64ToByteBigEndian(number)...), hash.Bytes()...)
 }
 
+// blobSidecarsKey = blobSidecarsPrefix + num (uint64 big endian) + hash
+func blobSidecarsKey(number uint64, hash common.Hash) []byte {
+	return append(append(blobSidecarsPrefix, common.Int64ToByteBigEndian(number)...), hash.Bytes()...)
+}
+
 // TxLookupKey = txLookupPrefix + hash
 func TxLookupKey(hash common.Hash) []byte {
 	return append(txLookupPrefix, hash.Bytes()...)
@@ -274,6 +288,58 @@ func TrieNodeKey(hash common.ExtHash) []byte {
 	}
 }
 
+// AccountTrieNodeKey = shard byte + trieNodeAccountPrefix + hex path
+// The shard byte packs the first two nibbles of the path so that the account trie
+// nodes are spread over the shards of the state trie database.
+func AccountTrieNodeKey(path []byte) []byte {
+	key := make([]byte, 0, 1+len(trieNodeAccountPrefix)+len(path))
+	key = append(key, accountTrieNodeShardByte(path))
+	key = append(key, trieNodeAccountPrefix...)
+	return append(key, path...)
+}
+
+// AccountTrieNodePrefixes returns the key prefixes covering all the account trie nodes
+// whose path starts with the given path. More than one prefix is returned if the path
+// is shorter than two nibbles, because the shard byte is taken from the first two nibbles.
+func AccountTrieNodePrefixes(path []byte) [][]byte {
+	if len(path) >= 2 {
+		return [][]byte{AccountTrieNodeKey(path)}
+	}
+	var prefixes [][]byte
+	for b := 0; b < 256; b++ {
+		if len(path) == 1 && byte(b>>4) != path[0] {
+			continue
+		}
+		prefix := append([]byte{byte(b)}, trieNodeAccountPrefix...)
+		prefixes = append(prefixes, append(prefix, path...))
+	}
+	return prefixes
+}
+
+func accountTrieNodeShardByte(path []byte) byte {
+	var b byte
+	if len(path) > 0 {
+		b = path[0] << 4
+	}
+	if len(path) > 1 {
+		b |= path[1] & 0x0f
+	}
+	return b
+}
+
+// StorageTrieNodeKey = owner hash + trieNodeStoragePrefix + hex path
+func StorageTrieNodeKey(owner common.Hash, path []byte) []byte {
+	key := make([]byte, 0, common.HashLength+len(trieNodeStoragePrefix)+len(path))
+	key = append(key, owner.Bytes()...)
+	key = append(key, trieNodeStoragePrefix...)
+	return append(key, path...)
+}
+
+// trieHistoryKey = trieHistoryPrefix + state id (uint64 big endian)
+func trieHistoryKey(id uint64) []byte {
+	return append(append([]byte{}, trieHistoryPrefix...), common.Int64ToByteBigEndian(id)...)
+}
+
 type PruningMark struct {
 	Number uint64
 	Hash   common.ExtHash
@@ -317,3 +383,113 @@ func (c *SupplyCheckpoint) Copy() *SupplyCheckpoint {
 func supplyCheckpointKey(blockNumber uint64) []byte {
 	return append(supplyCheckpointPrefix, common.Int64ToByteBigEndian(blockNumber)...)
 }
+
+// singleKeys maps the keys without a variable part to their names.
+var singleKeys = map[string]string{
+	string(databaseVerisionKey):           "database version",
+	string(headHeaderKey):                 "head header hash",
+	string(headBlockKey):                  "head block hash",
+	string(headBlockBackupKey):            "head block backup hashes",
+	string(headFastBlockKey):              "head fast block hash",
+	string(headFastBlockBackupKey):        "head fast block backup hashes",
+	string(fastTrieProgressKey):           "fast trie progress",
+	string(validSectionKey):               "bloom bits valid sections",
+	string(snapshotJournalKey):            "snapshot journal",
+	string(SnapshotGeneratorKey):          "snapshot generator",
+	string(snapshotDisabledKey):           "snapshot disabled",
+	string(snapshotRecoveryKey):           "snapshot recovery number",
+	string(snapshotSyncStatusKey):         "snapshot sync status",
+	string(snapshotRootKey):               "snapshot root",
+	string(badBlockKey):                   "bad blocks",
+	string(pruningEnabledKey):             "pruning enabled",
+	string(lastPrunedBlockNumberKey):      "last pruned block number",
+	string(stateSchemeKey):                "state scheme",
+	string(persistentStateIDKey):          "persistent state id",
+	string(lastServiceChainTxReceiptKey):  "last service chain tx receipt",
+	string(lastIndexedBlockKey):           "last indexed block number",
+	string(governanceHistoryKey):          "governance index history",
+	string(governanceStateKey):            "governance state",
+	string(migrationStatusKey):            "state migration status",
+	string(migrationOldDBPathKey):         "state migration old db path",
+	string(lastSupplyCheckpointNumberKey): "last supply checkpoint number",
+	string(chaindatafetcherCheckpointKey): "chaindatafetcher checkpoint",
+}
+
+// hashKeyPrefixes lists the schemas of which the key is prefix + 32-byte hash.
+var hashKeyPrefixes = []struct {
+	prefix []byte
+	name   string
+}{
+	{headerNumberPrefix, "header number"},
+	{txLookupPrefix, "tx lookup entry"},
+	{SnapshotAccountPrefix, "account snapshot"},
+	{codePrefix, "code"},
+	{preimagePrefix, "preimage"},
+	{configPrefix, "chain config"},
+	{snapshotKeyPrefix, "istanbul snapshot"},
+	{senderTxHashToTxHashPrefix, "sender tx hash to tx hash"},
+	{childChainTxHashPrefix, "child chain tx hash"},
+	{receiptFromParentChainKeyPrefix, "receipt from parent chain"},
+	{valueTransferTxHashPrefix, "value transfer tx hash"},
+}
+
+// DescribeKey returns a human-readable description of the given key based on the database schema.
+// It returns an empty string if the key does not match any known schema.
+func DescribeKey(key []byte) string {
+	if name, ok := singleKeys[string(key)]; ok {
+		return name
+	}
+
+	numHashLen := 1 + 8 + common.HashLength
+	switch {
+	case bytes.HasPrefix(key, headerPrefix) && len(key) == numHashLen:
+		return fmt.Sprintf("header (number: %d, hash: %s)", binary.BigEndian.Uint64(key[1:9]), common.BytesToHash(key[9:]).Hex())
+	case bytes.HasPrefix(key, headerPrefix) && len(key) == numHashLen+len(headerTDSuffix) && bytes.HasSuffix(key, headerTDSuffix):
+		return fmt.Sprintf("total difficulty (number: %d, hash: %s)", binary.BigEndian.Uint64(key[1:9]), common.BytesToHash(key[9:numHashLen]).Hex())
+	case bytes.HasPrefix(key, headerPrefix) && len(key) == 1+8+len(headerHashSuffix) && bytes.HasSuffix(key, headerHashSuffix):
+		return fmt.Sprintf("canonical hash (number: %d)", binary.BigEndian.Uint64(key[1:9]))
+	case bytes.HasPrefix(key, blockBodyPrefix) && len(key) == numHashLen:
+		return fmt.Sprintf("block body (number: %d, hash: %s)", binary.BigEndian.Uint64(key[1:9]), common.BytesToHash(key[9:]).Hex())
+	case bytes.HasPrefix(key, blockReceiptsPrefix) && len(key) == numHashLen:
+		return fmt.Sprintf("block receipts (number: %d, hash: %s)", binary.BigEndian.Uint64(key[1:9]), common.BytesToHash(key[9:]).Hex())
+	case bytes.HasPrefix(key, SnapshotStoragePrefix) && len(key) == len(SnapshotStoragePrefix)+2*common.HashLength:
+		offset := len(SnapshotStoragePrefix)
+		return fmt.Sprintf("storage snapshot (account: %s, storage: %s)",
+			common.BytesToHash(key[offset:offset+common.HashLength]).Hex(), common.BytesToHash(key[offset+common.HashLength:]).Hex())
+	case bytes.HasPrefix(key, bloomBitsPrefix) && len(key) == len(bloomBitsPrefix)+10+common.HashLength:
+		return fmt.Sprintf("bloom bits (bit: %d, section: %d, hash: %s)",
+			binary.BigEndian.Uint16(key[1:3]), binary.BigEndian.Uint64(key[3:11]), common.BytesToHash(key[11:]).Hex())
+	case bytes.HasPrefix(key, BloomBitsIndexPrefix):
+		return "bloom bits index"
+	case bytes.HasPrefix(key, sectionHeadKeyPrefix):
+		return "bloom bits section head"
+	case bytes.HasPrefix(key, pruningMarkPrefix) && len(key) == pruningMarkKeyLen:
+		mark := parsePruningMarkKey(key)
+		return fmt.Sprintf("pruning mark (number: %d, hash: %s)", mark.Number, mark.Hash.Hex())
+	case bytes.HasPrefix(key, governancePrefix) && len(key) == len(governancePrefix)+8:
+		return fmt.Sprintf("governance (number: %d)", binary.LittleEndian.Uint64(key[len(governancePrefix):]))
+	case bytes.HasPrefix(key, databaseDirPrefix) && len(key) == len(databaseDirPrefix)+8:
+		if et := binary.BigEndian.Uint64(key[len(databaseDirPrefix):]); et < uint64(databaseEntryTypeSize) {
+			return fmt.Sprintf("database directory (entry: %s)", DBEntryType(et))
+		}
+	case bytes.HasPrefix(key, trieHistoryPrefix) && len(key) == len(trieHistoryPrefix)+8:
+		return fmt.Sprintf("trie history (state id: %d)", binary.BigEndian.Uint64(key[len(trieHistoryPrefix):]))
+	case bytes.HasPrefix(key, supplyCheckpointPrefix) && len(key) == len(supplyCheckpointPrefix)+8:
+		return fmt.Sprintf("supply checkpoint (number: %d)", binary.BigEndian.Uint64(key[len(supplyCheckpointPrefix):]))
+	}
+
+	for _, p := range hashKeyPrefixes {
+		if bytes.HasPrefix(key, p.prefix) && len(key) == len(p.prefix)+common.HashLength {
+			return fmt.Sprintf("%s (hash: %s)", p.name, common.BytesToHash(key[len(p.prefix):]).Hex())
+		}
+	}
+
+	// Trie nodes are stored without a prefix.
+	switch len(key) {
+	case common.HashLength:
+		return fmt.Sprintf("trie node (hash: %s)", common.BytesToHash(key).Hex())
+	case common.ExtHashLength:
+		return fmt.Sprintf("trie node (exthash: %s)", common.BytesToExtHash(key).Hex())
+	}
+	return ""
+}
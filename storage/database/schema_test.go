@@ -0,0 +1,67 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"testing"
+
+	"github.com/kaiachain/kaia/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribeKey(t *testing.T) {
+	hash := common.HexToHash("0x1122334455667788990011223344556677889900112233445566778899001122")
+
+	testcases := []struct {
+		key      []byte
+		expected string
+	}{
+		{headBlockKey, "head block hash"},
+		{headerKey(100, hash), "header (number: 100, hash: " + hash.Hex() + ")"},
+		{headerTDKey(100, hash), "total difficulty (number: 100, hash: " + hash.Hex() + ")"},
+		{headerHashKey(100), "canonical hash (number: 100)"},
+		{headerNumberKey(hash), "header number (hash: " + hash.Hex() + ")"},
+		{blockBodyKey(7, hash), "block body (number: 7, hash: " + hash.Hex() + ")"},
+		{blockReceiptsKey(7, hash), "block receipts (number: 7, hash: " + hash.Hex() + ")"},
+		{TxLookupKey(hash), "tx lookup entry (hash: " + hash.Hex() + ")"},
+		{CodeKey(hash), "code (hash: " + hash.Hex() + ")"},
+		{StorageSnapshotKey(hash, hash), "storage snapshot (account: " + hash.Hex() + ", storage: " + hash.Hex() + ")"},
+		{makeKey(governancePrefix, 3), "governance (number: 3)"},
+		{databaseDirKey(uint64(StateTrieDB)), "database directory (entry: statetrie)"},
+		{TrieNodeKey(hash.ExtendZero()), "trie node (hash: " + hash.Hex() + ")"},
+		{[]byte("unknown-key"), ""},
+	}
+
+	for _, tc := range testcases {
+		assert.Equal(t, tc.expected, DescribeKey(tc.key), "key: %x", tc.key)
+	}
+}
+
+func TestParseDBEntryType(t *testing.T) {
+	for et := MiscDB; et < databaseEntryTypeSize; et++ {
+		parsed, err := ParseDBEntryType(et.String())
+		assert.NoError(t, err)
+		assert.Equal(t, et, parsed)
+	}
+
+	parsed, err := ParseDBEntryType("StateTrie")
+	assert.NoError(t, err)
+	assert.Equal(t, StateTrieDB, parsed)
+
+	_, err = ParseDBEntryType("nodb")
+	assert.Error(t, err)
+}
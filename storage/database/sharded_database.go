@@ -137,6 +137,22 @@ func (db *shardedDB) getShardByKey(key []byte) (Database, error) {
 	}
 }
 
+// GetShard returns the idx-th shard of the given database.
+// If the database is not sharded, only the index 0 is valid and the database itself is returned.
+func GetShard(db Database, idx uint) (Database, error) {
+	sdb, ok := db.(*shardedDB)
+	if !ok {
+		if idx != 0 {
+			return nil, fmt.Errorf("database is not sharded, but shard %d is requested", idx)
+		}
+		return db, nil
+	}
+	if idx >= sdb.numShards {
+		return nil, fmt.Errorf("shard index out of range (index: %d, numShards: %d)", idx, sdb.numShards)
+	}
+	return sdb.shards[idx], nil
+}
+
 func (db *shardedDB) Put(key []byte, value []byte) error {
 	if shard, err := db.getShardByKey(key); err != nil {
 		return err
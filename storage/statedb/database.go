@@ -113,6 +113,8 @@ type Database struct {
 	trieNodeCache                TrieNodeCache        // GC friendly memory cache of trie node RLPs
 	trieNodeCacheConfig          *TrieNodeCacheConfig // Configuration of trieNodeCache
 	savingTrieNodeCacheTriggered bool                 // Whether saving trie node cache has been triggered or not
+
+	pathLayers *pathLayers // Layers of the path-based scheme. Nil if the hash-based scheme is used.
 }
 
 // rawNode is a simple binary blob used to differentiate between collapsed trie
@@ -316,6 +318,7 @@ func NewDatabaseWithNewCache(diskDB database.DBManager, cacheConfig *TrieNodeCac
 		preimages:           make(map[common.Hash][]byte),
 		trieNodeCache:       trieNodeCache,
 		trieNodeCacheConfig: cacheConfig,
+		pathLayers:          openPathLayers(diskDB),
 	}
 }
 
@@ -328,6 +331,7 @@ func NewDatabaseWithExistingCache(diskDB database.DBManager, cache TrieNodeCache
 		nodes:         map[common.ExtHash]*cachedNode{{}: {}},
 		preimages:     make(map[common.Hash][]byte),
 		trieNodeCache: cache,
+		pathLayers:    openPathLayers(diskDB),
 	}
 }
 
@@ -581,7 +585,11 @@ func (db *Database) NodeFromOld(hash common.ExtHash) ([]byte, error) {
 }
 
 // DoesExistCachedNode returns if the node exists on cached trie node in memory.
+// In the path-based scheme, it also returns true if the hash is the state root of a diff layer.
 func (db *Database) DoesExistCachedNode(hash common.ExtHash) bool {
+	if db.pathLayers != nil && db.pathLayers.hasDiff(hash.Unextend()) {
+		return true
+	}
 	// Retrieve the node from cache if available
 	db.lock.RLock()
 	_, ok := db.nodes[hash]
@@ -590,7 +598,11 @@ func (db *Database) DoesExistCachedNode(hash common.ExtHash) bool {
 }
 
 // DoesExistNodeInPersistent returns if the node exists on the persistent database or its cache.
+// In the path-based scheme, it returns if the hash is the state root of the disk layer.
 func (db *Database) DoesExistNodeInPersistent(hash common.ExtHash) bool {
+	if db.pathLayers != nil {
+		return db.pathLayers.isDiskRoot(hash.Unextend())
+	}
 	// Retrieve the node from DB cache if available
 	if enc := db.getCachedNode(hash); enc != nil {
 		return true
@@ -903,6 +915,10 @@ func (db *Database) concurrentCommit(hash common.ExtHash, resultCh chan<- commit
 //
 // As a side effect, all pre-images accumulated up to this point are also written.
 func (db *Database) Commit(root common.Hash, report bool, blockNum uint64) error {
+	if db.pathLayers != nil {
+		// The trie is stacked on the most recently committed layer. Use CommitState for the state of a block.
+		return db.commitPath(root, db.pathLayers.headRoot(), report, blockNum)
+	}
 	hash := root.ExtendZero()
 	// Create a database batch to flush persistent data out. It is important that
 	// outside code doesn't see an inconsistent state (referenced data removed from
@@ -0,0 +1,896 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package statedb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/blockchain/types/account"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/rlp"
+	"github.com/kaiachain/kaia/storage/database"
+)
+
+// In the path-based scheme, a trie node is stored at the key derived from its owner
+// (zero for the account trie, the account hash for a storage trie) and its path.
+// A path holds only one version of a node, so obsolete nodes are overwritten in
+// place instead of being garbage collected.
+//
+// The latest state transitions are kept as in-memory diff layers, which form a tree
+// keyed by state root on top of the disk layer so that the states of side chains can
+// be committed as well. When the diff layers below a new state exceed the limit, the
+// bottom-most one of them is flattened into the disk layer, the layers of the side
+// chains forked below it are discarded, and its reverse diff is written as a trie
+// history so that the disk layer can be rewound later. The diff layers live only in
+// memory, so those up to the current state are flushed into the disk layer on shutdown.
+
+const (
+	defaultPathDiffLayers   = 128   // Number of diff layers kept in memory on top of the disk layer
+	defaultPathStateHistory = 90000 // Number of reverse diffs kept in the database for rewinds
+)
+
+var (
+	errStateNotRecoverable = errors.New("state is not recoverable")
+	errUnknownParent       = errors.New("parent state is not found in the layers")
+	errUnknownState        = errors.New("state is not found in the layers")
+)
+
+// pathNode is a trie node with its hash.
+type pathNode struct {
+	hash common.Hash
+	blob []byte
+}
+
+// nodeSet is a set of trie nodes keyed by owner and path.
+type nodeSet map[common.Hash]map[string]*pathNode
+
+func (s nodeSet) add(owner common.Hash, path []byte, hash common.Hash, blob []byte) {
+	nodes, ok := s[owner]
+	if !ok {
+		nodes = make(map[string]*pathNode)
+		s[owner] = nodes
+	}
+	nodes[string(path)] = &pathNode{hash: hash, blob: blob}
+}
+
+// nodeIndex holds the diff layers having a node, keyed by owner and path.
+type nodeIndex map[common.Hash]map[string][]*diffLayer
+
+func (idx nodeIndex) add(dl *diffLayer) {
+	for owner, nodes := range dl.nodes {
+		paths, ok := idx[owner]
+		if !ok {
+			paths = make(map[string][]*diffLayer)
+			idx[owner] = paths
+		}
+		for path := range nodes {
+			paths[path] = append(paths[path], dl)
+		}
+	}
+}
+
+func (idx nodeIndex) remove(dl *diffLayer) {
+	for owner, nodes := range dl.nodes {
+		paths := idx[owner]
+		for path := range nodes {
+			layers := paths[path]
+			for i, l := range layers {
+				if l == dl {
+					layers = append(layers[:i], layers[i+1:]...)
+					break
+				}
+			}
+			if len(layers) == 0 {
+				delete(paths, path)
+			} else {
+				paths[path] = layers
+			}
+		}
+		if len(paths) == 0 {
+			delete(idx, owner)
+		}
+	}
+}
+
+// diffLayer holds the trie nodes updated by the state transition from parent to root.
+// The parent is either the disk layer or another diff layer.
+type diffLayer struct {
+	root   common.Hash
+	parent common.Hash
+	block  uint64
+	nodes  nodeSet
+}
+
+// trieHistory is the reverse diff of the state transition from Parent to Root.
+type trieHistory struct {
+	Parent common.Hash
+	Root   common.Hash
+	Block  uint64
+	Nodes  []trieHistoryNode
+}
+
+// trieHistoryNode is a trie node before the state transition.
+// Blob is empty if the node did not exist.
+type trieHistoryNode struct {
+	Owner common.Hash
+	Path  []byte
+	Blob  []byte
+}
+
+// pathLayers manages the disk layer and the diff layers of the path-based scheme.
+type pathLayers struct {
+	diskDB     database.DBManager
+	maxDiffs   int
+	maxHistory uint64
+
+	lock     sync.RWMutex               // Lock for the fields below
+	diskRoot common.Hash                // State root of the disk layer
+	diskID   uint64                     // State id of the disk layer, increased by each flattened diff layer
+	diffs    map[common.Hash]*diffLayer // Diff layers keyed by state root, forming a tree on top of the disk layer
+	index    nodeIndex                  // Diff layers having a node, keyed by owner and path
+	head     common.Hash                // State root of the most recently committed or recovered layer
+
+	writeLock sync.Mutex // Lock for serializing the layer updates
+}
+
+func newPathLayers(diskDB database.DBManager) *pathLayers {
+	pl := &pathLayers{
+		diskDB:     diskDB,
+		maxDiffs:   defaultPathDiffLayers,
+		maxHistory: defaultPathStateHistory,
+		diskID:     diskDB.ReadPersistentStateID(),
+		diffs:      make(map[common.Hash]*diffLayer),
+		index:      make(nodeIndex),
+	}
+	pl.diskRoot = pl.readDiskRoot()
+	pl.head = pl.diskRoot
+
+	// The nodes of the next state may have been written without its id if the node was
+	// terminated while flattening. Adopt the id if the disk layer matches its history.
+	if h, err := pl.readHistory(pl.diskID + 1); err == nil && h.Root == pl.diskRoot && h.Parent != h.Root {
+		logger.Warn("Recovered the persistent state id of the path-based scheme", "id", pl.diskID+1, "root", pl.diskRoot)
+		pl.diskID++
+		diskDB.WritePersistentStateID(pl.diskID)
+	}
+	return pl
+}
+
+// readDiskRoot returns the state root of the disk layer, which is the hash of the account trie root node.
+func (pl *pathLayers) readDiskRoot() common.Hash {
+	blob := pl.diskDB.ReadAccountTrieNode(nil)
+	if len(blob) == 0 {
+		return types.EmptyRootHash
+	}
+	return crypto.Keccak256Hash(blob)
+}
+
+func (pl *pathLayers) readDisk(owner common.Hash, path []byte) []byte {
+	if owner == (common.Hash{}) {
+		return pl.diskDB.ReadAccountTrieNode(path)
+	}
+	return pl.diskDB.ReadStorageTrieNode(owner, path)
+}
+
+func (pl *pathLayers) writeDisk(batch database.Batch, owner common.Hash, path []byte, blob []byte) {
+	switch {
+	case owner == (common.Hash{}) && len(blob) == 0:
+		pl.diskDB.DeleteAccountTrieNodeFromBatch(batch, path)
+	case owner == (common.Hash{}):
+		pl.diskDB.PutAccountTrieNodeToBatch(batch, path, blob)
+	case len(blob) == 0:
+		pl.diskDB.DeleteStorageTrieNodeFromBatch(batch, owner, path)
+	default:
+		pl.diskDB.PutStorageTrieNodeToBatch(batch, owner, path, blob)
+	}
+}
+
+func (pl *pathLayers) readHistory(id uint64) (*trieHistory, error) {
+	blob := pl.diskDB.ReadTrieHistory(id)
+	if len(blob) == 0 {
+		return nil, fmt.Errorf("trie history %d not found", id)
+	}
+	h := new(trieHistory)
+	if err := rlp.DecodeBytes(blob, h); err != nil {
+		return nil, fmt.Errorf("invalid trie history %d: %w", id, err)
+	}
+	return h, nil
+}
+
+// node returns the trie node of the owner at the path if its hash matches the given hash.
+// The diff layers having a node at the path are looked up by the index, and then the disk layer.
+// A node is identified by its hash, so a node of any layer, even of a side chain, is the same
+// node if its hash matches.
+func (pl *pathLayers) node(owner common.Hash, path []byte, hash common.Hash) []byte {
+	pl.lock.RLock()
+	for _, dl := range pl.index[owner][string(path)] {
+		if n := dl.nodes[owner][string(path)]; n.hash == hash {
+			pl.lock.RUnlock()
+			return n.blob
+		}
+	}
+	pl.lock.RUnlock()
+
+	blob := pl.readDisk(owner, path)
+	if len(blob) == 0 || crypto.Keccak256Hash(blob) != hash {
+		return nil
+	}
+	return blob
+}
+
+// headRoot returns the state root of the most recently committed or recovered layer.
+func (pl *pathLayers) headRoot() common.Hash {
+	pl.lock.RLock()
+	defer pl.lock.RUnlock()
+
+	return pl.head
+}
+
+// hasDiff returns true if a diff layer of the given state root exists.
+func (pl *pathLayers) hasDiff(root common.Hash) bool {
+	pl.lock.RLock()
+	defer pl.lock.RUnlock()
+
+	_, ok := pl.diffs[root]
+	return ok
+}
+
+// isDiskRoot returns true if the disk layer is at the given state root.
+func (pl *pathLayers) isDiskRoot(root common.Hash) bool {
+	pl.lock.RLock()
+	defer pl.lock.RUnlock()
+
+	return pl.diskRoot == root
+}
+
+// add inserts a new diff layer of the state transition from the parent root to the root.
+// The parent must be the disk layer or one of the diff layers. If the diff layers below the
+// new one exceed the limit, the bottom-most ones of them are flattened into the disk layer.
+func (pl *pathLayers) add(root, parent common.Hash, block uint64, nodes nodeSet) error {
+	pl.writeLock.Lock()
+	defer pl.writeLock.Unlock()
+
+	pl.lock.Lock()
+	// The state already exists, e.g. of a re-imported block or of a block without state changes.
+	if _, ok := pl.diffs[root]; ok || root == pl.diskRoot {
+		pl.head = root
+		pl.lock.Unlock()
+		return nil
+	}
+	if root == parent {
+		pl.lock.Unlock()
+		return nil
+	}
+	if _, ok := pl.diffs[parent]; !ok && parent != pl.diskRoot {
+		pl.lock.Unlock()
+		return fmt.Errorf("%w: %x", errUnknownParent, parent)
+	}
+	pl.putLayer(&diffLayer{root: root, parent: parent, block: block, nodes: nodes})
+	pl.head = root
+	pl.lock.Unlock()
+
+	layers := pl.chain(root)
+	for len(layers) > pl.maxDiffs {
+		if err := pl.flatten(layers[0]); err != nil {
+			return err
+		}
+		layers = layers[1:]
+	}
+	return nil
+}
+
+// chain returns the diff layers from the disk layer up to the given state root, the oldest first.
+func (pl *pathLayers) chain(root common.Hash) []*diffLayer {
+	pl.lock.RLock()
+	defer pl.lock.RUnlock()
+
+	var layers []*diffLayer
+	for dl := pl.diffs[root]; dl != nil; dl = pl.diffs[dl.parent] {
+		layers = append(layers, dl)
+	}
+	for i, j := 0, len(layers)-1; i < j; i, j = i+1, j-1 {
+		layers[i], layers[j] = layers[j], layers[i]
+	}
+	return layers
+}
+
+// descends returns true if the diff layer of the root is a descendant of the given ancestor.
+//
+// Note that this function assumes that the lock is held!
+func (pl *pathLayers) descends(root, ancestor common.Hash) bool {
+	for dl := pl.diffs[root]; dl != nil; dl = pl.diffs[dl.parent] {
+		if dl.parent == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// discardLayers removes the diff layers for which the given function returns true.
+// The layers are selected before any of them is removed, so that the ancestry is intact.
+//
+// Note that this function assumes that the lock is held!
+func (pl *pathLayers) discardLayers(discard func(root common.Hash) bool) int {
+	var roots []common.Hash
+	for root := range pl.diffs {
+		if discard(root) {
+			roots = append(roots, root)
+		}
+	}
+	for _, root := range roots {
+		pl.dropLayer(root)
+	}
+	return len(roots)
+}
+
+// putLayer inserts the diff layer into the layers and the index.
+//
+// Note that this function assumes that the lock is held!
+func (pl *pathLayers) putLayer(dl *diffLayer) {
+	pl.diffs[dl.root] = dl
+	pl.index.add(dl)
+}
+
+// dropLayer removes the diff layer of the root from the layers and the index.
+//
+// Note that this function assumes that the lock is held!
+func (pl *pathLayers) dropLayer(root common.Hash) {
+	if dl, ok := pl.diffs[root]; ok {
+		delete(pl.diffs, root)
+		pl.index.remove(dl)
+	}
+}
+
+// flush flattens the diff layers up to the given state root into the disk layer, discarding the side chains.
+func (pl *pathLayers) flush(root common.Hash) error {
+	pl.writeLock.Lock()
+	defer pl.writeLock.Unlock()
+
+	if !pl.hasDiff(root) && !pl.isDiskRoot(root) {
+		return fmt.Errorf("%w: %x", errUnknownState, root)
+	}
+	for _, dl := range pl.chain(root) {
+		if err := pl.flatten(dl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flatten writes the given diff layer, whose parent must be the disk layer, into the disk layer,
+// deleting the nodes which become unreachable including the storage tries of the deleted accounts,
+// and stores its reverse diff as a trie history. The diff layer is kept readable until the disk
+// layer is updated, and then the diff layers not on top of it are discarded.
+func (pl *pathLayers) flatten(dl *diffLayer) error {
+	var (
+		start = time.Now()
+		batch = pl.diskDB.NewBatch(database.StateTrieDB)
+		hist  = &trieHistory{Parent: pl.diskRoot, Root: dl.root, Block: dl.block}
+	)
+	defer batch.Release()
+
+	owners := make([]common.Hash, 0, len(dl.nodes))
+	for owner := range dl.nodes {
+		owners = append(owners, owner)
+	}
+	sort.Slice(owners, func(i, j int) bool { return bytes.Compare(owners[i][:], owners[j][:]) < 0 })
+
+	for _, owner := range owners {
+		// The paths of the nodes in the new state which may differ from the disk layer:
+		// the nodes written by the diff layer, and the nodes referenced by them.
+		live := make(map[string]struct{})
+		paths := make([]string, 0, len(dl.nodes[owner]))
+		for path, n := range dl.nodes[owner] {
+			live[path] = struct{}{}
+			children, err := childPaths([]byte(path), n.blob)
+			if err != nil {
+				return err
+			}
+			for _, child := range children {
+				live[string(child)] = struct{}{}
+			}
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+
+		for _, path := range paths {
+			n := dl.nodes[owner][path]
+			prev := pl.readDisk(owner, []byte(path))
+			if bytes.Equal(prev, n.blob) {
+				continue
+			}
+			hist.Nodes = append(hist.Nodes, trieHistoryNode{Owner: owner, Path: []byte(path), Blob: prev})
+			pl.writeDisk(batch, owner, []byte(path), n.blob)
+
+			// The children of the overwritten node become unreachable unless they are in the new state.
+			children, err := childPaths([]byte(path), prev)
+			if err != nil {
+				return err
+			}
+			for _, child := range children {
+				if err := pl.deleteStaleNodes(batch, hist, owner, child, live); err != nil {
+					return err
+				}
+			}
+			if _, err := database.WriteBatchesOverThreshold(batch); err != nil {
+				return err
+			}
+		}
+	}
+	if dl.root == types.EmptyRootHash {
+		// The whole account trie is deleted.
+		if err := pl.deleteTrie(batch, hist, common.Hash{}); err != nil {
+			return err
+		}
+	}
+	if err := pl.deleteStaleStorages(batch, hist, dl); err != nil {
+		return err
+	}
+
+	// The history is written first, so that the disk layer can be always rewound.
+	enc, err := rlp.EncodeToBytes(hist)
+	if err != nil {
+		return err
+	}
+	id := pl.diskID + 1
+	pl.diskDB.WriteTrieHistory(id, enc)
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	pl.diskDB.WritePersistentStateID(id)
+	if id > pl.maxHistory {
+		pl.diskDB.DeleteTrieHistory(id - pl.maxHistory)
+	}
+
+	pl.lock.Lock()
+	pl.diskRoot, pl.diskID = dl.root, id
+	pl.dropLayer(dl.root)
+	discarded := pl.discardLayers(func(root common.Hash) bool { return !pl.descends(root, dl.root) })
+	pl.lock.Unlock()
+
+	logger.Debug("Flattened a diff layer into disk", "block", dl.block, "root", dl.root, "id", id,
+		"updated", len(hist.Nodes), "discarded", discarded, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// deleteStaleNodes deletes the disk node of the owner at the path, which was a child of an overwritten node,
+// and the disk nodes reachable from it unless the path is in the new state. The stale nodes are found by
+// following the children of the deleted nodes, so that the disk is not scanned for each written node.
+// Nodes become unreachable only below an overwritten node, except the storage tries left without
+// a root node, which are deleted by deleteStaleStorages.
+func (pl *pathLayers) deleteStaleNodes(batch database.Batch, hist *trieHistory, owner common.Hash, path []byte, live map[string]struct{}) error {
+	if _, ok := live[string(path)]; ok {
+		return nil
+	}
+	blob := pl.readDisk(owner, path)
+	if len(blob) == 0 {
+		return nil
+	}
+	hist.Nodes = append(hist.Nodes, trieHistoryNode{Owner: owner, Path: path, Blob: blob})
+	pl.writeDisk(batch, owner, path, nil)
+
+	children, err := childPaths(path, blob)
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		if err := pl.deleteStaleNodes(batch, hist, owner, child, live); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// childPaths returns the paths of the children of the trie node blob at the path, which are stored
+// apart from it. The children embedded in the blob are followed. It returns nil if the blob is empty.
+func childPaths(path []byte, blob []byte) ([][]byte, error) {
+	if len(blob) == 0 {
+		return nil, nil
+	}
+	n, err := decodeNode(nil, blob)
+	if err != nil {
+		return nil, err
+	}
+	return appendChildPaths(nil, path, n), nil
+}
+
+func appendChildPaths(paths [][]byte, path []byte, n node) [][]byte {
+	switch n := n.(type) {
+	case *shortNode:
+		return appendChildPaths(paths, concat(path, n.Key...), n.Val)
+	case *fullNode:
+		for i := 0; i < 16; i++ {
+			paths = appendChildPaths(paths, concat(path, byte(i)), n.Children[i])
+		}
+	case hashNode:
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// deleteStaleStorages deletes the storage tries of the accounts which are deleted or left with an empty
+// storage by the diff layer. An account whose leaf is rewritten or deleted is either deleted, or has its
+// new leaf in the diff layer since the leaf blob depends on its path. The history must hold the account
+// trie nodes overwritten or deleted by the diff layer.
+func (pl *pathLayers) deleteStaleStorages(batch database.Batch, hist *trieHistory, dl *diffLayer) error {
+	// The accounts in the new state, and the accounts whose leaf is rewritten or deleted
+	var (
+		storageRoots = make(map[common.Hash]common.Hash)
+		accounts     []common.Hash
+	)
+	for path, n := range dl.nodes[common.Hash{}] {
+		if addrHash, value, ok := decodeAccountLeaf([]byte(path), n.blob); ok {
+			storageRoots[addrHash] = accountStorageRoot(value)
+			accounts = append(accounts, addrHash)
+		}
+	}
+	for _, n := range hist.Nodes {
+		if n.Owner != (common.Hash{}) {
+			continue
+		}
+		if addrHash, _, ok := decodeAccountLeaf(n.Path, n.Blob); ok {
+			if _, exists := storageRoots[addrHash]; !exists {
+				storageRoots[addrHash] = types.EmptyRootHash
+				accounts = append(accounts, addrHash)
+			}
+		}
+	}
+	sort.Slice(accounts, func(i, j int) bool { return bytes.Compare(accounts[i][:], accounts[j][:]) < 0 })
+
+	for _, addrHash := range accounts {
+		// A non-empty storage trie has its root node written by the diff layer if it is changed.
+		if storageRoots[addrHash] != types.EmptyRootHash {
+			continue
+		}
+		if _, ok := dl.nodes[addrHash]; ok || len(pl.readDisk(addrHash, nil)) == 0 {
+			continue
+		}
+		if err := pl.deleteTrie(batch, hist, addrHash); err != nil {
+			return err
+		}
+		if _, err := database.WriteBatchesOverThreshold(batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeAccountLeaf returns the account hash and the encoded account if the blob is an account trie leaf at the path.
+func decodeAccountLeaf(path []byte, blob []byte) (common.Hash, []byte, bool) {
+	if len(blob) == 0 {
+		return common.Hash{}, nil, false
+	}
+	n, err := decodeNode(nil, blob)
+	if err != nil {
+		return common.Hash{}, nil, false
+	}
+	short, ok := n.(*shortNode)
+	if !ok || !hasTerm(short.Key) {
+		return common.Hash{}, nil, false
+	}
+	value, ok := short.Val.(valueNode)
+	if !ok {
+		return common.Hash{}, nil, false
+	}
+	return common.BytesToHash(hexToKeybytes(concat(path, short.Key...))), value, true
+}
+
+// accountStorageRoot returns the storage root of the encoded account, which is empty if it is not a program account.
+func accountStorageRoot(value []byte) common.Hash {
+	serializer := account.NewAccountSerializer()
+	if err := rlp.DecodeBytes(value, serializer); err != nil {
+		return types.EmptyRootHash
+	}
+	if pa := account.GetProgramAccount(serializer.GetAccount()); pa != nil {
+		return pa.GetStorageRoot().Unextend()
+	}
+	return types.EmptyRootHash
+}
+
+// deleteTrie deletes all the disk nodes of the owner by iterating the key range of the trie.
+func (pl *pathLayers) deleteTrie(batch database.Batch, hist *trieHistory, owner common.Hash) error {
+	var (
+		db         = pl.diskDB.GetDatabase(database.StateTrieDB)
+		prefixes   [][]byte
+		pathOffset int
+	)
+	if owner == (common.Hash{}) {
+		prefixes = database.AccountTrieNodePrefixes(nil)
+		pathOffset = len(database.AccountTrieNodeKey(nil))
+	} else {
+		prefixes = [][]byte{database.StorageTrieNodeKey(owner, nil)}
+		pathOffset = len(database.StorageTrieNodeKey(owner, nil))
+	}
+	for _, prefix := range prefixes {
+		it := db.NewIterator(prefix, nil)
+		for it.Next() {
+			nodePath := it.Key()[pathOffset:]
+			hist.Nodes = append(hist.Nodes, trieHistoryNode{Owner: owner, Path: common.CopyBytes(nodePath), Blob: common.CopyBytes(it.Value())})
+			pl.writeDisk(batch, owner, nodePath, nil)
+		}
+		err := it.Error()
+		it.Release()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recover discards the layers on top of the given state root of the given block, and makes it
+// the head. If the state is older than the disk layer, the disk layer is rewound by applying
+// the trie histories, which discards all the diff layers.
+func (pl *pathLayers) recover(root common.Hash, block uint64) error {
+	pl.writeLock.Lock()
+	defer pl.writeLock.Unlock()
+
+	pl.lock.Lock()
+	if _, ok := pl.diffs[root]; ok || pl.diskRoot == root {
+		pl.discardLayers(func(r common.Hash) bool { return pl.descends(r, root) })
+		pl.head = root
+		pl.lock.Unlock()
+		return nil
+	}
+	pl.lock.Unlock()
+
+	// Make sure the state is reachable by the histories before modifying the disk layer.
+	var histories []*trieHistory
+	for id := pl.diskID; ; id-- {
+		if id == 0 {
+			return errStateNotRecoverable
+		}
+		h, err := pl.readHistory(id)
+		if err != nil || h.Block <= block {
+			// The history of the block next to the target must have the root as its parent.
+			return errStateNotRecoverable
+		}
+		histories = append(histories, h)
+		if h.Parent == root {
+			break
+		}
+	}
+
+	pl.lock.Lock()
+	defer pl.lock.Unlock()
+
+	pl.diffs = make(map[common.Hash]*diffLayer)
+	pl.index = make(nodeIndex)
+	pl.head = root
+	for _, h := range histories {
+		batch := pl.diskDB.NewBatch(database.StateTrieDB)
+		for _, n := range h.Nodes {
+			pl.writeDisk(batch, n.Owner, n.Path, n.Blob)
+			if _, err := database.WriteBatchesOverThreshold(batch); err != nil {
+				batch.Release()
+				return err
+			}
+		}
+		err := batch.Write()
+		batch.Release()
+		if err != nil {
+			return err
+		}
+		pl.diskDB.WritePersistentStateID(pl.diskID - 1)
+		pl.diskDB.DeleteTrieHistory(pl.diskID)
+		pl.diskID--
+		pl.diskRoot = h.Parent
+		logger.Info("Rewound the disk layer of the path-based scheme", "block", h.Block, "root", h.Parent, "id", pl.diskID)
+	}
+	return nil
+}
+
+// openPathLayers returns the layers of the path-based scheme if the database uses the scheme.
+func openPathLayers(diskDB database.DBManager) *pathLayers {
+	if diskDB.ReadStateScheme() != database.PathScheme {
+		return nil
+	}
+	return newPathLayers(diskDB)
+}
+
+// Scheme returns the storage scheme of the trie nodes.
+func (db *Database) Scheme() string {
+	if db.pathLayers != nil {
+		return database.PathScheme
+	}
+	return database.HashScheme
+}
+
+// nodeByPath retrieves a trie node of the owner at the path. In the hash-based scheme,
+// the owner and the path are ignored.
+func (db *Database) nodeByPath(owner common.Hash, path []byte, hash common.ExtHash) (n node, fromDB bool) {
+	if db.pathLayers == nil {
+		return db.node(hash)
+	}
+	if enc := db.getCachedNode(hash); enc != nil {
+		if dec, err := decodeNode(hash[:], enc); err == nil {
+			return dec, false
+		} else {
+			logger.Error("node from cached trie node fails to be decoded!", "err", err)
+		}
+	}
+
+	db.lock.RLock()
+	node := db.nodes[hash]
+	db.lock.RUnlock()
+	if node != nil {
+		return node.obj(hash), false
+	}
+
+	enc := db.pathLayers.node(owner, path, hash.Unextend())
+	if enc == nil {
+		return nil, true
+	}
+	db.setCachedNode(hash, enc)
+	recordTrieCacheMiss()
+	return mustDecodeNode(hash[:], enc), true
+}
+
+// nodeBlobByPath retrieves an encoded trie node of the owner at the path. In the hash-based
+// scheme, the owner and the path are ignored.
+func (db *Database) nodeBlobByPath(owner common.Hash, path []byte, hash common.ExtHash) ([]byte, error) {
+	if db.pathLayers == nil {
+		return db.Node(hash)
+	}
+	if common.EmptyExtHash(hash) {
+		return nil, ErrZeroHashNode
+	}
+	if enc := db.getCachedNode(hash); enc != nil {
+		return enc, nil
+	}
+
+	db.lock.RLock()
+	node := db.nodes[hash]
+	db.lock.RUnlock()
+	if node != nil {
+		return node.rlp(), nil
+	}
+
+	enc := db.pathLayers.node(owner, path, hash.Unextend())
+	if enc == nil {
+		return nil, &MissingNodeError{NodeHash: hash.Unextend(), Path: path}
+	}
+	db.setCachedNode(hash, enc)
+	recordTrieCacheMiss()
+	return enc, nil
+}
+
+// commitPath moves the trie of the root from memory into a new diff layer of the path-based scheme,
+// which is the state transition from the parent root.
+func (db *Database) commitPath(root, parent common.Hash, report bool, blockNum uint64) error {
+	hash := root.ExtendZero()
+
+	db.lock.RLock()
+	commitStart := time.Now()
+	db.diskDB.WritePreimages(0, db.preimages)
+	numPreimages := len(db.preimages)
+
+	nodes := make(nodeSet)
+	if err := db.collectPathNodes(hash, common.Hash{}, nil, nodes); err != nil {
+		db.lock.RUnlock()
+		return err
+	}
+	db.lock.RUnlock()
+
+	if err := db.pathLayers.add(root, parent, blockNum, nodes); err != nil {
+		return err
+	}
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.preimages = make(map[common.Hash][]byte)
+	db.preimagesSize = 0
+
+	numNodes, nodesSize := len(db.nodes), db.nodesSize
+	db.uncache(hash)
+	commitEnd := time.Now()
+
+	memcacheCommitTimeGauge.Update(int64(commitEnd.Sub(commitStart)))
+	memcacheCommitSizeMeter.Mark(int64(nodesSize - db.nodesSize))
+	memcacheCommitNodesMeter.Mark(int64(numNodes - len(db.nodes)))
+
+	localLogger := logger.Info
+	if !report {
+		localLogger = logger.Debug
+	}
+	localLogger("Committed trie into a diff layer", "blockNum", blockNum, "root", root,
+		"updated nodes", numNodes-len(db.nodes), "updated nodes size", nodesSize-db.nodesSize,
+		"time", commitEnd.Sub(commitStart), "livenodes", len(db.nodes), "livesize", db.nodesSize, "preimages", numPreimages)
+	return nil
+}
+
+// collectPathNodes collects the cached nodes reachable from the given node with their owners and paths.
+// A storage trie referenced from an account leaf is owned by the account hash, which is the key of the leaf.
+//
+// Note that this function assumes that the database's lock is held!
+func (db *Database) collectPathNodes(hash common.ExtHash, owner common.Hash, path []byte, nodes nodeSet) error {
+	cached, ok := db.nodes[hash]
+	if !ok {
+		return nil
+	}
+	nodes.add(owner, path, hash.Unextend(), cached.rlp())
+
+	switch n := cached.obj(hash).(type) {
+	case *shortNode:
+		childPath := concat(path, n.Key...)
+		if child, ok := n.Val.(hashNode); ok {
+			if err := db.collectPathNodes(common.BytesToExtHash(child), owner, childPath, nodes); err != nil {
+				return err
+			}
+		}
+		if len(cached.children) == 0 {
+			return nil
+		}
+		if _, ok := n.Val.(valueNode); !ok || !hasTerm(n.Key) || owner != (common.Hash{}) {
+			return fmt.Errorf("unexpected external reference from trie node %x (owner: %x, path: %x)", hash, owner, path)
+		}
+		account := common.BytesToHash(hexToKeybytes(childPath))
+		for child := range cached.children {
+			if err := db.collectPathNodes(child, account, nil, nodes); err != nil {
+				return err
+			}
+		}
+	case *fullNode:
+		for i := 0; i < 16; i++ {
+			if child, ok := n.Children[i].(hashNode); ok {
+				if err := db.collectPathNodes(common.BytesToExtHash(child), owner, concat(path, byte(i)), nodes); err != nil {
+					return err
+				}
+			}
+		}
+		if len(cached.children) != 0 {
+			return fmt.Errorf("unexpected external reference from trie node %x (owner: %x, path: %x)", hash, owner, path)
+		}
+	}
+	return nil
+}
+
+// CommitState commits the state trie of the given block, which is the state transition from
+// the parent root. In the path-based scheme, the parent must be the disk layer or one of the diff layers.
+// In the hash-based scheme, it is the same as Commit.
+func (db *Database) CommitState(root, parent common.Hash, report bool, blockNum uint64) error {
+	if db.pathLayers == nil {
+		return db.Commit(root, report, blockNum)
+	}
+	return db.commitPath(root, parent, report, blockNum)
+}
+
+// Flush writes the diff layers of the path-based scheme up to the given state root into the disk
+// layer, discarding the side chains. It should be called with the state of the current block before
+// closing the database, since the diff layers are kept only in memory. It is no-op in the hash-based scheme.
+func (db *Database) Flush(root common.Hash) error {
+	if db.pathLayers == nil {
+		return nil
+	}
+	return db.pathLayers.flush(root)
+}
+
+// Recover reverts the state of the path-based scheme to the given state root of the given block,
+// discarding the diff layers on top of it and rewinding the disk layer with the trie histories.
+// It is no-op in the hash-based scheme.
+func (db *Database) Recover(root common.Hash, block uint64) error {
+	if db.pathLayers == nil {
+		return nil
+	}
+	return db.pathLayers.recover(root, block)
+}
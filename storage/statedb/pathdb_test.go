@@ -0,0 +1,475 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package statedb
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/blockchain/types/account"
+	"github.com/kaiachain/kaia/blockchain/types/accountkey"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/params"
+	"github.com/kaiachain/kaia/rlp"
+	"github.com/kaiachain/kaia/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPathSchemeDB() database.DBManager {
+	dbm := database.NewMemoryDBManager()
+	dbm.WriteStateScheme(database.PathScheme)
+	return dbm
+}
+
+// updatePathTrie applies the updates (deletes if the value is empty) to the trie of the root and commits it.
+func updatePathTrie(t *testing.T, db *Database, root common.Hash, updates map[string]string, blockNum uint64) common.Hash {
+	tr, err := NewTrie(root, db, nil)
+	require.NoError(t, err)
+	for k, v := range updates {
+		if v == "" {
+			require.NoError(t, tr.TryDelete(crypto.Keccak256([]byte(k))))
+		} else {
+			require.NoError(t, tr.TryUpdate(crypto.Keccak256([]byte(k)), []byte(v)))
+		}
+	}
+	newRoot, err := tr.Commit(nil)
+	require.NoError(t, err)
+	require.NoError(t, db.CommitState(newRoot, root, false, blockNum))
+	return newRoot
+}
+
+// encodeContractAccount returns the account trie leaf value of a smart contract account with the storage root.
+func encodeContractAccount(t *testing.T, storageRoot common.Hash) []byte {
+	acc, err := account.NewAccountWithMap(account.SmartContractAccountType, map[account.AccountValueKeyType]interface{}{
+		account.AccountValueKeyNonce:         uint64(1),
+		account.AccountValueKeyBalance:       big.NewInt(0),
+		account.AccountValueKeyHumanReadable: false,
+		account.AccountValueKeyAccountKey:    accountkey.NewAccountKeyLegacy(),
+		account.AccountValueKeyStorageRoot:   storageRoot,
+		account.AccountValueKeyCodeHash:      crypto.Keccak256([]byte("code")),
+		account.AccountValueKeyCodeInfo:      params.CodeInfo(0),
+	})
+	require.NoError(t, err)
+	enc, err := rlp.EncodeToBytes(account.NewAccountSerializerWithAccount(acc))
+	require.NoError(t, err)
+	return enc
+}
+
+func checkPathTrie(t *testing.T, db *Database, root common.Hash, expected map[string]string) {
+	tr, err := NewTrie(root, db, nil)
+	require.NoError(t, err)
+	for k, v := range expected {
+		got, err := tr.TryGet(crypto.Keccak256([]byte(k)))
+		require.NoError(t, err)
+		assert.Equal(t, v, string(got), "key: %s", k)
+	}
+}
+
+// countAccountTrieNodes returns the number of account trie nodes stored in the disk layer.
+func countAccountTrieNodes(dbm database.DBManager) int {
+	count := 0
+	for _, prefix := range database.AccountTrieNodePrefixes(nil) {
+		it := dbm.GetDatabase(database.StateTrieDB).NewIterator(prefix, nil)
+		for it.Next() {
+			count++
+		}
+		it.Release()
+	}
+	return count
+}
+
+// checkNodeIndex checks that the index holds exactly the nodes of the diff layers.
+func checkNodeIndex(t *testing.T, pl *pathLayers) {
+	expected := make(nodeIndex)
+	for _, dl := range pl.diffs {
+		expected.add(dl)
+	}
+	require.Equal(t, len(expected), len(pl.index))
+	for owner, paths := range expected {
+		require.Equal(t, len(paths), len(pl.index[owner]), "owner %x", owner)
+		for path, layers := range paths {
+			assert.ElementsMatch(t, layers, pl.index[owner][path], "owner %x, path %x", owner, path)
+		}
+	}
+}
+
+func TestPathScheme_CommitAndFlush(t *testing.T) {
+	dbm := newPathSchemeDB()
+	db := NewDatabase(dbm)
+	assert.Equal(t, database.PathScheme, db.Scheme())
+
+	kvs := map[string]string{}
+	for i := 0; i < 100; i++ {
+		kvs[fmt.Sprintf("key%d", i)] = fmt.Sprintf("value%d", i)
+	}
+	root := updatePathTrie(t, db, types.EmptyRootHash, kvs, 1)
+	assert.True(t, db.DoesExistCachedNode(root.ExtendZero()))
+	assert.False(t, db.DoesExistNodeInPersistent(root.ExtendZero()))
+	checkPathTrie(t, db, root, kvs)
+
+	// The diff layer is not visible to another database before it is flushed.
+	_, err := NewTrie(root, NewDatabase(dbm), nil)
+	assert.Error(t, err)
+
+	require.NoError(t, db.Flush(root))
+	assert.True(t, db.DoesExistNodeInPersistent(root.ExtendZero()))
+
+	reopened := NewDatabase(dbm)
+	assert.True(t, reopened.DoesExistNodeInPersistent(root.ExtendZero()))
+	checkPathTrie(t, reopened, root, kvs)
+}
+
+func TestPathScheme_StorageTrie(t *testing.T) {
+	dbm := newPathSchemeDB()
+	db := NewDatabase(dbm)
+	owner := crypto.Keccak256Hash([]byte("account"))
+
+	// Commit a storage trie, and link it from the account leaf.
+	stTrie, err := NewStorageTrie(common.ExtHash{}, db, &TrieOpts{Owner: owner})
+	require.NoError(t, err)
+	require.NoError(t, stTrie.TryUpdate(crypto.Keccak256([]byte("slot")), []byte("storage value")))
+	stRoot, err := stTrie.CommitExt(nil)
+	require.NoError(t, err)
+
+	accTrie, err := NewTrie(common.Hash{}, db, nil)
+	require.NoError(t, err)
+	require.NoError(t, accTrie.TryUpdate(owner[:], []byte("account with storage")))
+	require.NoError(t, accTrie.TryUpdate(crypto.Keccak256([]byte("other")), []byte("account without storage")))
+	root, err := accTrie.Commit(func(_ [][]byte, _ []byte, leaf []byte, parent common.ExtHash, _ int) error {
+		if string(leaf) == "account with storage" {
+			db.Reference(stRoot, parent)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Commit(root, false, 1))
+	require.NoError(t, db.Flush(root))
+
+	// The storage trie nodes are stored under the owner.
+	assert.NotNil(t, dbm.ReadStorageTrieNode(owner, nil))
+
+	reopened := NewDatabase(dbm)
+	stTrie, err = NewStorageTrie(stRoot, reopened, &TrieOpts{Owner: owner})
+	require.NoError(t, err)
+	value, err := stTrie.TryGet(crypto.Keccak256([]byte("slot")))
+	require.NoError(t, err)
+	assert.Equal(t, "storage value", string(value))
+
+	// The storage trie cannot be found without the owner.
+	_, err = NewStorageTrie(stRoot, reopened, nil)
+	assert.Error(t, err)
+}
+
+func TestPathScheme_DeleteStaleNodes(t *testing.T) {
+	dbm := newPathSchemeDB()
+	db := NewDatabase(dbm)
+
+	kvs := map[string]string{}
+	for i := 0; i < 1000; i++ {
+		kvs[fmt.Sprintf("key%d", i)] = fmt.Sprintf("value%d", i)
+	}
+	root := updatePathTrie(t, db, types.EmptyRootHash, kvs, 1)
+	require.NoError(t, db.Flush(root))
+	numNodes := countAccountTrieNodes(dbm)
+
+	// Delete most of the keys. The unreachable nodes should be removed from the disk layer.
+	updates := map[string]string{}
+	for i := 10; i < 1000; i++ {
+		updates[fmt.Sprintf("key%d", i)] = ""
+	}
+	root = updatePathTrie(t, db, root, updates, 2)
+	require.NoError(t, db.Flush(root))
+
+	tr, err := NewTrie(root, NewDatabase(dbm), nil)
+	require.NoError(t, err)
+	reachable := 0
+	for it := tr.NodeIterator(nil); it.Next(true); {
+		if it.Hash() != (common.Hash{}) {
+			reachable++
+		}
+	}
+	assert.Less(t, reachable, numNodes)
+	assert.Equal(t, reachable, countAccountTrieNodes(dbm))
+
+	// Deleting all the keys removes all the nodes.
+	updates = map[string]string{}
+	for i := 0; i < 10; i++ {
+		updates[fmt.Sprintf("key%d", i)] = ""
+	}
+	root = updatePathTrie(t, db, root, updates, 3)
+	assert.Equal(t, types.EmptyRootHash, root)
+	require.NoError(t, db.Flush(root))
+	assert.Equal(t, 0, countAccountTrieNodes(dbm))
+	assert.True(t, NewDatabase(dbm).DoesExistNodeInPersistent(root.ExtendZero()))
+}
+
+func TestPathScheme_DeleteStaleNodesOnReshape(t *testing.T) {
+	dbm := newPathSchemeDB()
+	db := NewDatabase(dbm)
+	db.pathLayers.maxDiffs = 1
+
+	// Keep inserting and deleting keys, which splits and collapses the short and full nodes.
+	var (
+		root  = types.EmptyRootHash
+		kvs   = map[string]string{}
+		roots []common.Hash
+	)
+	for block := uint64(1); block <= 20; block++ {
+		updates := map[string]string{}
+		for i := 0; i < 30; i++ {
+			key := fmt.Sprintf("key%d", (int(block)*37+i*11)%200)
+			if _, ok := kvs[key]; ok && i%3 != 0 {
+				updates[key] = ""
+				delete(kvs, key)
+			} else {
+				updates[key] = fmt.Sprintf("value%d-%d", block, i)
+				kvs[key] = updates[key]
+			}
+		}
+		root = updatePathTrie(t, db, root, updates, block)
+		roots = append(roots, root)
+	}
+	require.NoError(t, db.Flush(root))
+
+	// The disk layer holds exactly the nodes reachable from the state root.
+	tr, err := NewTrie(root, NewDatabase(dbm), nil)
+	require.NoError(t, err)
+	reachable := 0
+	for it := tr.NodeIterator(nil); it.Next(true); {
+		if it.Hash() != (common.Hash{}) {
+			reachable++
+		}
+	}
+	assert.Equal(t, reachable, countAccountTrieNodes(dbm))
+	checkPathTrie(t, NewDatabase(dbm), root, kvs)
+
+	// The deleted nodes are restored by rewinding the disk layer.
+	require.NoError(t, db.Recover(roots[9], 10))
+	tr, err = NewTrie(roots[9], NewDatabase(dbm), nil)
+	require.NoError(t, err)
+	reachable = 0
+	for it := tr.NodeIterator(nil); it.Next(true); {
+		require.NoError(t, it.Error())
+		if it.Hash() != (common.Hash{}) {
+			reachable++
+		}
+	}
+	assert.Equal(t, reachable, countAccountTrieNodes(dbm))
+}
+
+func TestPathScheme_Recover(t *testing.T) {
+	dbm := newPathSchemeDB()
+	db := NewDatabase(dbm)
+	db.pathLayers.maxDiffs = 1
+
+	var (
+		roots  []common.Hash
+		states []map[string]string
+		root   = types.EmptyRootHash
+		kvs    = map[string]string{}
+	)
+	for block := uint64(1); block <= 5; block++ {
+		updates := map[string]string{}
+		for i := 0; i < 50; i++ {
+			updates[fmt.Sprintf("key%d-%d", block, i)] = fmt.Sprintf("value%d", i)
+			kvs[fmt.Sprintf("key%d-%d", block, i)] = fmt.Sprintf("value%d", i)
+		}
+		if block > 1 {
+			updates[fmt.Sprintf("key%d-%d", block-1, 0)] = "" // delete a key of the previous block
+			delete(kvs, fmt.Sprintf("key%d-%d", block-1, 0))
+		}
+		root = updatePathTrie(t, db, root, updates, block)
+		roots = append(roots, root)
+
+		state := make(map[string]string)
+		for k, v := range kvs {
+			state[k] = v
+		}
+		states = append(states, state)
+	}
+	// Only the last state is in the diff layer, and the others are flattened.
+	assert.True(t, db.DoesExistCachedNode(roots[4].ExtendZero()))
+	assert.True(t, db.DoesExistNodeInPersistent(roots[3].ExtendZero()))
+	_, err := NewTrie(roots[1], db, nil)
+	assert.Error(t, err)
+
+	// Discard the diff layer, and rewind the disk layer.
+	require.NoError(t, db.Recover(roots[1], 2))
+	assert.False(t, db.DoesExistCachedNode(roots[4].ExtendZero()))
+	assert.True(t, db.DoesExistNodeInPersistent(roots[1].ExtendZero()))
+	checkPathTrie(t, db, roots[1], states[1])
+	checkPathTrie(t, NewDatabase(dbm), roots[1], states[1])
+	_, err = NewTrie(roots[3], db, nil)
+	assert.Error(t, err)
+
+	// The state cannot be recovered to an unknown root.
+	assert.Equal(t, errStateNotRecoverable, db.Recover(common.HexToHash("0x1234"), 3))
+
+	// The chain continues from the recovered state.
+	root = updatePathTrie(t, db, roots[1], map[string]string{"new": "value"}, 3)
+	require.NoError(t, db.Flush(root))
+	states[1]["new"] = "value"
+	checkPathTrie(t, NewDatabase(dbm), root, states[1])
+}
+
+func TestPathScheme_SideChain(t *testing.T) {
+	dbm := newPathSchemeDB()
+	db := NewDatabase(dbm)
+
+	root1 := updatePathTrie(t, db, types.EmptyRootHash, map[string]string{"a": "1"}, 1)
+	require.NoError(t, db.Flush(root1))
+	root2 := updatePathTrie(t, db, root1, map[string]string{"b": "2"}, 2)
+
+	// The state of a side chain is stacked on its parent next to the canonical one.
+	side2 := updatePathTrie(t, db, root1, map[string]string{"c": "3"}, 2)
+	assert.Equal(t, side2, db.pathLayers.headRoot())
+	checkPathTrie(t, db, root2, map[string]string{"a": "1", "b": "2", "c": ""})
+	checkPathTrie(t, db, side2, map[string]string{"a": "1", "b": "", "c": "3"})
+
+	// Committing an existing state is no-op except that it becomes the head.
+	assert.NoError(t, db.CommitState(root2, root1, false, 2))
+	assert.NoError(t, db.CommitState(root1, types.EmptyRootHash, false, 1))
+	assert.Equal(t, root1, db.pathLayers.headRoot())
+	assert.True(t, db.pathLayers.hasDiff(root2))
+	assert.True(t, db.pathLayers.hasDiff(side2))
+
+	checkNodeIndex(t, db.pathLayers)
+
+	// The parent must be one of the layers.
+	assert.ErrorIs(t, db.CommitState(common.HexToHash("0x1234"), common.HexToHash("0x5678"), false, 3), errUnknownParent)
+
+	// Flattening the side chain discards the canonical chain forked below it.
+	db.pathLayers.maxDiffs = 1
+	side3 := updatePathTrie(t, db, side2, map[string]string{"d": "4"}, 3)
+	assert.True(t, db.DoesExistNodeInPersistent(side2.ExtendZero()))
+	assert.True(t, db.pathLayers.hasDiff(side3))
+	assert.False(t, db.pathLayers.hasDiff(root2))
+	_, err := NewTrie(root2, db, nil)
+	assert.Error(t, err)
+	assert.ErrorIs(t, db.CommitState(common.HexToHash("0x1234"), root2, false, 3), errUnknownParent)
+
+	assert.ErrorIs(t, db.Flush(root2), errUnknownState)
+	checkNodeIndex(t, db.pathLayers)
+	require.NoError(t, db.Flush(side3))
+	assert.Empty(t, db.pathLayers.index)
+	checkPathTrie(t, NewDatabase(dbm), side3, map[string]string{"a": "1", "b": "", "c": "3", "d": "4"})
+}
+
+func TestPathScheme_RecoverSideChain(t *testing.T) {
+	db := NewDatabase(newPathSchemeDB())
+
+	root1 := updatePathTrie(t, db, types.EmptyRootHash, map[string]string{"a": "1"}, 1)
+	root2 := updatePathTrie(t, db, root1, map[string]string{"b": "2"}, 2)
+	root3 := updatePathTrie(t, db, root2, map[string]string{"c": "3"}, 3)
+	side2 := updatePathTrie(t, db, root1, map[string]string{"d": "4"}, 2)
+
+	// Recovering the canonical state discards the layers on top of it only.
+	require.NoError(t, db.Recover(root2, 2))
+	assert.Equal(t, root2, db.pathLayers.headRoot())
+	assert.False(t, db.pathLayers.hasDiff(root3))
+	assert.True(t, db.pathLayers.hasDiff(root1))
+	assert.True(t, db.pathLayers.hasDiff(side2))
+	checkNodeIndex(t, db.pathLayers)
+
+	// Flushing writes the chain of the state only.
+	require.NoError(t, db.Flush(root2))
+	assert.True(t, db.DoesExistNodeInPersistent(root2.ExtendZero()))
+	assert.False(t, db.pathLayers.hasDiff(side2))
+	checkPathTrie(t, db, root2, map[string]string{"a": "1", "b": "2", "c": "", "d": ""})
+}
+
+func TestPathScheme_DeleteStaleStorages(t *testing.T) {
+	var (
+		owner = crypto.Keccak256Hash([]byte("contract"))
+		other = crypto.Keccak256Hash([]byte("other"))
+	)
+	for _, tc := range []struct {
+		name  string
+		leaf  []byte // the new account trie leaf of the owner, which deletes the account if nil
+		alive bool   // whether the storage trie is kept
+	}{
+		{"delete account", nil, false},
+		{"empty storage", encodeContractAccount(t, types.EmptyRootHash), false},
+		{"keep storage", nil, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dbm := newPathSchemeDB()
+			db := NewDatabase(dbm)
+
+			// Commit a contract account with its storage trie.
+			stTrie, err := NewStorageTrie(common.ExtHash{}, db, &TrieOpts{Owner: owner})
+			require.NoError(t, err)
+			for i := 0; i < 20; i++ {
+				require.NoError(t, stTrie.TryUpdate(crypto.Keccak256([]byte(fmt.Sprintf("slot%d", i))), []byte("storage value")))
+			}
+			stRoot, err := stTrie.CommitExt(nil)
+			require.NoError(t, err)
+			leaf := encodeContractAccount(t, stRoot.Unextend())
+
+			accTrie, err := NewTrie(common.Hash{}, db, nil)
+			require.NoError(t, err)
+			require.NoError(t, accTrie.TryUpdate(owner[:], leaf))
+			require.NoError(t, accTrie.TryUpdate(other[:], []byte("account without storage")))
+			root1, err := accTrie.Commit(func(_ [][]byte, _ []byte, l []byte, parent common.ExtHash, _ int) error {
+				if string(l) == string(leaf) {
+					db.Reference(stRoot, parent)
+				}
+				return nil
+			})
+			require.NoError(t, err)
+			require.NoError(t, db.CommitState(root1, types.EmptyRootHash, false, 1))
+			require.NoError(t, db.Flush(root1))
+			require.NotNil(t, dbm.ReadStorageTrieNode(owner, nil))
+
+			// Update the account, or another account if the storage is kept.
+			accTrie, err = NewTrie(root1, db, nil)
+			require.NoError(t, err)
+			switch {
+			case tc.alive:
+				require.NoError(t, accTrie.TryUpdate(other[:], []byte("updated account")))
+			case tc.leaf == nil:
+				require.NoError(t, accTrie.TryDelete(owner[:]))
+			default:
+				require.NoError(t, accTrie.TryUpdate(owner[:], tc.leaf))
+			}
+			root2, err := accTrie.Commit(nil)
+			require.NoError(t, err)
+			require.NoError(t, db.CommitState(root2, root1, false, 2))
+			require.NoError(t, db.Flush(root2))
+
+			if tc.alive {
+				assert.NotNil(t, dbm.ReadStorageTrieNode(owner, nil))
+				return
+			}
+			it := dbm.GetDatabase(database.StateTrieDB).NewIterator(database.StorageTrieNodeKey(owner, nil), nil)
+			assert.False(t, it.Next(), "stale storage trie node")
+			it.Release()
+
+			// The storage trie is restored by rewinding the disk layer.
+			require.NoError(t, db.Recover(root1, 1))
+			stTrie, err = NewStorageTrie(stRoot, NewDatabase(dbm), &TrieOpts{Owner: owner})
+			require.NoError(t, err)
+			value, err := stTrie.TryGet(crypto.Keccak256([]byte("slot0")))
+			require.NoError(t, err)
+			assert.Equal(t, "storage value", string(value))
+		})
+	}
+}
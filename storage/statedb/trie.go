@@ -39,6 +39,10 @@ type TrieOpts struct {
 	// will schedule obsolete nodes to be pruned when the given block number becomes obsolete.
 	// This option is only viable when the pruning is enabled on database.
 	PruningBlockNumber uint64
+
+	// Owner is the hash of the account address owning the storage trie, and zero for the account trie.
+	// It is required to locate the trie nodes in the path-based scheme.
+	Owner common.Hash
 }
 
 // LeafCallback is a callback type invoked when a trie operation reaches a leaf
@@ -229,7 +233,7 @@ func (t *Trie) tryGetNode(origNode node, path []byte, pos int) (item []byte, new
 		if hash == nil {
 			return nil, origNode, 0, errors.New("non-consensus node")
 		}
-		blob, err := t.db.Node(common.BytesToExtHash(hash))
+		blob, err := t.db.nodeBlobByPath(t.Owner, path[:pos], common.BytesToExtHash(hash))
 		return blob, origNode, 1, err
 	}
 	// Path still needs to be traversed, descend into children
@@ -480,7 +484,7 @@ func (t *Trie) delete(n node, prefix, key []byte) (bool, node, error) {
 				// shortNode{..., shortNode{...}}.  Since the entry
 				// might not be loaded yet, resolve it just for this
 				// check.
-				cnode, err := t.resolve(n.Children[pos], prefix)
+				cnode, err := t.resolve(n.Children[pos], append(prefix, byte(pos)))
 				if err != nil {
 					return false, nil, err
 				}
@@ -537,7 +541,7 @@ func (t *Trie) resolve(n node, prefix []byte) (node, error) {
 
 func (t *Trie) resolveHash(n hashNode, prefix []byte) (node, error) {
 	hash := common.BytesToExtHash(n)
-	node, fromDB := t.db.node(hash)
+	node, fromDB := t.db.nodeByPath(t.Owner, prefix, hash)
 	if t.Prefetching && fromDB {
 		memcacheCleanPrefetchMissMeter.Mark(1)
 	}
@@ -501,6 +501,9 @@ func TestDefaultTxsWithDefaultAccountKey(t *testing.T) {
 		if i == types.TxTypeKaiaLast {
 			i = types.TxTypeEthereumAccessList
 		}
+		if i == types.TxTypeEthereumBlob {
+			continue // blob txs cannot be sent without a sidecar.
+		}
 
 		_, err := types.NewTxInternalData(i)
 		if err == nil {
@@ -1862,6 +1865,9 @@ func TestRoleBasedKeySendTx(t *testing.T) {
 		if i == types.TxTypeKaiaLast {
 			i = types.TxTypeEthereumAccessList
 		}
+		if i == types.TxTypeEthereumBlob {
+			continue // blob txs cannot be sent without a sidecar.
+		}
 
 		if i.IsLegacyTransaction() || i.IsEthTypedTransaction() {
 			continue // accounts with role-based key cannot send the legacy tx and ethereum typed tx.
@@ -0,0 +1,135 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package tests
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/kaiachain/kaia/blockchain"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/log"
+	"github.com/kaiachain/kaia/work"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestApplyTransactionsWithBundles checks that the bundles are executed at the top of
+// the block, and a bundle is reverted entirely if any of its transactions fails.
+func TestApplyTransactionsWithBundles(t *testing.T) {
+	log.EnableLogForTest(log.LvlCrit, log.LvlError)
+
+	bcdata, err := NewBCData(6, 4)
+	require.NoError(t, err)
+	defer bcdata.Shutdown()
+
+	var (
+		signer    = types.LatestSignerForChainID(bcdata.bc.Config().ChainID)
+		gasPrice  = new(big.Int).SetUint64(bcdata.bc.Config().UnitPrice)
+		recipient = common.HexToAddress("0xAAAA")
+	)
+	statedb, err := bcdata.bc.State()
+	require.NoError(t, err)
+
+	transfer := func(key *ecdsa.PrivateKey, nonce uint64) *types.Transaction {
+		tx := types.NewTransaction(nonce, recipient, big.NewInt(1), 21000, gasPrice, nil)
+		require.NoError(t, tx.SignWithKeys(signer, []*ecdsa.PrivateKey{key}))
+		return tx
+	}
+	var (
+		key0, key1     = bcdata.privKeys[0], bcdata.privKeys[1]
+		nonce0, nonce1 = statedb.GetNonce(*bcdata.addrs[0]), statedb.GetNonce(*bcdata.addrs[1])
+
+		succeeded = types.NewBundle(types.Transactions{transfer(key0, nonce0), transfer(key1, nonce1)}, 0)
+		// The second transaction fails due to the nonce gap, so the first one is reverted as well.
+		failed = types.NewBundle(types.Transactions{transfer(key0, nonce0+1), transfer(key1, nonce1+2)}, 0)
+		// The transaction has the same nonce with the first transaction of the failed bundle.
+		single = transfer(key0, nonce0+1)
+	)
+
+	header, err := bcdata.prepareHeader()
+	require.NoError(t, err)
+	pending := types.NewTransactionsByPriceAndNonce(signer, map[common.Address]types.Transactions{
+		*bcdata.addrs[0]: {single},
+	}, header.BaseFee)
+
+	task := work.NewTask(bcdata.bc.Config(), signer, statedb, header)
+	task.ApplyTransactions(pending, []*types.Bundle{succeeded, failed}, bcdata.bc, *bcdata.rewardBase)
+
+	txs := task.Transactions()
+	require.Len(t, txs, 3)
+	assert.Equal(t, succeeded.Txs[0].Hash(), txs[0].Hash())
+	assert.Equal(t, succeeded.Txs[1].Hash(), txs[1].Hash())
+	assert.Equal(t, single.Hash(), txs[2].Hash())
+	assert.Len(t, task.Receipts(), 3)
+
+	// The transactions of the reverted bundle are reported as skipped.
+	skipped := task.SkippedTxs()
+	require.Len(t, skipped, 2)
+	assert.Equal(t, failed.Txs[0].Hash(), skipped[0].Tx.Hash())
+	assert.Equal(t, failed.Txs[1].Hash(), skipped[1].Tx.Hash())
+	assert.ErrorIs(t, skipped[0].Err, blockchain.ErrNonceTooHigh)
+	assert.Equal(t, []common.Hash{failed.Hash()}, task.FailedBundles())
+
+	assert.Equal(t, nonce0+2, task.State().GetNonce(*bcdata.addrs[0]))
+	assert.Equal(t, nonce1+1, task.State().GetNonce(*bcdata.addrs[1]))
+	assert.Equal(t, big.NewInt(3), task.State().GetBalance(recipient))
+
+	var gasUsed uint64
+	for _, r := range task.Receipts() {
+		gasUsed += r.GasUsed
+	}
+	assert.Equal(t, gasUsed, header.GasUsed)
+}
+
+// TestApplyTransactionsBundleLimit checks that up to work.MaxBundlesPerBlock bundles are tried in a block.
+func TestApplyTransactionsBundleLimit(t *testing.T) {
+	log.EnableLogForTest(log.LvlCrit, log.LvlError)
+
+	bcdata, err := NewBCData(6, 4)
+	require.NoError(t, err)
+	defer bcdata.Shutdown()
+
+	var (
+		signer   = types.LatestSignerForChainID(bcdata.bc.Config().ChainID)
+		gasPrice = new(big.Int).SetUint64(bcdata.bc.Config().UnitPrice)
+	)
+	statedb, err := bcdata.bc.State()
+	require.NoError(t, err)
+	nonce := statedb.GetNonce(*bcdata.addrs[0])
+
+	// Every bundle fails due to the nonce gap, so that all of them are tried.
+	bundles := make([]*types.Bundle, work.MaxBundlesPerBlock+1)
+	for i := range bundles {
+		tx := types.NewTransaction(nonce+1, common.HexToAddress("0xAAAA"), big.NewInt(int64(i+1)), 21000, gasPrice, nil)
+		require.NoError(t, tx.SignWithKeys(signer, []*ecdsa.PrivateKey{bcdata.privKeys[0]}))
+		bundles[i] = types.NewBundle(types.Transactions{tx}, 0)
+	}
+
+	header, err := bcdata.prepareHeader()
+	require.NoError(t, err)
+	pending := types.NewTransactionsByPriceAndNonce(signer, map[common.Address]types.Transactions{}, header.BaseFee)
+
+	task := work.NewTask(bcdata.bc.Config(), signer, statedb, header)
+	task.ApplyTransactions(pending, bundles, bcdata.bc, *bcdata.rewardBase)
+
+	assert.Len(t, task.FailedBundles(), work.MaxBundlesPerBlock)
+	assert.NotContains(t, task.FailedBundles(), bundles[work.MaxBundlesPerBlock].Hash())
+	assert.Len(t, task.SkippedTxs(), work.MaxBundlesPerBlock)
+}
@@ -204,6 +204,13 @@ func newKaiaNode(t *testing.T, dir string, validator *TestAccountType, config *p
 	return fullNode, kaiaNode, nil
 }
 
+// isNonceCollision returns true if the transaction was rejected since a transaction
+// of the same nonce is already in the pool. After the Magma hardfork, the pool
+// rejects it as an underpriced replacement.
+func isNonceCollision(err error) bool {
+	return errors.Is(err, blockchain.ErrAlreadyNonceExistInPool) || errors.Is(err, blockchain.ErrReplaceUnderpriced)
+}
+
 // deployRandomTxs creates a random transaction
 func deployRandomTxs(t *testing.T, txpool work.TxPool, chainId *big.Int, sender *TestAccountType, txNum int) []*types.Transaction {
 	var tx *types.Transaction
@@ -223,7 +230,7 @@ func deployRandomTxs(t *testing.T, txpool work.TxPool, chainId *big.Int, sender
 		tx, _ = genLegacyTransaction(t, signer, sender, receiver, nil, gasPrice)
 
 		err = txpool.AddLocal(tx)
-		require.True(t, err == nil || err == blockchain.ErrAlreadyNonceExistInPool)
+		require.True(t, err == nil || isNonceCollision(err))
 
 		txs = append(txs, tx)
 		sender.AddNonce()
@@ -239,7 +246,7 @@ func deployValueTransferTx(t *testing.T, txpool work.TxPool, chainId *big.Int, s
 	tx, _ := genLegacyTransaction(t, signer, sender, toAcc, nil, gasPrice)
 
 	err := txpool.AddLocal(tx)
-	require.True(t, err == nil || err == blockchain.ErrAlreadyNonceExistInPool)
+	require.True(t, err == nil || isNonceCollision(err))
 
 	sender.AddNonce()
 	return tx
@@ -269,7 +276,7 @@ func deployContractDeployTx(t *testing.T, txpool work.TxPool, chainId *big.Int,
 	require.Nil(t, err)
 
 	err = txpool.AddLocal(tx)
-	if err != nil && err != blockchain.ErrAlreadyNonceExistInPool {
+	if err != nil && !isNonceCollision(err) {
 		t.Fatal(err)
 	}
 
@@ -299,7 +306,7 @@ func deployContractExecutionTx(t *testing.T, txpool work.TxPool, chainId *big.In
 	require.Nil(t, err)
 
 	err = txpool.AddLocal(tx)
-	if err != nil && err != blockchain.ErrAlreadyNonceExistInPool {
+	if err != nil && !isNonceCollision(err) {
 		t.Fatal(err)
 	}
 
@@ -0,0 +1,89 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package tests
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/kaiachain/kaia/blockchain"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/log"
+	"github.com/kaiachain/kaia/work"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestApplyTransactionsSkippedTxs checks that the transactions which cannot be
+// included in the block are reported with the reasons.
+func TestApplyTransactionsSkippedTxs(t *testing.T) {
+	log.EnableLogForTest(log.LvlCrit, log.LvlError)
+
+	bcdata, err := NewBCData(6, 4)
+	require.NoError(t, err)
+	defer bcdata.Shutdown()
+
+	var (
+		signer    = types.LatestSignerForChainID(bcdata.bc.Config().ChainID)
+		gasPrice  = new(big.Int).SetUint64(bcdata.bc.Config().UnitPrice)
+		recipient = common.HexToAddress("0xAAAA")
+	)
+	statedb, err := bcdata.bc.State()
+	require.NoError(t, err)
+
+	transfer := func(key *ecdsa.PrivateKey, nonce uint64) *types.Transaction {
+		tx := types.NewTransaction(nonce, recipient, big.NewInt(1), 21000, gasPrice, nil)
+		require.NoError(t, tx.SignWithKeys(signer, []*ecdsa.PrivateKey{key}))
+		return tx
+	}
+	poor, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	var (
+		key0, key1     = bcdata.privKeys[0], bcdata.privKeys[1]
+		nonce0, nonce1 = statedb.GetNonce(*bcdata.addrs[0]), statedb.GetNonce(*bcdata.addrs[1])
+
+		included  = transfer(key0, nonce0)
+		nonceGap  = transfer(key1, nonce1+1)
+		noBalance = transfer(poor, 0)
+	)
+
+	header, err := bcdata.prepareHeader()
+	require.NoError(t, err)
+	pending := types.NewTransactionsByPriceAndNonce(signer, map[common.Address]types.Transactions{
+		*bcdata.addrs[0]:                       {included},
+		*bcdata.addrs[1]:                       {nonceGap},
+		crypto.PubkeyToAddress(poor.PublicKey): {noBalance},
+	}, header.BaseFee)
+
+	task := work.NewTask(bcdata.bc.Config(), signer, statedb, header)
+	task.ApplyTransactions(pending, nil, bcdata.bc, *bcdata.rewardBase)
+
+	require.Len(t, task.Transactions(), 1)
+	assert.Equal(t, included.Hash(), task.Transactions()[0].Hash())
+
+	reasons := make(map[common.Hash]error)
+	for _, skipped := range task.SkippedTxs() {
+		reasons[skipped.Tx.Hash()] = skipped.Err
+	}
+	require.Len(t, reasons, 2)
+	assert.ErrorIs(t, reasons[nonceGap.Hash()], blockchain.ErrNonceTooHigh)
+	assert.ErrorContains(t, reasons[noBalance.Hash()], "insufficient balance")
+}
@@ -113,6 +113,9 @@ func TestValidationPoolInsertEthTxType(t *testing.T) {
 		if i == types.TxTypeKaiaLast {
 			i = types.TxTypeEthereumAccessList
 		}
+		if i == types.TxTypeEthereumBlob {
+			continue // blob txs cannot be sent without a sidecar.
+		}
 
 		_, err := types.NewTxInternalData(i)
 		if err == nil {
@@ -239,6 +242,9 @@ func TestValidationPoolInsertMagma(t *testing.T) {
 		if i == types.TxTypeKaiaLast {
 			i = types.TxTypeEthereumAccessList
 		}
+		if i == types.TxTypeEthereumBlob {
+			continue // blob txs cannot be sent without a sidecar.
+		}
 
 		_, err := types.NewTxInternalData(i)
 		if err == nil {
@@ -363,6 +369,9 @@ func TestValidationPoolInsertPrague(t *testing.T) {
 		if i == types.TxTypeKaiaLast {
 			i = types.TxTypeEthereumAccessList
 		}
+		if i == types.TxTypeEthereumBlob {
+			continue // blob txs cannot be sent without a sidecar.
+		}
 
 		_, err := types.NewTxInternalData(i)
 		if err == nil {
@@ -519,6 +528,9 @@ func TestValidationBlockTx(t *testing.T) {
 		if i == types.TxTypeKaiaLast {
 			i = types.TxTypeEthereumAccessList
 		}
+		if i == types.TxTypeEthereumBlob {
+			continue // blob txs cannot be sent without a sidecar.
+		}
 
 		_, err := types.NewTxInternalData(i)
 		if err == nil {
@@ -742,6 +754,9 @@ func TestValidationInvalidSig(t *testing.T) {
 		if i == types.TxTypeKaiaLast {
 			i = types.TxTypeEthereumAccessList
 		}
+		if i == types.TxTypeEthereumBlob {
+			continue // blob txs cannot be sent without a sidecar.
+		}
 
 		_, err := types.NewTxInternalData(i)
 		if err == nil {
@@ -966,6 +981,9 @@ func TestInvalidBalance(t *testing.T) {
 		if i == types.TxTypeKaiaLast {
 			i = types.TxTypeEthereumAccessList
 		}
+		if i == types.TxTypeEthereumBlob {
+			continue // blob txs cannot be sent without a sidecar.
+		}
 
 		_, err := types.NewTxInternalData(i)
 		if err == nil {
@@ -1356,6 +1374,9 @@ func TestInvalidBalanceBlockTx(t *testing.T) {
 		if i == types.TxTypeKaiaLast {
 			i = types.TxTypeEthereumAccessList
 		}
+		if i == types.TxTypeEthereumBlob {
+			continue // blob txs cannot be sent without a sidecar.
+		}
 
 		_, err := types.NewTxInternalData(i)
 		if err == nil {
@@ -1767,6 +1788,9 @@ func TestValidationTxSizeAfterRLP(t *testing.T) {
 		if i == types.TxTypeKaiaLast {
 			i = types.TxTypeEthereumAccessList
 		}
+		if i == types.TxTypeEthereumBlob {
+			continue // blob txs cannot be sent without a sidecar.
+		}
 
 		tx, err := types.NewTxInternalData(i)
 		if err == nil {
@@ -1930,6 +1954,9 @@ func TestValidationPoolResetAfterSenderKeyChange(t *testing.T) {
 		if i == types.TxTypeKaiaLast {
 			i = types.TxTypeEthereumAccessList
 		}
+		if i == types.TxTypeEthereumBlob {
+			continue // blob txs cannot be sent without a sidecar.
+		}
 
 		_, err := types.NewTxInternalData(i)
 		if err == nil {
@@ -2072,6 +2099,9 @@ func TestValidationPoolResetAfterFeePayerKeyChange(t *testing.T) {
 		if i == types.TxTypeKaiaLast {
 			i = types.TxTypeEthereumAccessList
 		}
+		if i == types.TxTypeEthereumBlob {
+			continue // blob txs cannot be sent without a sidecar.
+		}
 
 		_, err := types.NewTxInternalData(i)
 		if err == nil {
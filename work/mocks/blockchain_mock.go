@@ -269,6 +269,20 @@ func (mr *MockBlockChainMockRecorder) Genesis() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Genesis", reflect.TypeOf((*MockBlockChain)(nil).Genesis))
 }
 
+// GetBlobSidecars mocks base method.
+func (m *MockBlockChain) GetBlobSidecars(arg0 common.Hash, arg1 uint64) []*types.BlobTxSidecarWithHash {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlobSidecars", arg0, arg1)
+	ret0, _ := ret[0].([]*types.BlobTxSidecarWithHash)
+	return ret0
+}
+
+// GetBlobSidecars indicates an expected call of GetBlobSidecars.
+func (mr *MockBlockChainMockRecorder) GetBlobSidecars(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlobSidecars", reflect.TypeOf((*MockBlockChain)(nil).GetBlobSidecars), arg0, arg1)
+}
+
 // GetBlock mocks base method.
 func (m *MockBlockChain) GetBlock(arg0 common.Hash, arg1 uint64) *types.Block {
 	m.ctrl.T.Helper()
@@ -1095,6 +1109,18 @@ func (mr *MockBlockChainMockRecorder) Validator() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validator", reflect.TypeOf((*MockBlockChain)(nil).Validator))
 }
 
+// WriteBlobSidecars mocks base method.
+func (m *MockBlockChain) WriteBlobSidecars(arg0 common.Hash, arg1 uint64, arg2 []*types.BlobTxSidecarWithHash) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "WriteBlobSidecars", arg0, arg1, arg2)
+}
+
+// WriteBlobSidecars indicates an expected call of WriteBlobSidecars.
+func (mr *MockBlockChainMockRecorder) WriteBlobSidecars(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteBlobSidecars", reflect.TypeOf((*MockBlockChain)(nil).WriteBlobSidecars), arg0, arg1, arg2)
+}
+
 // WriteBlockWithState mocks base method.
 func (m *MockBlockChain) WriteBlockWithState(arg0 *types.Block, arg1 []*types.Receipt, arg2 *state.StateDB) (blockchain.WriteResult, error) {
 	m.ctrl.T.Helper()
@@ -38,6 +38,20 @@ func (m *MockTxPool) EXPECT() *MockTxPoolMockRecorder {
 	return m.recorder
 }
 
+// AddBundle mocks base method.
+func (m *MockTxPool) AddBundle(arg0 *types.Bundle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBundle", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBundle indicates an expected call of AddBundle.
+func (mr *MockTxPoolMockRecorder) AddBundle(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBundle", reflect.TypeOf((*MockTxPool)(nil).AddBundle), arg0)
+}
+
 // AddLocal mocks base method.
 func (m *MockTxPool) AddLocal(arg0 *types.Transaction) error {
 	m.ctrl.T.Helper()
@@ -52,6 +66,34 @@ func (mr *MockTxPoolMockRecorder) AddLocal(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLocal", reflect.TypeOf((*MockTxPool)(nil).AddLocal), arg0)
 }
 
+// AddPrivate mocks base method.
+func (m *MockTxPool) AddPrivate(arg0 *types.Transaction, arg1 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPrivate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPrivate indicates an expected call of AddPrivate.
+func (mr *MockTxPoolMockRecorder) AddPrivate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPrivate", reflect.TypeOf((*MockTxPool)(nil).AddPrivate), arg0, arg1)
+}
+
+// AddPrivateRemote mocks base method.
+func (m *MockTxPool) AddPrivateRemote(arg0 *types.Transaction, arg1 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPrivateRemote", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPrivateRemote indicates an expected call of AddPrivateRemote.
+func (mr *MockTxPoolMockRecorder) AddPrivateRemote(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPrivateRemote", reflect.TypeOf((*MockTxPool)(nil).AddPrivateRemote), arg0, arg1)
+}
+
 // CachedPendingTxsByCount mocks base method.
 func (m *MockTxPool) CachedPendingTxsByCount(arg0 int) types.Transactions {
 	m.ctrl.T.Helper()
@@ -81,6 +123,35 @@ func (mr *MockTxPoolMockRecorder) Content() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Content", reflect.TypeOf((*MockTxPool)(nil).Content))
 }
 
+// ContentFrom mocks base method.
+func (m *MockTxPool) ContentFrom(arg0 common.Address) (types.Transactions, types.Transactions) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContentFrom", arg0)
+	ret0, _ := ret[0].(types.Transactions)
+	ret1, _ := ret[1].(types.Transactions)
+	return ret0, ret1
+}
+
+// ContentFrom indicates an expected call of ContentFrom.
+func (mr *MockTxPoolMockRecorder) ContentFrom(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContentFrom", reflect.TypeOf((*MockTxPool)(nil).ContentFrom), arg0)
+}
+
+// DroppedTx mocks base method.
+func (m *MockTxPool) DroppedTx(arg0 common.Hash) *blockchain.DroppedTx {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DroppedTx", arg0)
+	ret0, _ := ret[0].(*blockchain.DroppedTx)
+	return ret0
+}
+
+// DroppedTx indicates an expected call of DroppedTx.
+func (mr *MockTxPoolMockRecorder) DroppedTx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DroppedTx", reflect.TypeOf((*MockTxPool)(nil).DroppedTx), arg0)
+}
+
 // GasPrice mocks base method.
 func (m *MockTxPool) GasPrice() *big.Int {
 	m.ctrl.T.Helper()
@@ -109,6 +180,20 @@ func (mr *MockTxPoolMockRecorder) Get(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTxPool)(nil).Get), arg0)
 }
 
+// GetBlobSidecar mocks base method.
+func (m *MockTxPool) GetBlobSidecar(arg0 common.Hash) *types.BlobTxSidecar {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlobSidecar", arg0)
+	ret0, _ := ret[0].(*types.BlobTxSidecar)
+	return ret0
+}
+
+// GetBlobSidecar indicates an expected call of GetBlobSidecar.
+func (mr *MockTxPoolMockRecorder) GetBlobSidecar(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlobSidecar", reflect.TypeOf((*MockTxPool)(nil).GetBlobSidecar), arg0)
+}
+
 // GetPendingNonce mocks base method.
 func (m *MockTxPool) GetPendingNonce(arg0 common.Address) uint64 {
 	m.ctrl.T.Helper()
@@ -150,6 +235,76 @@ func (mr *MockTxPoolMockRecorder) Pending() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pending", reflect.TypeOf((*MockTxPool)(nil).Pending))
 }
 
+// PendingBundles mocks base method.
+func (m *MockTxPool) PendingBundles(arg0 uint64) []*types.Bundle {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingBundles", arg0)
+	ret0, _ := ret[0].([]*types.Bundle)
+	return ret0
+}
+
+// PendingBundles indicates an expected call of PendingBundles.
+func (mr *MockTxPoolMockRecorder) PendingBundles(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingBundles", reflect.TypeOf((*MockTxPool)(nil).PendingBundles), arg0)
+}
+
+// PriceBump mocks base method.
+func (m *MockTxPool) PriceBump() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PriceBump")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// PriceBump indicates an expected call of PriceBump.
+func (mr *MockTxPoolMockRecorder) PriceBump() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PriceBump", reflect.TypeOf((*MockTxPool)(nil).PriceBump))
+}
+
+// PrivateTxExpiry mocks base method.
+func (m *MockTxPool) PrivateTxExpiry(arg0 common.Hash) (uint64, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrivateTxExpiry", arg0)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// PrivateTxExpiry indicates an expected call of PrivateTxExpiry.
+func (mr *MockTxPoolMockRecorder) PrivateTxExpiry(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrivateTxExpiry", reflect.TypeOf((*MockTxPool)(nil).PrivateTxExpiry), arg0)
+}
+
+// PublicPending mocks base method.
+func (m *MockTxPool) PublicPending() (map[common.Address]types.Transactions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublicPending")
+	ret0, _ := ret[0].(map[common.Address]types.Transactions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublicPending indicates an expected call of PublicPending.
+func (mr *MockTxPoolMockRecorder) PublicPending() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublicPending", reflect.TypeOf((*MockTxPool)(nil).PublicPending))
+}
+
+// RemoveBundles mocks base method.
+func (m *MockTxPool) RemoveBundles(arg0 []common.Hash) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoveBundles", arg0)
+}
+
+// RemoveBundles indicates an expected call of RemoveBundles.
+func (mr *MockTxPoolMockRecorder) RemoveBundles(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveBundles", reflect.TypeOf((*MockTxPool)(nil).RemoveBundles), arg0)
+}
+
 // SetGasPrice mocks base method.
 func (m *MockTxPool) SetGasPrice(arg0 *big.Int) {
 	m.ctrl.T.Helper()
@@ -191,6 +346,20 @@ func (mr *MockTxPoolMockRecorder) Stats() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockTxPool)(nil).Stats))
 }
 
+// Status mocks base method.
+func (m *MockTxPool) Status(arg0 []common.Hash) []blockchain.TxStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status", arg0)
+	ret0, _ := ret[0].([]blockchain.TxStatus)
+	return ret0
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockTxPoolMockRecorder) Status(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockTxPool)(nil).Status), arg0)
+}
+
 // Stop mocks base method.
 func (m *MockTxPool) Stop() {
 	m.ctrl.T.Helper()
@@ -215,6 +384,48 @@ func (mr *MockTxPoolMockRecorder) StopSpamThrottler() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopSpamThrottler", reflect.TypeOf((*MockTxPool)(nil).StopSpamThrottler))
 }
 
+// SubscribeDroppedTxsEvent mocks base method.
+func (m *MockTxPool) SubscribeDroppedTxsEvent(arg0 chan<- blockchain.DroppedTxsEvent) event.Subscription {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeDroppedTxsEvent", arg0)
+	ret0, _ := ret[0].(event.Subscription)
+	return ret0
+}
+
+// SubscribeDroppedTxsEvent indicates an expected call of SubscribeDroppedTxsEvent.
+func (mr *MockTxPoolMockRecorder) SubscribeDroppedTxsEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeDroppedTxsEvent", reflect.TypeOf((*MockTxPool)(nil).SubscribeDroppedTxsEvent), arg0)
+}
+
+// SubscribeMissingBlobSidecarsEvent mocks base method.
+func (m *MockTxPool) SubscribeMissingBlobSidecarsEvent(arg0 chan<- blockchain.MissingBlobSidecarsEvent) event.Subscription {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeMissingBlobSidecarsEvent", arg0)
+	ret0, _ := ret[0].(event.Subscription)
+	return ret0
+}
+
+// SubscribeMissingBlobSidecarsEvent indicates an expected call of SubscribeMissingBlobSidecarsEvent.
+func (mr *MockTxPoolMockRecorder) SubscribeMissingBlobSidecarsEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeMissingBlobSidecarsEvent", reflect.TypeOf((*MockTxPool)(nil).SubscribeMissingBlobSidecarsEvent), arg0)
+}
+
+// SubscribeNewPrivateTxsEvent mocks base method.
+func (m *MockTxPool) SubscribeNewPrivateTxsEvent(arg0 chan<- blockchain.NewPrivateTxsEvent) event.Subscription {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeNewPrivateTxsEvent", arg0)
+	ret0, _ := ret[0].(event.Subscription)
+	return ret0
+}
+
+// SubscribeNewPrivateTxsEvent indicates an expected call of SubscribeNewPrivateTxsEvent.
+func (mr *MockTxPoolMockRecorder) SubscribeNewPrivateTxsEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeNewPrivateTxsEvent", reflect.TypeOf((*MockTxPool)(nil).SubscribeNewPrivateTxsEvent), arg0)
+}
+
 // SubscribeNewTxsEvent mocks base method.
 func (m *MockTxPool) SubscribeNewTxsEvent(arg0 chan<- blockchain.NewTxsEvent) event.Subscription {
 	m.ctrl.T.Helper()
@@ -0,0 +1,78 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package work
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+)
+
+const (
+	// TxOrderingPrice orders the pending transactions by the effective gas tip,
+	// and by the arrival time among the same tip.
+	TxOrderingPrice = "price"
+	// TxOrderingFCFS orders the pending transactions by the arrival time.
+	TxOrderingFCFS = "fcfs"
+
+	DefaultTxOrdering = TxOrderingPrice
+)
+
+// TxOrderingPolicy decides the order in which the worker pulls the pending
+// transactions of the txpool into a new block. The nonce order of each account
+// must be honoured by the returned transaction set.
+type TxOrderingPolicy interface {
+	// Name returns the name of the policy used in the configuration.
+	Name() string
+
+	// NewTransactionSet creates a transaction set from the pending transactions.
+	// The pending map is reowned by the transaction set.
+	NewTransactionSet(signer types.Signer, pending map[common.Address]types.Transactions, baseFee *big.Int) types.TransactionSet
+}
+
+// NewTxOrderingPolicy returns the ordering policy of the given name.
+// The default policy is returned if the name is empty.
+func NewTxOrderingPolicy(name string) (TxOrderingPolicy, error) {
+	switch name {
+	case "", TxOrderingPrice:
+		return &priceAndNonceOrdering{}, nil
+	case TxOrderingFCFS:
+		return &fcfsOrdering{}, nil
+	default:
+		return nil, fmt.Errorf("unknown tx ordering policy: %s", name)
+	}
+}
+
+// priceAndNonceOrdering is the profit-maximizing ordering policy.
+type priceAndNonceOrdering struct{}
+
+func (*priceAndNonceOrdering) Name() string { return TxOrderingPrice }
+
+func (*priceAndNonceOrdering) NewTransactionSet(signer types.Signer, pending map[common.Address]types.Transactions, baseFee *big.Int) types.TransactionSet {
+	return types.NewTransactionsByPriceAndNonce(signer, pending, baseFee)
+}
+
+// fcfsOrdering is the first-come-first-served ordering policy.
+type fcfsOrdering struct{}
+
+func (*fcfsOrdering) Name() string { return TxOrderingFCFS }
+
+func (*fcfsOrdering) NewTransactionSet(_ types.Signer, pending map[common.Address]types.Transactions, baseFee *big.Int) types.TransactionSet {
+	return types.NewTransactionsByTimeAndNonce(pending, baseFee)
+}
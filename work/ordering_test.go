@@ -0,0 +1,46 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package work
+
+import (
+	"testing"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTxOrderingPolicy(t *testing.T) {
+	testcases := []struct {
+		name     string
+		expected string
+		set      types.TransactionSet
+	}{
+		{"", TxOrderingPrice, &types.TransactionsByPriceAndNonce{}},
+		{TxOrderingPrice, TxOrderingPrice, &types.TransactionsByPriceAndNonce{}},
+		{TxOrderingFCFS, TxOrderingFCFS, &types.TransactionsByTimeAndNonce{}},
+	}
+	for _, tc := range testcases {
+		policy, err := NewTxOrderingPolicy(tc.name)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, policy.Name())
+		assert.IsType(t, tc.set, policy.NewTransactionSet(types.LatestSignerForChainID(nil), nil, nil))
+	}
+
+	_, err := NewTxOrderingPolicy("unknown")
+	assert.Error(t, err)
+}
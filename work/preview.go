@@ -0,0 +1,93 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package work
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/consensus/misc"
+)
+
+// PendingBlockPreview is the result of simulating the next block with the
+// current transactions of the txpool.
+type PendingBlockPreview struct {
+	Header   *types.Header
+	Txs      types.Transactions
+	Receipts types.Receipts
+	Skipped  []*SkippedTx
+}
+
+// previewPendingBlock builds the next block on a throwaway state in the same way
+// as a consensus node does, without touching the current work of the worker.
+func (self *worker) previewPendingBlock() (*PendingBlockPreview, error) {
+	self.mu.Lock()
+	extra := self.extra
+	self.mu.Unlock()
+
+	parent := self.chain.CurrentBlock()
+	nextBlockNum := new(big.Int).Add(parent.Number(), common.Big1)
+
+	// The private transactions of the pool must not be exposed by the preview
+	pending, err := self.backend.TxPool().PublicPending()
+	if err != nil {
+		return nil, err
+	}
+	bundles := self.backend.TxPool().PendingBundles(nextBlockNum.Uint64())
+
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     nextBlockNum,
+		Extra:      extra,
+		Time:       big.NewInt(time.Now().Unix()),
+	}
+	if self.config.IsMagmaForkEnabled(nextBlockNum) {
+		pset := self.govModule.GetParamSet(nextBlockNum.Uint64())
+		header.BaseFee = misc.NextMagmaBlockBaseFee(parent.Header(), pset.ToKip71Config())
+		pending = types.FilterTransactionWithBaseFee(pending, header.BaseFee)
+	}
+	if self.config.IsBlobTxForkEnabled(nextBlockNum) {
+		excessBlobGas := misc.CalcExcessBlobGas(self.config, parent.Header())
+		header.ExcessBlobGas = &excessBlobGas
+		header.BlobGasUsed = new(uint64)
+	}
+	if err := self.engine.Prepare(self.chain, header); err != nil {
+		return nil, err
+	}
+	stateDB, err := self.chain.StateAt(parent.Root())
+	if err != nil {
+		return nil, err
+	}
+	task := NewTask(self.config, types.MakeSigner(self.config, header.Number), stateDB, header)
+	task.simulation = true
+	self.engine.Initialize(self.chain, header, task.state)
+
+	txs := self.txOrdering.NewTransactionSet(task.signer, pending, header.BaseFee)
+	for _, module := range self.txPoolModules {
+		txs = module.PreCommitTxs(header, txs)
+	}
+	task.ApplyTransactions(txs, bundles, self.chain, self.rewardbase)
+
+	return &PendingBlockPreview{
+		Header:   task.header,
+		Txs:      task.txs,
+		Receipts: task.receipts,
+		Skipped:  task.skipped,
+	}, nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package work
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kaiachain/kaia/blockchain/state"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	consensus_mock "github.com/kaiachain/kaia/consensus/mocks"
+	"github.com/kaiachain/kaia/params"
+	"github.com/kaiachain/kaia/storage/database"
+	"github.com/kaiachain/kaia/work/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// previewTestBackend is a Backend serving only the txpool.
+type previewTestBackend struct {
+	Backend
+	txPool TxPool
+}
+
+func (b *previewTestBackend) TxPool() TxPool { return b.txPool }
+
+// newPreviewTestWorker returns a worker previewing the block next to the genesis.
+func newPreviewTestWorker(ctrl *gomock.Controller, txPool TxPool) *worker {
+	var (
+		mockChain  = mocks.NewMockBlockChain(ctrl)
+		mockEngine = consensus_mock.NewMockEngine(ctrl)
+		genesis    = types.NewBlockWithHeader(&types.Header{Number: common.Big0, Time: common.Big0, BlockScore: common.Big1})
+	)
+	mockChain.EXPECT().CurrentBlock().Return(genesis).AnyTimes()
+	mockChain.EXPECT().StateAt(gomock.Any()).DoAndReturn(func(common.Hash) (*state.StateDB, error) {
+		return state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil, nil)
+	}).AnyTimes()
+	mockEngine.EXPECT().Prepare(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockEngine.EXPECT().Initialize(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	return &worker{
+		config:     params.TestChainConfig,
+		engine:     mockEngine,
+		backend:    &previewTestBackend{txPool: txPool},
+		chain:      mockChain,
+		txOrdering: &priceAndNonceOrdering{},
+	}
+}
+
+// Tests that the preview never includes the private transactions of the pool.
+func TestPreviewPendingBlock_PrivateTx(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Pending, which includes the private transactions, must not be called.
+	mockTxPool := mocks.NewMockTxPool(ctrl)
+	mockTxPool.EXPECT().PublicPending().Return(map[common.Address]types.Transactions{}, nil).Times(1)
+	mockTxPool.EXPECT().PendingBundles(uint64(1)).Return(nil).Times(1)
+
+	preview, err := newPreviewTestWorker(ctrl, mockTxPool).previewPendingBlock()
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1), preview.Header.Number)
+	assert.Empty(t, preview.Txs)
+}
@@ -59,20 +59,71 @@ type TxPool interface {
 	// The slice should be modifiable by the caller.
 	Pending() (map[common.Address]types.Transactions, error)
 
+	// PublicPending should return pending transactions except the private ones.
+	// The slice should be modifiable by the caller.
+	PublicPending() (map[common.Address]types.Transactions, error)
+
 	CachedPendingTxsByCount(count int) types.Transactions
 
 	// SubscribeNewTxsEvent should return an event subscription of
 	// NewTxsEvent and send events to the given channel.
 	SubscribeNewTxsEvent(chan<- blockchain.NewTxsEvent) event.Subscription
 
+	// SubscribeNewPrivateTxsEvent should return an event subscription of
+	// NewPrivateTxsEvent and send events to the given channel.
+	SubscribeNewPrivateTxsEvent(chan<- blockchain.NewPrivateTxsEvent) event.Subscription
+
 	GetPendingNonce(addr common.Address) uint64
 	AddLocal(tx *types.Transaction) error
+
+	// AddPrivate should add the given transaction to the pool as a private one,
+	// which expires after the given number of blocks.
+	AddPrivate(tx *types.Transaction, expiryBlocks uint64) error
+
+	// AddPrivateRemote should add the given private transaction received from
+	// a peer to the pool, keeping it private until the given expiry block number.
+	AddPrivateRemote(tx *types.Transaction, expiry uint64) error
+
+	// PrivateTxExpiry should return the expiry block number of the private
+	// transaction of the given hash, and false if it is not private.
+	PrivateTxExpiry(hash common.Hash) (uint64, bool)
+
+	// AddBundle should add the given transaction bundle to the pool.
+	AddBundle(bundle *types.Bundle) error
+
+	// PendingBundles should return the transaction bundles which can be
+	// included in the block of the given number.
+	PendingBundles(blockNumber uint64) []*types.Bundle
+
+	// RemoveBundles should remove the transaction bundles of the given hashes.
+	RemoveBundles(hashes []common.Hash)
+
 	GasPrice() *big.Int
 	SetGasPrice(price *big.Int)
+	PriceBump() uint64
 	Stop()
 	Get(hash common.Hash) *types.Transaction
+
+	// GetBlobSidecar should return the sidecar of the pooled blob transaction
+	// of the given hash.
+	GetBlobSidecar(hash common.Hash) *types.BlobTxSidecar
+
 	Stats() (int, int)
 	Content() (map[common.Address]types.Transactions, map[common.Address]types.Transactions)
+	ContentFrom(addr common.Address) (types.Transactions, types.Transactions)
+	Status(hashes []common.Hash) []blockchain.TxStatus
+
+	// DroppedTx should return the recently dropped transaction of the given hash.
+	DroppedTx(hash common.Hash) *blockchain.DroppedTx
+
+	// SubscribeDroppedTxsEvent should return an event subscription of
+	// DroppedTxsEvent and send events to the given channel.
+	SubscribeDroppedTxsEvent(chan<- blockchain.DroppedTxsEvent) event.Subscription
+
+	// SubscribeMissingBlobSidecarsEvent should return an event subscription of
+	// MissingBlobSidecarsEvent and send events to the given channel.
+	SubscribeMissingBlobSidecarsEvent(chan<- blockchain.MissingBlobSidecarsEvent) event.Subscription
+
 	StartSpamThrottler(conf *blockchain.ThrottlerConfig) error
 	StopSpamThrottler()
 }
@@ -101,12 +152,12 @@ type Miner struct {
 	shouldStart int32 // should start indicates whether we should start after sync
 }
 
-func New(backend Backend, config *params.ChainConfig, mux *event.TypeMux, engine consensus.Engine, nodetype common.ConnType, rewardbase common.Address, TxResendUseLegacy bool, govModule gov.GovModule) *Miner {
+func New(backend Backend, config *params.ChainConfig, mux *event.TypeMux, engine consensus.Engine, nodetype common.ConnType, rewardbase common.Address, TxResendUseLegacy bool, govModule gov.GovModule, txOrdering TxOrderingPolicy) *Miner {
 	miner := &Miner{
 		backend:  backend,
 		mux:      mux,
 		engine:   engine,
-		worker:   newWorker(config, engine, rewardbase, backend, mux, nodetype, TxResendUseLegacy, govModule),
+		worker:   newWorker(config, engine, rewardbase, backend, mux, nodetype, TxResendUseLegacy, govModule, txOrdering),
 		canStart: 1,
 	}
 	// TODO-Kaia drop or missing tx
@@ -224,11 +275,24 @@ func (self *Miner) PendingBlock() *types.Block {
 	return self.worker.pendingBlock()
 }
 
+// PendingBlockPreview simulates the next block with the current transactions of
+// the txpool, in the order the block would be built by a consensus node. The
+// transactions which could not be included are returned along with the errors.
+func (self *Miner) PendingBlockPreview() (*PendingBlockPreview, error) {
+	return self.worker.previewPendingBlock()
+}
+
 // RegisterExecutionModule registers kaiax.ExecutionModule to underlying worker.
 func (self *Miner) RegisterExecutionModule(modules ...kaiax.ExecutionModule) {
 	self.worker.RegisterExecutionModule(modules...)
 }
 
+// RegisterTxPoolModule registers kaiax.TxPoolModule to underlying worker.
+// The worker lets the modules filter or reorder the transactions of a new block.
+func (self *Miner) RegisterTxPoolModule(modules ...kaiax.TxPoolModule) {
+	self.worker.RegisterTxPoolModule(modules...)
+}
+
 // BlockChain is an interface of blockchain.BlockChain used by ProtocolManager.
 //
 //go:generate mockgen -destination=./mocks/blockchain_mock.go -package=mocks github.com/kaiachain/kaia/work BlockChain
@@ -255,6 +319,8 @@ type BlockChain interface {
 	GetBodyRLP(hash common.Hash) rlp.RawValue
 
 	GetReceiptsByBlockHash(blockHash common.Hash) types.Receipts
+	GetBlobSidecars(hash common.Hash, number uint64) []*types.BlobTxSidecarWithHash
+	WriteBlobSidecars(hash common.Hash, number uint64, sidecars []*types.BlobTxSidecarWithHash)
 
 	InsertChain(chain types.Blocks) (int, error)
 	TrieNode(hash common.Hash) ([]byte, error)
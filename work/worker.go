@@ -23,6 +23,8 @@
 package work
 
 import (
+	"errors"
+	"fmt"
 	"math/big"
 	"sync"
 	"sync/atomic"
@@ -57,8 +59,14 @@ const (
 	chainSideChanSize = 10
 	// maxResendSize is the size of resending transactions to peer in order to prevent the txs from missing.
 	maxResendTxSize = 1000
+	// MaxBundlesPerBlock is the maximum number of bundles tried in a block. Each bundle copies
+	// the working state to be reverted, so the number is bounded within the block generation time.
+	MaxBundlesPerBlock = 32
 )
 
+// errBundleTxReverted is returned if a transaction of a bundle is reverted by the EVM.
+var errBundleTxReverted = errors.New("transaction reverted")
+
 var (
 	// Metrics for miner
 	timeLimitReachedCounter = metrics.NewRegisteredCounter("miner/timelimitreached", nil)
@@ -72,6 +80,8 @@ var (
 	nonceTooHighTxsGauge    = metrics.NewRegisteredGauge("miner/nonce/high/txs", nil)
 	gasLimitReachedTxsGauge = metrics.NewRegisteredGauge("miner/limitreached/gas/txs", nil)
 	strangeErrorTxsCounter  = metrics.NewRegisteredCounter("miner/strangeerror/txs", nil)
+	committedBundlesCounter = metrics.NewRegisteredCounter("miner/bundles/committed", nil)
+	revertedBundlesCounter  = metrics.NewRegisteredCounter("miner/bundles/reverted", nil)
 
 	blockBaseFee              = metrics.NewRegisteredGauge("miner/block/mining/basefee", nil)
 	blockMiningTimer          = kaiametrics.NewRegisteredHybridTimer("miner/block/mining/time", nil)
@@ -118,10 +128,22 @@ type Task struct {
 	header   *types.Header
 	txs      []*types.Transaction
 	receipts []*types.Receipt
+	skipped  []*SkippedTx
+
+	failedBundles []common.Hash // bundles which failed or were reverted, except by the time limit
+
+	simulation bool // If true, the task leaves no mark on the transactions of the txpool
 
 	createdAt time.Time
 }
 
+// SkippedTx is a transaction which was not included in the task, with the error
+// that caused it to be skipped.
+type SkippedTx struct {
+	Tx  *types.Transaction
+	Err error
+}
+
 type Result struct {
 	Task  *Task
 	Block *types.Block
@@ -153,6 +175,7 @@ type worker struct {
 	chainDB          database.DBManager
 	govModule        gov.GovModule
 	executionModules []kaiax.ExecutionModule
+	txPoolModules    []kaiax.TxPoolModule
 
 	extra []byte
 
@@ -168,10 +191,11 @@ type worker struct {
 	mining int32
 	atWork int32
 
-	nodetype common.ConnType
+	nodetype   common.ConnType
+	txOrdering TxOrderingPolicy
 }
 
-func newWorker(config *params.ChainConfig, engine consensus.Engine, rewardbase common.Address, backend Backend, mux *event.TypeMux, nodetype common.ConnType, TxResendUseLegacy bool, govModule gov.GovModule) *worker {
+func newWorker(config *params.ChainConfig, engine consensus.Engine, rewardbase common.Address, backend Backend, mux *event.TypeMux, nodetype common.ConnType, TxResendUseLegacy bool, govModule gov.GovModule, txOrdering TxOrderingPolicy) *worker {
 	worker := &worker{
 		config:      config,
 		engine:      engine,
@@ -188,6 +212,7 @@ func newWorker(config *params.ChainConfig, engine consensus.Engine, rewardbase c
 		nodetype:    nodetype,
 		rewardbase:  rewardbase,
 		govModule:   govModule,
+		txOrdering:  txOrdering,
 	}
 
 	// Subscribe NewTxsEvent for tx pool
@@ -495,6 +520,17 @@ func (self *worker) makeCurrent(parent *types.Block, header *types.Header) error
 	return nil
 }
 
+// blockGenerationInterval returns the target block interval of the given block height in seconds.
+// It is the governed interval if set, otherwise the node-local one.
+func (self *worker) blockGenerationInterval(num uint64) int64 {
+	if self.govModule != nil {
+		if interval := self.govModule.GetParamSet(num).BlockInterval; interval > 0 {
+			return int64(interval)
+		}
+	}
+	return params.BlockGenerationInterval
+}
+
 func (self *worker) commitNewWork() {
 	self.mu.Lock()
 	defer self.mu.Unlock()
@@ -509,7 +545,7 @@ func (self *worker) commitNewWork() {
 	tstamp := tstart.Unix()
 	if self.nodetype == common.CONSENSUSNODE {
 		parentTimestamp := parent.Time().Int64()
-		ideal := time.Unix(parentTimestamp+params.BlockGenerationInterval, 0)
+		ideal := time.Unix(parentTimestamp+self.blockGenerationInterval(nextBlockNum.Uint64()), 0)
 		// If a timestamp of this block is faster than the ideal timestamp,
 		// wait for a while and get a new timestamp
 		if tstart.Before(ideal) {
@@ -529,6 +565,7 @@ func (self *worker) commitNewWork() {
 	}
 
 	var pending map[common.Address]types.Transactions
+	var bundles []*types.Bundle
 	var err error
 	var nextBaseFee *big.Int
 	if self.nodetype == common.CONSENSUSNODE {
@@ -538,6 +575,7 @@ func (self *worker) commitNewWork() {
 			logger.Error("Failed to fetch pending transactions", "err", err)
 			return
 		}
+		bundles = self.backend.TxPool().PendingBundles(nextBlockNum.Uint64())
 
 		if self.config.IsMagmaForkEnabled(nextBlockNum) {
 			// NOTE-Kaia NextBlockBaseFee needs the header of parent, self.chain.CurrentBlock
@@ -557,6 +595,11 @@ func (self *worker) commitNewWork() {
 	if self.config.IsMagmaForkEnabled(nextBlockNum) {
 		header.BaseFee = nextBaseFee
 	}
+	if self.config.IsBlobTxForkEnabled(nextBlockNum) {
+		excessBlobGas := misc.CalcExcessBlobGas(self.config, parent.Header())
+		header.ExcessBlobGas = &excessBlobGas
+		header.BlobGasUsed = new(uint64)
+	}
 	if err := self.engine.Prepare(self.chain, header); err != nil {
 		logger.Error("Failed to prepare header for mining", "err", err)
 		return
@@ -577,10 +620,18 @@ func (self *worker) commitNewWork() {
 	// Create the current work task
 	work := self.current
 	if self.nodetype == common.CONSENSUSNODE {
-		txs := types.NewTransactionsByPriceAndNonce(self.current.signer, pending, work.header.BaseFee)
-		work.commitTransactions(self.mux, txs, self.chain, self.rewardbase)
+		txs := self.txOrdering.NewTransactionSet(self.current.signer, pending, work.header.BaseFee)
+		for _, module := range self.txPoolModules {
+			txs = module.PreCommitTxs(work.header, txs)
+		}
+		work.commitTransactions(self.mux, txs, bundles, self.chain, self.rewardbase)
 		finishedCommitTx := time.Now()
 
+		// Evict the failed bundles so that they are not executed again for every block.
+		if failed := work.FailedBundles(); len(failed) > 0 {
+			self.backend.TxPool().RemoveBundles(failed)
+		}
+
 		// Create the new block to seal with the consensus engine
 		if work.Block, err = self.engine.Finalize(self.chain, header, work.state, work.txs, work.receipts); err != nil {
 			logger.Error("Failed to finalize block for sealing", "err", err)
@@ -647,8 +698,12 @@ func (self *worker) RegisterExecutionModule(modules ...kaiax.ExecutionModule) {
 	self.executionModules = append(self.executionModules, modules...)
 }
 
-func (env *Task) commitTransactions(mux *event.TypeMux, txs *types.TransactionsByPriceAndNonce, bc BlockChain, rewardbase common.Address) {
-	coalescedLogs := env.ApplyTransactions(txs, bc, rewardbase)
+func (self *worker) RegisterTxPoolModule(modules ...kaiax.TxPoolModule) {
+	self.txPoolModules = append(self.txPoolModules, modules...)
+}
+
+func (env *Task) commitTransactions(mux *event.TypeMux, txs types.TransactionSet, bundles []*types.Bundle, bc BlockChain, rewardbase common.Address) {
+	coalescedLogs := env.ApplyTransactions(txs, bundles, bc, rewardbase)
 
 	if len(coalescedLogs) > 0 || env.tcount > 0 {
 		// make a copy, the state caches the logs and these logs get "upgraded" from pending to mined
@@ -670,7 +725,13 @@ func (env *Task) commitTransactions(mux *event.TypeMux, txs *types.TransactionsB
 	}
 }
 
-func (env *Task) ApplyTransactions(txs *types.TransactionsByPriceAndNonce, bc BlockChain, rewardbase common.Address) []*types.Log {
+// ApplyTransactions executes the bundles at the top of the block, and then the transactions
+// in the order given by the transaction set until the block is full or the time limit is
+// reached. Each bundle is executed atomically; if any transaction of the bundle fails, the
+// whole bundle is reverted and reported by FailedBundles. Up to MaxBundlesPerBlock bundles
+// are tried, and the rest are left untouched.
+// Use State to get the resulting state, since the state may be replaced to revert a bundle.
+func (env *Task) ApplyTransactions(txs types.TransactionSet, bundles []*types.Bundle, bc BlockChain, rewardbase common.Address) []*types.Log {
 	var coalescedLogs []*types.Log
 
 	// Limit the execution time of all transactions in a block
@@ -715,6 +776,31 @@ func (env *Task) ApplyTransactions(txs *types.TransactionsByPriceAndNonce, bc Bl
 		RunningEVM: chEVM,
 	}
 
+	for i, bundle := range bundles {
+		if atomic.LoadInt32(&abort) != 0 {
+			break
+		}
+		if i >= MaxBundlesPerBlock {
+			logger.Debug("Bundle limit reached", "tried", i, "left", len(bundles)-i)
+			break
+		}
+		logs, err := env.commitBundle(bundle, bc, rewardbase, vmConfig)
+		if err != nil {
+			logger.Debug("Bundle reverted", "hash", bundle.Hash(), "err", err)
+			for _, tx := range bundle.Txs {
+				env.skip(tx, err)
+			}
+			revertedBundlesCounter.Inc(1)
+			if errors.Is(err, vm.ErrTotalTimeLimitReached) {
+				break
+			}
+			env.failedBundles = append(env.failedBundles, bundle.Hash())
+			continue
+		}
+		coalescedLogs = append(coalescedLogs, logs...)
+		committedBundlesCounter.Inc(1)
+	}
+
 	var numTxsChecked int64 = 0
 	var numTxsNonceTooLow int64 = 0
 	var numTxsNonceTooHigh int64 = 0
@@ -751,6 +837,9 @@ CommitTransactionLoop:
 		env.state.SetTxContext(tx.Hash(), common.Hash{}, env.tcount)
 
 		err, logs := env.commitTransaction(tx, bc, rewardbase, vmConfig)
+		if err != nil {
+			env.skip(tx, err)
+		}
 		switch err {
 		case blockchain.ErrGasLimitReached:
 			// Pop the current out-of-gas transaction without shifting in the next from the account
@@ -758,6 +847,11 @@ CommitTransactionLoop:
 			numTxsGasLimitReached++
 			txs.Pop()
 
+		case blockchain.ErrBlobGasLimitReached:
+			// Pop the current transaction exceeding the blob gas limit without shifting in the next from the account
+			logger.Trace("Blob gas limit exceeded for current block", "sender", from)
+			txs.Pop()
+
 		case blockchain.ErrNonceTooLow:
 			// New head notification data race between the transaction pool and miner, shift
 			logger.Trace("Skipping transaction with low nonce", "sender", from, "nonce", tx.Nonce())
@@ -813,11 +907,21 @@ CommitTransactionLoop:
 }
 
 func (env *Task) commitTransaction(tx *types.Transaction, bc BlockChain, rewardbase common.Address, vmConfig *vm.Config) (error, []*types.Log) {
+	// Check the blob gas left in the block before executing a blob transaction
+	blobGas := tx.BlobGas()
+	if blobGas > 0 {
+		if env.header.BlobGasUsed == nil {
+			return blockchain.ErrTxTypeNotSupported, nil
+		}
+		if *env.header.BlobGasUsed+blobGas > params.MaxBlobGasPerBlock {
+			return blockchain.ErrBlobGasLimitReached, nil
+		}
+	}
 	snap := env.state.Snapshot()
 
 	receipt, _, err := bc.ApplyTransaction(env.config, &rewardbase, env.state, env.header, tx, &env.header.GasUsed, vmConfig)
 	if err != nil {
-		if err != vm.ErrInsufficientBalance && err != vm.ErrTotalTimeLimitReached {
+		if err != vm.ErrInsufficientBalance && err != vm.ErrTotalTimeLimitReached && !env.simulation {
 			tx.MarkUnexecutable(true)
 		}
 		env.state.RevertToSnapshot(snap)
@@ -825,10 +929,50 @@ func (env *Task) commitTransaction(tx *types.Transaction, bc BlockChain, rewardb
 	}
 	env.txs = append(env.txs, tx)
 	env.receipts = append(env.receipts, receipt)
+	if blobGas > 0 {
+		blobGasUsed := *env.header.BlobGasUsed + blobGas
+		env.header.BlobGasUsed = &blobGasUsed
+	}
 
 	return nil, receipt.Logs
 }
 
+// commitBundle executes the transactions of the bundle in order. If any transaction fails
+// or is reverted, the state and the task are restored to the ones before the bundle, so
+// that none of the transactions of the bundle is included in the block.
+func (env *Task) commitBundle(bundle *types.Bundle, bc BlockChain, rewardbase common.Address, vmConfig *vm.Config) ([]*types.Log, error) {
+	// The journal is cleared after each transaction, so the state is copied
+	// instead of taking a snapshot to revert the transactions of the bundle.
+	var (
+		state       = env.state.Copy()
+		gasUsed     = env.header.GasUsed
+		blobGasUsed = env.header.BlobGasUsed
+		tcount      = env.tcount
+		numTxs      = len(env.txs)
+		logs        []*types.Log
+	)
+	for _, tx := range bundle.Txs {
+		env.state.SetTxContext(tx.Hash(), common.Hash{}, env.tcount)
+
+		err, txLogs := env.commitTransaction(tx, bc, rewardbase, vmConfig)
+		if err == nil && env.receipts[len(env.receipts)-1].Status != types.ReceiptStatusSuccessful {
+			err = errBundleTxReverted
+		}
+		if err != nil {
+			env.state = state
+			env.header.GasUsed = gasUsed
+			env.header.BlobGasUsed = blobGasUsed
+			env.tcount = tcount
+			env.txs = env.txs[:numTxs]
+			env.receipts = env.receipts[:numTxs]
+			return nil, fmt.Errorf("bundle tx %x: %w", tx.Hash(), err)
+		}
+		logs = append(logs, txLogs...)
+		env.tcount++
+	}
+	return logs, nil
+}
+
 func NewTask(config *params.ChainConfig, signer types.Signer, statedb *state.StateDB, header *types.Header) *Task {
 	return &Task{
 		config:    config,
@@ -839,5 +983,16 @@ func NewTask(config *params.ChainConfig, signer types.Signer, statedb *state.Sta
 	}
 }
 
+// skip records the transaction which failed to be included in the task.
+func (env *Task) skip(tx *types.Transaction, err error) {
+	env.skipped = append(env.skipped, &SkippedTx{Tx: tx, Err: err})
+}
+
 func (env *Task) Transactions() []*types.Transaction { return env.txs }
 func (env *Task) Receipts() []*types.Receipt         { return env.receipts }
+func (env *Task) SkippedTxs() []*SkippedTx           { return env.skipped }
+func (env *Task) FailedBundles() []common.Hash       { return env.failedBundles }
+
+// State returns the state of the task. Note that the state given to NewTask is
+// replaced by its copy if a bundle is reverted during ApplyTransactions.
+func (env *Task) State() *state.StateDB { return env.state }
@@ -41,4 +41,6 @@ func (*FakeWorker) HashRate() (tot int64)                                    { r
 func (*FakeWorker) SetExtra([]byte) error                                    { return nil }
 func (*FakeWorker) Pending() (*types.Block, *state.StateDB)                  { return nil, nil }
 func (*FakeWorker) PendingBlock() *types.Block                               { return nil }
+func (*FakeWorker) PendingBlockPreview() (*PendingBlockPreview, error)       { return nil, nil }
 func (*FakeWorker) RegisterExecutionModule(modules ...kaiax.ExecutionModule) {}
+func (*FakeWorker) RegisterTxPoolModule(modules ...kaiax.TxPoolModule)       {}
@@ -0,0 +1,49 @@
+// Copyright 2025 The Kaia Authors
+// This file is part of the Kaia library.
+//
+// The Kaia library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Kaia library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Kaia library. If not, see <http://www.gnu.org/licenses/>.
+
+package work
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kaiachain/kaia/kaiax/gov"
+	gov_mock "github.com/kaiachain/kaia/kaiax/gov/mock"
+	"github.com/kaiachain/kaia/params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockGenerationInterval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockGov := gov_mock.NewMockGovModule(ctrl)
+
+	// The governed block interval is activated at the block 10
+	mockGov.EXPECT().GetParamSet(gomock.Any()).DoAndReturn(func(num uint64) gov.ParamSet {
+		if num < 10 {
+			return gov.ParamSet{}
+		}
+		return gov.ParamSet{BlockInterval: 3}
+	}).AnyTimes()
+
+	w := &worker{govModule: mockGov}
+	assert.Equal(t, params.BlockGenerationInterval, w.blockGenerationInterval(9))
+	assert.Equal(t, int64(3), w.blockGenerationInterval(10))
+
+	// The node-local interval is used without the governance module
+	w = &worker{}
+	assert.Equal(t, params.BlockGenerationInterval, w.blockGenerationInterval(10))
+}